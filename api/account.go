@@ -3,16 +3,24 @@ package api
 import (
 	"database/sql"
 	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/lib/pq"
 	db "github.com/mativm02/bank_system/db/sqlc"
+	"github.com/mativm02/bank_system/page"
+	"github.com/mativm02/bank_system/policy"
 	"github.com/mativm02/bank_system/token"
+	"github.com/mativm02/bank_system/util"
+	"github.com/mativm02/bank_system/val"
 )
 
 type createAccountRequest struct {
 	Currency string `json:"currency" binding:"required,currency"`
+	Type     string `json:"type" binding:"omitempty,account_type"`
+	Nickname string `json:"nickname" binding:"omitempty,max=50"`
 }
 
 func (server *Server) createAccount(ctx *gin.Context) {
@@ -24,14 +32,32 @@ func (server *Server) createAccount(ctx *gin.Context) {
 
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
 
-	arg := db.CreateAccountParams{
-		Owner:    authPayload.Username,
-		Balance:  0,
-		Currency: req.Currency,
+	accountType := req.Type
+	if accountType == "" {
+		accountType = util.CheckingAccount
+	}
+
+	arg := db.CreateAccountTxParams{
+		CreateAccountParams: db.CreateAccountParams{
+			Owner:    authPayload.Username,
+			Balance:  0,
+			Currency: req.Currency,
+			Type:     accountType,
+			Nickname: req.Nickname,
+		},
+		MaxAccounts: server.config.MaxAccounts(accountType),
 	}
 
-	account, err := server.store.CreateAccount(ctx, arg)
+	result, err := server.store.CreateAccountTx(ctx, arg)
 	if err != nil {
+		if errors.Is(err, db.ErrAccountLimitReached) {
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrEmailNotVerified) {
+			ctx.JSON(http.StatusForbidden, policyErrorResponse(err, policy.ReasonEmailNotVerified))
+			return
+		}
 		if pqErr, ok := err.(*pq.Error); ok {
 			switch pqErr.Code.Name() {
 			case "foreign_key_violation", "unique_violation":
@@ -44,11 +70,12 @@ func (server *Server) createAccount(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, account)
+	ctx.JSON(http.StatusOK, result.Account)
 }
 
+// getAccountRequest identifies the account by its ID or by its account number.
 type getAccountRequest struct {
-	ID int64 `uri:"id" binding:"required,min=1"`
+	ID string `uri:"id" binding:"required"`
 }
 
 func (server *Server) getAccount(ctx *gin.Context) {
@@ -58,7 +85,22 @@ func (server *Server) getAccount(ctx *gin.Context) {
 		return
 	}
 
-	account, err := server.store.GetAccount(ctx, req.ID)
+	var account db.Account
+	id, err := strconv.ParseInt(req.ID, 10, 64)
+	if err == nil {
+		if id < 1 {
+			ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("id must be a positive number")))
+			return
+		}
+		account, err = server.store.GetAccount(ctx, id)
+	} else {
+		number := util.NormalizeAccountNumber(req.ID)
+		if err := val.ValidateAccountNumber(number); err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("account number %w", err)))
+			return
+		}
+		account, err = server.store.GetAccountByNumber(ctx, number)
+	}
 	if err != nil {
 		// If the account does not exist, we return a 404 Not Found error.
 		if err == sql.ErrNoRows {
@@ -79,9 +121,68 @@ func (server *Server) getAccount(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, account)
 }
 
+type getSpendingAllowanceRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type spendingAllowanceResponse struct {
+	AccountID int64  `json:"account_id"`
+	Currency  string `json:"currency"`
+	db.SpendingAllowance
+}
+
+// getSpendingAllowance shows how much more the account can send to other owners before it reaches
+// its spending limits or the limits of its owner. The transfer fees do not count toward the limits.
+func (server *Server) getSpendingAllowance(ctx *gin.Context) {
+	var req getSpendingAllowanceRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, err := server.store.GetAccount(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if account.Owner != authPayload.Username {
+		err := errors.New("you are not the owner of this account")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	allowance, err := db.GetSpendingAllowance(ctx, server.store, account, server.defaultSpendingLimits())
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, spendingAllowanceResponse{
+		AccountID:         account.ID,
+		Currency:          account.Currency,
+		SpendingAllowance: allowance,
+	})
+}
+
+var accountOrderFields = []string{db.OrderByID, db.OrderByBalance, db.OrderByCreatedAt}
+
 type listAccountsRequest struct {
-	PageID   int32 `form:"page_id" binding:"required,min=1"`
-	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+	PageSize  int32  `form:"page_size" binding:"omitempty,min=5,max=10"`
+	PageToken string `form:"page_token"`
+	Currency  string `form:"currency" binding:"omitempty,currency"`
+	Type      string `form:"type" binding:"omitempty,account_type"`
+	OrderBy   string `form:"order_by"`
+}
+
+type listAccountsResponse struct {
+	Accounts      []db.Account `json:"accounts"`
+	NextPageToken string       `json:"next_page_token,omitempty"`
 }
 
 func (server *Server) listAccounts(ctx *gin.Context) {
@@ -90,22 +191,45 @@ func (server *Server) listAccounts(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
+
+	order, err := page.ParseOrderBy(req.OrderBy, accountOrderFields, page.Order{Field: db.OrderByID})
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("order_by %w", err)))
+		return
+	}
+
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
 
-	account, err := server.store.ListAccounts(ctx, db.ListAccountsParams{
-		Owner:  authPayload.Username,
-		Limit:  req.PageSize,
-		Offset: (req.PageID - 1) * req.PageSize, // PageID starts from 1.
-	})
+	arg := db.ListAccountsParams{
+		Owner:    authPayload.Username,
+		Currency: sql.NullString{String: req.Currency, Valid: req.Currency != ""},
+		Type:     sql.NullString{String: req.Type, Valid: req.Type != ""},
+		OrderBy:  order.Field,
+		SortDesc: order.Desc,
+	}
+
+	query := page.Fingerprint(arg)
+	arg.CursorValue, arg.CursorID, err = server.pageTokens.Cursor(req.PageToken, query)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	// One more account is fetched to know whether there is a next page.
+	pageSize := page.Size(req.PageSize)
+	arg.PageSize = pageSize + 1
+	accounts, err := server.store.ListAccounts(ctx, arg)
 	if err != nil {
-		// If the account does not exist, we return a 404 Not Found error.
-		if err == sql.ErrNoRows {
-			ctx.JSON(http.StatusNotFound, errorResponse(err))
-			return
-		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	ctx.JSON(http.StatusOK, account)
+	rsp := listAccountsResponse{Accounts: accounts}
+	if len(accounts) > int(pageSize) {
+		rsp.Accounts = accounts[:pageSize]
+		last := rsp.Accounts[pageSize-1]
+		rsp.NextPageToken = server.pageTokens.Encode(page.Token{Value: last.SortValue(order.Field), ID: last.ID, Query: query})
+	}
+
+	ctx.JSON(http.StatusOK, rsp)
 }
@@ -8,12 +8,16 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/golang/mock/gomock"
 	mockdb "github.com/mativm02/bank_system/db/mock"
 	db "github.com/mativm02/bank_system/db/sqlc"
+	"github.com/mativm02/bank_system/page"
+	"github.com/mativm02/bank_system/policy"
 	"github.com/mativm02/bank_system/token"
 	"github.com/mativm02/bank_system/util"
 	"github.com/stretchr/testify/require"
@@ -110,6 +114,191 @@ func TestGetAccountAPI(t *testing.T) {
 	}
 }
 
+func TestGetAccountByNumberAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	account := randomAccount(user.Username)
+
+	// The last digit is changed, so the check digits do not match.
+	lastDigit := account.Number[len(account.Number)-1]
+	mistyped := account.Number[:len(account.Number)-1] + string('0'+(lastDigit-'0'+1)%10)
+
+	testCases := []struct {
+		name          string
+		number        string
+		buildStub     func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "OK",
+			number: account.Number,
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountByNumber(gomock.Any(), gomock.Eq(account.Number)).Times(1).Return(account, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchAccount(t, recorder.Body, account)
+			},
+		},
+		{
+			name:   "TypedNumber",
+			number: strings.ToLower(account.Number[:4]) + " " + account.Number[4:12] + " " + account.Number[12:],
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountByNumber(gomock.Any(), gomock.Eq(account.Number)).Times(1).Return(account, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "NotFound",
+			number: account.Number,
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountByNumber(gomock.Any(), gomock.Eq(account.Number)).Times(1).Return(db.Account{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:   "InvalidCheckDigits",
+			number: mistyped,
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountByNumber(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStub(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/accounts/"+url.PathEscape(tc.number), nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Hour)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestGetSpendingAllowanceAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	account := randomAccount(user.Username)
+	otherAccount := randomAccount(util.RandomOwner())
+
+	testCases := []struct {
+		name          string
+		accountID     int64
+		buildStub     func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "OK",
+			accountID: account.ID,
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetAccountSpendingLimit(gomock.Any(), gomock.Eq(account.ID)).Times(1).
+					Return(db.AccountSpendingLimit{}, sql.ErrNoRows)
+				userLimit := db.UserSpendingLimit{
+					Username:   account.Owner,
+					Currency:   account.Currency,
+					DailyLimit: sql.NullInt64{Int64: 0, Valid: true},
+				}
+				store.EXPECT().GetUserSpendingLimit(gomock.Any(), gomock.Any()).Times(1).Return(userLimit, nil)
+				store.EXPECT().SumAccountSpending(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.SumAccountSpendingRow{DailyTotal: 400, MonthlyTotal: 1500}, nil)
+				store.EXPECT().SumUserSpending(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.SumUserSpendingRow{DailyTotal: 700, MonthlyTotal: 2500}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp spendingAllowanceResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Equal(t, account.ID, rsp.AccountID)
+				require.Equal(t, db.Allowance{Limit: 1000, Spent: 400, Remaining: 600}, rsp.AccountDaily)
+				require.Equal(t, db.Allowance{Limit: 10000, Spent: 1500, Remaining: 8500}, rsp.AccountMonthly)
+				// The admin lifted the daily limit of the user.
+				require.Equal(t, db.Allowance{Spent: 700}, rsp.UserDaily)
+				require.Equal(t, db.Allowance{Limit: 20000, Spent: 2500, Remaining: 17500}, rsp.UserMonthly)
+			},
+		},
+		{
+			name:      "Unauthorized",
+			accountID: otherAccount.ID,
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(otherAccount.ID)).Times(1).Return(otherAccount, nil)
+				store.EXPECT().SumAccountSpending(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:      "NotFound",
+			accountID: account.ID,
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(db.Account{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:      "InternalError",
+			accountID: account.ID,
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetAccountSpendingLimit(gomock.Any(), gomock.Eq(account.ID)).Times(1).
+					Return(db.AccountSpendingLimit{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:      "InvalidID",
+			accountID: -1,
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStub(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/accounts/%d/spending_allowance", tc.accountID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Hour)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
 func TestCreateAccountAPI(t *testing.T) {
 	user, _ := randomUser(t)
 	account := randomAccount(user.Username)
@@ -127,9 +316,10 @@ func TestCreateAccountAPI(t *testing.T) {
 				arg := db.CreateAccountParams{
 					Owner:    user.Username,
 					Currency: functionBody.Currency,
+					Type:     util.CheckingAccount,
 				}
 
-				store.EXPECT().CreateAccount(gomock.Any(), arg).Times(1).Return(account, nil)
+				store.EXPECT().CreateAccountTx(gomock.Any(), db.CreateAccountTxParams{CreateAccountParams: arg, MaxAccounts: 1}).Times(1).Return(db.CreateAccountTxResult{Account: account}, nil)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
@@ -146,10 +336,11 @@ func TestCreateAccountAPI(t *testing.T) {
 			name: "InternalError",
 			buildStub: func(store *mockdb.MockStore, functionBody createAccountRequest) {
 				arg := db.CreateAccountParams{
-					Currency: functionBody.Currency,
 					Owner:    user.Username,
+					Currency: functionBody.Currency,
+					Type:     util.CheckingAccount,
 				}
-				store.EXPECT().CreateAccount(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.Account{}, sql.ErrConnDone)
+				store.EXPECT().CreateAccountTx(gomock.Any(), gomock.Eq(db.CreateAccountTxParams{CreateAccountParams: arg, MaxAccounts: 1})).Times(1).Return(db.CreateAccountTxResult{}, sql.ErrConnDone)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
@@ -161,10 +352,80 @@ func TestCreateAccountAPI(t *testing.T) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Hour)
 			},
 		},
+		{
+			name: "EmailNotVerified",
+			buildStub: func(store *mockdb.MockStore, functionBody createAccountRequest) {
+				store.EXPECT().CreateAccountTx(gomock.Any(), gomock.Any()).Times(1).Return(db.CreateAccountTxResult{}, db.ErrEmailNotVerified)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+				require.Contains(t, recorder.Body.String(), policy.ReasonEmailNotVerified)
+			},
+			functionBody: createAccountRequest{
+				Currency: account.Currency,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Hour)
+			},
+		},
+		{
+			name: "SavingsAccount",
+			buildStub: func(store *mockdb.MockStore, functionBody createAccountRequest) {
+				arg := db.CreateAccountParams{
+					Owner:    user.Username,
+					Currency: functionBody.Currency,
+					Type:     util.SavingsAccount,
+					Nickname: functionBody.Nickname,
+				}
+				store.EXPECT().CreateAccountTx(gomock.Any(), db.CreateAccountTxParams{CreateAccountParams: arg, MaxAccounts: 3}).Times(1).Return(db.CreateAccountTxResult{Account: account}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+			functionBody: createAccountRequest{
+				Currency: account.Currency,
+				Type:     util.SavingsAccount,
+				Nickname: "Holidays",
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Hour)
+			},
+		},
+		{
+			name: "AccountLimitReached",
+			buildStub: func(store *mockdb.MockStore, functionBody createAccountRequest) {
+				store.EXPECT().CreateAccountTx(gomock.Any(), gomock.Any()).Times(1).Return(db.CreateAccountTxResult{}, db.ErrAccountLimitReached)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+			functionBody: createAccountRequest{
+				Currency: account.Currency,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Hour)
+			},
+		},
+		{
+			name: "InvalidType",
+			buildStub: func(store *mockdb.MockStore, functionBody createAccountRequest) {
+				store.EXPECT().CreateAccountTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+			functionBody: createAccountRequest{
+				Currency: account.Currency,
+				Type:     "invalid",
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Hour)
+			},
+		},
 		{
 			name: "InvalidCurrency",
 			buildStub: func(store *mockdb.MockStore, functionBody createAccountRequest) {
-				store.EXPECT().CreateAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateAccountTx(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusBadRequest, recorder.Code)
@@ -223,26 +484,20 @@ func TestListAccounts(t *testing.T) {
 			name: "OK",
 			buildStub: func(store *mockdb.MockStore, functionBody listAccountsRequest) {
 				arg := db.ListAccountsParams{
-					Owner:  user.Username,
-					Limit:  functionBody.PageSize,
-					Offset: (functionBody.PageID - 1) * functionBody.PageSize,
+					Owner:    user.Username,
+					OrderBy:  db.OrderByID,
+					PageSize: functionBody.PageSize + 1,
 				}
 				store.EXPECT().ListAccounts(gomock.Any(), gomock.Eq(arg)).Times(1).Return(accounts, nil)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
 
-				data, err := ioutil.ReadAll(recorder.Body)
-				require.NoError(t, err)
-
-				var gotAccounts []db.Account
-				err = json.Unmarshal(data, &gotAccounts)
-				require.NoError(t, err)
-
-				require.Equal(t, accounts, gotAccounts)
+				rsp := requireBodyMatchListAccounts(t, recorder.Body)
+				require.Equal(t, accounts, rsp.Accounts)
+				require.Empty(t, rsp.NextPageToken)
 			},
 			functionBody: listAccountsRequest{
-				PageID:   1,
 				PageSize: 5,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
@@ -250,20 +505,58 @@ func TestListAccounts(t *testing.T) {
 			},
 		},
 		{
-			name: "InternalError",
+			name: "DefaultPageSize",
 			buildStub: func(store *mockdb.MockStore, functionBody listAccountsRequest) {
 				arg := db.ListAccountsParams{
-					Owner:  user.Username,
-					Limit:  functionBody.PageSize,
-					Offset: (functionBody.PageID - 1) * functionBody.PageSize,
+					Owner:    user.Username,
+					OrderBy:  db.OrderByID,
+					PageSize: page.DefaultSize + 1,
 				}
-				store.EXPECT().ListAccounts(gomock.Any(), gomock.Eq(arg)).Times(1).Return(nil, sql.ErrConnDone)
+				store.EXPECT().ListAccounts(gomock.Any(), gomock.Eq(arg)).Times(1).Return(accounts, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+			functionBody: listAccountsRequest{},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Hour)
+			},
+		},
+		{
+			name: "FilterAndSort",
+			buildStub: func(store *mockdb.MockStore, functionBody listAccountsRequest) {
+				arg := db.ListAccountsParams{
+					Owner:    user.Username,
+					Currency: sql.NullString{String: util.USD, Valid: true},
+					Type:     sql.NullString{String: util.SavingsAccount, Valid: true},
+					OrderBy:  db.OrderByBalance,
+					SortDesc: true,
+					PageSize: functionBody.PageSize + 1,
+				}
+				store.EXPECT().ListAccounts(gomock.Any(), gomock.Eq(arg)).Times(1).Return(accounts, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+			functionBody: listAccountsRequest{
+				PageSize: 5,
+				Currency: util.USD,
+				Type:     util.SavingsAccount,
+				OrderBy:  "balance desc",
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Hour)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStub: func(store *mockdb.MockStore, functionBody listAccountsRequest) {
+				store.EXPECT().ListAccounts(gomock.Any(), gomock.Any()).Times(1).Return(nil, sql.ErrConnDone)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
 			},
 			functionBody: listAccountsRequest{
-				PageID:   1,
 				PageSize: 5,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
@@ -271,7 +564,7 @@ func TestListAccounts(t *testing.T) {
 			},
 		},
 		{
-			name: "InvalidPageID",
+			name: "InvalidPageToken",
 			buildStub: func(store *mockdb.MockStore, functionBody listAccountsRequest) {
 				store.EXPECT().ListAccounts(gomock.Any(), gomock.Any()).Times(0)
 			},
@@ -279,8 +572,8 @@ func TestListAccounts(t *testing.T) {
 				require.Equal(t, http.StatusBadRequest, recorder.Code)
 			},
 			functionBody: listAccountsRequest{
-				PageID:   -1,
-				PageSize: 5,
+				PageSize:  5,
+				PageToken: "invalid.token",
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Hour)
@@ -295,29 +588,23 @@ func TestListAccounts(t *testing.T) {
 				require.Equal(t, http.StatusBadRequest, recorder.Code)
 			},
 			functionBody: listAccountsRequest{
-				PageID:   1,
-				PageSize: 0,
+				PageSize: 20,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Hour)
 			},
 		},
 		{
-			name: "NotFound",
+			name: "InvalidOrderBy",
 			buildStub: func(store *mockdb.MockStore, functionBody listAccountsRequest) {
-				arg := db.ListAccountsParams{
-					Owner:  user.Username,
-					Limit:  functionBody.PageSize,
-					Offset: (functionBody.PageID - 1) * functionBody.PageSize,
-				}
-				store.EXPECT().ListAccounts(gomock.Any(), gomock.Eq(arg)).Times(1).Return(nil, sql.ErrNoRows)
+				store.EXPECT().ListAccounts(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusNotFound, recorder.Code)
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
 			},
 			functionBody: listAccountsRequest{
-				PageID:   1,
 				PageSize: 5,
+				OrderBy:  "owner",
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Hour)
@@ -334,8 +621,7 @@ func TestListAccounts(t *testing.T) {
 
 			server := newTestServer(t, store)
 			recorder := httptest.NewRecorder()
-			url := fmt.Sprintf("/accounts?page_id=%d&page_size=%d", tc.functionBody.PageID, tc.functionBody.PageSize)
-			request, err := http.NewRequest(http.MethodGet, url, nil)
+			request, err := http.NewRequest(http.MethodGet, listAccountsURL(tc.functionBody), nil)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -347,12 +633,93 @@ func TestListAccounts(t *testing.T) {
 	}
 }
 
+func TestListAccountsNextPage(t *testing.T) {
+	user, _ := randomUser(t)
+	accounts := make([]db.Account, 6)
+	for i := range accounts {
+		accounts[i] = randomAccount(user.Username)
+		accounts[i].ID = int64(i + 1)
+	}
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	server := newTestServer(t, store)
+
+	arg := db.ListAccountsParams{
+		Owner:    user.Username,
+		OrderBy:  db.OrderByID,
+		PageSize: 6,
+	}
+	store.EXPECT().ListAccounts(gomock.Any(), gomock.Eq(arg)).Times(1).Return(accounts, nil)
+
+	req := listAccountsRequest{PageSize: 5}
+	recorder := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodGet, listAccountsURL(req), nil)
+	require.NoError(t, err)
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Hour)
+	server.router.ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	rsp := requireBodyMatchListAccounts(t, recorder.Body)
+	require.Equal(t, accounts[:5], rsp.Accounts)
+	require.NotEmpty(t, rsp.NextPageToken)
+
+	// The next page starts after the last account of the first page.
+	arg.CursorValue = sql.NullInt64{Int64: 5, Valid: true}
+	arg.CursorID = sql.NullInt64{Int64: 5, Valid: true}
+	store.EXPECT().ListAccounts(gomock.Any(), gomock.Eq(arg)).Times(1).Return(accounts[5:], nil)
+
+	req.PageToken = rsp.NextPageToken
+	recorder = httptest.NewRecorder()
+	request, err = http.NewRequest(http.MethodGet, listAccountsURL(req), nil)
+	require.NoError(t, err)
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Hour)
+	server.router.ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	rsp = requireBodyMatchListAccounts(t, recorder.Body)
+	require.Equal(t, accounts[5:], rsp.Accounts)
+	require.Empty(t, rsp.NextPageToken)
+
+	// The token cannot be used with other filters.
+	req.Currency = util.EUR
+	recorder = httptest.NewRecorder()
+	request, err = http.NewRequest(http.MethodGet, listAccountsURL(req), nil)
+	require.NoError(t, err)
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Hour)
+	server.router.ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func listAccountsURL(req listAccountsRequest) string {
+	query := url.Values{}
+	if req.PageSize != 0 {
+		query.Set("page_size", fmt.Sprint(req.PageSize))
+	}
+	if req.PageToken != "" {
+		query.Set("page_token", req.PageToken)
+	}
+	if req.Currency != "" {
+		query.Set("currency", req.Currency)
+	}
+	if req.Type != "" {
+		query.Set("type", req.Type)
+	}
+	if req.OrderBy != "" {
+		query.Set("order_by", req.OrderBy)
+	}
+	return "/accounts?" + query.Encode()
+}
+
 func randomAccount(owner string) db.Account {
 	return db.Account{
 		ID:       util.RandomInt(1, 1000),
 		Owner:    owner,
 		Balance:  util.RandomMoney(),
 		Currency: util.RandomCurrency(),
+		Number:   util.RandomAccountNumber(),
+		Type:     util.CheckingAccount,
 	}
 }
 
@@ -366,3 +733,13 @@ func requireBodyMatchAccount(t *testing.T, body *bytes.Buffer, account db.Accoun
 
 	require.Equal(t, account, gotAccount)
 }
+
+func requireBodyMatchListAccounts(t *testing.T, body *bytes.Buffer) listAccountsResponse {
+	data, err := ioutil.ReadAll(body)
+	require.NoError(t, err)
+
+	var rsp listAccountsResponse
+	err = json.Unmarshal(data, &rsp)
+	require.NoError(t, err)
+	return rsp
+}
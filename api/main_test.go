@@ -11,10 +11,29 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+// testSpendingLimits are the default spending limits of the test server.
+var testSpendingLimits = db.SpendingLimits{
+	Account: db.SpendingLimit{Daily: 1000, Monthly: 10000},
+	User:    db.SpendingLimit{Daily: 2000, Monthly: 20000},
+}
+
+// testCoolingOff is the cooling-off of the new destinations of the test server.
+var testCoolingOff = db.CoolingOff{Period: 24 * time.Hour, MaxAmount: 100}
+
 func newTestServer(t *testing.T, store db.Store) *Server {
 	config := util.Config{
-		TokenSymmetricKey:   util.RandomString(32),
-		AccessTokenDuration: 15 * time.Minute,
+		TokenSymmetricKey:        util.RandomString(32),
+		PageTokenKey:             util.RandomString(32),
+		PayeeCoolingOffPeriod:    testCoolingOff.Period,
+		PayeeCoolingOffMaxAmount: testCoolingOff.MaxAmount,
+		MaxCheckingAccounts:      1,
+		MaxSavingsAccounts:       3,
+		MaxBusinessAccounts:      2,
+		AccountDailySpendLimit:   testSpendingLimits.Account.Daily,
+		AccountMonthlySpendLimit: testSpendingLimits.Account.Monthly,
+		UserDailySpendLimit:      testSpendingLimits.User.Daily,
+		UserMonthlySpendLimit:    testSpendingLimits.User.Monthly,
+		AccessTokenDuration:      15 * time.Minute,
 	}
 
 	server, err := NewServer(config, store)
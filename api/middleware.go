@@ -1,10 +1,15 @@
 package api
 
 import (
+	"database/sql"
 	"errors"
+	"fmt"
+	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/mativm02/bank_system/apikey"
+	"github.com/mativm02/bank_system/policy"
 	"github.com/mativm02/bank_system/token"
 )
 
@@ -14,7 +19,10 @@ const (
 	authorizationPayloadKey = "authorization_payload"
 )
 
-func authMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
+// authMiddleware authenticates the request with the bearer credential,
+// which is either an access token or an API key of the user.
+// The tokens of revoked API clients, or issued before the client rotated its secret, are rejected.
+func authMiddleware(tokenMaker token.Maker, apiKeys *apikey.Authenticator) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		authorizationHeader := ctx.GetHeader(authorizationHeaderKey)
 		if len(authorizationHeader) == 0 {
@@ -38,8 +46,17 @@ func authMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
 			return
 		}
 
-		accessToken := fields[1]
-		payload, err := tokenMaker.VerifyToken(accessToken)
+		credential := fields[1]
+		var payload *token.Payload
+		var err error
+		if apikey.IsKey(credential) {
+			payload, err = apiKeys.Authenticate(ctx, credential)
+		} else {
+			payload, err = tokenMaker.VerifyToken(credential)
+			if err == nil {
+				err = apiKeys.VerifyClient(ctx, payload)
+			}
+		}
 		if err != nil {
 			ctx.AbortWithStatusJSON(401, errorResponse(err))
 			return
@@ -49,3 +66,52 @@ func authMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
 		ctx.Next()
 	}
 }
+
+// policyMiddleware rejects the request with 403 if the authenticated user is not allowed
+// to perform the operation. The reason is returned so clients can prompt the user.
+func policyMiddleware(checker *policy.Checker, operation policy.Operation) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+		err := checker.Check(ctx, authPayload.Username, operation)
+		if err != nil {
+			var policyErr *policy.Error
+			switch {
+			case errors.As(err, &policyErr):
+				ctx.AbortWithStatusJSON(http.StatusForbidden, policyErrorResponse(policyErr, policyErr.Reason))
+			case errors.Is(err, sql.ErrNoRows):
+				ctx.AbortWithStatusJSON(http.StatusNotFound, errorResponse(err))
+			default:
+				ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+			}
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+// policyErrorResponse tells the client why the policy does not allow the operation,
+// with the reason of the policy.
+func policyErrorResponse(err error, reason string) gin.H {
+	return gin.H{
+		"error":  err.Error(),
+		"reason": reason,
+	}
+}
+
+// scopeMiddleware rejects the request with 403 if the token was issued to an API client
+// that was not granted the scope. User tokens are not limited by scopes.
+func scopeMiddleware(scope string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+		if !authPayload.HasScope(scope) {
+			err := fmt.Errorf("token is missing the %s scope", scope)
+			ctx.AbortWithStatusJSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+
+		ctx.Next()
+	}
+}
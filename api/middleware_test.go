@@ -1,6 +1,7 @@
 package api
 
 import (
+	"database/sql"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -8,13 +9,17 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/mativm02/bank_system/apikey"
+	mockdb "github.com/mativm02/bank_system/db/mock"
+	db "github.com/mativm02/bank_system/db/sqlc"
 	"github.com/mativm02/bank_system/token"
 	"github.com/mativm02/bank_system/util"
 	"github.com/stretchr/testify/require"
 )
 
 func addAuthorization(t *testing.T, request *http.Request, tokenMaker token.Maker, authorizationType string, username string, duration time.Duration) {
-	token, _, err := tokenMaker.CreateToken(username, duration)
+	token, _, err := tokenMaker.CreateToken(username, util.DepositorRole, duration)
 	require.NoError(t, err)
 
 	authorizationHeader := fmt.Sprintf("%s %s", authorizationType, token)
@@ -82,7 +87,7 @@ func TestAuthMiddleware(t *testing.T) {
 			server := newTestServer(t, nil)
 			// Create a new route only for this test
 			authPath := "/auth"
-			server.router.GET(authPath, authMiddleware(server.tokenMaker), func(ctx *gin.Context) {
+			server.router.GET(authPath, authMiddleware(server.tokenMaker, server.apiKeys), func(ctx *gin.Context) {
 				ctx.JSON(http.StatusOK, gin.H{})
 			})
 			// Create a new recorder and send a request to the server
@@ -100,3 +105,218 @@ func TestAuthMiddleware(t *testing.T) {
 
 	}
 }
+
+func TestScopeMiddleware(t *testing.T) {
+	testCases := []struct {
+		name          string
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "UserToken",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, util.RandomOwner(), time.Hour)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "ClientTokenWithScope",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addClientAuthorization(t, request, tokenMaker, []string{token.ScopeAccountsRead})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "ClientTokenWithoutScope",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addClientAuthorization(t, request, tokenMaker, []string{token.ScopeTransfersRead})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetApiClient(gomock.Any(), gomock.Any()).AnyTimes().Return(db.ApiClient{SecretRotatedAt: time.Now().Add(-time.Hour)}, nil)
+
+			server := newTestServer(t, store)
+			scopePath := "/scope"
+			server.router.GET(scopePath, authMiddleware(server.tokenMaker, server.apiKeys), scopeMiddleware(token.ScopeAccountsRead), func(ctx *gin.Context) {
+				ctx.JSON(http.StatusOK, gin.H{})
+			})
+
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodGet, scopePath, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func addClientAuthorization(t *testing.T, request *http.Request, tokenMaker token.Maker, scopes []string) {
+	addClientTokenAuthorization(t, request, tokenMaker, util.RandomString(12), scopes)
+}
+
+func addClientTokenAuthorization(t *testing.T, request *http.Request, tokenMaker token.Maker, clientID string, scopes []string) {
+	token, _, err := tokenMaker.CreateClientToken(clientID, util.RandomOwner(), scopes, time.Hour)
+	require.NoError(t, err)
+
+	request.Header.Set(authorizationHeaderKey, fmt.Sprintf("%s %s", authorizationTypeBearer, token))
+}
+
+func TestAuthMiddlewareAPIClient(t *testing.T) {
+	client := db.ApiClient{
+		ID:              util.RandomString(12),
+		Owner:           util.RandomOwner(),
+		Scopes:          []string{token.ScopeAccountsRead},
+		SecretRotatedAt: time.Now().Add(-time.Hour),
+	}
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetApiClient(gomock.Any(), gomock.Eq(client.ID)).Times(1).Return(client, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "Revoked",
+			buildStubs: func(store *mockdb.MockStore) {
+				revoked := client
+				revoked.RevokedAt = sql.NullTime{Time: time.Now(), Valid: true}
+				store.EXPECT().GetApiClient(gomock.Any(), gomock.Eq(client.ID)).Times(1).Return(revoked, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "IssuedBeforeRotation",
+			buildStubs: func(store *mockdb.MockStore) {
+				rotated := client
+				rotated.SecretRotatedAt = time.Now().Add(time.Minute)
+				store.EXPECT().GetApiClient(gomock.Any(), gomock.Eq(client.ID)).Times(1).Return(rotated, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			authPath := "/auth"
+			server.router.GET(authPath, authMiddleware(server.tokenMaker, server.apiKeys), func(ctx *gin.Context) {
+				ctx.JSON(http.StatusOK, gin.H{})
+			})
+
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodGet, authPath, nil)
+			require.NoError(t, err)
+
+			addClientTokenAuthorization(t, request, server.tokenMaker, client.ID, client.Scopes)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestAuthMiddlewareAPIKey(t *testing.T) {
+	key, prefix, hashedKey, err := apikey.Generate()
+	require.NoError(t, err)
+
+	apiKey := db.ApiKey{
+		ID:        util.RandomInt(1, 1000),
+		Owner:     util.RandomOwner(),
+		Prefix:    prefix,
+		HashedKey: hashedKey,
+		Scopes:    []string{token.ScopeAccountsRead},
+	}
+
+	testCases := []struct {
+		name          string
+		key           string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			key:  key,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetApiKeyByPrefix(gomock.Any(), gomock.Eq(prefix)).Times(1).Return(apiKey, nil)
+				store.EXPECT().TouchApiKey(gomock.Any(), gomock.Eq(apiKey.ID)).Times(1).Return(nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "WrongKey",
+			key:  apikey.Prefix + prefix + "_" + util.RandomString(64),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetApiKeyByPrefix(gomock.Any(), gomock.Eq(prefix)).Times(1).Return(apiKey, nil)
+				store.EXPECT().TouchApiKey(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			authPath := "/auth"
+			server.router.GET(authPath, authMiddleware(server.tokenMaker, server.apiKeys), scopeMiddleware(token.ScopeAccountsRead), func(ctx *gin.Context) {
+				ctx.JSON(http.StatusOK, gin.H{})
+			})
+
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodGet, authPath, nil)
+			require.NoError(t, err)
+
+			request.Header.Set(authorizationHeaderKey, fmt.Sprintf("%s %s", authorizationTypeBearer, tc.key))
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
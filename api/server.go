@@ -6,32 +6,55 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
+	"github.com/mativm02/bank_system/apikey"
 	db "github.com/mativm02/bank_system/db/sqlc"
+	"github.com/mativm02/bank_system/page"
+	"github.com/mativm02/bank_system/policy"
 	"github.com/mativm02/bank_system/token"
 	"github.com/mativm02/bank_system/util"
+	"github.com/mativm02/bank_system/val"
 )
 
 // Server servers HTTP requests to the API.
 type Server struct {
-	store      db.Store    // It will allow us to access the database.
-	router     *gin.Engine // It will allow us to access the router.
-	tokenMaker token.Maker // It will allow us to access the token maker.
-	config     util.Config // It will allow us to access the configuration.
+	store          db.Store    // It will allow us to access the database.
+	router         *gin.Engine // It will allow us to access the router.
+	tokenMaker     token.Maker // It will allow us to access the token maker.
+	config         util.Config // It will allow us to access the configuration.
+	policy         *policy.Checker
+	passwordHasher *util.PasswordHasher
+	passwordPolicy val.PasswordPolicy
+	apiKeys        *apikey.Authenticator
+	pageTokens     *page.Signer
 }
 
 func NewServer(config util.Config, store db.Store) (*Server, error) {
-	tokenMaker, err := token.NewPasetoMaker(config.TokenSymmetricKey)
+	tokenMaker, err := token.NewMaker(config)
 	if err != nil {
 		return nil, fmt.Errorf("cannot create token maker: %w", err)
 	}
+	passwordHasher, err := util.NewPasswordHasher(config)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create password hasher: %w", err)
+	}
+	pageTokens, err := page.NewSigner(config.PageTokenKey)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create page token signer: %w", err)
+	}
 	server := &Server{
-		store:      store,
-		tokenMaker: tokenMaker,
-		config:     config,
+		store:          store,
+		tokenMaker:     tokenMaker,
+		config:         config,
+		policy:         policy.NewChecker(store),
+		passwordHasher: passwordHasher,
+		passwordPolicy: val.NewPasswordPolicy(config),
+		apiKeys:        apikey.NewAuthenticator(store),
+		pageTokens:     pageTokens,
 	}
 
 	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
 		v.RegisterValidation("currency", validCurrency)
+		v.RegisterValidation("account_type", validAccountType)
 	}
 
 	server.setupRouter()
@@ -46,13 +69,16 @@ func (server *Server) setupRouter() {
 	router.POST("/users/login", server.loginUser)
 	router.POST("/token/renew_access", server.renewAccessToken)
 
-	authRoutes := router.Group("/").Use(authMiddleware(server.tokenMaker))
+	authRoutes := router.Group("/").Use(authMiddleware(server.tokenMaker, server.apiKeys))
 
-	authRoutes.POST("/accounts", server.createAccount)
-	authRoutes.GET("/accounts/:id", server.getAccount)
-	authRoutes.GET("/accounts", server.listAccounts)
+	authRoutes.POST("/accounts", scopeMiddleware(token.ScopeAccountsWrite), server.createAccount)
+	authRoutes.GET("/accounts/:id", scopeMiddleware(token.ScopeAccountsRead), server.getAccount)
+	authRoutes.GET("/accounts", scopeMiddleware(token.ScopeAccountsRead), server.listAccounts)
+	authRoutes.GET("/accounts/:id/spending_allowance", scopeMiddleware(token.ScopeAccountsRead), server.getSpendingAllowance)
 
-	authRoutes.POST("/transfers", server.createTransfer)
+	authRoutes.POST("/transfers", scopeMiddleware(token.ScopeTransfersWrite), policyMiddleware(server.policy, policy.CreateTransfer), server.createTransfer)
+	authRoutes.GET("/transfers", scopeMiddleware(token.ScopeTransfersRead), server.listTransfers)
+	authRoutes.POST("/transfers/quote", scopeMiddleware(token.ScopeTransfersRead), server.quoteTransfer)
 
 	server.router = router
 }
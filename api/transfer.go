@@ -5,17 +5,24 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	db "github.com/mativm02/bank_system/db/sqlc"
+	"github.com/mativm02/bank_system/page"
 	"github.com/mativm02/bank_system/token"
+	"github.com/mativm02/bank_system/util"
+	"github.com/mativm02/bank_system/val"
 )
 
+// transferRequest names the destination by account ID, by account number or by the ID of a payee of the user.
 type transferRequest struct {
-	FromAccountID int64  `json:"from_account_id" binding:"required,min=1"`
-	ToAccountID   int64  `json:"to_account_id" binding:"required,min=1"`
-	Amount        int64  `json:"amount" binding:"required,gt=0"`
-	Currency      string `json:"currency" binding:"required,currency"`
+	FromAccountID   int64  `json:"from_account_id" binding:"required,min=1"`
+	ToAccountID     int64  `json:"to_account_id" binding:"omitempty,min=1"`
+	ToAccountNumber string `json:"to_account_number"`
+	PayeeID         int64  `json:"payee_id" binding:"omitempty,min=1"`
+	Amount          int64  `json:"amount" binding:"required,gt=0"`
+	Currency        string `json:"currency" binding:"required,currency"`
 }
 
 func (server *Server) createTransfer(ctx *gin.Context) {
@@ -25,37 +32,169 @@ func (server *Server) createTransfer(ctx *gin.Context) {
 		return
 	}
 
-	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
-
-	fromAccount, valid := server.validAccount(ctx, req.FromAccountID, req.Currency)
+	fromAccount, toAccount, valid := server.validTransfer(ctx, req)
 	if !valid {
 		return
 	}
 
-	if fromAccount.Owner != authPayload.Username {
-		err := errors.New("from account does not belong to the authenticated user")
-		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+	arg := db.TransferTxParams{
+		CreateTransferParams: db.CreateTransferParams{
+			FromAccountID: fromAccount.ID,
+			ToAccountID:   toAccount.ID,
+			Amount:        req.Amount,
+		},
+		Limits: server.defaultSpendingLimits(),
+		// The cooling-off applies to the destination, however the request names it.
+		CoolingOff: db.CoolingOff{
+			Period:    server.config.PayeeCoolingOffPeriod,
+			MaxAmount: server.config.PayeeCoolingOffMaxAmount,
+		},
+	}
+
+	result, err := server.store.TransferTx(ctx, arg)
+	if err != nil {
+		if errors.Is(err, db.ErrSpendingLimitExceeded) || errors.Is(err, db.ErrCoolingOff) {
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// defaultSpendingLimits are the configured limits of the accounts and users without limits set by an admin.
+func (server *Server) defaultSpendingLimits() db.SpendingLimits {
+	return db.SpendingLimits{
+		Account: db.SpendingLimit{
+			Daily:   server.config.AccountDailySpendLimit,
+			Monthly: server.config.AccountMonthlySpendLimit,
+		},
+		User: db.SpendingLimit{
+			Daily:   server.config.UserDailySpendLimit,
+			Monthly: server.config.UserMonthlySpendLimit,
+		},
+	}
+}
+
+type transferQuoteResponse struct {
+	FromAccountID int64  `json:"from_account_id"`
+	ToAccountID   int64  `json:"to_account_id"`
+	Amount        int64  `json:"amount"`
+	Fee           int64  `json:"fee"`
+	Total         int64  `json:"total"`
+	Currency      string `json:"currency"`
+}
+
+// quoteTransfer previews the fee of a transfer without moving money.
+func (server *Server) quoteTransfer(ctx *gin.Context) {
+	var req transferRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
-	_, valid = server.validAccount(ctx, req.ToAccountID, req.Currency)
+	fromAccount, toAccount, valid := server.validTransfer(ctx, req)
 	if !valid {
 		return
 	}
 
-	arg := db.CreateTransferParams{
-		FromAccountID: req.FromAccountID,
-		ToAccountID:   req.ToAccountID,
+	fee, err := db.TransferFee(ctx, server.store, fromAccount, toAccount, req.Amount)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, transferQuoteResponse{
+		FromAccountID: fromAccount.ID,
+		ToAccountID:   toAccount.ID,
 		Amount:        req.Amount,
+		Fee:           fee,
+		Total:         req.Amount + fee,
+		Currency:      fromAccount.Currency,
+	})
+}
+
+// validTransfer resolves the destination of the transfer and checks that the user can send the amount
+// between the accounts. It writes the error response when the transfer is not valid.
+func (server *Server) validTransfer(ctx *gin.Context, req transferRequest) (fromAccount, toAccount db.Account, valid bool) {
+	destinations := 0
+	for _, set := range []bool{req.ToAccountID != 0, req.ToAccountNumber != "", req.PayeeID != 0} {
+		if set {
+			destinations++
+		}
+	}
+	if destinations != 1 {
+		err := errors.New("exactly one of to_account_id, to_account_number and payee_id must be set")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
 	}
 
-	result, err := server.store.TransferTx(ctx, arg)
+	if req.ToAccountNumber != "" {
+		number := util.NormalizeAccountNumber(req.ToAccountNumber)
+		if err := val.ValidateAccountNumber(number); err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("to_account_number %w", err)))
+			return
+		}
+
+		account, err := server.store.GetAccountByNumber(ctx, number)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				ctx.JSON(http.StatusNotFound, errorResponse(err))
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		req.ToAccountID = account.ID
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	if req.PayeeID != 0 {
+		var payee db.Payee
+		payee, valid = server.validPayee(ctx, req.PayeeID, authPayload.Username)
+		if !valid {
+			return
+		}
+		req.ToAccountID = payee.AccountID
+	}
+
+	fromAccount, valid = server.validAccount(ctx, req.FromAccountID, req.Currency)
+	if !valid {
+		return
+	}
+
+	if fromAccount.Owner != authPayload.Username {
+		err := errors.New("from account does not belong to the authenticated user")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return fromAccount, toAccount, false
+	}
+
+	toAccount, valid = server.validAccount(ctx, req.ToAccountID, req.Currency)
+	return
+}
+
+// validPayee checks that the payee belongs to the user.
+func (server *Server) validPayee(ctx *gin.Context, payeeID int64, username string) (db.Payee, bool) {
+	payee, err := server.store.GetPayee(ctx, payeeID)
 	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return payee, false
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
-		return
+		return payee, false
 	}
 
-	ctx.JSON(http.StatusOK, result)
+	if payee.Owner != username {
+		err := errors.New("payee does not belong to the authenticated user")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return payee, false
+	}
+
+	return payee, true
 }
 
 func (server *Server) validAccount(ctx *gin.Context, accountID int64, currency string) (db.Account, bool) {
@@ -77,3 +216,86 @@ func (server *Server) validAccount(ctx *gin.Context, accountID int64, currency s
 
 	return account, true
 }
+
+var transferOrderFields = []string{db.OrderByCreatedAt, db.OrderByAmount}
+
+type listTransfersRequest struct {
+	PageSize              int32     `form:"page_size" binding:"omitempty,min=5,max=10"`
+	PageToken             string    `form:"page_token"`
+	AccountID             int64     `form:"account_id" binding:"omitempty,min=1"`
+	CounterpartyAccountID int64     `form:"counterparty_account_id" binding:"omitempty,min=1"`
+	Currency              string    `form:"currency" binding:"omitempty,currency"`
+	StartTime             time.Time `form:"start_time" time_format:"2006-01-02T15:04:05Z07:00"`
+	EndTime               time.Time `form:"end_time" time_format:"2006-01-02T15:04:05Z07:00"`
+	MinAmount             int64     `form:"min_amount" binding:"omitempty,gt=0"`
+	MaxAmount             int64     `form:"max_amount" binding:"omitempty,gt=0"`
+	OrderBy               string    `form:"order_by"`
+}
+
+type listTransfersResponse struct {
+	Transfers     []db.Transfer `json:"transfers"`
+	NextPageToken string        `json:"next_page_token,omitempty"`
+}
+
+func (server *Server) listTransfers(ctx *gin.Context) {
+	var req listTransfersRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !req.StartTime.IsZero() && !req.EndTime.IsZero() && !req.EndTime.After(req.StartTime) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("end_time must be after start_time")))
+		return
+	}
+	if req.MinAmount != 0 && req.MaxAmount != 0 && req.MaxAmount < req.MinAmount {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("max_amount must not be less than min_amount")))
+		return
+	}
+
+	order, err := page.ParseOrderBy(req.OrderBy, transferOrderFields, page.Order{Field: db.OrderByCreatedAt, Desc: true})
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("order_by %w", err)))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	arg := db.ListTransfersParams{
+		Owner:                 authPayload.Username,
+		AccountID:             sql.NullInt64{Int64: req.AccountID, Valid: req.AccountID != 0},
+		CounterpartyAccountID: sql.NullInt64{Int64: req.CounterpartyAccountID, Valid: req.CounterpartyAccountID != 0},
+		Currency:              sql.NullString{String: req.Currency, Valid: req.Currency != ""},
+		StartTime:             sql.NullTime{Time: req.StartTime, Valid: !req.StartTime.IsZero()},
+		EndTime:               sql.NullTime{Time: req.EndTime, Valid: !req.EndTime.IsZero()},
+		MinAmount:             sql.NullInt64{Int64: req.MinAmount, Valid: req.MinAmount != 0},
+		MaxAmount:             sql.NullInt64{Int64: req.MaxAmount, Valid: req.MaxAmount != 0},
+		OrderBy:               order.Field,
+		SortDesc:              order.Desc,
+	}
+
+	query := page.Fingerprint(arg)
+	arg.CursorValue, arg.CursorID, err = server.pageTokens.Cursor(req.PageToken, query)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	// One more transfer is fetched to know whether there is a next page.
+	pageSize := page.Size(req.PageSize)
+	arg.PageSize = pageSize + 1
+	transfers, err := server.store.ListTransfers(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := listTransfersResponse{Transfers: transfers}
+	if len(transfers) > int(pageSize) {
+		rsp.Transfers = transfers[:pageSize]
+		last := rsp.Transfers[pageSize-1]
+		rsp.NextPageToken = server.pageTokens.Encode(page.Token{Value: last.SortValue(order.Field), ID: last.ID, Query: query})
+	}
+
+	ctx.JSON(http.StatusOK, rsp)
+}
@@ -10,11 +10,12 @@ import (
 	"github.com/lib/pq"
 	db "github.com/mativm02/bank_system/db/sqlc"
 	"github.com/mativm02/bank_system/util"
+	"github.com/rs/zerolog/log"
 )
 
 type createUserRequest struct {
 	Username string `json:"username" binding:"required,alphanum"`
-	Password string `json:"password" binding:"required,min=6"`
+	Password string `json:"password" binding:"required"`
 	FullName string `json:"full_name" binding:"required"`
 	Email    string `json:"email" binding:"required,email"`
 }
@@ -44,7 +45,12 @@ func (server *Server) createUser(ctx *gin.Context) {
 		return
 	}
 
-	hashedPassword, err := util.HashPassword(req.Password)
+	if violations := server.passwordPolicy.Validate(req.Password, req.Username); len(violations) > 0 {
+		ctx.JSON(http.StatusBadRequest, passwordPolicyResponse(violations))
+		return
+	}
+
+	hashedPassword, err := server.passwordHasher.Hash(req.Password)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
@@ -74,6 +80,18 @@ func (server *Server) createUser(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, rsp)
 }
 
+// passwordPolicyResponse lists the message of every password rule that was broken.
+func passwordPolicyResponse(violations []error) gin.H {
+	messages := make([]string, len(violations))
+	for i, err := range violations {
+		messages[i] = err.Error()
+	}
+	return gin.H{
+		"error":      "password does not follow the password policy",
+		"violations": messages,
+	}
+}
+
 type loginUserRequest struct {
 	Username string `json:"username" binding:"required,alphanum"`
 	Password string `json:"password" binding:"required,min=6"`
@@ -111,8 +129,13 @@ func (server *Server) loginUser(ctx *gin.Context) {
 		return
 	}
 
+	if server.passwordHasher.NeedsRehash(user.HashedPassword) {
+		server.rehashPassword(ctx, user, req.Password)
+	}
+
 	accessToken, accessPayload, err := server.tokenMaker.CreateToken(
 		user.Username,
+		user.Role,
 		server.config.AccessTokenDuration,
 	)
 	if err != nil {
@@ -122,6 +145,7 @@ func (server *Server) loginUser(ctx *gin.Context) {
 
 	refreshToken, refreshPayload, err := server.tokenMaker.CreateToken(
 		user.Username,
+		user.Role,
 		server.config.RefreshTokenDuration,
 	)
 	if err != nil {
@@ -153,3 +177,19 @@ func (server *Server) loginUser(ctx *gin.Context) {
 	}
 	ctx.JSON(http.StatusOK, rsp)
 }
+
+// rehashPassword replaces an outdated hash of the password of the user.
+// A failure is only logged: the user is authenticated and the hash is upgraded on a later login.
+func (server *Server) rehashPassword(ctx *gin.Context, user db.User, password string) {
+	hashedPassword, err := server.passwordHasher.Hash(password)
+	if err == nil {
+		err = server.store.UpdateUserHashedPassword(ctx, db.UpdateUserHashedPasswordParams{
+			Username:          user.Username,
+			HashedPassword:    hashedPassword,
+			OldHashedPassword: user.HashedPassword,
+		})
+	}
+	if err != nil {
+		log.Error().Err(err).Str("username", user.Username).Msg("cannot rehash password")
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/gin-gonic/gin"
@@ -18,6 +19,7 @@ import (
 	db "github.com/mativm02/bank_system/db/sqlc"
 	"github.com/mativm02/bank_system/util"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
 )
 
 type eqCreateUserParamsMatcher struct {
@@ -48,6 +50,31 @@ func EqCreateUserParams(arg db.CreateUserParams, password string) gomock.Matcher
 	return eqCreateUserParamsMatcher{arg, password}
 }
 
+type eqRehashParamsMatcher struct {
+	user     db.User
+	password string
+}
+
+func (e eqRehashParamsMatcher) Matches(x interface{}) bool {
+	arg, ok := x.(db.UpdateUserHashedPasswordParams)
+	if !ok {
+		return false
+	}
+
+	return arg.Username == e.user.Username &&
+		arg.OldHashedPassword == e.user.HashedPassword &&
+		strings.HasPrefix(arg.HashedPassword, "$argon2id$") &&
+		util.CheckPassword(e.password, arg.HashedPassword) == nil
+}
+
+func (e eqRehashParamsMatcher) String() string {
+	return fmt.Sprintf("rehashes the password %v of user %v", e.password, e.user.Username)
+}
+
+func EqRehashParams(user db.User, password string) gomock.Matcher {
+	return eqRehashParamsMatcher{user, password}
+}
+
 func TestCreateUserAPI(t *testing.T) {
 	user, password := randomUser(t)
 
@@ -168,6 +195,42 @@ func TestCreateUserAPI(t *testing.T) {
 				require.Equal(t, http.StatusBadRequest, recorder.Code)
 			},
 		},
+		{
+			name: "CommonPassword",
+			body: gin.H{
+				"username":  user.Username,
+				"password":  "qwerty123",
+				"full_name": user.FullName,
+				"email":     user.Email,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateUser(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				requireBodyMatchViolations(t, recorder.Body, []string{"must not be a commonly used password"})
+			},
+		},
+		{
+			name: "PasswordContainsUsername",
+			body: gin.H{
+				"username":  user.Username,
+				"password":  "x" + user.Username + "9",
+				"full_name": user.FullName,
+				"email":     user.Email,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateUser(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				requireBodyMatchViolations(t, recorder.Body, []string{"must not contain the username"})
+			},
+		},
 	}
 
 	for i := range testCases {
@@ -225,6 +288,34 @@ func TestLoginUserAPI(t *testing.T) {
 				require.Equal(t, http.StatusOK, recorder.Code)
 			},
 		},
+		{
+			name: "RehashOutdatedPassword",
+			body: gin.H{
+				"username": user.Username,
+				"password": password,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				bcryptHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+				require.NoError(t, err)
+
+				outdatedUser := user
+				outdatedUser.HashedPassword = string(bcryptHash)
+
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(outdatedUser, nil)
+				store.EXPECT().
+					UpdateUserHashedPassword(gomock.Any(), EqRehashParams(outdatedUser, password)).
+					Times(1)
+				store.EXPECT().
+					CreateSession(gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
 		{
 			name: "UserNotFound",
 			body: gin.H{
@@ -346,3 +437,15 @@ func requireBodyMatchUser(t *testing.T, body *bytes.Buffer, user db.User) {
 	require.Equal(t, user.Email, gotUser.Email)
 	require.Empty(t, gotUser.HashedPassword)
 }
+
+func requireBodyMatchViolations(t *testing.T, body *bytes.Buffer, violations []string) {
+	data, err := ioutil.ReadAll(body)
+	require.NoError(t, err)
+
+	var rsp struct {
+		Violations []string `json:"violations"`
+	}
+	err = json.Unmarshal(data, &rsp)
+	require.NoError(t, err)
+	require.Equal(t, violations, rsp.Violations)
+}
@@ -11,3 +11,10 @@ var validCurrency validator.Func = func(fl validator.FieldLevel) bool {
 	}
 	return false
 }
+
+var validAccountType validator.Func = func(fl validator.FieldLevel) bool {
+	if accountType, ok := fl.Field().Interface().(string); ok {
+		return util.IsSupportedAccountType(accountType)
+	}
+	return false
+}
@@ -0,0 +1,137 @@
+package apikey
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	db "github.com/mativm02/bank_system/db/sqlc"
+	"github.com/mativm02/bank_system/token"
+	"github.com/mativm02/bank_system/util"
+)
+
+// Prefix starts every API key, so they can be told apart from access tokens and found by secret scanners.
+const Prefix = "sbk_"
+
+const (
+	lookupSize = 6
+	secretSize = 32
+)
+
+var (
+	ErrKeyInvalid    = errors.New("API key invalid")
+	ErrKeyExpired    = errors.New("API key expired")
+	ErrClientRevoked = errors.New("API client revoked")
+)
+
+// Generate returns a new API key sbk_<prefix>_<secret> along with its prefix, which is stored
+// in clear to look the key up, and the hash of the whole key, which is stored instead of the key.
+func Generate() (key string, prefix string, hashedKey string, err error) {
+	prefix, err = util.GenerateSecret("", lookupSize)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	secret, err := util.GenerateSecret("", secretSize)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	key = Prefix + prefix + "_" + secret
+	return key, prefix, util.HashSecret(key), nil
+}
+
+// IsKey reports whether the credential looks like an API key rather than an access token.
+func IsKey(credential string) bool {
+	return strings.HasPrefix(credential, Prefix)
+}
+
+func parse(key string) (prefix string, ok bool) {
+	if !IsKey(key) {
+		return "", false
+	}
+
+	prefix, secret, ok := strings.Cut(strings.TrimPrefix(key, Prefix), "_")
+	if !ok || prefix == "" || secret == "" {
+		return "", false
+	}
+	return prefix, true
+}
+
+// Authenticator verifies the API keys against the ones stored in the database,
+// and the tokens of the API clients against the clients.
+type Authenticator struct {
+	store db.Store
+}
+
+func NewAuthenticator(store db.Store) *Authenticator {
+	return &Authenticator{
+		store: store,
+	}
+}
+
+// Authenticate returns a payload acting for the owner of the key, limited to the scopes of the key.
+// The payload is never turned into a token, so it has no ID and expires with the key, if ever.
+func (authenticator *Authenticator) Authenticate(ctx context.Context, key string) (*token.Payload, error) {
+	prefix, ok := parse(key)
+	if !ok {
+		return nil, ErrKeyInvalid
+	}
+
+	apiKey, err := authenticator.store.GetApiKeyByPrefix(ctx, prefix)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrKeyInvalid
+		}
+		return nil, fmt.Errorf("cannot get API key: %w", err)
+	}
+
+	if !util.CheckSecret(key, apiKey.HashedKey) || apiKey.RevokedAt.Valid {
+		return nil, ErrKeyInvalid
+	}
+
+	if apiKey.ExpiresAt.Valid && apiKey.ExpiresAt.Time.Before(time.Now()) {
+		return nil, ErrKeyExpired
+	}
+
+	err = authenticator.store.TouchApiKey(ctx, apiKey.ID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot update API key last use: %w", err)
+	}
+
+	payload := &token.Payload{
+		Username: apiKey.Owner,
+		APIKeyID: apiKey.ID,
+		Scopes:   apiKey.Scopes,
+		IssuedAt: apiKey.CreatedAt,
+	}
+	if apiKey.ExpiresAt.Valid {
+		payload.ExpiredAt = apiKey.ExpiresAt.Time
+	}
+	return payload, nil
+}
+
+// VerifyClient returns ErrClientRevoked if the payload was issued to an API client that has been revoked,
+// or before the client rotated its secret, so revoking or rotating also stops the tokens already issued.
+// The payloads of the users and of the API keys are not checked.
+func (authenticator *Authenticator) VerifyClient(ctx context.Context, payload *token.Payload) error {
+	if payload.ClientID == "" {
+		return nil
+	}
+
+	client, err := authenticator.store.GetApiClient(ctx, payload.ClientID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrClientRevoked
+		}
+		return fmt.Errorf("cannot get API client: %w", err)
+	}
+
+	if client.RevokedAt.Valid || payload.IssuedAt.Before(client.SecretRotatedAt) {
+		return ErrClientRevoked
+	}
+	return nil
+}
@@ -0,0 +1,231 @@
+package apikey
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/mativm02/bank_system/db/mock"
+	db "github.com/mativm02/bank_system/db/sqlc"
+	"github.com/mativm02/bank_system/token"
+	"github.com/mativm02/bank_system/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	key, prefix, hashedKey, err := Generate()
+	require.NoError(t, err)
+	require.True(t, IsKey(key))
+	require.True(t, strings.HasPrefix(key, Prefix+prefix+"_"))
+	require.True(t, util.CheckSecret(key, hashedKey))
+
+	parsed, ok := parse(key)
+	require.True(t, ok)
+	require.Equal(t, prefix, parsed)
+
+	_, ok = parse(Prefix + prefix)
+	require.False(t, ok)
+}
+
+func TestAuthenticate(t *testing.T) {
+	key, prefix, hashedKey, err := Generate()
+	require.NoError(t, err)
+
+	apiKey := db.ApiKey{
+		ID:        util.RandomInt(1, 1000),
+		Owner:     util.RandomOwner(),
+		Name:      util.RandomString(8),
+		Prefix:    prefix,
+		HashedKey: hashedKey,
+		Scopes:    []string{token.ScopeAccountsRead},
+		CreatedAt: time.Now(),
+	}
+
+	testCases := []struct {
+		name          string
+		key           string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, payload *token.Payload, err error)
+	}{
+		{
+			name: "OK",
+			key:  key,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetApiKeyByPrefix(gomock.Any(), gomock.Eq(prefix)).Times(1).Return(apiKey, nil)
+				store.EXPECT().TouchApiKey(gomock.Any(), gomock.Eq(apiKey.ID)).Times(1).Return(nil)
+			},
+			checkResponse: func(t *testing.T, payload *token.Payload, err error) {
+				require.NoError(t, err)
+				require.Equal(t, apiKey.Owner, payload.Username)
+				require.Equal(t, apiKey.ID, payload.APIKeyID)
+				require.Empty(t, payload.Role)
+				require.True(t, payload.HasScope(token.ScopeAccountsRead))
+				require.False(t, payload.HasScope(token.ScopeTransfersWrite))
+			},
+		},
+		{
+			name: "NotFound",
+			key:  key,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetApiKeyByPrefix(gomock.Any(), gomock.Eq(prefix)).Times(1).Return(db.ApiKey{}, sql.ErrNoRows)
+				store.EXPECT().TouchApiKey(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, payload *token.Payload, err error) {
+				require.ErrorIs(t, err, ErrKeyInvalid)
+			},
+		},
+		{
+			name: "WrongSecret",
+			key:  Prefix + prefix + "_" + util.RandomString(64),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetApiKeyByPrefix(gomock.Any(), gomock.Eq(prefix)).Times(1).Return(apiKey, nil)
+				store.EXPECT().TouchApiKey(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, payload *token.Payload, err error) {
+				require.ErrorIs(t, err, ErrKeyInvalid)
+			},
+		},
+		{
+			name: "Revoked",
+			key:  key,
+			buildStubs: func(store *mockdb.MockStore) {
+				revoked := apiKey
+				revoked.RevokedAt = sql.NullTime{Time: time.Now(), Valid: true}
+				store.EXPECT().GetApiKeyByPrefix(gomock.Any(), gomock.Eq(prefix)).Times(1).Return(revoked, nil)
+				store.EXPECT().TouchApiKey(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, payload *token.Payload, err error) {
+				require.ErrorIs(t, err, ErrKeyInvalid)
+			},
+		},
+		{
+			name: "Expired",
+			key:  key,
+			buildStubs: func(store *mockdb.MockStore) {
+				expired := apiKey
+				expired.ExpiresAt = sql.NullTime{Time: time.Now().Add(-time.Minute), Valid: true}
+				store.EXPECT().GetApiKeyByPrefix(gomock.Any(), gomock.Eq(prefix)).Times(1).Return(expired, nil)
+				store.EXPECT().TouchApiKey(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, payload *token.Payload, err error) {
+				require.ErrorIs(t, err, ErrKeyExpired)
+			},
+		},
+		{
+			name: "NotAnAPIKey",
+			key:  util.RandomString(32),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetApiKeyByPrefix(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, payload *token.Payload, err error) {
+				require.ErrorIs(t, err, ErrKeyInvalid)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			payload, err := NewAuthenticator(store).Authenticate(context.Background(), tc.key)
+			tc.checkResponse(t, payload, err)
+		})
+	}
+}
+
+func TestVerifyClient(t *testing.T) {
+	client := db.ApiClient{
+		ID:              util.RandomString(12),
+		Owner:           util.RandomOwner(),
+		Scopes:          []string{token.ScopeAccountsRead},
+		SecretRotatedAt: time.Now().Add(-time.Hour),
+	}
+	payload, err := token.NewClientPayload(client.ID, client.Owner, client.Scopes, time.Minute)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name       string
+		payload    *token.Payload
+		buildStubs func(store *mockdb.MockStore)
+		check      func(t *testing.T, err error)
+	}{
+		{
+			name:    "OK",
+			payload: payload,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetApiClient(gomock.Any(), gomock.Eq(client.ID)).Times(1).Return(client, nil)
+			},
+			check: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name:    "UserToken",
+			payload: &token.Payload{Username: client.Owner},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetApiClient(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name:    "Revoked",
+			payload: payload,
+			buildStubs: func(store *mockdb.MockStore) {
+				revoked := client
+				revoked.RevokedAt = sql.NullTime{Time: time.Now(), Valid: true}
+				store.EXPECT().GetApiClient(gomock.Any(), gomock.Eq(client.ID)).Times(1).Return(revoked, nil)
+			},
+			check: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, ErrClientRevoked)
+			},
+		},
+		{
+			name:    "IssuedBeforeRotation",
+			payload: payload,
+			buildStubs: func(store *mockdb.MockStore) {
+				rotated := client
+				rotated.SecretRotatedAt = payload.IssuedAt.Add(time.Millisecond)
+				store.EXPECT().GetApiClient(gomock.Any(), gomock.Eq(client.ID)).Times(1).Return(rotated, nil)
+			},
+			check: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, ErrClientRevoked)
+			},
+		},
+		{
+			name:    "NotFound",
+			payload: payload,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetApiClient(gomock.Any(), gomock.Eq(client.ID)).Times(1).Return(db.ApiClient{}, sql.ErrNoRows)
+			},
+			check: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, ErrClientRevoked)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			err := NewAuthenticator(store).VerifyClient(context.Background(), tc.payload)
+			tc.check(t, err)
+		})
+	}
+}
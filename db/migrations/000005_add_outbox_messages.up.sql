@@ -0,0 +1,14 @@
+CREATE TABLE "outbox_messages" (
+  "id" bigserial PRIMARY KEY,
+  "task_type" varchar NOT NULL,
+  "payload" jsonb NOT NULL,
+  "queue" varchar NOT NULL,
+  "max_retry" int NOT NULL DEFAULT 0,
+  "process_at" timestamptz NOT NULL DEFAULT (now()),
+  "attempts" int NOT NULL DEFAULT 0,
+  "last_error" varchar,
+  "published_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "outbox_messages" ("id") WHERE "published_at" IS NULL;
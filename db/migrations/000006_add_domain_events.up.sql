@@ -0,0 +1,13 @@
+CREATE TABLE "domain_events" (
+  "id" bigserial PRIMARY KEY,
+  "event_type" varchar NOT NULL,
+  "usernames" varchar[] NOT NULL DEFAULT '{}',
+  "account_ids" bigint[] NOT NULL DEFAULT '{}',
+  "payload" jsonb NOT NULL,
+  "published_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "domain_events" ("id") WHERE "published_at" IS NULL;
+
+CREATE INDEX ON "domain_events" USING GIN ("account_ids");
@@ -0,0 +1,38 @@
+CREATE TABLE "webhook_subscriptions" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "url" varchar NOT NULL,
+  "secret" varchar NOT NULL,
+  "event_types" varchar[] NOT NULL DEFAULT '{}',
+  "is_active" boolean NOT NULL DEFAULT true,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "webhook_deliveries" (
+  "id" bigserial PRIMARY KEY,
+  "subscription_id" bigint NOT NULL,
+  "event_id" bigint NOT NULL,
+  "event_type" varchar NOT NULL,
+  "payload" jsonb NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "attempts" int NOT NULL DEFAULT 0,
+  "response_status" int,
+  "last_error" varchar,
+  "delivered_at" timestamptz,
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "webhook_subscriptions" ("owner");
+
+CREATE UNIQUE INDEX ON "webhook_deliveries" ("subscription_id", "event_id");
+
+COMMENT ON COLUMN "webhook_subscriptions"."event_types" IS 'empty means every event type';
+
+COMMENT ON COLUMN "webhook_deliveries"."status" IS 'pending, succeeded or dead';
+
+ALTER TABLE "webhook_subscriptions" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "webhook_deliveries" ADD FOREIGN KEY ("subscription_id") REFERENCES "webhook_subscriptions" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "webhook_deliveries" ADD FOREIGN KEY ("event_id") REFERENCES "domain_events" ("id");
@@ -0,0 +1,11 @@
+CREATE TABLE "notification_preferences" (
+  "username" varchar PRIMARY KEY,
+  "transfer_mode" varchar NOT NULL DEFAULT 'always',
+  "transfer_threshold" bigint NOT NULL DEFAULT 0,
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+COMMENT ON COLUMN "notification_preferences"."transfer_mode" IS 'always, above_threshold or never';
+
+ALTER TABLE "notification_preferences" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
@@ -0,0 +1 @@
+ALTER TABLE "users" ADD COLUMN "locale" varchar NOT NULL DEFAULT 'en';
@@ -0,0 +1,10 @@
+CREATE TABLE "password_history" (
+  "id" bigserial PRIMARY KEY,
+  "username" varchar NOT NULL,
+  "hashed_password" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "password_history" ("username", "id");
+
+ALTER TABLE "password_history" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
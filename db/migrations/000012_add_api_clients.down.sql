@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "api_clients";
+
+ALTER TABLE "users" DROP COLUMN "role";
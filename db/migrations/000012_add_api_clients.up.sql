@@ -0,0 +1,23 @@
+ALTER TABLE "users" ADD COLUMN "role" varchar NOT NULL DEFAULT 'depositor';
+
+COMMENT ON COLUMN "users"."role" IS 'depositor or admin';
+
+CREATE TABLE "api_clients" (
+  "id" varchar PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "name" varchar NOT NULL,
+  "hashed_secret" varchar NOT NULL,
+  "scopes" varchar[] NOT NULL,
+  "created_by" varchar NOT NULL,
+  "revoked_at" timestamptz,
+  "secret_rotated_at" timestamptz NOT NULL DEFAULT (now()),
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "api_clients" ("owner");
+
+COMMENT ON COLUMN "api_clients"."owner" IS 'user the client acts for';
+
+ALTER TABLE "api_clients" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "api_clients" ADD FOREIGN KEY ("created_by") REFERENCES "users" ("username");
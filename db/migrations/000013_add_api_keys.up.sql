@@ -0,0 +1,20 @@
+CREATE TABLE "api_keys" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "name" varchar NOT NULL,
+  "prefix" varchar UNIQUE NOT NULL,
+  "hashed_key" varchar NOT NULL,
+  "scopes" varchar[] NOT NULL,
+  "expires_at" timestamptz,
+  "last_used_at" timestamptz,
+  "revoked_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "api_keys" ("owner");
+
+COMMENT ON COLUMN "api_keys"."prefix" IS 'public part of the key used to look it up';
+
+COMMENT ON COLUMN "api_keys"."expires_at" IS 'null means the key never expires';
+
+ALTER TABLE "api_keys" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
@@ -0,0 +1,17 @@
+ALTER TABLE "entries" ADD COLUMN "transfer_id" bigint;
+
+-- The entries of a transfer are created in the same transaction as the transfer,
+-- so they share its creation time.
+UPDATE "entries" e SET "transfer_id" = t."id"
+FROM "transfers" t
+WHERE e."created_at" = t."created_at"
+  AND ((e."account_id" = t."from_account_id" AND e."amount" = -t."amount")
+    OR (e."account_id" = t."to_account_id" AND e."amount" = t."amount"));
+
+CREATE INDEX ON "entries" ("account_id", "created_at");
+
+CREATE INDEX ON "entries" ("transfer_id");
+
+COMMENT ON COLUMN "entries"."transfer_id" IS 'transfer that created the entry';
+
+ALTER TABLE "entries" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
@@ -0,0 +1,15 @@
+ALTER TABLE "accounts" ADD COLUMN "number" varchar;
+
+-- The existing accounts get a random number with the check digits computed like util.NewAccountNumber:
+-- 98 minus the digits followed by the prefix SB (28 11) and 00, modulo 97.
+UPDATE "accounts" a SET "number" = 'SB' || lpad((98 - mod((r."digits" || '281100')::numeric, 97))::text, 2, '0') || r."digits"
+FROM (
+  SELECT "id", lpad(floor(random() * 1e16)::bigint::text, 16, '0') AS "digits" FROM "accounts"
+) r
+WHERE a."id" = r."id";
+
+ALTER TABLE "accounts" ALTER COLUMN "number" SET NOT NULL;
+
+CREATE UNIQUE INDEX ON "accounts" ("number");
+
+COMMENT ON COLUMN "accounts"."number" IS 'IBAN-like number with mod-97 check digits';
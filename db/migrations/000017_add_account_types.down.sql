@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS "accounts_owner_currency_type_idx";
+
+-- This fails if a user has opened more than one account in a currency.
+ALTER TABLE "accounts" ADD CONSTRAINT "owner_currency_key" UNIQUE ("owner", "currency");
+
+ALTER TABLE "accounts" DROP COLUMN "nickname";
+
+ALTER TABLE "accounts" DROP COLUMN "type";
@@ -0,0 +1,12 @@
+ALTER TABLE "accounts" ADD COLUMN "type" varchar NOT NULL DEFAULT 'checking';
+
+ALTER TABLE "accounts" ADD COLUMN "nickname" varchar NOT NULL DEFAULT '';
+
+-- The number of accounts of each type per currency is limited by the application instead.
+ALTER TABLE "accounts" DROP CONSTRAINT IF EXISTS "owner_currency_key";
+
+CREATE INDEX ON "accounts" ("owner", "currency", "type");
+
+COMMENT ON COLUMN "accounts"."type" IS 'checking, savings or business';
+
+COMMENT ON COLUMN "accounts"."nickname" IS 'empty when the owner has not named the account';
@@ -0,0 +1,12 @@
+-- This fails once interest has been paid, as the transfers reference the accounts of the bank.
+DELETE FROM "accounts" WHERE "owner" = 'simple-bank' AND "nickname" = 'interest';
+
+DELETE FROM "users" WHERE "username" = 'simple-bank';
+
+ALTER TABLE "accounts" DROP COLUMN "interest_product_id";
+
+DROP TABLE IF EXISTS "interest_accruals";
+
+DROP TABLE IF EXISTS "interest_postings";
+
+DROP TABLE IF EXISTS "interest_products";
@@ -0,0 +1,68 @@
+CREATE TABLE "interest_products" (
+  "id" bigserial PRIMARY KEY,
+  "name" varchar UNIQUE NOT NULL,
+  "annual_rate_bps" bigint NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "interest_postings" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL DEFAULT 0,
+  "carry_micros" bigint NOT NULL DEFAULT 0,
+  "transfer_id" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "interest_accruals" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "product_id" bigint NOT NULL,
+  "accrual_date" date NOT NULL,
+  "balance" bigint NOT NULL,
+  "annual_rate_bps" bigint NOT NULL,
+  "amount_micros" bigint NOT NULL,
+  "posting_id" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "accounts" ADD COLUMN "interest_product_id" bigint;
+
+CREATE INDEX ON "interest_postings" ("account_id", "id");
+
+CREATE UNIQUE INDEX ON "interest_accruals" ("account_id", "accrual_date");
+
+CREATE INDEX ON "interest_accruals" ("account_id") WHERE "posting_id" IS NULL;
+
+COMMENT ON COLUMN "interest_products"."annual_rate_bps" IS 'annual interest rate in basis points, 250 is 2.50%';
+
+COMMENT ON COLUMN "interest_postings"."carry_micros" IS 'accrued interest below one minor unit, carried to the next posting';
+
+COMMENT ON COLUMN "interest_accruals"."balance" IS 'balance the interest was computed on';
+
+COMMENT ON COLUMN "interest_accruals"."amount_micros" IS 'interest of the day in millionths of a minor unit, rounded down';
+
+ALTER TABLE "accounts" ADD FOREIGN KEY ("interest_product_id") REFERENCES "interest_products" ("id");
+
+ALTER TABLE "interest_postings" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "interest_postings" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "interest_accruals" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "interest_accruals" ADD FOREIGN KEY ("product_id") REFERENCES "interest_products" ("id");
+
+ALTER TABLE "interest_accruals" ADD FOREIGN KEY ("posting_id") REFERENCES "interest_postings" ("id");
+
+-- The bank pays the interest from its own accounts, one per currency, owned by a user that cannot log in:
+-- its username is not a valid username for the users and its password hash matches no password.
+-- Its email is not verified, so it gets no notifications.
+INSERT INTO "users" ("username", "hashed_password", "full_name", "email")
+VALUES ('simple-bank', '', 'Simple Bank', 'simple-bank@localhost');
+
+-- The account numbers are computed like in 000016_add_account_numbers.
+INSERT INTO "accounts" ("owner", "balance", "currency", "number", "type", "nickname")
+SELECT 'simple-bank', 0, c."currency", 'SB' || lpad((98 - mod((c."digits" || '281100')::numeric, 97))::text, 2, '0') || c."digits", 'business', 'interest'
+FROM (
+  SELECT "currency", lpad(floor(random() * 1e16)::bigint::text, 16, '0') AS "digits" FROM (VALUES ('USD'), ('EUR'), ('CAD')) v ("currency")
+) c;
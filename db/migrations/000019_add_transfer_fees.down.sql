@@ -0,0 +1,4 @@
+-- The revenue accounts of the bank are kept, as the entries of the fees already paid reference them.
+ALTER TABLE "entries" DROP COLUMN "type";
+
+DROP TABLE IF EXISTS "fee_schedules";
@@ -0,0 +1,30 @@
+CREATE TABLE "fee_schedules" (
+  "id" bigserial PRIMARY KEY,
+  "currency" varchar NOT NULL,
+  "min_amount" bigint NOT NULL DEFAULT 0,
+  "flat_fee" bigint NOT NULL DEFAULT 0,
+  "percentage_bps" bigint NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "entries" ADD COLUMN "type" varchar NOT NULL DEFAULT 'transfer';
+
+CREATE UNIQUE INDEX ON "fee_schedules" ("currency", "min_amount");
+
+COMMENT ON COLUMN "fee_schedules"."min_amount" IS 'smallest amount of the tier: a transfer pays the fee of the tier with the highest min_amount up to its amount';
+
+COMMENT ON COLUMN "fee_schedules"."percentage_bps" IS 'part of the amount in basis points added to the flat fee, rounded down';
+
+COMMENT ON COLUMN "entries"."type" IS 'transfer or fee';
+
+-- The fees are paid to the revenue accounts of the bank, one per currency.
+-- The account numbers are computed like in 000016_add_account_numbers.
+-- The down migration keeps the accounts, so they are only created once.
+INSERT INTO "accounts" ("owner", "balance", "currency", "number", "type", "nickname")
+SELECT 'simple-bank', 0, c."currency", 'SB' || lpad((98 - mod((c."digits" || '281100')::numeric, 97))::text, 2, '0') || c."digits", 'business', 'fees'
+FROM (
+  SELECT "currency", lpad(floor(random() * 1e16)::bigint::text, 16, '0') AS "digits" FROM (VALUES ('USD'), ('EUR'), ('CAD')) v ("currency")
+) c
+WHERE NOT EXISTS (
+  SELECT 1 FROM "accounts" a WHERE a."owner" = 'simple-bank' AND a."currency" = c."currency" AND a."nickname" = 'fees'
+);
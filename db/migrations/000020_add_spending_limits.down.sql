@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS "transfers_from_account_id_created_at_idx";
+
+DROP TABLE IF EXISTS "user_spending_limits";
+
+DROP TABLE IF EXISTS "account_spending_limits";
@@ -0,0 +1,29 @@
+CREATE TABLE "account_spending_limits" (
+  "account_id" bigint PRIMARY KEY,
+  "daily_limit" bigint,
+  "monthly_limit" bigint,
+  "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "user_spending_limits" (
+  "username" varchar NOT NULL,
+  "currency" varchar NOT NULL,
+  "daily_limit" bigint,
+  "monthly_limit" bigint,
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("username", "currency")
+);
+
+CREATE INDEX ON "transfers" ("from_account_id", "created_at");
+
+COMMENT ON COLUMN "account_spending_limits"."daily_limit" IS 'most the account can send in 24 hours, 0 is no limit and NULL is the default limit';
+
+COMMENT ON COLUMN "account_spending_limits"."monthly_limit" IS 'most the account can send in 30 days, 0 is no limit and NULL is the default limit';
+
+COMMENT ON COLUMN "user_spending_limits"."daily_limit" IS 'most the accounts of the user in the currency can send together in 24 hours, 0 is no limit and NULL is the default limit';
+
+COMMENT ON COLUMN "user_spending_limits"."monthly_limit" IS 'most the accounts of the user in the currency can send together in 30 days, 0 is no limit and NULL is the default limit';
+
+ALTER TABLE "account_spending_limits" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "user_spending_limits" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
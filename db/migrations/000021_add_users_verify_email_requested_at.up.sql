@@ -0,0 +1,6 @@
+ALTER TABLE "users" ADD COLUMN "verify_email_requested_at" timestamptz;
+
+COMMENT ON COLUMN "users"."verify_email_requested_at" IS 'when the last verification email was queued, which throttles the resends';
+
+UPDATE "users" u
+SET "verify_email_requested_at" = (SELECT max("created_at") FROM "verify_emails" v WHERE v."username" = u."username");
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS "accounts_owner_id_idx";
+
+DROP INDEX IF EXISTS "transfers_created_at_id_idx";
+
+DROP INDEX IF EXISTS "transfers_amount_id_idx";
@@ -0,0 +1,5 @@
+CREATE INDEX ON "accounts" ("owner", "id");
+
+CREATE INDEX ON "transfers" ("created_at", "id");
+
+CREATE INDEX ON "transfers" ("amount", "id");
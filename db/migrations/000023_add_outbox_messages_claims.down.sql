@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS "outbox_messages_id_idx";
+
+ALTER TABLE "outbox_messages" DROP COLUMN IF EXISTS "failed_at";
+
+ALTER TABLE "outbox_messages" DROP COLUMN IF EXISTS "locked_until";
+
+CREATE INDEX ON "outbox_messages" ("id") WHERE "published_at" IS NULL;
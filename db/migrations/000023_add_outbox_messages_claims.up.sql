@@ -0,0 +1,11 @@
+ALTER TABLE "outbox_messages" ADD COLUMN "locked_until" timestamptz;
+
+ALTER TABLE "outbox_messages" ADD COLUMN "failed_at" timestamptz;
+
+DROP INDEX IF EXISTS "outbox_messages_id_idx";
+
+CREATE INDEX ON "outbox_messages" ("id") WHERE "published_at" IS NULL AND "failed_at" IS NULL;
+
+COMMENT ON COLUMN "outbox_messages"."locked_until" IS 'a relay claimed the message and is publishing it until then';
+
+COMMENT ON COLUMN "outbox_messages"."failed_at" IS 'the relay gave up on the message after too many failed attempts';
@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS "domain_events_id_idx";
+
+ALTER TABLE "domain_events" DROP COLUMN IF EXISTS "failed_at";
+
+ALTER TABLE "domain_events" DROP COLUMN IF EXISTS "locked_until";
+
+ALTER TABLE "domain_events" DROP COLUMN IF EXISTS "last_error";
+
+ALTER TABLE "domain_events" DROP COLUMN IF EXISTS "attempts";
+
+CREATE INDEX ON "domain_events" ("id") WHERE "published_at" IS NULL;
@@ -0,0 +1,15 @@
+ALTER TABLE "domain_events" ADD COLUMN "attempts" int NOT NULL DEFAULT 0;
+
+ALTER TABLE "domain_events" ADD COLUMN "last_error" varchar;
+
+ALTER TABLE "domain_events" ADD COLUMN "locked_until" timestamptz;
+
+ALTER TABLE "domain_events" ADD COLUMN "failed_at" timestamptz;
+
+DROP INDEX IF EXISTS "domain_events_id_idx";
+
+CREATE INDEX ON "domain_events" ("id") WHERE "published_at" IS NULL AND "failed_at" IS NULL;
+
+COMMENT ON COLUMN "domain_events"."locked_until" IS 'a relay claimed the event and is publishing it until then';
+
+COMMENT ON COLUMN "domain_events"."failed_at" IS 'the relay gave up on the event after too many failed attempts';
@@ -0,0 +1,15 @@
+DROP INDEX IF EXISTS "accounts_owner_balance_id_idx";
+
+DROP INDEX IF EXISTS "accounts_owner_created_at_id_idx";
+
+DROP INDEX IF EXISTS "transfers_from_account_id_created_at_id_idx";
+
+DROP INDEX IF EXISTS "transfers_to_account_id_created_at_id_idx";
+
+DROP INDEX IF EXISTS "transfers_from_account_id_amount_id_idx";
+
+DROP INDEX IF EXISTS "transfers_to_account_id_amount_id_idx";
+
+CREATE INDEX ON "transfers" ("created_at", "id");
+
+CREATE INDEX ON "transfers" ("amount", "id");
@@ -0,0 +1,15 @@
+CREATE INDEX ON "accounts" ("owner", "balance", "id");
+
+CREATE INDEX ON "accounts" ("owner", "created_at", "id");
+
+DROP INDEX IF EXISTS "transfers_created_at_id_idx";
+
+DROP INDEX IF EXISTS "transfers_amount_id_idx";
+
+CREATE INDEX ON "transfers" ("from_account_id", "created_at", "id");
+
+CREATE INDEX ON "transfers" ("to_account_id", "created_at", "id");
+
+CREATE INDEX ON "transfers" ("from_account_id", "amount", "id");
+
+CREATE INDEX ON "transfers" ("to_account_id", "amount", "id");
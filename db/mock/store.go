@@ -7,6 +7,7 @@ package mockdb
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	uuid "github.com/google/uuid"
@@ -51,6 +52,111 @@ func (mr *MockStoreMockRecorder) AddAccountBalance(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAccountBalance", reflect.TypeOf((*MockStore)(nil).AddAccountBalance), arg0, arg1)
 }
 
+// ApplyPendingEmail mocks base method.
+func (m *MockStore) ApplyPendingEmail(arg0 context.Context, arg1 db.ApplyPendingEmailParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyPendingEmail", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyPendingEmail indicates an expected call of ApplyPendingEmail.
+func (mr *MockStoreMockRecorder) ApplyPendingEmail(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyPendingEmail", reflect.TypeOf((*MockStore)(nil).ApplyPendingEmail), arg0, arg1)
+}
+
+// CancelPendingEmail mocks base method.
+func (m *MockStore) CancelPendingEmail(arg0 context.Context, arg1 string) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelPendingEmail", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelPendingEmail indicates an expected call of CancelPendingEmail.
+func (mr *MockStoreMockRecorder) CancelPendingEmail(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelPendingEmail", reflect.TypeOf((*MockStore)(nil).CancelPendingEmail), arg0, arg1)
+}
+
+// ClaimInterestAccruals mocks base method.
+func (m *MockStore) ClaimInterestAccruals(arg0 context.Context, arg1 db.ClaimInterestAccrualsParams) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimInterestAccruals", arg0, arg1)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimInterestAccruals indicates an expected call of ClaimInterestAccruals.
+func (mr *MockStoreMockRecorder) ClaimInterestAccruals(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimInterestAccruals", reflect.TypeOf((*MockStore)(nil).ClaimInterestAccruals), arg0, arg1)
+}
+
+// ClaimPendingOutboxMessages mocks base method.
+func (m *MockStore) ClaimPendingOutboxMessages(arg0 context.Context, arg1 db.ClaimPendingOutboxMessagesParams) ([]db.OutboxMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimPendingOutboxMessages", arg0, arg1)
+	ret0, _ := ret[0].([]db.OutboxMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimPendingOutboxMessages indicates an expected call of ClaimPendingOutboxMessages.
+func (mr *MockStoreMockRecorder) ClaimPendingOutboxMessages(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimPendingOutboxMessages", reflect.TypeOf((*MockStore)(nil).ClaimPendingOutboxMessages), arg0, arg1)
+}
+
+// ClaimUnpublishedDomainEvents mocks base method.
+func (m *MockStore) ClaimUnpublishedDomainEvents(arg0 context.Context, arg1 db.ClaimUnpublishedDomainEventsParams) ([]db.DomainEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimUnpublishedDomainEvents", arg0, arg1)
+	ret0, _ := ret[0].([]db.DomainEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimUnpublishedDomainEvents indicates an expected call of ClaimUnpublishedDomainEvents.
+func (mr *MockStoreMockRecorder) ClaimUnpublishedDomainEvents(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimUnpublishedDomainEvents", reflect.TypeOf((*MockStore)(nil).ClaimUnpublishedDomainEvents), arg0, arg1)
+}
+
+// CountAccountsByOwner mocks base method.
+func (m *MockStore) CountAccountsByOwner(arg0 context.Context, arg1 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAccountsByOwner", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAccountsByOwner indicates an expected call of CountAccountsByOwner.
+func (mr *MockStoreMockRecorder) CountAccountsByOwner(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAccountsByOwner", reflect.TypeOf((*MockStore)(nil).CountAccountsByOwner), arg0, arg1)
+}
+
+// CountAccountsByType mocks base method.
+func (m *MockStore) CountAccountsByType(arg0 context.Context, arg1 db.CountAccountsByTypeParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAccountsByType", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAccountsByType indicates an expected call of CountAccountsByType.
+func (mr *MockStoreMockRecorder) CountAccountsByType(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAccountsByType", reflect.TypeOf((*MockStore)(nil).CountAccountsByType), arg0, arg1)
+}
+
 // CreateAccount mocks base method.
 func (m *MockStore) CreateAccount(arg0 context.Context, arg1 db.CreateAccountParams) (db.Account, error) {
 	m.ctrl.T.Helper()
@@ -66,6 +172,66 @@ func (mr *MockStoreMockRecorder) CreateAccount(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccount", reflect.TypeOf((*MockStore)(nil).CreateAccount), arg0, arg1)
 }
 
+// CreateAccountTx mocks base method.
+func (m *MockStore) CreateAccountTx(arg0 context.Context, arg1 db.CreateAccountTxParams) (db.CreateAccountTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAccountTx", arg0, arg1)
+	ret0, _ := ret[0].(db.CreateAccountTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAccountTx indicates an expected call of CreateAccountTx.
+func (mr *MockStoreMockRecorder) CreateAccountTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccountTx", reflect.TypeOf((*MockStore)(nil).CreateAccountTx), arg0, arg1)
+}
+
+// CreateApiClient mocks base method.
+func (m *MockStore) CreateApiClient(arg0 context.Context, arg1 db.CreateApiClientParams) (db.ApiClient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateApiClient", arg0, arg1)
+	ret0, _ := ret[0].(db.ApiClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateApiClient indicates an expected call of CreateApiClient.
+func (mr *MockStoreMockRecorder) CreateApiClient(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateApiClient", reflect.TypeOf((*MockStore)(nil).CreateApiClient), arg0, arg1)
+}
+
+// CreateApiKey mocks base method.
+func (m *MockStore) CreateApiKey(arg0 context.Context, arg1 db.CreateApiKeyParams) (db.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateApiKey", arg0, arg1)
+	ret0, _ := ret[0].(db.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateApiKey indicates an expected call of CreateApiKey.
+func (mr *MockStoreMockRecorder) CreateApiKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateApiKey", reflect.TypeOf((*MockStore)(nil).CreateApiKey), arg0, arg1)
+}
+
+// CreateDomainEvent mocks base method.
+func (m *MockStore) CreateDomainEvent(arg0 context.Context, arg1 db.CreateDomainEventParams) (db.DomainEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDomainEvent", arg0, arg1)
+	ret0, _ := ret[0].(db.DomainEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDomainEvent indicates an expected call of CreateDomainEvent.
+func (mr *MockStoreMockRecorder) CreateDomainEvent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDomainEvent", reflect.TypeOf((*MockStore)(nil).CreateDomainEvent), arg0, arg1)
+}
+
 // CreateEntry mocks base method.
 func (m *MockStore) CreateEntry(arg0 context.Context, arg1 db.CreateEntryParams) (db.Entry, error) {
 	m.ctrl.T.Helper()
@@ -81,6 +247,110 @@ func (mr *MockStoreMockRecorder) CreateEntry(arg0, arg1 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEntry", reflect.TypeOf((*MockStore)(nil).CreateEntry), arg0, arg1)
 }
 
+// CreateFeeSchedule mocks base method.
+func (m *MockStore) CreateFeeSchedule(arg0 context.Context, arg1 db.CreateFeeScheduleParams) (db.FeeSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFeeSchedule", arg0, arg1)
+	ret0, _ := ret[0].(db.FeeSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFeeSchedule indicates an expected call of CreateFeeSchedule.
+func (mr *MockStoreMockRecorder) CreateFeeSchedule(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFeeSchedule", reflect.TypeOf((*MockStore)(nil).CreateFeeSchedule), arg0, arg1)
+}
+
+// CreateInterestAccrual mocks base method.
+func (m *MockStore) CreateInterestAccrual(arg0 context.Context, arg1 db.CreateInterestAccrualParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInterestAccrual", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateInterestAccrual indicates an expected call of CreateInterestAccrual.
+func (mr *MockStoreMockRecorder) CreateInterestAccrual(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInterestAccrual", reflect.TypeOf((*MockStore)(nil).CreateInterestAccrual), arg0, arg1)
+}
+
+// CreateInterestPosting mocks base method.
+func (m *MockStore) CreateInterestPosting(arg0 context.Context, arg1 int64) (db.InterestPosting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInterestPosting", arg0, arg1)
+	ret0, _ := ret[0].(db.InterestPosting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateInterestPosting indicates an expected call of CreateInterestPosting.
+func (mr *MockStoreMockRecorder) CreateInterestPosting(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInterestPosting", reflect.TypeOf((*MockStore)(nil).CreateInterestPosting), arg0, arg1)
+}
+
+// CreateInterestProduct mocks base method.
+func (m *MockStore) CreateInterestProduct(arg0 context.Context, arg1 db.CreateInterestProductParams) (db.InterestProduct, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInterestProduct", arg0, arg1)
+	ret0, _ := ret[0].(db.InterestProduct)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateInterestProduct indicates an expected call of CreateInterestProduct.
+func (mr *MockStoreMockRecorder) CreateInterestProduct(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInterestProduct", reflect.TypeOf((*MockStore)(nil).CreateInterestProduct), arg0, arg1)
+}
+
+// CreateOutboxMessage mocks base method.
+func (m *MockStore) CreateOutboxMessage(arg0 context.Context, arg1 db.CreateOutboxMessageParams) (db.OutboxMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOutboxMessage", arg0, arg1)
+	ret0, _ := ret[0].(db.OutboxMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOutboxMessage indicates an expected call of CreateOutboxMessage.
+func (mr *MockStoreMockRecorder) CreateOutboxMessage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOutboxMessage", reflect.TypeOf((*MockStore)(nil).CreateOutboxMessage), arg0, arg1)
+}
+
+// CreatePasswordHistory mocks base method.
+func (m *MockStore) CreatePasswordHistory(arg0 context.Context, arg1 db.CreatePasswordHistoryParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePasswordHistory", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePasswordHistory indicates an expected call of CreatePasswordHistory.
+func (mr *MockStoreMockRecorder) CreatePasswordHistory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePasswordHistory", reflect.TypeOf((*MockStore)(nil).CreatePasswordHistory), arg0, arg1)
+}
+
+// CreatePayee mocks base method.
+func (m *MockStore) CreatePayee(arg0 context.Context, arg1 db.CreatePayeeParams) (db.Payee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePayee", arg0, arg1)
+	ret0, _ := ret[0].(db.Payee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePayee indicates an expected call of CreatePayee.
+func (mr *MockStoreMockRecorder) CreatePayee(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePayee", reflect.TypeOf((*MockStore)(nil).CreatePayee), arg0, arg1)
+}
+
 // CreateSession mocks base method.
 func (m *MockStore) CreateSession(arg0 context.Context, arg1 db.CreateSessionParams) (db.Session, error) {
 	m.ctrl.T.Helper()
@@ -156,6 +426,36 @@ func (mr *MockStoreMockRecorder) CreateVerifyEmail(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVerifyEmail", reflect.TypeOf((*MockStore)(nil).CreateVerifyEmail), arg0, arg1)
 }
 
+// CreateWebhookDelivery mocks base method.
+func (m *MockStore) CreateWebhookDelivery(arg0 context.Context, arg1 db.CreateWebhookDeliveryParams) (db.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhookDelivery", arg0, arg1)
+	ret0, _ := ret[0].(db.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebhookDelivery indicates an expected call of CreateWebhookDelivery.
+func (mr *MockStoreMockRecorder) CreateWebhookDelivery(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhookDelivery", reflect.TypeOf((*MockStore)(nil).CreateWebhookDelivery), arg0, arg1)
+}
+
+// CreateWebhookSubscription mocks base method.
+func (m *MockStore) CreateWebhookSubscription(arg0 context.Context, arg1 db.CreateWebhookSubscriptionParams) (db.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhookSubscription", arg0, arg1)
+	ret0, _ := ret[0].(db.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebhookSubscription indicates an expected call of CreateWebhookSubscription.
+func (mr *MockStoreMockRecorder) CreateWebhookSubscription(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhookSubscription", reflect.TypeOf((*MockStore)(nil).CreateWebhookSubscription), arg0, arg1)
+}
+
 // DeleteAccount mocks base method.
 func (m *MockStore) DeleteAccount(arg0 context.Context, arg1 int64) error {
 	m.ctrl.T.Helper()
@@ -170,6 +470,62 @@ func (mr *MockStoreMockRecorder) DeleteAccount(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccount", reflect.TypeOf((*MockStore)(nil).DeleteAccount), arg0, arg1)
 }
 
+// DeleteFeeSchedule mocks base method.
+func (m *MockStore) DeleteFeeSchedule(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFeeSchedule", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFeeSchedule indicates an expected call of DeleteFeeSchedule.
+func (mr *MockStoreMockRecorder) DeleteFeeSchedule(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFeeSchedule", reflect.TypeOf((*MockStore)(nil).DeleteFeeSchedule), arg0, arg1)
+}
+
+// DeleteOldPasswordHistory mocks base method.
+func (m *MockStore) DeleteOldPasswordHistory(arg0 context.Context, arg1 db.DeleteOldPasswordHistoryParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOldPasswordHistory", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOldPasswordHistory indicates an expected call of DeleteOldPasswordHistory.
+func (mr *MockStoreMockRecorder) DeleteOldPasswordHistory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOldPasswordHistory", reflect.TypeOf((*MockStore)(nil).DeleteOldPasswordHistory), arg0, arg1)
+}
+
+// DeletePayee mocks base method.
+func (m *MockStore) DeletePayee(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePayee", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePayee indicates an expected call of DeletePayee.
+func (mr *MockStoreMockRecorder) DeletePayee(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePayee", reflect.TypeOf((*MockStore)(nil).DeletePayee), arg0, arg1)
+}
+
+// DeleteWebhookSubscription mocks base method.
+func (m *MockStore) DeleteWebhookSubscription(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhookSubscription", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhookSubscription indicates an expected call of DeleteWebhookSubscription.
+func (mr *MockStoreMockRecorder) DeleteWebhookSubscription(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhookSubscription", reflect.TypeOf((*MockStore)(nil).DeleteWebhookSubscription), arg0, arg1)
+}
+
 // GetAccount mocks base method.
 func (m *MockStore) GetAccount(arg0 context.Context, arg1 int64) (db.Account, error) {
 	m.ctrl.T.Helper()
@@ -185,6 +541,21 @@ func (mr *MockStoreMockRecorder) GetAccount(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*MockStore)(nil).GetAccount), arg0, arg1)
 }
 
+// GetAccountByNumber mocks base method.
+func (m *MockStore) GetAccountByNumber(arg0 context.Context, arg1 string) (db.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountByNumber", arg0, arg1)
+	ret0, _ := ret[0].(db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountByNumber indicates an expected call of GetAccountByNumber.
+func (mr *MockStoreMockRecorder) GetAccountByNumber(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountByNumber", reflect.TypeOf((*MockStore)(nil).GetAccountByNumber), arg0, arg1)
+}
+
 // GetAccountForUpdate mocks base method.
 func (m *MockStore) GetAccountForUpdate(arg0 context.Context, arg1 int64) (db.Account, error) {
 	m.ctrl.T.Helper()
@@ -200,113 +571,981 @@ func (mr *MockStoreMockRecorder) GetAccountForUpdate(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountForUpdate", reflect.TypeOf((*MockStore)(nil).GetAccountForUpdate), arg0, arg1)
 }
 
-// GetEntry mocks base method.
-func (m *MockStore) GetEntry(arg0 context.Context, arg1 int64) (db.Entry, error) {
+// GetAccountPayments mocks base method.
+func (m *MockStore) GetAccountPayments(arg0 context.Context, arg1 db.GetAccountPaymentsParams) (db.GetAccountPaymentsRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetEntry", arg0, arg1)
-	ret0, _ := ret[0].(db.Entry)
+	ret := m.ctrl.Call(m, "GetAccountPayments", arg0, arg1)
+	ret0, _ := ret[0].(db.GetAccountPaymentsRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetEntry indicates an expected call of GetEntry.
-func (mr *MockStoreMockRecorder) GetEntry(arg0, arg1 interface{}) *gomock.Call {
+// GetAccountPayments indicates an expected call of GetAccountPayments.
+func (mr *MockStoreMockRecorder) GetAccountPayments(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntry", reflect.TypeOf((*MockStore)(nil).GetEntry), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountPayments", reflect.TypeOf((*MockStore)(nil).GetAccountPayments), arg0, arg1)
 }
 
-// GetSession mocks base method.
-func (m *MockStore) GetSession(arg0 context.Context, arg1 uuid.UUID) (db.Session, error) {
+// GetAccountSpendingLimit mocks base method.
+func (m *MockStore) GetAccountSpendingLimit(arg0 context.Context, arg1 int64) (db.AccountSpendingLimit, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSession", arg0, arg1)
-	ret0, _ := ret[0].(db.Session)
+	ret := m.ctrl.Call(m, "GetAccountSpendingLimit", arg0, arg1)
+	ret0, _ := ret[0].(db.AccountSpendingLimit)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetSession indicates an expected call of GetSession.
-func (mr *MockStoreMockRecorder) GetSession(arg0, arg1 interface{}) *gomock.Call {
+// GetAccountSpendingLimit indicates an expected call of GetAccountSpendingLimit.
+func (mr *MockStoreMockRecorder) GetAccountSpendingLimit(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockStore)(nil).GetSession), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountSpendingLimit", reflect.TypeOf((*MockStore)(nil).GetAccountSpendingLimit), arg0, arg1)
 }
 
-// GetTransfer mocks base method.
-func (m *MockStore) GetTransfer(arg0 context.Context, arg1 int64) (db.Transfer, error) {
+// GetApiClient mocks base method.
+func (m *MockStore) GetApiClient(arg0 context.Context, arg1 string) (db.ApiClient, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTransfer", arg0, arg1)
-	ret0, _ := ret[0].(db.Transfer)
+	ret := m.ctrl.Call(m, "GetApiClient", arg0, arg1)
+	ret0, _ := ret[0].(db.ApiClient)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetTransfer indicates an expected call of GetTransfer.
-func (mr *MockStoreMockRecorder) GetTransfer(arg0, arg1 interface{}) *gomock.Call {
+// GetApiClient indicates an expected call of GetApiClient.
+func (mr *MockStoreMockRecorder) GetApiClient(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransfer", reflect.TypeOf((*MockStore)(nil).GetTransfer), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApiClient", reflect.TypeOf((*MockStore)(nil).GetApiClient), arg0, arg1)
 }
 
-// GetUser mocks base method.
-func (m *MockStore) GetUser(arg0 context.Context, arg1 string) (db.User, error) {
+// GetApiKey mocks base method.
+func (m *MockStore) GetApiKey(arg0 context.Context, arg1 int64) (db.ApiKey, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUser", arg0, arg1)
-	ret0, _ := ret[0].(db.User)
+	ret := m.ctrl.Call(m, "GetApiKey", arg0, arg1)
+	ret0, _ := ret[0].(db.ApiKey)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUser indicates an expected call of GetUser.
-func (mr *MockStoreMockRecorder) GetUser(arg0, arg1 interface{}) *gomock.Call {
+// GetApiKey indicates an expected call of GetApiKey.
+func (mr *MockStoreMockRecorder) GetApiKey(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockStore)(nil).GetUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApiKey", reflect.TypeOf((*MockStore)(nil).GetApiKey), arg0, arg1)
 }
 
-// ListAccounts mocks base method.
-func (m *MockStore) ListAccounts(arg0 context.Context, arg1 db.ListAccountsParams) ([]db.Account, error) {
+// GetApiKeyByPrefix mocks base method.
+func (m *MockStore) GetApiKeyByPrefix(arg0 context.Context, arg1 string) (db.ApiKey, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListAccounts", arg0, arg1)
-	ret0, _ := ret[0].([]db.Account)
+	ret := m.ctrl.Call(m, "GetApiKeyByPrefix", arg0, arg1)
+	ret0, _ := ret[0].(db.ApiKey)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListAccounts indicates an expected call of ListAccounts.
-func (mr *MockStoreMockRecorder) ListAccounts(arg0, arg1 interface{}) *gomock.Call {
+// GetApiKeyByPrefix indicates an expected call of GetApiKeyByPrefix.
+func (mr *MockStoreMockRecorder) GetApiKeyByPrefix(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccounts", reflect.TypeOf((*MockStore)(nil).ListAccounts), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApiKeyByPrefix", reflect.TypeOf((*MockStore)(nil).GetApiKeyByPrefix), arg0, arg1)
 }
 
-// ListEntries mocks base method.
-func (m *MockStore) ListEntries(arg0 context.Context, arg1 db.ListEntriesParams) ([]db.Entry, error) {
+// GetDomainEvent mocks base method.
+func (m *MockStore) GetDomainEvent(arg0 context.Context, arg1 int64) (db.DomainEvent, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListEntries", arg0, arg1)
-	ret0, _ := ret[0].([]db.Entry)
+	ret := m.ctrl.Call(m, "GetDomainEvent", arg0, arg1)
+	ret0, _ := ret[0].(db.DomainEvent)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListEntries indicates an expected call of ListEntries.
-func (mr *MockStoreMockRecorder) ListEntries(arg0, arg1 interface{}) *gomock.Call {
+// GetDomainEvent indicates an expected call of GetDomainEvent.
+func (mr *MockStoreMockRecorder) GetDomainEvent(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEntries", reflect.TypeOf((*MockStore)(nil).ListEntries), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDomainEvent", reflect.TypeOf((*MockStore)(nil).GetDomainEvent), arg0, arg1)
 }
 
-// ListTransfers mocks base method.
-func (m *MockStore) ListTransfers(arg0 context.Context, arg1 db.ListTransfersParams) ([]db.Transfer, error) {
+// GetEntry mocks base method.
+func (m *MockStore) GetEntry(arg0 context.Context, arg1 int64) (db.Entry, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListTransfers", arg0, arg1)
-	ret0, _ := ret[0].([]db.Transfer)
+	ret := m.ctrl.Call(m, "GetEntry", arg0, arg1)
+	ret0, _ := ret[0].(db.Entry)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListTransfers indicates an expected call of ListTransfers.
-func (mr *MockStoreMockRecorder) ListTransfers(arg0, arg1 interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
+// GetEntry indicates an expected call of GetEntry.
+func (mr *MockStoreMockRecorder) GetEntry(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntry", reflect.TypeOf((*MockStore)(nil).GetEntry), arg0, arg1)
+}
+
+// GetFeeSchedule mocks base method.
+func (m *MockStore) GetFeeSchedule(arg0 context.Context, arg1 db.GetFeeScheduleParams) (db.FeeSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeeSchedule", arg0, arg1)
+	ret0, _ := ret[0].(db.FeeSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFeeSchedule indicates an expected call of GetFeeSchedule.
+func (mr *MockStoreMockRecorder) GetFeeSchedule(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeeSchedule", reflect.TypeOf((*MockStore)(nil).GetFeeSchedule), arg0, arg1)
+}
+
+// GetInterestProduct mocks base method.
+func (m *MockStore) GetInterestProduct(arg0 context.Context, arg1 int64) (db.InterestProduct, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInterestProduct", arg0, arg1)
+	ret0, _ := ret[0].(db.InterestProduct)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInterestProduct indicates an expected call of GetInterestProduct.
+func (mr *MockStoreMockRecorder) GetInterestProduct(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInterestProduct", reflect.TypeOf((*MockStore)(nil).GetInterestProduct), arg0, arg1)
+}
+
+// GetLastInterestPosting mocks base method.
+func (m *MockStore) GetLastInterestPosting(arg0 context.Context, arg1 int64) (db.InterestPosting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastInterestPosting", arg0, arg1)
+	ret0, _ := ret[0].(db.InterestPosting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastInterestPosting indicates an expected call of GetLastInterestPosting.
+func (mr *MockStoreMockRecorder) GetLastInterestPosting(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastInterestPosting", reflect.TypeOf((*MockStore)(nil).GetLastInterestPosting), arg0, arg1)
+}
+
+// GetNotificationPreference mocks base method.
+func (m *MockStore) GetNotificationPreference(arg0 context.Context, arg1 string) (db.NotificationPreference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotificationPreference", arg0, arg1)
+	ret0, _ := ret[0].(db.NotificationPreference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotificationPreference indicates an expected call of GetNotificationPreference.
+func (mr *MockStoreMockRecorder) GetNotificationPreference(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationPreference", reflect.TypeOf((*MockStore)(nil).GetNotificationPreference), arg0, arg1)
+}
+
+// GetPayee mocks base method.
+func (m *MockStore) GetPayee(arg0 context.Context, arg1 int64) (db.Payee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPayee", arg0, arg1)
+	ret0, _ := ret[0].(db.Payee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPayee indicates an expected call of GetPayee.
+func (mr *MockStoreMockRecorder) GetPayee(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPayee", reflect.TypeOf((*MockStore)(nil).GetPayee), arg0, arg1)
+}
+
+// GetPayeeByAccount mocks base method.
+func (m *MockStore) GetPayeeByAccount(arg0 context.Context, arg1 db.GetPayeeByAccountParams) (db.Payee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPayeeByAccount", arg0, arg1)
+	ret0, _ := ret[0].(db.Payee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPayeeByAccount indicates an expected call of GetPayeeByAccount.
+func (mr *MockStoreMockRecorder) GetPayeeByAccount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPayeeByAccount", reflect.TypeOf((*MockStore)(nil).GetPayeeByAccount), arg0, arg1)
+}
+
+// GetSession mocks base method.
+func (m *MockStore) GetSession(arg0 context.Context, arg1 uuid.UUID) (db.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSession", arg0, arg1)
+	ret0, _ := ret[0].(db.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSession indicates an expected call of GetSession.
+func (mr *MockStoreMockRecorder) GetSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockStore)(nil).GetSession), arg0, arg1)
+}
+
+// GetSystemAccount mocks base method.
+func (m *MockStore) GetSystemAccount(arg0 context.Context, arg1 db.GetSystemAccountParams) (db.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSystemAccount", arg0, arg1)
+	ret0, _ := ret[0].(db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSystemAccount indicates an expected call of GetSystemAccount.
+func (mr *MockStoreMockRecorder) GetSystemAccount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSystemAccount", reflect.TypeOf((*MockStore)(nil).GetSystemAccount), arg0, arg1)
+}
+
+// GetTransfer mocks base method.
+func (m *MockStore) GetTransfer(arg0 context.Context, arg1 int64) (db.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransfer", arg0, arg1)
+	ret0, _ := ret[0].(db.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransfer indicates an expected call of GetTransfer.
+func (mr *MockStoreMockRecorder) GetTransfer(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransfer", reflect.TypeOf((*MockStore)(nil).GetTransfer), arg0, arg1)
+}
+
+// GetUser mocks base method.
+func (m *MockStore) GetUser(arg0 context.Context, arg1 string) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockStoreMockRecorder) GetUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockStore)(nil).GetUser), arg0, arg1)
+}
+
+// GetUserForUpdate mocks base method.
+func (m *MockStore) GetUserForUpdate(arg0 context.Context, arg1 string) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserForUpdate indicates an expected call of GetUserForUpdate.
+func (mr *MockStoreMockRecorder) GetUserForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserForUpdate", reflect.TypeOf((*MockStore)(nil).GetUserForUpdate), arg0, arg1)
+}
+
+// GetUserSpendingLimit mocks base method.
+func (m *MockStore) GetUserSpendingLimit(arg0 context.Context, arg1 db.GetUserSpendingLimitParams) (db.UserSpendingLimit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserSpendingLimit", arg0, arg1)
+	ret0, _ := ret[0].(db.UserSpendingLimit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserSpendingLimit indicates an expected call of GetUserSpendingLimit.
+func (mr *MockStoreMockRecorder) GetUserSpendingLimit(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserSpendingLimit", reflect.TypeOf((*MockStore)(nil).GetUserSpendingLimit), arg0, arg1)
+}
+
+// GetWebhookDelivery mocks base method.
+func (m *MockStore) GetWebhookDelivery(arg0 context.Context, arg1 int64) (db.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookDelivery", arg0, arg1)
+	ret0, _ := ret[0].(db.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookDelivery indicates an expected call of GetWebhookDelivery.
+func (mr *MockStoreMockRecorder) GetWebhookDelivery(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookDelivery", reflect.TypeOf((*MockStore)(nil).GetWebhookDelivery), arg0, arg1)
+}
+
+// GetWebhookSubscription mocks base method.
+func (m *MockStore) GetWebhookSubscription(arg0 context.Context, arg1 int64) (db.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookSubscription", arg0, arg1)
+	ret0, _ := ret[0].(db.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookSubscription indicates an expected call of GetWebhookSubscription.
+func (mr *MockStoreMockRecorder) GetWebhookSubscription(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookSubscription", reflect.TypeOf((*MockStore)(nil).GetWebhookSubscription), arg0, arg1)
+}
+
+// InvalidateVerifyEmails mocks base method.
+func (m *MockStore) InvalidateVerifyEmails(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvalidateVerifyEmails", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InvalidateVerifyEmails indicates an expected call of InvalidateVerifyEmails.
+func (mr *MockStoreMockRecorder) InvalidateVerifyEmails(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateVerifyEmails", reflect.TypeOf((*MockStore)(nil).InvalidateVerifyEmails), arg0, arg1)
+}
+
+// ListAccounts mocks base method.
+func (m *MockStore) ListAccounts(arg0 context.Context, arg1 db.ListAccountsParams) ([]db.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccounts", arg0, arg1)
+	ret0, _ := ret[0].([]db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccounts indicates an expected call of ListAccounts.
+func (mr *MockStoreMockRecorder) ListAccounts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccounts", reflect.TypeOf((*MockStore)(nil).ListAccounts), arg0, arg1)
+}
+
+// ListAccountsByBalance mocks base method.
+func (m *MockStore) ListAccountsByBalance(arg0 context.Context, arg1 db.ListAccountsByBalanceParams) ([]db.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccountsByBalance", arg0, arg1)
+	ret0, _ := ret[0].([]db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccountsByBalance indicates an expected call of ListAccountsByBalance.
+func (mr *MockStoreMockRecorder) ListAccountsByBalance(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccountsByBalance", reflect.TypeOf((*MockStore)(nil).ListAccountsByBalance), arg0, arg1)
+}
+
+// ListAccountsByBalanceDesc mocks base method.
+func (m *MockStore) ListAccountsByBalanceDesc(arg0 context.Context, arg1 db.ListAccountsByBalanceDescParams) ([]db.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccountsByBalanceDesc", arg0, arg1)
+	ret0, _ := ret[0].([]db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccountsByBalanceDesc indicates an expected call of ListAccountsByBalanceDesc.
+func (mr *MockStoreMockRecorder) ListAccountsByBalanceDesc(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccountsByBalanceDesc", reflect.TypeOf((*MockStore)(nil).ListAccountsByBalanceDesc), arg0, arg1)
+}
+
+// ListAccountsByCreatedAt mocks base method.
+func (m *MockStore) ListAccountsByCreatedAt(arg0 context.Context, arg1 db.ListAccountsByCreatedAtParams) ([]db.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccountsByCreatedAt", arg0, arg1)
+	ret0, _ := ret[0].([]db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccountsByCreatedAt indicates an expected call of ListAccountsByCreatedAt.
+func (mr *MockStoreMockRecorder) ListAccountsByCreatedAt(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccountsByCreatedAt", reflect.TypeOf((*MockStore)(nil).ListAccountsByCreatedAt), arg0, arg1)
+}
+
+// ListAccountsByCreatedAtDesc mocks base method.
+func (m *MockStore) ListAccountsByCreatedAtDesc(arg0 context.Context, arg1 db.ListAccountsByCreatedAtDescParams) ([]db.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccountsByCreatedAtDesc", arg0, arg1)
+	ret0, _ := ret[0].([]db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccountsByCreatedAtDesc indicates an expected call of ListAccountsByCreatedAtDesc.
+func (mr *MockStoreMockRecorder) ListAccountsByCreatedAtDesc(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccountsByCreatedAtDesc", reflect.TypeOf((*MockStore)(nil).ListAccountsByCreatedAtDesc), arg0, arg1)
+}
+
+// ListAccountsByID mocks base method.
+func (m *MockStore) ListAccountsByID(arg0 context.Context, arg1 db.ListAccountsByIDParams) ([]db.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccountsByID", arg0, arg1)
+	ret0, _ := ret[0].([]db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccountsByID indicates an expected call of ListAccountsByID.
+func (mr *MockStoreMockRecorder) ListAccountsByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccountsByID", reflect.TypeOf((*MockStore)(nil).ListAccountsByID), arg0, arg1)
+}
+
+// ListAccountsByIDDesc mocks base method.
+func (m *MockStore) ListAccountsByIDDesc(arg0 context.Context, arg1 db.ListAccountsByIDDescParams) ([]db.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccountsByIDDesc", arg0, arg1)
+	ret0, _ := ret[0].([]db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccountsByIDDesc indicates an expected call of ListAccountsByIDDesc.
+func (mr *MockStoreMockRecorder) ListAccountsByIDDesc(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccountsByIDDesc", reflect.TypeOf((*MockStore)(nil).ListAccountsByIDDesc), arg0, arg1)
+}
+
+// ListAccountsWithUnpostedInterest mocks base method.
+func (m *MockStore) ListAccountsWithUnpostedInterest(arg0 context.Context, arg1 time.Time) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccountsWithUnpostedInterest", arg0, arg1)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccountsWithUnpostedInterest indicates an expected call of ListAccountsWithUnpostedInterest.
+func (mr *MockStoreMockRecorder) ListAccountsWithUnpostedInterest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccountsWithUnpostedInterest", reflect.TypeOf((*MockStore)(nil).ListAccountsWithUnpostedInterest), arg0, arg1)
+}
+
+// ListApiKeys mocks base method.
+func (m *MockStore) ListApiKeys(arg0 context.Context, arg1 db.ListApiKeysParams) ([]db.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListApiKeys", arg0, arg1)
+	ret0, _ := ret[0].([]db.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListApiKeys indicates an expected call of ListApiKeys.
+func (mr *MockStoreMockRecorder) ListApiKeys(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListApiKeys", reflect.TypeOf((*MockStore)(nil).ListApiKeys), arg0, arg1)
+}
+
+// ListEntries mocks base method.
+func (m *MockStore) ListEntries(arg0 context.Context, arg1 db.ListEntriesParams) ([]db.Entry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEntries", arg0, arg1)
+	ret0, _ := ret[0].([]db.Entry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEntries indicates an expected call of ListEntries.
+func (mr *MockStoreMockRecorder) ListEntries(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEntries", reflect.TypeOf((*MockStore)(nil).ListEntries), arg0, arg1)
+}
+
+// ListInterestAccruals mocks base method.
+func (m *MockStore) ListInterestAccruals(arg0 context.Context, arg1 db.ListInterestAccrualsParams) ([]db.InterestAccrual, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListInterestAccruals", arg0, arg1)
+	ret0, _ := ret[0].([]db.InterestAccrual)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListInterestAccruals indicates an expected call of ListInterestAccruals.
+func (mr *MockStoreMockRecorder) ListInterestAccruals(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInterestAccruals", reflect.TypeOf((*MockStore)(nil).ListInterestAccruals), arg0, arg1)
+}
+
+// ListInterestBearingAccounts mocks base method.
+func (m *MockStore) ListInterestBearingAccounts(arg0 context.Context, arg1 time.Time) ([]db.ListInterestBearingAccountsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListInterestBearingAccounts", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListInterestBearingAccountsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListInterestBearingAccounts indicates an expected call of ListInterestBearingAccounts.
+func (mr *MockStoreMockRecorder) ListInterestBearingAccounts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInterestBearingAccounts", reflect.TypeOf((*MockStore)(nil).ListInterestBearingAccounts), arg0, arg1)
+}
+
+// ListPasswordHistory mocks base method.
+func (m *MockStore) ListPasswordHistory(arg0 context.Context, arg1 db.ListPasswordHistoryParams) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPasswordHistory", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPasswordHistory indicates an expected call of ListPasswordHistory.
+func (mr *MockStoreMockRecorder) ListPasswordHistory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPasswordHistory", reflect.TypeOf((*MockStore)(nil).ListPasswordHistory), arg0, arg1)
+}
+
+// ListPayees mocks base method.
+func (m *MockStore) ListPayees(arg0 context.Context, arg1 db.ListPayeesParams) ([]db.Payee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPayees", arg0, arg1)
+	ret0, _ := ret[0].([]db.Payee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPayees indicates an expected call of ListPayees.
+func (mr *MockStoreMockRecorder) ListPayees(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPayees", reflect.TypeOf((*MockStore)(nil).ListPayees), arg0, arg1)
+}
+
+// ListTransfers mocks base method.
+func (m *MockStore) ListTransfers(arg0 context.Context, arg1 db.ListTransfersParams) ([]db.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransfers", arg0, arg1)
+	ret0, _ := ret[0].([]db.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransfers indicates an expected call of ListTransfers.
+func (mr *MockStoreMockRecorder) ListTransfers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransfers", reflect.TypeOf((*MockStore)(nil).ListTransfers), arg0, arg1)
 }
 
+// ListTransfersByAmount mocks base method.
+func (m *MockStore) ListTransfersByAmount(arg0 context.Context, arg1 db.ListTransfersByAmountParams) ([]db.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransfersByAmount", arg0, arg1)
+	ret0, _ := ret[0].([]db.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransfersByAmount indicates an expected call of ListTransfersByAmount.
+func (mr *MockStoreMockRecorder) ListTransfersByAmount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransfersByAmount", reflect.TypeOf((*MockStore)(nil).ListTransfersByAmount), arg0, arg1)
+}
+
+// ListTransfersByAmountDesc mocks base method.
+func (m *MockStore) ListTransfersByAmountDesc(arg0 context.Context, arg1 db.ListTransfersByAmountDescParams) ([]db.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransfersByAmountDesc", arg0, arg1)
+	ret0, _ := ret[0].([]db.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransfersByAmountDesc indicates an expected call of ListTransfersByAmountDesc.
+func (mr *MockStoreMockRecorder) ListTransfersByAmountDesc(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransfersByAmountDesc", reflect.TypeOf((*MockStore)(nil).ListTransfersByAmountDesc), arg0, arg1)
+}
+
+// ListTransfersByCreatedAt mocks base method.
+func (m *MockStore) ListTransfersByCreatedAt(arg0 context.Context, arg1 db.ListTransfersByCreatedAtParams) ([]db.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransfersByCreatedAt", arg0, arg1)
+	ret0, _ := ret[0].([]db.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransfersByCreatedAt indicates an expected call of ListTransfersByCreatedAt.
+func (mr *MockStoreMockRecorder) ListTransfersByCreatedAt(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransfersByCreatedAt", reflect.TypeOf((*MockStore)(nil).ListTransfersByCreatedAt), arg0, arg1)
+}
+
+// ListTransfersByCreatedAtDesc mocks base method.
+func (m *MockStore) ListTransfersByCreatedAtDesc(arg0 context.Context, arg1 db.ListTransfersByCreatedAtDescParams) ([]db.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransfersByCreatedAtDesc", arg0, arg1)
+	ret0, _ := ret[0].([]db.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransfersByCreatedAtDesc indicates an expected call of ListTransfersByCreatedAtDesc.
+func (mr *MockStoreMockRecorder) ListTransfersByCreatedAtDesc(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransfersByCreatedAtDesc", reflect.TypeOf((*MockStore)(nil).ListTransfersByCreatedAtDesc), arg0, arg1)
+}
+
+// ListWebhookDeliveries mocks base method.
+func (m *MockStore) ListWebhookDeliveries(arg0 context.Context, arg1 db.ListWebhookDeliveriesParams) ([]db.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhookDeliveries", arg0, arg1)
+	ret0, _ := ret[0].([]db.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebhookDeliveries indicates an expected call of ListWebhookDeliveries.
+func (mr *MockStoreMockRecorder) ListWebhookDeliveries(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhookDeliveries", reflect.TypeOf((*MockStore)(nil).ListWebhookDeliveries), arg0, arg1)
+}
+
+// ListWebhookSubscriptions mocks base method.
+func (m *MockStore) ListWebhookSubscriptions(arg0 context.Context, arg1 db.ListWebhookSubscriptionsParams) ([]db.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhookSubscriptions", arg0, arg1)
+	ret0, _ := ret[0].([]db.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebhookSubscriptions indicates an expected call of ListWebhookSubscriptions.
+func (mr *MockStoreMockRecorder) ListWebhookSubscriptions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhookSubscriptions", reflect.TypeOf((*MockStore)(nil).ListWebhookSubscriptions), arg0, arg1)
+}
+
+// ListWebhookSubscriptionsForEvent mocks base method.
+func (m *MockStore) ListWebhookSubscriptionsForEvent(arg0 context.Context, arg1 db.ListWebhookSubscriptionsForEventParams) ([]db.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhookSubscriptionsForEvent", arg0, arg1)
+	ret0, _ := ret[0].([]db.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebhookSubscriptionsForEvent indicates an expected call of ListWebhookSubscriptionsForEvent.
+func (mr *MockStoreMockRecorder) ListWebhookSubscriptionsForEvent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhookSubscriptionsForEvent", reflect.TypeOf((*MockStore)(nil).ListWebhookSubscriptionsForEvent), arg0, arg1)
+}
+
+// MarkDomainEventFailed mocks base method.
+func (m *MockStore) MarkDomainEventFailed(arg0 context.Context, arg1 db.MarkDomainEventFailedParams) (db.DomainEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkDomainEventFailed", arg0, arg1)
+	ret0, _ := ret[0].(db.DomainEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkDomainEventFailed indicates an expected call of MarkDomainEventFailed.
+func (mr *MockStoreMockRecorder) MarkDomainEventFailed(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkDomainEventFailed", reflect.TypeOf((*MockStore)(nil).MarkDomainEventFailed), arg0, arg1)
+}
+
+// MarkDomainEventPublished mocks base method.
+func (m *MockStore) MarkDomainEventPublished(arg0 context.Context, arg1 int64) (db.DomainEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkDomainEventPublished", arg0, arg1)
+	ret0, _ := ret[0].(db.DomainEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkDomainEventPublished indicates an expected call of MarkDomainEventPublished.
+func (mr *MockStoreMockRecorder) MarkDomainEventPublished(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkDomainEventPublished", reflect.TypeOf((*MockStore)(nil).MarkDomainEventPublished), arg0, arg1)
+}
+
+// MarkOutboxMessageFailed mocks base method.
+func (m *MockStore) MarkOutboxMessageFailed(arg0 context.Context, arg1 db.MarkOutboxMessageFailedParams) (db.OutboxMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkOutboxMessageFailed", arg0, arg1)
+	ret0, _ := ret[0].(db.OutboxMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkOutboxMessageFailed indicates an expected call of MarkOutboxMessageFailed.
+func (mr *MockStoreMockRecorder) MarkOutboxMessageFailed(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkOutboxMessageFailed", reflect.TypeOf((*MockStore)(nil).MarkOutboxMessageFailed), arg0, arg1)
+}
+
+// MarkOutboxMessagePublished mocks base method.
+func (m *MockStore) MarkOutboxMessagePublished(arg0 context.Context, arg1 int64) (db.OutboxMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkOutboxMessagePublished", arg0, arg1)
+	ret0, _ := ret[0].(db.OutboxMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkOutboxMessagePublished indicates an expected call of MarkOutboxMessagePublished.
+func (mr *MockStoreMockRecorder) MarkOutboxMessagePublished(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkOutboxMessagePublished", reflect.TypeOf((*MockStore)(nil).MarkOutboxMessagePublished), arg0, arg1)
+}
+
+// PostInterestTx mocks base method.
+func (m *MockStore) PostInterestTx(arg0 context.Context, arg1 db.PostInterestTxParams) (db.PostInterestTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostInterestTx", arg0, arg1)
+	ret0, _ := ret[0].(db.PostInterestTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PostInterestTx indicates an expected call of PostInterestTx.
+func (mr *MockStoreMockRecorder) PostInterestTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostInterestTx", reflect.TypeOf((*MockStore)(nil).PostInterestTx), arg0, arg1)
+}
+
+// RecordVerifyEmailRequest mocks base method.
+func (m *MockStore) RecordVerifyEmailRequest(arg0 context.Context, arg1 string) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordVerifyEmailRequest", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordVerifyEmailRequest indicates an expected call of RecordVerifyEmailRequest.
+func (mr *MockStoreMockRecorder) RecordVerifyEmailRequest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordVerifyEmailRequest", reflect.TypeOf((*MockStore)(nil).RecordVerifyEmailRequest), arg0, arg1)
+}
+
+// RelayDomainEvents mocks base method.
+func (m *MockStore) RelayDomainEvents(arg0 context.Context, arg1 db.RelayDomainEventsParams) (db.RelayDomainEventsResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RelayDomainEvents", arg0, arg1)
+	ret0, _ := ret[0].(db.RelayDomainEventsResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RelayDomainEvents indicates an expected call of RelayDomainEvents.
+func (mr *MockStoreMockRecorder) RelayDomainEvents(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RelayDomainEvents", reflect.TypeOf((*MockStore)(nil).RelayDomainEvents), arg0, arg1)
+}
+
+// RelayOutbox mocks base method.
+func (m *MockStore) RelayOutbox(arg0 context.Context, arg1 db.RelayOutboxParams) (db.RelayOutboxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RelayOutbox", arg0, arg1)
+	ret0, _ := ret[0].(db.RelayOutboxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RelayOutbox indicates an expected call of RelayOutbox.
+func (mr *MockStoreMockRecorder) RelayOutbox(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RelayOutbox", reflect.TypeOf((*MockStore)(nil).RelayOutbox), arg0, arg1)
+}
+
+// ResendVerifyEmailTx mocks base method.
+func (m *MockStore) ResendVerifyEmailTx(arg0 context.Context, arg1 db.ResendVerifyEmailTxParams) (db.ResendVerifyEmailTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResendVerifyEmailTx", arg0, arg1)
+	ret0, _ := ret[0].(db.ResendVerifyEmailTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResendVerifyEmailTx indicates an expected call of ResendVerifyEmailTx.
+func (mr *MockStoreMockRecorder) ResendVerifyEmailTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResendVerifyEmailTx", reflect.TypeOf((*MockStore)(nil).ResendVerifyEmailTx), arg0, arg1)
+}
+
+// RevokeApiClient mocks base method.
+func (m *MockStore) RevokeApiClient(arg0 context.Context, arg1 string) (db.ApiClient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeApiClient", arg0, arg1)
+	ret0, _ := ret[0].(db.ApiClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeApiClient indicates an expected call of RevokeApiClient.
+func (mr *MockStoreMockRecorder) RevokeApiClient(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeApiClient", reflect.TypeOf((*MockStore)(nil).RevokeApiClient), arg0, arg1)
+}
+
+// RevokeApiKey mocks base method.
+func (m *MockStore) RevokeApiKey(arg0 context.Context, arg1 int64) (db.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeApiKey", arg0, arg1)
+	ret0, _ := ret[0].(db.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeApiKey indicates an expected call of RevokeApiKey.
+func (mr *MockStoreMockRecorder) RevokeApiKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeApiKey", reflect.TypeOf((*MockStore)(nil).RevokeApiKey), arg0, arg1)
+}
+
+// RotateApiClientSecret mocks base method.
+func (m *MockStore) RotateApiClientSecret(arg0 context.Context, arg1 db.RotateApiClientSecretParams) (db.ApiClient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateApiClientSecret", arg0, arg1)
+	ret0, _ := ret[0].(db.ApiClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateApiClientSecret indicates an expected call of RotateApiClientSecret.
+func (mr *MockStoreMockRecorder) RotateApiClientSecret(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateApiClientSecret", reflect.TypeOf((*MockStore)(nil).RotateApiClientSecret), arg0, arg1)
+}
+
+// SetAccountInterestProduct mocks base method.
+func (m *MockStore) SetAccountInterestProduct(arg0 context.Context, arg1 db.SetAccountInterestProductParams) (db.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAccountInterestProduct", arg0, arg1)
+	ret0, _ := ret[0].(db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetAccountInterestProduct indicates an expected call of SetAccountInterestProduct.
+func (mr *MockStoreMockRecorder) SetAccountInterestProduct(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAccountInterestProduct", reflect.TypeOf((*MockStore)(nil).SetAccountInterestProduct), arg0, arg1)
+}
+
+// SetAccountSpendingLimit mocks base method.
+func (m *MockStore) SetAccountSpendingLimit(arg0 context.Context, arg1 db.SetAccountSpendingLimitParams) (db.AccountSpendingLimit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAccountSpendingLimit", arg0, arg1)
+	ret0, _ := ret[0].(db.AccountSpendingLimit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetAccountSpendingLimit indicates an expected call of SetAccountSpendingLimit.
+func (mr *MockStoreMockRecorder) SetAccountSpendingLimit(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAccountSpendingLimit", reflect.TypeOf((*MockStore)(nil).SetAccountSpendingLimit), arg0, arg1)
+}
+
+// SetUserSpendingLimit mocks base method.
+func (m *MockStore) SetUserSpendingLimit(arg0 context.Context, arg1 db.SetUserSpendingLimitParams) (db.UserSpendingLimit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserSpendingLimit", arg0, arg1)
+	ret0, _ := ret[0].(db.UserSpendingLimit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetUserSpendingLimit indicates an expected call of SetUserSpendingLimit.
+func (mr *MockStoreMockRecorder) SetUserSpendingLimit(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserSpendingLimit", reflect.TypeOf((*MockStore)(nil).SetUserSpendingLimit), arg0, arg1)
+}
+
+// SumAccountSpending mocks base method.
+func (m *MockStore) SumAccountSpending(arg0 context.Context, arg1 db.SumAccountSpendingParams) (db.SumAccountSpendingRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumAccountSpending", arg0, arg1)
+	ret0, _ := ret[0].(db.SumAccountSpendingRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumAccountSpending indicates an expected call of SumAccountSpending.
+func (mr *MockStoreMockRecorder) SumAccountSpending(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumAccountSpending", reflect.TypeOf((*MockStore)(nil).SumAccountSpending), arg0, arg1)
+}
+
+// SumTransfersByCounterparty mocks base method.
+func (m *MockStore) SumTransfersByCounterparty(arg0 context.Context, arg1 db.SumTransfersByCounterpartyParams) ([]db.SumTransfersByCounterpartyRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumTransfersByCounterparty", arg0, arg1)
+	ret0, _ := ret[0].([]db.SumTransfersByCounterpartyRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumTransfersByCounterparty indicates an expected call of SumTransfersByCounterparty.
+func (mr *MockStoreMockRecorder) SumTransfersByCounterparty(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumTransfersByCounterparty", reflect.TypeOf((*MockStore)(nil).SumTransfersByCounterparty), arg0, arg1)
+}
+
+// SumTransfersByDirection mocks base method.
+func (m *MockStore) SumTransfersByDirection(arg0 context.Context, arg1 db.SumTransfersByDirectionParams) ([]db.SumTransfersByDirectionRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumTransfersByDirection", arg0, arg1)
+	ret0, _ := ret[0].([]db.SumTransfersByDirectionRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumTransfersByDirection indicates an expected call of SumTransfersByDirection.
+func (mr *MockStoreMockRecorder) SumTransfersByDirection(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumTransfersByDirection", reflect.TypeOf((*MockStore)(nil).SumTransfersByDirection), arg0, arg1)
+}
+
+// SumTransfersByMonth mocks base method.
+func (m *MockStore) SumTransfersByMonth(arg0 context.Context, arg1 db.SumTransfersByMonthParams) ([]db.SumTransfersByMonthRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumTransfersByMonth", arg0, arg1)
+	ret0, _ := ret[0].([]db.SumTransfersByMonthRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumTransfersByMonth indicates an expected call of SumTransfersByMonth.
+func (mr *MockStoreMockRecorder) SumTransfersByMonth(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumTransfersByMonth", reflect.TypeOf((*MockStore)(nil).SumTransfersByMonth), arg0, arg1)
+}
+
+// SumUserSpending mocks base method.
+func (m *MockStore) SumUserSpending(arg0 context.Context, arg1 db.SumUserSpendingParams) (db.SumUserSpendingRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumUserSpending", arg0, arg1)
+	ret0, _ := ret[0].(db.SumUserSpendingRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumUserSpending indicates an expected call of SumUserSpending.
+func (mr *MockStoreMockRecorder) SumUserSpending(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumUserSpending", reflect.TypeOf((*MockStore)(nil).SumUserSpending), arg0, arg1)
+}
+
+// TouchApiKey mocks base method.
+func (m *MockStore) TouchApiKey(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchApiKey", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchApiKey indicates an expected call of TouchApiKey.
+func (mr *MockStoreMockRecorder) TouchApiKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchApiKey", reflect.TypeOf((*MockStore)(nil).TouchApiKey), arg0, arg1)
+}
+
 // TransferTx mocks base method.
-func (m *MockStore) TransferTx(arg0 context.Context, arg1 db.CreateTransferParams) (db.TransferTxResults, error) {
+func (m *MockStore) TransferTx(arg0 context.Context, arg1 db.TransferTxParams) (db.TransferTxResults, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "TransferTx", arg0, arg1)
 	ret0, _ := ret[0].(db.TransferTxResults)
@@ -335,6 +1574,21 @@ func (mr *MockStoreMockRecorder) UpdateAccount(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccount", reflect.TypeOf((*MockStore)(nil).UpdateAccount), arg0, arg1)
 }
 
+// UpdateInterestPosting mocks base method.
+func (m *MockStore) UpdateInterestPosting(arg0 context.Context, arg1 db.UpdateInterestPostingParams) (db.InterestPosting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateInterestPosting", arg0, arg1)
+	ret0, _ := ret[0].(db.InterestPosting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateInterestPosting indicates an expected call of UpdateInterestPosting.
+func (mr *MockStoreMockRecorder) UpdateInterestPosting(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInterestPosting", reflect.TypeOf((*MockStore)(nil).UpdateInterestPosting), arg0, arg1)
+}
+
 // UpdateUser mocks base method.
 func (m *MockStore) UpdateUser(arg0 context.Context, arg1 db.UpdateUserParams) (db.User, error) {
 	m.ctrl.T.Helper()
@@ -350,6 +1604,35 @@ func (mr *MockStoreMockRecorder) UpdateUser(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockStore)(nil).UpdateUser), arg0, arg1)
 }
 
+// UpdateUserHashedPassword mocks base method.
+func (m *MockStore) UpdateUserHashedPassword(arg0 context.Context, arg1 db.UpdateUserHashedPasswordParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserHashedPassword", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserHashedPassword indicates an expected call of UpdateUserHashedPassword.
+func (mr *MockStoreMockRecorder) UpdateUserHashedPassword(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserHashedPassword", reflect.TypeOf((*MockStore)(nil).UpdateUserHashedPassword), arg0, arg1)
+}
+
+// UpdateUserTx mocks base method.
+func (m *MockStore) UpdateUserTx(arg0 context.Context, arg1 db.UpdateUserTxParams) (db.UpdateUserTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserTx", arg0, arg1)
+	ret0, _ := ret[0].(db.UpdateUserTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserTx indicates an expected call of UpdateUserTx.
+func (mr *MockStoreMockRecorder) UpdateUserTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserTx", reflect.TypeOf((*MockStore)(nil).UpdateUserTx), arg0, arg1)
+}
+
 // UpdateVerifyEmail mocks base method.
 func (m *MockStore) UpdateVerifyEmail(arg0 context.Context, arg1 db.UpdateVerifyEmailParams) (db.VerifyEmail, error) {
 	m.ctrl.T.Helper()
@@ -365,6 +1648,36 @@ func (mr *MockStoreMockRecorder) UpdateVerifyEmail(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVerifyEmail", reflect.TypeOf((*MockStore)(nil).UpdateVerifyEmail), arg0, arg1)
 }
 
+// UpdateWebhookDeliveryAttempt mocks base method.
+func (m *MockStore) UpdateWebhookDeliveryAttempt(arg0 context.Context, arg1 db.UpdateWebhookDeliveryAttemptParams) (db.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebhookDeliveryAttempt", arg0, arg1)
+	ret0, _ := ret[0].(db.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWebhookDeliveryAttempt indicates an expected call of UpdateWebhookDeliveryAttempt.
+func (mr *MockStoreMockRecorder) UpdateWebhookDeliveryAttempt(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebhookDeliveryAttempt", reflect.TypeOf((*MockStore)(nil).UpdateWebhookDeliveryAttempt), arg0, arg1)
+}
+
+// UpsertNotificationPreference mocks base method.
+func (m *MockStore) UpsertNotificationPreference(arg0 context.Context, arg1 db.UpsertNotificationPreferenceParams) (db.NotificationPreference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertNotificationPreference", arg0, arg1)
+	ret0, _ := ret[0].(db.NotificationPreference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertNotificationPreference indicates an expected call of UpsertNotificationPreference.
+func (mr *MockStoreMockRecorder) UpsertNotificationPreference(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertNotificationPreference", reflect.TypeOf((*MockStore)(nil).UpsertNotificationPreference), arg0, arg1)
+}
+
 // VerifyEmailTx mocks base method.
 func (m *MockStore) VerifyEmailTx(arg0 context.Context, arg1 db.VerifyEmailTxParams) (db.VerifyEmailTxResult, error) {
 	m.ctrl.T.Helper()
@@ -2,24 +2,100 @@
 INSERT INTO accounts (
     owner,
     balance,
-    currency
+    currency,
+    number,
+    type,
+    nickname
 ) VALUES (
-    $1, $2, $3
+    $1, $2, $3, $4, $5, $6
 )
 RETURNING *;
 
 -- name: GetAccount :one
 SELECT * FROM accounts WHERE id = $1 LIMIT 1;
 
+-- name: GetAccountByNumber :one
+SELECT * FROM accounts WHERE number = $1 LIMIT 1;
+
+-- name: GetSystemAccount :one
+-- The bank has one account per currency and purpose, named by its nickname.
+SELECT * FROM accounts
+WHERE owner = sqlc.arg(owner) AND currency = sqlc.arg(currency) AND nickname = sqlc.arg(nickname)
+ORDER BY id
+LIMIT 1;
+
 -- name: GetAccountForUpdate :one
 SELECT * FROM accounts WHERE id = $1 LIMIT 1 FOR NO KEY UPDATE;
 
--- name: ListAccounts :many
-SELECT * FROM accounts 
-WHERE owner = $1
+-- name: ListAccountsByID :many
+-- The accounts are listed by ListAccounts, with one query per sort order so that the order
+-- and the cursor are plain columns. Keyset pagination: the page starts after the cursor, the
+-- sort value and the ID of the last account of the previous page.
+SELECT * FROM accounts
+WHERE
+    owner = sqlc.arg(owner)
+    AND (sqlc.narg(currency)::varchar IS NULL OR currency = sqlc.narg(currency))
+    AND (sqlc.narg(type)::varchar IS NULL OR type = sqlc.narg(type))
+    AND (sqlc.narg(cursor_id)::bigint IS NULL OR id > sqlc.narg(cursor_id)::bigint)
 ORDER BY id
-LIMIT $2
-OFFSET $3;
+LIMIT sqlc.arg(page_size);
+
+-- name: ListAccountsByIDDesc :many
+SELECT * FROM accounts
+WHERE
+    owner = sqlc.arg(owner)
+    AND (sqlc.narg(currency)::varchar IS NULL OR currency = sqlc.narg(currency))
+    AND (sqlc.narg(type)::varchar IS NULL OR type = sqlc.narg(type))
+    AND (sqlc.narg(cursor_id)::bigint IS NULL OR id < sqlc.narg(cursor_id)::bigint)
+ORDER BY id DESC
+LIMIT sqlc.arg(page_size);
+
+-- name: ListAccountsByBalance :many
+SELECT * FROM accounts
+WHERE
+    owner = sqlc.arg(owner)
+    AND (sqlc.narg(currency)::varchar IS NULL OR currency = sqlc.narg(currency))
+    AND (sqlc.narg(type)::varchar IS NULL OR type = sqlc.narg(type))
+    AND (sqlc.narg(cursor_id)::bigint IS NULL OR (balance, id) > (sqlc.narg(cursor_balance)::bigint, sqlc.narg(cursor_id)::bigint))
+ORDER BY balance, id
+LIMIT sqlc.arg(page_size);
+
+-- name: ListAccountsByBalanceDesc :many
+SELECT * FROM accounts
+WHERE
+    owner = sqlc.arg(owner)
+    AND (sqlc.narg(currency)::varchar IS NULL OR currency = sqlc.narg(currency))
+    AND (sqlc.narg(type)::varchar IS NULL OR type = sqlc.narg(type))
+    AND (sqlc.narg(cursor_id)::bigint IS NULL OR (balance, id) < (sqlc.narg(cursor_balance)::bigint, sqlc.narg(cursor_id)::bigint))
+ORDER BY balance DESC, id DESC
+LIMIT sqlc.arg(page_size);
+
+-- name: ListAccountsByCreatedAt :many
+SELECT * FROM accounts
+WHERE
+    owner = sqlc.arg(owner)
+    AND (sqlc.narg(currency)::varchar IS NULL OR currency = sqlc.narg(currency))
+    AND (sqlc.narg(type)::varchar IS NULL OR type = sqlc.narg(type))
+    AND (sqlc.narg(cursor_id)::bigint IS NULL OR (created_at, id) > (sqlc.narg(cursor_created_at)::timestamptz, sqlc.narg(cursor_id)::bigint))
+ORDER BY created_at, id
+LIMIT sqlc.arg(page_size);
+
+-- name: ListAccountsByCreatedAtDesc :many
+SELECT * FROM accounts
+WHERE
+    owner = sqlc.arg(owner)
+    AND (sqlc.narg(currency)::varchar IS NULL OR currency = sqlc.narg(currency))
+    AND (sqlc.narg(type)::varchar IS NULL OR type = sqlc.narg(type))
+    AND (sqlc.narg(cursor_id)::bigint IS NULL OR (created_at, id) < (sqlc.narg(cursor_created_at)::timestamptz, sqlc.narg(cursor_id)::bigint))
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg(page_size);
+
+-- name: CountAccountsByOwner :one
+SELECT count(*) FROM accounts WHERE owner = $1;
+
+-- name: CountAccountsByType :one
+SELECT count(*) FROM accounts
+WHERE owner = $1 AND currency = $2 AND type = $3;
 
 -- name: UpdateAccount :one
 UPDATE accounts
@@ -27,6 +103,12 @@ SET balance = $2
 WHERE id = $1
 RETURNING *;
 
+-- name: SetAccountInterestProduct :one
+UPDATE accounts
+SET interest_product_id = sqlc.narg(interest_product_id)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
 -- name: AddAccountBalance :one
 UPDATE accounts
 SET balance = balance + sqlc.arg(amount)
@@ -0,0 +1,32 @@
+-- name: CreateApiClient :one
+INSERT INTO api_clients (
+    id,
+    owner,
+    name,
+    hashed_secret,
+    scopes,
+    created_by
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+RETURNING *;
+
+-- name: GetApiClient :one
+SELECT * FROM api_clients WHERE id = $1 LIMIT 1;
+
+-- name: RotateApiClientSecret :one
+UPDATE api_clients
+SET
+    hashed_secret = $2,
+    secret_rotated_at = now()
+WHERE
+    id = $1
+RETURNING *;
+
+-- name: RevokeApiClient :one
+UPDATE api_clients
+SET
+    revoked_at = COALESCE(revoked_at, now())
+WHERE
+    id = $1
+RETURNING *;
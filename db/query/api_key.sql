@@ -0,0 +1,41 @@
+-- name: CreateApiKey :one
+INSERT INTO api_keys (
+    owner,
+    name,
+    prefix,
+    hashed_key,
+    scopes,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+RETURNING *;
+
+-- name: GetApiKey :one
+SELECT * FROM api_keys WHERE id = $1 LIMIT 1;
+
+-- name: GetApiKeyByPrefix :one
+SELECT * FROM api_keys WHERE prefix = $1 LIMIT 1;
+
+-- name: ListApiKeys :many
+SELECT * FROM api_keys
+WHERE owner = $1
+ORDER BY id
+LIMIT $2
+OFFSET $3;
+
+-- name: TouchApiKey :exec
+UPDATE api_keys
+SET
+    last_used_at = now()
+WHERE
+    id = $1
+    AND (last_used_at IS NULL OR last_used_at < now() - interval '1 minute');
+
+-- name: RevokeApiKey :one
+UPDATE api_keys
+SET
+    revoked_at = COALESCE(revoked_at, now())
+WHERE
+    id = $1
+RETURNING *;
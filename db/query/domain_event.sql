@@ -0,0 +1,51 @@
+-- name: CreateDomainEvent :one
+INSERT INTO domain_events (
+    event_type,
+    usernames,
+    account_ids,
+    payload
+) VALUES (
+    $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: GetDomainEvent :one
+SELECT * FROM domain_events WHERE id = $1 LIMIT 1;
+
+-- name: ClaimUnpublishedDomainEvents :many
+-- Claims a batch of unpublished events for the lease, so the concurrent relays skip them while they
+-- are published outside of any transaction. The claim of a relay that stopped expires with the lease.
+UPDATE domain_events
+SET locked_until = now() + sqlc.arg(lease_seconds)::int * interval '1 second'
+WHERE id IN (
+    SELECT id FROM domain_events
+    WHERE
+        published_at IS NULL
+        AND failed_at IS NULL
+        AND (locked_until IS NULL OR locked_until < now())
+    ORDER BY id
+    LIMIT sqlc.arg(batch_size)
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: MarkDomainEventPublished :one
+UPDATE domain_events
+SET
+    attempts = attempts + 1,
+    last_error = NULL,
+    locked_until = NULL,
+    published_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: MarkDomainEventFailed :one
+-- The event is given up once it has failed max_attempts times.
+UPDATE domain_events
+SET
+    attempts = attempts + 1,
+    last_error = sqlc.arg(last_error),
+    locked_until = NULL,
+    failed_at = CASE WHEN attempts + 1 >= sqlc.arg(max_attempts)::int THEN now() END
+WHERE id = sqlc.arg(id)
+RETURNING *;
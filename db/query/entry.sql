@@ -1,9 +1,11 @@
 -- name: CreateEntry :one
 INSERT INTO entries (
   account_id,
-  amount
+  amount,
+  transfer_id,
+  type
 ) VALUES (
-  $1, $2
+  $1, $2, $3, $4
 ) RETURNING *;
 
 -- name: GetEntry :one
@@ -0,0 +1,21 @@
+-- name: CreateFeeSchedule :one
+INSERT INTO fee_schedules (
+    currency,
+    min_amount,
+    flat_fee,
+    percentage_bps
+) VALUES (
+    $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: GetFeeSchedule :one
+-- The tiers of a currency start at their min_amount, so the amount falls in the tier
+-- with the highest min_amount up to the amount.
+SELECT * FROM fee_schedules
+WHERE currency = sqlc.arg(currency) AND min_amount <= sqlc.arg(amount)
+ORDER BY min_amount DESC
+LIMIT 1;
+
+-- name: DeleteFeeSchedule :exec
+DELETE FROM fee_schedules WHERE id = $1;
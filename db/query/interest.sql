@@ -0,0 +1,82 @@
+-- name: CreateInterestProduct :one
+INSERT INTO interest_products (
+    name,
+    annual_rate_bps
+) VALUES (
+    $1, $2
+)
+RETURNING *;
+
+-- name: GetInterestProduct :one
+SELECT * FROM interest_products WHERE id = $1 LIMIT 1;
+
+-- name: ListInterestBearingAccounts :many
+-- The balance is the sum of the entries of the account until the end of the day, so accruing
+-- a past day uses the balance the account had then. Only the positive balances earn interest.
+SELECT
+    a.id AS account_id,
+    sum(e.amount)::bigint AS balance,
+    p.id AS product_id,
+    p.annual_rate_bps
+FROM accounts a
+JOIN interest_products p ON p.id = a.interest_product_id
+JOIN entries e ON e.account_id = a.id AND e.created_at < sqlc.arg(day_end)
+GROUP BY a.id, p.id
+HAVING sum(e.amount) > 0
+ORDER BY a.id;
+
+-- name: CreateInterestAccrual :execrows
+-- An account accrues interest once a day, so accruing a day again does nothing.
+INSERT INTO interest_accruals (
+    account_id,
+    product_id,
+    accrual_date,
+    balance,
+    annual_rate_bps,
+    amount_micros
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+ON CONFLICT (account_id, accrual_date) DO NOTHING;
+
+-- name: ListInterestAccruals :many
+SELECT * FROM interest_accruals
+WHERE account_id = $1
+ORDER BY accrual_date DESC
+LIMIT $2
+OFFSET $3;
+
+-- name: ListAccountsWithUnpostedInterest :many
+SELECT DISTINCT account_id FROM interest_accruals
+WHERE posting_id IS NULL AND accrual_date < sqlc.arg(before)
+ORDER BY account_id;
+
+-- name: GetLastInterestPosting :one
+SELECT * FROM interest_postings
+WHERE account_id = $1
+ORDER BY id DESC
+LIMIT 1;
+
+-- name: CreateInterestPosting :one
+INSERT INTO interest_postings (
+    account_id
+) VALUES (
+    $1
+)
+RETURNING *;
+
+-- name: ClaimInterestAccruals :many
+-- The accruals are attached to the posting, so a concurrent posting cannot claim them again.
+UPDATE interest_accruals
+SET posting_id = sqlc.arg(posting_id)
+WHERE account_id = sqlc.arg(account_id) AND posting_id IS NULL AND accrual_date < sqlc.arg(before)
+RETURNING amount_micros;
+
+-- name: UpdateInterestPosting :one
+UPDATE interest_postings
+SET
+    amount = sqlc.arg(amount),
+    carry_micros = sqlc.arg(carry_micros),
+    transfer_id = sqlc.narg(transfer_id)
+WHERE id = sqlc.arg(id)
+RETURNING *;
@@ -0,0 +1,17 @@
+-- name: GetNotificationPreference :one
+SELECT * FROM notification_preferences WHERE username = $1 LIMIT 1;
+
+-- name: UpsertNotificationPreference :one
+INSERT INTO notification_preferences (
+    username,
+    transfer_mode,
+    transfer_threshold
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT (username) DO UPDATE
+SET
+    transfer_mode = EXCLUDED.transfer_mode,
+    transfer_threshold = EXCLUDED.transfer_threshold,
+    updated_at = now()
+RETURNING *;
@@ -0,0 +1,49 @@
+-- name: CreateOutboxMessage :one
+INSERT INTO outbox_messages (
+    task_type,
+    payload,
+    queue,
+    max_retry,
+    process_at
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: ClaimPendingOutboxMessages :many
+-- Claims a batch of pending messages for the lease, so the concurrent relays skip them while they
+-- are published outside of any transaction. The claim of a relay that stopped expires with the lease.
+UPDATE outbox_messages
+SET locked_until = now() + sqlc.arg(lease_seconds)::int * interval '1 second'
+WHERE id IN (
+    SELECT id FROM outbox_messages
+    WHERE
+        published_at IS NULL
+        AND failed_at IS NULL
+        AND (locked_until IS NULL OR locked_until < now())
+    ORDER BY id
+    LIMIT sqlc.arg(batch_size)
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: MarkOutboxMessagePublished :one
+UPDATE outbox_messages
+SET
+    attempts = attempts + 1,
+    last_error = NULL,
+    locked_until = NULL,
+    published_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: MarkOutboxMessageFailed :one
+-- The message is given up once it has failed max_attempts times.
+UPDATE outbox_messages
+SET
+    attempts = attempts + 1,
+    last_error = sqlc.arg(last_error),
+    locked_until = NULL,
+    failed_at = CASE WHEN attempts + 1 >= sqlc.arg(max_attempts)::int THEN now() END
+WHERE id = sqlc.arg(id)
+RETURNING *;
@@ -0,0 +1,24 @@
+-- name: CreatePasswordHistory :exec
+INSERT INTO password_history (
+    username,
+    hashed_password
+) VALUES (
+    $1, $2
+);
+
+-- name: ListPasswordHistory :many
+SELECT hashed_password FROM password_history
+WHERE username = $1
+ORDER BY id DESC
+LIMIT $2;
+
+-- name: DeleteOldPasswordHistory :exec
+DELETE FROM password_history AS ph
+WHERE
+    ph.username = sqlc.arg(username)
+    AND ph.id NOT IN (
+        SELECT recent.id FROM password_history AS recent
+        WHERE recent.username = sqlc.arg(username)
+        ORDER BY recent.id DESC
+        LIMIT sqlc.arg(keep)
+    );
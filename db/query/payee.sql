@@ -0,0 +1,28 @@
+-- name: CreatePayee :one
+INSERT INTO payees (
+    owner,
+    nickname,
+    account_id,
+    currency
+) VALUES (
+    $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: GetPayee :one
+SELECT * FROM payees WHERE id = $1 LIMIT 1;
+
+-- name: ListPayees :many
+SELECT * FROM payees
+WHERE owner = $1
+ORDER BY nickname
+LIMIT $2
+OFFSET $3;
+
+-- name: DeletePayee :exec
+DELETE FROM payees WHERE id = $1;
+
+-- name: GetPayeeByAccount :one
+SELECT * FROM payees
+WHERE owner = $1 AND account_id = $2
+LIMIT 1;
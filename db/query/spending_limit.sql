@@ -0,0 +1,58 @@
+-- name: SetAccountSpendingLimit :one
+INSERT INTO account_spending_limits (
+    account_id,
+    daily_limit,
+    monthly_limit
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT (account_id) DO UPDATE
+SET daily_limit = EXCLUDED.daily_limit, monthly_limit = EXCLUDED.monthly_limit, updated_at = now()
+RETURNING *;
+
+-- name: GetAccountSpendingLimit :one
+SELECT * FROM account_spending_limits WHERE account_id = $1 LIMIT 1;
+
+-- name: SetUserSpendingLimit :one
+INSERT INTO user_spending_limits (
+    username,
+    currency,
+    daily_limit,
+    monthly_limit
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT (username, currency) DO UPDATE
+SET daily_limit = EXCLUDED.daily_limit, monthly_limit = EXCLUDED.monthly_limit, updated_at = now()
+RETURNING *;
+
+-- name: GetUserSpendingLimit :one
+SELECT * FROM user_spending_limits WHERE username = $1 AND currency = $2 LIMIT 1;
+
+-- The spending of the rolling windows is the amount of the transfers sent since the start of the window.
+-- The transfers to the accounts of the same owner move no money out, so they are not part of it.
+
+-- name: SumAccountSpending :one
+SELECT
+    COALESCE(sum(t.amount) FILTER (WHERE t.created_at >= sqlc.arg(day_start)), 0)::bigint AS daily_total,
+    COALESCE(sum(t.amount), 0)::bigint AS monthly_total
+FROM transfers t
+JOIN accounts fa ON fa.id = t.from_account_id
+JOIN accounts ta ON ta.id = t.to_account_id
+WHERE
+    t.from_account_id = sqlc.arg(account_id)
+    AND ta.owner <> fa.owner
+    AND t.created_at >= sqlc.arg(month_start);
+
+-- name: SumUserSpending :one
+SELECT
+    COALESCE(sum(t.amount) FILTER (WHERE t.created_at >= sqlc.arg(day_start)), 0)::bigint AS daily_total,
+    COALESCE(sum(t.amount), 0)::bigint AS monthly_total
+FROM transfers t
+JOIN accounts fa ON fa.id = t.from_account_id
+JOIN accounts ta ON ta.id = t.to_account_id
+WHERE
+    fa.owner = sqlc.arg(owner)
+    AND fa.currency = sqlc.arg(currency)
+    AND ta.owner <> fa.owner
+    AND t.created_at >= sqlc.arg(month_start);
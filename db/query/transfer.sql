@@ -11,11 +11,266 @@ INSERT INTO transfers (
 SELECT * FROM transfers
 WHERE id = $1 LIMIT 1;
 
--- name: ListTransfers :many
-SELECT * FROM transfers
-WHERE 
-    from_account_id = $1 OR
-    to_account_id = $2
-ORDER BY id
-LIMIT $3
-OFFSET $4;
\ No newline at end of file
+-- name: ListTransfersByCreatedAt :many
+-- The transfers from or to the accounts of the owner are listed by ListTransfers, with one
+-- query per sort order so that the order and the cursor are plain columns. Keyset pagination:
+-- the page starts after the cursor, the sort value and the ID of the last transfer of the
+-- previous page. The transfers sent by the accounts of the owner and the ones they received
+-- from other users are read separately, each through an index on the account and the sort
+-- order, and merged. Both accounts of a transfer have the same currency.
+WITH owned AS (
+    SELECT id FROM accounts
+    WHERE
+        owner = sqlc.arg(owner)
+        AND (sqlc.narg(currency)::varchar IS NULL OR currency = sqlc.narg(currency))
+)
+(
+    SELECT * FROM transfers
+    WHERE
+        from_account_id IN (SELECT id FROM owned)
+        AND (
+            sqlc.narg(counterparty_account_id)::bigint IS NULL
+            OR to_account_id = sqlc.narg(counterparty_account_id)
+            OR (from_account_id = sqlc.narg(counterparty_account_id) AND to_account_id IN (SELECT id FROM owned))
+        )
+        AND (sqlc.narg(account_id)::bigint IS NULL OR from_account_id = sqlc.narg(account_id) OR to_account_id = sqlc.narg(account_id))
+        AND (sqlc.narg(start_time)::timestamptz IS NULL OR created_at >= sqlc.narg(start_time))
+        AND (sqlc.narg(end_time)::timestamptz IS NULL OR created_at < sqlc.narg(end_time))
+        AND (sqlc.narg(min_amount)::bigint IS NULL OR amount >= sqlc.narg(min_amount))
+        AND (sqlc.narg(max_amount)::bigint IS NULL OR amount <= sqlc.narg(max_amount))
+        AND (sqlc.narg(cursor_id)::bigint IS NULL OR (created_at, id) > (sqlc.narg(cursor_created_at)::timestamptz, sqlc.narg(cursor_id)::bigint))
+    ORDER BY created_at, id
+    LIMIT sqlc.arg(page_size)
+)
+UNION ALL
+(
+    SELECT * FROM transfers
+    WHERE
+        to_account_id IN (SELECT id FROM owned)
+        AND from_account_id NOT IN (SELECT id FROM owned)
+        AND (sqlc.narg(counterparty_account_id)::bigint IS NULL OR from_account_id = sqlc.narg(counterparty_account_id))
+        AND (sqlc.narg(account_id)::bigint IS NULL OR from_account_id = sqlc.narg(account_id) OR to_account_id = sqlc.narg(account_id))
+        AND (sqlc.narg(start_time)::timestamptz IS NULL OR created_at >= sqlc.narg(start_time))
+        AND (sqlc.narg(end_time)::timestamptz IS NULL OR created_at < sqlc.narg(end_time))
+        AND (sqlc.narg(min_amount)::bigint IS NULL OR amount >= sqlc.narg(min_amount))
+        AND (sqlc.narg(max_amount)::bigint IS NULL OR amount <= sqlc.narg(max_amount))
+        AND (sqlc.narg(cursor_id)::bigint IS NULL OR (created_at, id) > (sqlc.narg(cursor_created_at)::timestamptz, sqlc.narg(cursor_id)::bigint))
+    ORDER BY created_at, id
+    LIMIT sqlc.arg(page_size)
+)
+ORDER BY created_at, id
+LIMIT sqlc.arg(page_size);
+
+-- name: ListTransfersByCreatedAtDesc :many
+WITH owned AS (
+    SELECT id FROM accounts
+    WHERE
+        owner = sqlc.arg(owner)
+        AND (sqlc.narg(currency)::varchar IS NULL OR currency = sqlc.narg(currency))
+)
+(
+    SELECT * FROM transfers
+    WHERE
+        from_account_id IN (SELECT id FROM owned)
+        AND (
+            sqlc.narg(counterparty_account_id)::bigint IS NULL
+            OR to_account_id = sqlc.narg(counterparty_account_id)
+            OR (from_account_id = sqlc.narg(counterparty_account_id) AND to_account_id IN (SELECT id FROM owned))
+        )
+        AND (sqlc.narg(account_id)::bigint IS NULL OR from_account_id = sqlc.narg(account_id) OR to_account_id = sqlc.narg(account_id))
+        AND (sqlc.narg(start_time)::timestamptz IS NULL OR created_at >= sqlc.narg(start_time))
+        AND (sqlc.narg(end_time)::timestamptz IS NULL OR created_at < sqlc.narg(end_time))
+        AND (sqlc.narg(min_amount)::bigint IS NULL OR amount >= sqlc.narg(min_amount))
+        AND (sqlc.narg(max_amount)::bigint IS NULL OR amount <= sqlc.narg(max_amount))
+        AND (sqlc.narg(cursor_id)::bigint IS NULL OR (created_at, id) < (sqlc.narg(cursor_created_at)::timestamptz, sqlc.narg(cursor_id)::bigint))
+    ORDER BY created_at DESC, id DESC
+    LIMIT sqlc.arg(page_size)
+)
+UNION ALL
+(
+    SELECT * FROM transfers
+    WHERE
+        to_account_id IN (SELECT id FROM owned)
+        AND from_account_id NOT IN (SELECT id FROM owned)
+        AND (sqlc.narg(counterparty_account_id)::bigint IS NULL OR from_account_id = sqlc.narg(counterparty_account_id))
+        AND (sqlc.narg(account_id)::bigint IS NULL OR from_account_id = sqlc.narg(account_id) OR to_account_id = sqlc.narg(account_id))
+        AND (sqlc.narg(start_time)::timestamptz IS NULL OR created_at >= sqlc.narg(start_time))
+        AND (sqlc.narg(end_time)::timestamptz IS NULL OR created_at < sqlc.narg(end_time))
+        AND (sqlc.narg(min_amount)::bigint IS NULL OR amount >= sqlc.narg(min_amount))
+        AND (sqlc.narg(max_amount)::bigint IS NULL OR amount <= sqlc.narg(max_amount))
+        AND (sqlc.narg(cursor_id)::bigint IS NULL OR (created_at, id) < (sqlc.narg(cursor_created_at)::timestamptz, sqlc.narg(cursor_id)::bigint))
+    ORDER BY created_at DESC, id DESC
+    LIMIT sqlc.arg(page_size)
+)
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg(page_size);
+
+-- name: ListTransfersByAmount :many
+WITH owned AS (
+    SELECT id FROM accounts
+    WHERE
+        owner = sqlc.arg(owner)
+        AND (sqlc.narg(currency)::varchar IS NULL OR currency = sqlc.narg(currency))
+)
+(
+    SELECT * FROM transfers
+    WHERE
+        from_account_id IN (SELECT id FROM owned)
+        AND (
+            sqlc.narg(counterparty_account_id)::bigint IS NULL
+            OR to_account_id = sqlc.narg(counterparty_account_id)
+            OR (from_account_id = sqlc.narg(counterparty_account_id) AND to_account_id IN (SELECT id FROM owned))
+        )
+        AND (sqlc.narg(account_id)::bigint IS NULL OR from_account_id = sqlc.narg(account_id) OR to_account_id = sqlc.narg(account_id))
+        AND (sqlc.narg(start_time)::timestamptz IS NULL OR created_at >= sqlc.narg(start_time))
+        AND (sqlc.narg(end_time)::timestamptz IS NULL OR created_at < sqlc.narg(end_time))
+        AND (sqlc.narg(min_amount)::bigint IS NULL OR amount >= sqlc.narg(min_amount))
+        AND (sqlc.narg(max_amount)::bigint IS NULL OR amount <= sqlc.narg(max_amount))
+        AND (sqlc.narg(cursor_id)::bigint IS NULL OR (amount, id) > (sqlc.narg(cursor_amount)::bigint, sqlc.narg(cursor_id)::bigint))
+    ORDER BY amount, id
+    LIMIT sqlc.arg(page_size)
+)
+UNION ALL
+(
+    SELECT * FROM transfers
+    WHERE
+        to_account_id IN (SELECT id FROM owned)
+        AND from_account_id NOT IN (SELECT id FROM owned)
+        AND (sqlc.narg(counterparty_account_id)::bigint IS NULL OR from_account_id = sqlc.narg(counterparty_account_id))
+        AND (sqlc.narg(account_id)::bigint IS NULL OR from_account_id = sqlc.narg(account_id) OR to_account_id = sqlc.narg(account_id))
+        AND (sqlc.narg(start_time)::timestamptz IS NULL OR created_at >= sqlc.narg(start_time))
+        AND (sqlc.narg(end_time)::timestamptz IS NULL OR created_at < sqlc.narg(end_time))
+        AND (sqlc.narg(min_amount)::bigint IS NULL OR amount >= sqlc.narg(min_amount))
+        AND (sqlc.narg(max_amount)::bigint IS NULL OR amount <= sqlc.narg(max_amount))
+        AND (sqlc.narg(cursor_id)::bigint IS NULL OR (amount, id) > (sqlc.narg(cursor_amount)::bigint, sqlc.narg(cursor_id)::bigint))
+    ORDER BY amount, id
+    LIMIT sqlc.arg(page_size)
+)
+ORDER BY amount, id
+LIMIT sqlc.arg(page_size);
+
+-- name: ListTransfersByAmountDesc :many
+WITH owned AS (
+    SELECT id FROM accounts
+    WHERE
+        owner = sqlc.arg(owner)
+        AND (sqlc.narg(currency)::varchar IS NULL OR currency = sqlc.narg(currency))
+)
+(
+    SELECT * FROM transfers
+    WHERE
+        from_account_id IN (SELECT id FROM owned)
+        AND (
+            sqlc.narg(counterparty_account_id)::bigint IS NULL
+            OR to_account_id = sqlc.narg(counterparty_account_id)
+            OR (from_account_id = sqlc.narg(counterparty_account_id) AND to_account_id IN (SELECT id FROM owned))
+        )
+        AND (sqlc.narg(account_id)::bigint IS NULL OR from_account_id = sqlc.narg(account_id) OR to_account_id = sqlc.narg(account_id))
+        AND (sqlc.narg(start_time)::timestamptz IS NULL OR created_at >= sqlc.narg(start_time))
+        AND (sqlc.narg(end_time)::timestamptz IS NULL OR created_at < sqlc.narg(end_time))
+        AND (sqlc.narg(min_amount)::bigint IS NULL OR amount >= sqlc.narg(min_amount))
+        AND (sqlc.narg(max_amount)::bigint IS NULL OR amount <= sqlc.narg(max_amount))
+        AND (sqlc.narg(cursor_id)::bigint IS NULL OR (amount, id) < (sqlc.narg(cursor_amount)::bigint, sqlc.narg(cursor_id)::bigint))
+    ORDER BY amount DESC, id DESC
+    LIMIT sqlc.arg(page_size)
+)
+UNION ALL
+(
+    SELECT * FROM transfers
+    WHERE
+        to_account_id IN (SELECT id FROM owned)
+        AND from_account_id NOT IN (SELECT id FROM owned)
+        AND (sqlc.narg(counterparty_account_id)::bigint IS NULL OR from_account_id = sqlc.narg(counterparty_account_id))
+        AND (sqlc.narg(account_id)::bigint IS NULL OR from_account_id = sqlc.narg(account_id) OR to_account_id = sqlc.narg(account_id))
+        AND (sqlc.narg(start_time)::timestamptz IS NULL OR created_at >= sqlc.narg(start_time))
+        AND (sqlc.narg(end_time)::timestamptz IS NULL OR created_at < sqlc.narg(end_time))
+        AND (sqlc.narg(min_amount)::bigint IS NULL OR amount >= sqlc.narg(min_amount))
+        AND (sqlc.narg(max_amount)::bigint IS NULL OR amount <= sqlc.narg(max_amount))
+        AND (sqlc.narg(cursor_id)::bigint IS NULL OR (amount, id) < (sqlc.narg(cursor_amount)::bigint, sqlc.narg(cursor_id)::bigint))
+    ORDER BY amount DESC, id DESC
+    LIMIT sqlc.arg(page_size)
+)
+ORDER BY amount DESC, id DESC
+LIMIT sqlc.arg(page_size);
+
+-- name: GetAccountPayments :one
+-- Sums what the owner sent to the account from any of their accounts and returns when they first paid it,
+-- or now if they never did.
+SELECT
+    COALESCE(min(t.created_at), now())::timestamptz AS first_paid_at,
+    COALESCE(sum(t.amount), 0)::bigint AS total
+FROM transfers t
+JOIN accounts fa ON fa.id = t.from_account_id
+WHERE
+    t.to_account_id = sqlc.arg(to_account_id)
+    AND fa.owner = sqlc.arg(owner);
+
+-- The transfer search aggregates the entries of the transfers, which are the movements of the
+-- accounts of the owner: a transfer between two accounts of the owner is both sent and received.
+-- The fees of the transfers are not part of the totals.
+
+-- name: SumTransfersByCounterparty :many
+SELECT
+    CASE WHEN e.account_id = t.from_account_id THEN t.to_account_id ELSE t.from_account_id END::bigint AS counterparty_account_id,
+    CASE WHEN e.amount < 0 THEN 'sent' ELSE 'received' END::varchar AS direction,
+    a.currency,
+    count(*) AS transfer_count,
+    sum(abs(e.amount))::bigint AS total_amount
+FROM entries e
+JOIN transfers t ON t.id = e.transfer_id
+JOIN accounts a ON a.id = e.account_id
+WHERE
+    a.owner = sqlc.arg(owner)
+    AND e.type = 'transfer'
+    AND e.created_at >= sqlc.arg(start_time)
+    AND e.created_at < sqlc.arg(end_time)
+    AND (sqlc.narg(account_id)::bigint IS NULL OR e.account_id = sqlc.narg(account_id))
+    AND (sqlc.narg(counterparty_account_id)::bigint IS NULL OR CASE WHEN e.account_id = t.from_account_id THEN t.to_account_id ELSE t.from_account_id END = sqlc.narg(counterparty_account_id))
+    AND (sqlc.narg(currency)::varchar IS NULL OR a.currency = sqlc.narg(currency))
+    AND (sqlc.narg(direction)::varchar IS NULL OR (sqlc.narg(direction) = 'sent') = (e.amount < 0))
+GROUP BY 1, 2, a.currency
+ORDER BY total_amount DESC, counterparty_account_id, direction
+LIMIT sqlc.arg(max_groups);
+
+-- name: SumTransfersByMonth :many
+SELECT
+    (date_trunc('month', e.created_at AT TIME ZONE 'UTC') AT TIME ZONE 'UTC')::timestamptz AS month,
+    CASE WHEN e.amount < 0 THEN 'sent' ELSE 'received' END::varchar AS direction,
+    a.currency,
+    count(*) AS transfer_count,
+    sum(abs(e.amount))::bigint AS total_amount
+FROM entries e
+JOIN transfers t ON t.id = e.transfer_id
+JOIN accounts a ON a.id = e.account_id
+WHERE
+    a.owner = sqlc.arg(owner)
+    AND e.type = 'transfer'
+    AND e.created_at >= sqlc.arg(start_time)
+    AND e.created_at < sqlc.arg(end_time)
+    AND (sqlc.narg(account_id)::bigint IS NULL OR e.account_id = sqlc.narg(account_id))
+    AND (sqlc.narg(counterparty_account_id)::bigint IS NULL OR CASE WHEN e.account_id = t.from_account_id THEN t.to_account_id ELSE t.from_account_id END = sqlc.narg(counterparty_account_id))
+    AND (sqlc.narg(currency)::varchar IS NULL OR a.currency = sqlc.narg(currency))
+    AND (sqlc.narg(direction)::varchar IS NULL OR (sqlc.narg(direction) = 'sent') = (e.amount < 0))
+GROUP BY 1, 2, a.currency
+ORDER BY month, direction, a.currency;
+
+-- name: SumTransfersByDirection :many
+SELECT
+    CASE WHEN e.amount < 0 THEN 'sent' ELSE 'received' END::varchar AS direction,
+    a.currency,
+    count(*) AS transfer_count,
+    sum(abs(e.amount))::bigint AS total_amount
+FROM entries e
+JOIN transfers t ON t.id = e.transfer_id
+JOIN accounts a ON a.id = e.account_id
+WHERE
+    a.owner = sqlc.arg(owner)
+    AND e.type = 'transfer'
+    AND e.created_at >= sqlc.arg(start_time)
+    AND e.created_at < sqlc.arg(end_time)
+    AND (sqlc.narg(account_id)::bigint IS NULL OR e.account_id = sqlc.narg(account_id))
+    AND (sqlc.narg(counterparty_account_id)::bigint IS NULL OR CASE WHEN e.account_id = t.from_account_id THEN t.to_account_id ELSE t.from_account_id END = sqlc.narg(counterparty_account_id))
+    AND (sqlc.narg(currency)::varchar IS NULL OR a.currency = sqlc.narg(currency))
+    AND (sqlc.narg(direction)::varchar IS NULL OR (sqlc.narg(direction) = 'sent') = (e.amount < 0))
+GROUP BY 1, a.currency
+ORDER BY direction, a.currency;
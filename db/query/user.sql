@@ -12,6 +12,9 @@ RETURNING *;
 -- name: GetUser :one
 SELECT * FROM users WHERE username = $1 LIMIT 1;
 
+-- name: GetUserForUpdate :one
+SELECT * FROM users WHERE username = $1 LIMIT 1 FOR NO KEY UPDATE;
+
 -- name: UpdateUser :one
 UPDATE users
 SET 
@@ -19,6 +22,39 @@ SET
     password_changed_at = COALESCE(sqlc.narg(password_changed_at), password_changed_at),
     full_name = COALESCE(sqlc.narg(full_name), full_name),
     email = COALESCE(sqlc.narg(email), email),
-    is_email_verified = COALESCE(sqlc.narg(is_email_verified), is_email_verified)
+    is_email_verified = COALESCE(sqlc.narg(is_email_verified), is_email_verified),
+    locale = COALESCE(sqlc.narg(locale), locale),
+    pending_email = COALESCE(sqlc.narg(pending_email), pending_email)
 WHERE username = sqlc.arg(username)
 RETURNING *;
+
+-- name: ApplyPendingEmail :one
+UPDATE users
+SET
+    email = pending_email,
+    pending_email = NULL,
+    is_email_verified = TRUE
+WHERE
+    username = sqlc.arg(username)
+    AND pending_email = sqlc.arg(email)::varchar
+RETURNING *;
+
+-- name: CancelPendingEmail :one
+UPDATE users
+SET pending_email = NULL
+WHERE username = $1
+RETURNING *;
+
+-- name: RecordVerifyEmailRequest :one
+UPDATE users
+SET verify_email_requested_at = now()
+WHERE username = $1
+RETURNING *;
+
+-- name: UpdateUserHashedPassword :exec
+UPDATE users
+SET
+    hashed_password = sqlc.arg(hashed_password)
+WHERE
+    username = sqlc.arg(username)
+    AND hashed_password = sqlc.arg(old_hashed_password);
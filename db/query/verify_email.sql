@@ -2,12 +2,22 @@
 INSERT INTO verify_emails (
     username,
     email,
-    secret_code
+    secret_code,
+    expired_at
 ) VALUES (
-    $1, $2, $3
+    $1, $2, $3, $4
 )
 RETURNING *;
 
+-- name: InvalidateVerifyEmails :exec
+UPDATE verify_emails
+SET
+    expired_at = now()
+WHERE
+    username = $1
+    AND is_used = FALSE
+    AND expired_at > now();
+
 -- name: UpdateVerifyEmail :one
 UPDATE verify_emails
 SET
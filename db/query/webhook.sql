@@ -0,0 +1,66 @@
+-- name: CreateWebhookSubscription :one
+INSERT INTO webhook_subscriptions (
+    owner,
+    url,
+    secret,
+    event_types
+) VALUES (
+    $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: GetWebhookSubscription :one
+SELECT * FROM webhook_subscriptions WHERE id = $1 LIMIT 1;
+
+-- name: ListWebhookSubscriptions :many
+SELECT * FROM webhook_subscriptions
+WHERE owner = $1
+ORDER BY id
+LIMIT $2
+OFFSET $3;
+
+-- name: ListWebhookSubscriptionsForEvent :many
+SELECT * FROM webhook_subscriptions
+WHERE
+    is_active = TRUE
+    AND owner = ANY(sqlc.arg(usernames)::varchar[])
+    AND (cardinality(event_types) = 0 OR sqlc.arg(event_type)::varchar = ANY(event_types))
+ORDER BY id;
+
+-- name: DeleteWebhookSubscription :exec
+DELETE FROM webhook_subscriptions WHERE id = $1;
+
+-- name: CreateWebhookDelivery :one
+INSERT INTO webhook_deliveries (
+    subscription_id,
+    event_id,
+    event_type,
+    payload
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT (subscription_id, event_id) DO UPDATE
+SET subscription_id = EXCLUDED.subscription_id
+RETURNING *;
+
+-- name: GetWebhookDelivery :one
+SELECT * FROM webhook_deliveries WHERE id = $1 LIMIT 1;
+
+-- name: ListWebhookDeliveries :many
+SELECT * FROM webhook_deliveries
+WHERE subscription_id = $1
+ORDER BY id DESC
+LIMIT $2
+OFFSET $3;
+
+-- name: UpdateWebhookDeliveryAttempt :one
+UPDATE webhook_deliveries
+SET
+    status = $2,
+    attempts = attempts + 1,
+    response_status = $3,
+    last_error = $4,
+    delivered_at = $5,
+    updated_at = now()
+WHERE id = $1
+RETURNING *;
@@ -7,13 +7,14 @@ package db
 
 import (
 	"context"
+	"database/sql"
 )
 
 const addAccountBalance = `-- name: AddAccountBalance :one
 UPDATE accounts
 SET balance = balance + $1
 WHERE id = $2
-RETURNING id, owner, balance, currency, created_at
+RETURNING id, owner, balance, currency, created_at, number, type, nickname, interest_product_id
 `
 
 type AddAccountBalanceParams struct {
@@ -30,29 +31,75 @@ func (q *Queries) AddAccountBalance(ctx context.Context, arg AddAccountBalancePa
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.Number,
+		&i.Type,
+		&i.Nickname,
+		&i.InterestProductID,
 	)
 	return i, err
 }
 
+const countAccountsByOwner = `-- name: CountAccountsByOwner :one
+SELECT count(*) FROM accounts WHERE owner = $1
+`
+
+func (q *Queries) CountAccountsByOwner(ctx context.Context, owner string) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countAccountsByOwner, owner)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countAccountsByType = `-- name: CountAccountsByType :one
+SELECT count(*) FROM accounts
+WHERE owner = $1 AND currency = $2 AND type = $3
+`
+
+type CountAccountsByTypeParams struct {
+	Owner    string `json:"owner"`
+	Currency string `json:"currency"`
+	Type     string `json:"type"`
+}
+
+func (q *Queries) CountAccountsByType(ctx context.Context, arg CountAccountsByTypeParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countAccountsByType, arg.Owner, arg.Currency, arg.Type)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createAccount = `-- name: CreateAccount :one
 INSERT INTO accounts (
     owner,
     balance,
-    currency
+    currency,
+    number,
+    type,
+    nickname
 ) VALUES (
-    $1, $2, $3
+    $1, $2, $3, $4, $5, $6
 )
-RETURNING id, owner, balance, currency, created_at
+RETURNING id, owner, balance, currency, created_at, number, type, nickname, interest_product_id
 `
 
 type CreateAccountParams struct {
 	Owner    string `json:"owner"`
 	Balance  int64  `json:"balance"`
 	Currency string `json:"currency"`
+	Number   string `json:"number"`
+	Type     string `json:"type"`
+	Nickname string `json:"nickname"`
 }
 
 func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error) {
-	row := q.db.QueryRowContext(ctx, createAccount, arg.Owner, arg.Balance, arg.Currency)
+	row := q.db.QueryRowContext(ctx, createAccount,
+		arg.Owner,
+		arg.Balance,
+		arg.Currency,
+		arg.Number,
+		arg.Type,
+		arg.Nickname,
+	)
 	var i Account
 	err := row.Scan(
 		&i.ID,
@@ -60,6 +107,10 @@ func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (A
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.Number,
+		&i.Type,
+		&i.Nickname,
+		&i.InterestProductID,
 	)
 	return i, err
 }
@@ -74,7 +125,7 @@ func (q *Queries) DeleteAccount(ctx context.Context, id int64) error {
 }
 
 const getAccount = `-- name: GetAccount :one
-SELECT id, owner, balance, currency, created_at FROM accounts WHERE id = $1 LIMIT 1
+SELECT id, owner, balance, currency, created_at, number, type, nickname, interest_product_id FROM accounts WHERE id = $1 LIMIT 1
 `
 
 func (q *Queries) GetAccount(ctx context.Context, id int64) (Account, error) {
@@ -86,12 +137,37 @@ func (q *Queries) GetAccount(ctx context.Context, id int64) (Account, error) {
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.Number,
+		&i.Type,
+		&i.Nickname,
+		&i.InterestProductID,
+	)
+	return i, err
+}
+
+const getAccountByNumber = `-- name: GetAccountByNumber :one
+SELECT id, owner, balance, currency, created_at, number, type, nickname, interest_product_id FROM accounts WHERE number = $1 LIMIT 1
+`
+
+func (q *Queries) GetAccountByNumber(ctx context.Context, number string) (Account, error) {
+	row := q.db.QueryRowContext(ctx, getAccountByNumber, number)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.Number,
+		&i.Type,
+		&i.Nickname,
+		&i.InterestProductID,
 	)
 	return i, err
 }
 
 const getAccountForUpdate = `-- name: GetAccountForUpdate :one
-SELECT id, owner, balance, currency, created_at FROM accounts WHERE id = $1 LIMIT 1 FOR NO KEY UPDATE
+SELECT id, owner, balance, currency, created_at, number, type, nickname, interest_product_id FROM accounts WHERE id = $1 LIMIT 1 FOR NO KEY UPDATE
 `
 
 func (q *Queries) GetAccountForUpdate(ctx context.Context, id int64) (Account, error) {
@@ -103,26 +179,315 @@ func (q *Queries) GetAccountForUpdate(ctx context.Context, id int64) (Account, e
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.Number,
+		&i.Type,
+		&i.Nickname,
+		&i.InterestProductID,
+	)
+	return i, err
+}
+
+const getSystemAccount = `-- name: GetSystemAccount :one
+SELECT id, owner, balance, currency, created_at, number, type, nickname, interest_product_id FROM accounts
+WHERE owner = $1 AND currency = $2 AND nickname = $3
+ORDER BY id
+LIMIT 1
+`
+
+type GetSystemAccountParams struct {
+	Owner    string `json:"owner"`
+	Currency string `json:"currency"`
+	Nickname string `json:"nickname"`
+}
+
+// The bank has one account per currency and purpose, named by its nickname.
+func (q *Queries) GetSystemAccount(ctx context.Context, arg GetSystemAccountParams) (Account, error) {
+	row := q.db.QueryRowContext(ctx, getSystemAccount, arg.Owner, arg.Currency, arg.Nickname)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.Number,
+		&i.Type,
+		&i.Nickname,
+		&i.InterestProductID,
 	)
 	return i, err
 }
 
-const listAccounts = `-- name: ListAccounts :many
-SELECT id, owner, balance, currency, created_at FROM accounts 
-WHERE owner = $1
+const listAccountsByBalance = `-- name: ListAccountsByBalance :many
+SELECT id, owner, balance, currency, created_at, number, type, nickname, interest_product_id FROM accounts
+WHERE
+    owner = $1
+    AND ($2::varchar IS NULL OR currency = $2)
+    AND ($3::varchar IS NULL OR type = $3)
+    AND ($4::bigint IS NULL OR (balance, id) > ($5::bigint, $4::bigint))
+ORDER BY balance, id
+LIMIT $6
+`
+
+type ListAccountsByBalanceParams struct {
+	Owner         string         `json:"owner"`
+	Currency      sql.NullString `json:"currency"`
+	Type          sql.NullString `json:"type"`
+	CursorID      sql.NullInt64  `json:"cursor_id"`
+	CursorBalance sql.NullInt64  `json:"cursor_balance"`
+	PageSize      int32          `json:"page_size"`
+}
+
+func (q *Queries) ListAccountsByBalance(ctx context.Context, arg ListAccountsByBalanceParams) ([]Account, error) {
+	rows, err := q.db.QueryContext(ctx, listAccountsByBalance,
+		arg.Owner,
+		arg.Currency,
+		arg.Type,
+		arg.CursorID,
+		arg.CursorBalance,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Account{}
+	for rows.Next() {
+		var i Account
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.Balance,
+			&i.Currency,
+			&i.CreatedAt,
+			&i.Number,
+			&i.Type,
+			&i.Nickname,
+			&i.InterestProductID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAccountsByBalanceDesc = `-- name: ListAccountsByBalanceDesc :many
+SELECT id, owner, balance, currency, created_at, number, type, nickname, interest_product_id FROM accounts
+WHERE
+    owner = $1
+    AND ($2::varchar IS NULL OR currency = $2)
+    AND ($3::varchar IS NULL OR type = $3)
+    AND ($4::bigint IS NULL OR (balance, id) < ($5::bigint, $4::bigint))
+ORDER BY balance DESC, id DESC
+LIMIT $6
+`
+
+type ListAccountsByBalanceDescParams struct {
+	Owner         string         `json:"owner"`
+	Currency      sql.NullString `json:"currency"`
+	Type          sql.NullString `json:"type"`
+	CursorID      sql.NullInt64  `json:"cursor_id"`
+	CursorBalance sql.NullInt64  `json:"cursor_balance"`
+	PageSize      int32          `json:"page_size"`
+}
+
+func (q *Queries) ListAccountsByBalanceDesc(ctx context.Context, arg ListAccountsByBalanceDescParams) ([]Account, error) {
+	rows, err := q.db.QueryContext(ctx, listAccountsByBalanceDesc,
+		arg.Owner,
+		arg.Currency,
+		arg.Type,
+		arg.CursorID,
+		arg.CursorBalance,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Account{}
+	for rows.Next() {
+		var i Account
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.Balance,
+			&i.Currency,
+			&i.CreatedAt,
+			&i.Number,
+			&i.Type,
+			&i.Nickname,
+			&i.InterestProductID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAccountsByCreatedAt = `-- name: ListAccountsByCreatedAt :many
+SELECT id, owner, balance, currency, created_at, number, type, nickname, interest_product_id FROM accounts
+WHERE
+    owner = $1
+    AND ($2::varchar IS NULL OR currency = $2)
+    AND ($3::varchar IS NULL OR type = $3)
+    AND ($4::bigint IS NULL OR (created_at, id) > ($5::timestamptz, $4::bigint))
+ORDER BY created_at, id
+LIMIT $6
+`
+
+type ListAccountsByCreatedAtParams struct {
+	Owner           string         `json:"owner"`
+	Currency        sql.NullString `json:"currency"`
+	Type            sql.NullString `json:"type"`
+	CursorID        sql.NullInt64  `json:"cursor_id"`
+	CursorCreatedAt sql.NullTime   `json:"cursor_created_at"`
+	PageSize        int32          `json:"page_size"`
+}
+
+func (q *Queries) ListAccountsByCreatedAt(ctx context.Context, arg ListAccountsByCreatedAtParams) ([]Account, error) {
+	rows, err := q.db.QueryContext(ctx, listAccountsByCreatedAt,
+		arg.Owner,
+		arg.Currency,
+		arg.Type,
+		arg.CursorID,
+		arg.CursorCreatedAt,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Account{}
+	for rows.Next() {
+		var i Account
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.Balance,
+			&i.Currency,
+			&i.CreatedAt,
+			&i.Number,
+			&i.Type,
+			&i.Nickname,
+			&i.InterestProductID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAccountsByCreatedAtDesc = `-- name: ListAccountsByCreatedAtDesc :many
+SELECT id, owner, balance, currency, created_at, number, type, nickname, interest_product_id FROM accounts
+WHERE
+    owner = $1
+    AND ($2::varchar IS NULL OR currency = $2)
+    AND ($3::varchar IS NULL OR type = $3)
+    AND ($4::bigint IS NULL OR (created_at, id) < ($5::timestamptz, $4::bigint))
+ORDER BY created_at DESC, id DESC
+LIMIT $6
+`
+
+type ListAccountsByCreatedAtDescParams struct {
+	Owner           string         `json:"owner"`
+	Currency        sql.NullString `json:"currency"`
+	Type            sql.NullString `json:"type"`
+	CursorID        sql.NullInt64  `json:"cursor_id"`
+	CursorCreatedAt sql.NullTime   `json:"cursor_created_at"`
+	PageSize        int32          `json:"page_size"`
+}
+
+func (q *Queries) ListAccountsByCreatedAtDesc(ctx context.Context, arg ListAccountsByCreatedAtDescParams) ([]Account, error) {
+	rows, err := q.db.QueryContext(ctx, listAccountsByCreatedAtDesc,
+		arg.Owner,
+		arg.Currency,
+		arg.Type,
+		arg.CursorID,
+		arg.CursorCreatedAt,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Account{}
+	for rows.Next() {
+		var i Account
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.Balance,
+			&i.Currency,
+			&i.CreatedAt,
+			&i.Number,
+			&i.Type,
+			&i.Nickname,
+			&i.InterestProductID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAccountsByID = `-- name: ListAccountsByID :many
+SELECT id, owner, balance, currency, created_at, number, type, nickname, interest_product_id FROM accounts
+WHERE
+    owner = $1
+    AND ($2::varchar IS NULL OR currency = $2)
+    AND ($3::varchar IS NULL OR type = $3)
+    AND ($4::bigint IS NULL OR id > $4::bigint)
 ORDER BY id
-LIMIT $2
-OFFSET $3
+LIMIT $5
 `
 
-type ListAccountsParams struct {
-	Owner  string `json:"owner"`
-	Limit  int32  `json:"limit"`
-	Offset int32  `json:"offset"`
+type ListAccountsByIDParams struct {
+	Owner    string         `json:"owner"`
+	Currency sql.NullString `json:"currency"`
+	Type     sql.NullString `json:"type"`
+	CursorID sql.NullInt64  `json:"cursor_id"`
+	PageSize int32          `json:"page_size"`
 }
 
-func (q *Queries) ListAccounts(ctx context.Context, arg ListAccountsParams) ([]Account, error) {
-	rows, err := q.db.QueryContext(ctx, listAccounts, arg.Owner, arg.Limit, arg.Offset)
+// The accounts are listed by ListAccounts, with one query per sort order so that the order
+// and the cursor are plain columns. Keyset pagination: the page starts after the cursor, the
+// sort value and the ID of the last account of the previous page.
+func (q *Queries) ListAccountsByID(ctx context.Context, arg ListAccountsByIDParams) ([]Account, error) {
+	rows, err := q.db.QueryContext(ctx, listAccountsByID,
+		arg.Owner,
+		arg.Currency,
+		arg.Type,
+		arg.CursorID,
+		arg.PageSize,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -136,6 +501,10 @@ func (q *Queries) ListAccounts(ctx context.Context, arg ListAccountsParams) ([]A
 			&i.Balance,
 			&i.Currency,
 			&i.CreatedAt,
+			&i.Number,
+			&i.Type,
+			&i.Nickname,
+			&i.InterestProductID,
 		); err != nil {
 			return nil, err
 		}
@@ -150,11 +519,98 @@ func (q *Queries) ListAccounts(ctx context.Context, arg ListAccountsParams) ([]A
 	return items, nil
 }
 
+const listAccountsByIDDesc = `-- name: ListAccountsByIDDesc :many
+SELECT id, owner, balance, currency, created_at, number, type, nickname, interest_product_id FROM accounts
+WHERE
+    owner = $1
+    AND ($2::varchar IS NULL OR currency = $2)
+    AND ($3::varchar IS NULL OR type = $3)
+    AND ($4::bigint IS NULL OR id < $4::bigint)
+ORDER BY id DESC
+LIMIT $5
+`
+
+type ListAccountsByIDDescParams struct {
+	Owner    string         `json:"owner"`
+	Currency sql.NullString `json:"currency"`
+	Type     sql.NullString `json:"type"`
+	CursorID sql.NullInt64  `json:"cursor_id"`
+	PageSize int32          `json:"page_size"`
+}
+
+func (q *Queries) ListAccountsByIDDesc(ctx context.Context, arg ListAccountsByIDDescParams) ([]Account, error) {
+	rows, err := q.db.QueryContext(ctx, listAccountsByIDDesc,
+		arg.Owner,
+		arg.Currency,
+		arg.Type,
+		arg.CursorID,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Account{}
+	for rows.Next() {
+		var i Account
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.Balance,
+			&i.Currency,
+			&i.CreatedAt,
+			&i.Number,
+			&i.Type,
+			&i.Nickname,
+			&i.InterestProductID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setAccountInterestProduct = `-- name: SetAccountInterestProduct :one
+UPDATE accounts
+SET interest_product_id = $1
+WHERE id = $2
+RETURNING id, owner, balance, currency, created_at, number, type, nickname, interest_product_id
+`
+
+type SetAccountInterestProductParams struct {
+	InterestProductID sql.NullInt64 `json:"interest_product_id"`
+	ID                int64         `json:"id"`
+}
+
+func (q *Queries) SetAccountInterestProduct(ctx context.Context, arg SetAccountInterestProductParams) (Account, error) {
+	row := q.db.QueryRowContext(ctx, setAccountInterestProduct, arg.InterestProductID, arg.ID)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.Number,
+		&i.Type,
+		&i.Nickname,
+		&i.InterestProductID,
+	)
+	return i, err
+}
+
 const updateAccount = `-- name: UpdateAccount :one
 UPDATE accounts
 SET balance = $2
 WHERE id = $1
-RETURNING id, owner, balance, currency, created_at
+RETURNING id, owner, balance, currency, created_at, number, type, nickname, interest_product_id
 `
 
 type UpdateAccountParams struct {
@@ -171,6 +627,10 @@ func (q *Queries) UpdateAccount(ctx context.Context, arg UpdateAccountParams) (A
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.Number,
+		&i.Type,
+		&i.Nickname,
+		&i.InterestProductID,
 	)
 	return i, err
 }
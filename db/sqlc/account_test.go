@@ -17,6 +17,9 @@ func createRandomAccount(t *testing.T) Account {
 		Owner:    user.Username,
 		Balance:  util.RandomMoney(),
 		Currency: util.RandomCurrency(),
+		Number:   util.RandomAccountNumber(),
+		Type:     util.RandomAccountType(),
+		Nickname: util.RandomString(6),
 	}
 
 	account, err := testQueries.CreateAccount(context.Background(), arg)
@@ -26,6 +29,9 @@ func createRandomAccount(t *testing.T) Account {
 	require.Equal(t, arg.Owner, account.Owner)
 	require.Equal(t, arg.Balance, account.Balance)
 	require.Equal(t, arg.Currency, account.Currency)
+	require.Equal(t, arg.Number, account.Number)
+	require.Equal(t, arg.Type, account.Type)
+	require.Equal(t, arg.Nickname, account.Nickname)
 
 	require.NotZero(t, account.ID)
 	require.NotZero(t, account.CreatedAt)
@@ -37,6 +43,65 @@ func TestCreateAccount(t *testing.T) {
 	createRandomAccount(t)
 }
 
+func TestCreateAccountTxMaxAccounts(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+	_, err := testQueries.UpdateUser(context.Background(), UpdateUserParams{
+		Username:        user.Username,
+		IsEmailVerified: sql.NullBool{Bool: true, Valid: true},
+	})
+	require.NoError(t, err)
+
+	arg := CreateAccountTxParams{
+		CreateAccountParams: CreateAccountParams{
+			Owner:    user.Username,
+			Currency: util.USD,
+			Type:     util.SavingsAccount,
+		},
+		MaxAccounts: 2,
+	}
+
+	for i := 0; i < 2; i++ {
+		result, err := store.CreateAccountTx(context.Background(), arg)
+		require.NoError(t, err)
+		require.Equal(t, util.SavingsAccount, result.Account.Type)
+	}
+
+	_, err = store.CreateAccountTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrAccountLimitReached)
+
+	// The limit applies to each type and currency separately.
+	arg.Type = util.CheckingAccount
+	_, err = store.CreateAccountTx(context.Background(), arg)
+	require.NoError(t, err)
+
+	arg.Type = util.SavingsAccount
+	arg.Currency = util.EUR
+	_, err = store.CreateAccountTx(context.Background(), arg)
+	require.NoError(t, err)
+}
+
+func TestCreateAccountTxEmailNotVerified(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+	require.False(t, user.IsEmailVerified)
+
+	arg := CreateAccountTxParams{
+		CreateAccountParams: CreateAccountParams{
+			Owner:    user.Username,
+			Currency: util.USD,
+		},
+		MaxAccounts: 2,
+	}
+
+	// The first account can be opened before the email is verified, but not a second one.
+	_, err := store.CreateAccountTx(context.Background(), arg)
+	require.NoError(t, err)
+
+	_, err = store.CreateAccountTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrEmailNotVerified)
+}
+
 func TestGetAccount(t *testing.T) {
 	account1 := createRandomAccount(t)
 	account2, err := testQueries.GetAccount(context.Background(), account1.ID)
@@ -50,6 +115,14 @@ func TestGetAccount(t *testing.T) {
 	require.WithinDuration(t, account1.CreatedAt, account2.CreatedAt, time.Second)
 }
 
+func TestGetAccountByNumber(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2, err := testQueries.GetAccountByNumber(context.Background(), account1.Number)
+	require.NoError(t, err)
+	require.Equal(t, account1.ID, account2.ID)
+	require.Equal(t, account1.Number, account2.Number)
+}
+
 func TestUpdateAccount(t *testing.T) {
 	account1 := createRandomAccount(t)
 
@@ -88,9 +161,9 @@ func TestListAccount(t *testing.T) {
 	}
 
 	arg := ListAccountsParams{
-		Owner:  lastAccount.Owner,
-		Limit:  5,
-		Offset: 0,
+		Owner:    lastAccount.Owner,
+		OrderBy:  OrderByID,
+		PageSize: 5,
 	}
 
 	accounts, err := testQueries.ListAccounts(context.Background(), arg)
@@ -101,4 +174,20 @@ func TestListAccount(t *testing.T) {
 		require.NotEmpty(t, account)
 		require.Equal(t, lastAccount.Owner, account.Owner)
 	}
+
+	// Only the accounts of the type are listed.
+	arg.Type = sql.NullString{String: lastAccount.Type, Valid: true}
+	accounts, err = testQueries.ListAccounts(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, accounts)
+	for _, account := range accounts {
+		require.Equal(t, lastAccount.Type, account.Type)
+	}
+
+	// The next page starts after the cursor.
+	arg.CursorValue = sql.NullInt64{Int64: lastAccount.ID, Valid: true}
+	arg.CursorID = sql.NullInt64{Int64: lastAccount.ID, Valid: true}
+	accounts, err = testQueries.ListAccounts(context.Background(), arg)
+	require.NoError(t, err)
+	require.Empty(t, accounts)
 }
@@ -0,0 +1,138 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.17.2
+// source: api_client.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/lib/pq"
+)
+
+const createApiClient = `-- name: CreateApiClient :one
+INSERT INTO api_clients (
+    id,
+    owner,
+    name,
+    hashed_secret,
+    scopes,
+    created_by
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+RETURNING id, owner, name, hashed_secret, scopes, created_by, revoked_at, secret_rotated_at, created_at
+`
+
+type CreateApiClientParams struct {
+	ID           string   `json:"id"`
+	Owner        string   `json:"owner"`
+	Name         string   `json:"name"`
+	HashedSecret string   `json:"hashed_secret"`
+	Scopes       []string `json:"scopes"`
+	CreatedBy    string   `json:"created_by"`
+}
+
+func (q *Queries) CreateApiClient(ctx context.Context, arg CreateApiClientParams) (ApiClient, error) {
+	row := q.db.QueryRowContext(ctx, createApiClient,
+		arg.ID,
+		arg.Owner,
+		arg.Name,
+		arg.HashedSecret,
+		pq.Array(arg.Scopes),
+		arg.CreatedBy,
+	)
+	var i ApiClient
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Name,
+		&i.HashedSecret,
+		pq.Array(&i.Scopes),
+		&i.CreatedBy,
+		&i.RevokedAt,
+		&i.SecretRotatedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getApiClient = `-- name: GetApiClient :one
+SELECT id, owner, name, hashed_secret, scopes, created_by, revoked_at, secret_rotated_at, created_at FROM api_clients WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetApiClient(ctx context.Context, id string) (ApiClient, error) {
+	row := q.db.QueryRowContext(ctx, getApiClient, id)
+	var i ApiClient
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Name,
+		&i.HashedSecret,
+		pq.Array(&i.Scopes),
+		&i.CreatedBy,
+		&i.RevokedAt,
+		&i.SecretRotatedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const revokeApiClient = `-- name: RevokeApiClient :one
+UPDATE api_clients
+SET
+    revoked_at = COALESCE(revoked_at, now())
+WHERE
+    id = $1
+RETURNING id, owner, name, hashed_secret, scopes, created_by, revoked_at, secret_rotated_at, created_at
+`
+
+func (q *Queries) RevokeApiClient(ctx context.Context, id string) (ApiClient, error) {
+	row := q.db.QueryRowContext(ctx, revokeApiClient, id)
+	var i ApiClient
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Name,
+		&i.HashedSecret,
+		pq.Array(&i.Scopes),
+		&i.CreatedBy,
+		&i.RevokedAt,
+		&i.SecretRotatedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const rotateApiClientSecret = `-- name: RotateApiClientSecret :one
+UPDATE api_clients
+SET
+    hashed_secret = $2,
+    secret_rotated_at = now()
+WHERE
+    id = $1
+RETURNING id, owner, name, hashed_secret, scopes, created_by, revoked_at, secret_rotated_at, created_at
+`
+
+type RotateApiClientSecretParams struct {
+	ID           string `json:"id"`
+	HashedSecret string `json:"hashed_secret"`
+}
+
+func (q *Queries) RotateApiClientSecret(ctx context.Context, arg RotateApiClientSecretParams) (ApiClient, error) {
+	row := q.db.QueryRowContext(ctx, rotateApiClientSecret, arg.ID, arg.HashedSecret)
+	var i ApiClient
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Name,
+		&i.HashedSecret,
+		pq.Array(&i.Scopes),
+		&i.CreatedBy,
+		&i.RevokedAt,
+		&i.SecretRotatedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,75 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mativm02/bank_system/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomApiClient(t *testing.T) ApiClient {
+	owner := createRandomUser(t)
+	admin := createRandomUser(t)
+
+	arg := CreateApiClientParams{
+		ID:           "sbc_" + util.RandomString(24),
+		Owner:        owner.Username,
+		Name:         util.RandomString(10),
+		HashedSecret: util.HashSecret(util.RandomString(32)),
+		Scopes:       []string{"accounts:read", "transfers:write"},
+		CreatedBy:    admin.Username,
+	}
+
+	client, err := testQueries.CreateApiClient(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.ID, client.ID)
+	require.Equal(t, arg.Owner, client.Owner)
+	require.Equal(t, arg.Name, client.Name)
+	require.Equal(t, arg.HashedSecret, client.HashedSecret)
+	require.Equal(t, arg.Scopes, client.Scopes)
+	require.Equal(t, arg.CreatedBy, client.CreatedBy)
+	require.False(t, client.RevokedAt.Valid)
+	require.NotZero(t, client.CreatedAt)
+
+	return client
+}
+
+func TestCreateApiClient(t *testing.T) {
+	createRandomApiClient(t)
+}
+
+func TestGetApiClient(t *testing.T) {
+	client1 := createRandomApiClient(t)
+
+	client2, err := testQueries.GetApiClient(context.Background(), client1.ID)
+	require.NoError(t, err)
+	require.Equal(t, client1, client2)
+}
+
+func TestRotateApiClientSecret(t *testing.T) {
+	client1 := createRandomApiClient(t)
+
+	hashedSecret := util.HashSecret(util.RandomString(32))
+	client2, err := testQueries.RotateApiClientSecret(context.Background(), RotateApiClientSecretParams{
+		ID:           client1.ID,
+		HashedSecret: hashedSecret,
+	})
+	require.NoError(t, err)
+	require.Equal(t, hashedSecret, client2.HashedSecret)
+	require.WithinDuration(t, time.Now(), client2.SecretRotatedAt, time.Second)
+}
+
+func TestRevokeApiClient(t *testing.T) {
+	client := createRandomApiClient(t)
+
+	revoked1, err := testQueries.RevokeApiClient(context.Background(), client.ID)
+	require.NoError(t, err)
+	require.True(t, revoked1.RevokedAt.Valid)
+
+	// Revoking again keeps the first revocation time
+	revoked2, err := testQueries.RevokeApiClient(context.Background(), client.ID)
+	require.NoError(t, err)
+	require.Equal(t, revoked1.RevokedAt, revoked2.RevokedAt)
+}
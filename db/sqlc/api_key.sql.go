@@ -0,0 +1,194 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.17.2
+// source: api_key.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
+)
+
+const createApiKey = `-- name: CreateApiKey :one
+INSERT INTO api_keys (
+    owner,
+    name,
+    prefix,
+    hashed_key,
+    scopes,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+RETURNING id, owner, name, prefix, hashed_key, scopes, expires_at, last_used_at, revoked_at, created_at
+`
+
+type CreateApiKeyParams struct {
+	Owner     string       `json:"owner"`
+	Name      string       `json:"name"`
+	Prefix    string       `json:"prefix"`
+	HashedKey string       `json:"hashed_key"`
+	Scopes    []string     `json:"scopes"`
+	ExpiresAt sql.NullTime `json:"expires_at"`
+}
+
+func (q *Queries) CreateApiKey(ctx context.Context, arg CreateApiKeyParams) (ApiKey, error) {
+	row := q.db.QueryRowContext(ctx, createApiKey,
+		arg.Owner,
+		arg.Name,
+		arg.Prefix,
+		arg.HashedKey,
+		pq.Array(arg.Scopes),
+		arg.ExpiresAt,
+	)
+	var i ApiKey
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Name,
+		&i.Prefix,
+		&i.HashedKey,
+		pq.Array(&i.Scopes),
+		&i.ExpiresAt,
+		&i.LastUsedAt,
+		&i.RevokedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getApiKey = `-- name: GetApiKey :one
+SELECT id, owner, name, prefix, hashed_key, scopes, expires_at, last_used_at, revoked_at, created_at FROM api_keys WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetApiKey(ctx context.Context, id int64) (ApiKey, error) {
+	row := q.db.QueryRowContext(ctx, getApiKey, id)
+	var i ApiKey
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Name,
+		&i.Prefix,
+		&i.HashedKey,
+		pq.Array(&i.Scopes),
+		&i.ExpiresAt,
+		&i.LastUsedAt,
+		&i.RevokedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getApiKeyByPrefix = `-- name: GetApiKeyByPrefix :one
+SELECT id, owner, name, prefix, hashed_key, scopes, expires_at, last_used_at, revoked_at, created_at FROM api_keys WHERE prefix = $1 LIMIT 1
+`
+
+func (q *Queries) GetApiKeyByPrefix(ctx context.Context, prefix string) (ApiKey, error) {
+	row := q.db.QueryRowContext(ctx, getApiKeyByPrefix, prefix)
+	var i ApiKey
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Name,
+		&i.Prefix,
+		&i.HashedKey,
+		pq.Array(&i.Scopes),
+		&i.ExpiresAt,
+		&i.LastUsedAt,
+		&i.RevokedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listApiKeys = `-- name: ListApiKeys :many
+SELECT id, owner, name, prefix, hashed_key, scopes, expires_at, last_used_at, revoked_at, created_at FROM api_keys
+WHERE owner = $1
+ORDER BY id
+LIMIT $2
+OFFSET $3
+`
+
+type ListApiKeysParams struct {
+	Owner  string `json:"owner"`
+	Limit  int32  `json:"limit"`
+	Offset int32  `json:"offset"`
+}
+
+func (q *Queries) ListApiKeys(ctx context.Context, arg ListApiKeysParams) ([]ApiKey, error) {
+	rows, err := q.db.QueryContext(ctx, listApiKeys, arg.Owner, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ApiKey{}
+	for rows.Next() {
+		var i ApiKey
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.Name,
+			&i.Prefix,
+			&i.HashedKey,
+			pq.Array(&i.Scopes),
+			&i.ExpiresAt,
+			&i.LastUsedAt,
+			&i.RevokedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const revokeApiKey = `-- name: RevokeApiKey :one
+UPDATE api_keys
+SET
+    revoked_at = COALESCE(revoked_at, now())
+WHERE
+    id = $1
+RETURNING id, owner, name, prefix, hashed_key, scopes, expires_at, last_used_at, revoked_at, created_at
+`
+
+func (q *Queries) RevokeApiKey(ctx context.Context, id int64) (ApiKey, error) {
+	row := q.db.QueryRowContext(ctx, revokeApiKey, id)
+	var i ApiKey
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Name,
+		&i.Prefix,
+		&i.HashedKey,
+		pq.Array(&i.Scopes),
+		&i.ExpiresAt,
+		&i.LastUsedAt,
+		&i.RevokedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const touchApiKey = `-- name: TouchApiKey :exec
+UPDATE api_keys
+SET
+    last_used_at = now()
+WHERE
+    id = $1
+    AND (last_used_at IS NULL OR last_used_at < now() - interval '1 minute')
+`
+
+func (q *Queries) TouchApiKey(ctx context.Context, id int64) error {
+	_, err := q.db.ExecContext(ctx, touchApiKey, id)
+	return err
+}
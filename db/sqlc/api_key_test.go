@@ -0,0 +1,91 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/mativm02/bank_system/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomApiKey(t *testing.T) ApiKey {
+	user := createRandomUser(t)
+
+	arg := CreateApiKeyParams{
+		Owner:     user.Username,
+		Name:      util.RandomString(10),
+		Prefix:    util.RandomString(12),
+		HashedKey: util.HashSecret(util.RandomString(32)),
+		Scopes:    []string{"accounts:read"},
+		ExpiresAt: sql.NullTime{Time: time.Now().Add(time.Hour), Valid: true},
+	}
+
+	apiKey, err := testQueries.CreateApiKey(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, apiKey.ID)
+	require.Equal(t, arg.Owner, apiKey.Owner)
+	require.Equal(t, arg.Name, apiKey.Name)
+	require.Equal(t, arg.Prefix, apiKey.Prefix)
+	require.Equal(t, arg.HashedKey, apiKey.HashedKey)
+	require.Equal(t, arg.Scopes, apiKey.Scopes)
+	require.WithinDuration(t, arg.ExpiresAt.Time, apiKey.ExpiresAt.Time, time.Second)
+	require.False(t, apiKey.LastUsedAt.Valid)
+	require.False(t, apiKey.RevokedAt.Valid)
+
+	return apiKey
+}
+
+func TestCreateApiKey(t *testing.T) {
+	createRandomApiKey(t)
+}
+
+func TestGetApiKeyByPrefix(t *testing.T) {
+	apiKey1 := createRandomApiKey(t)
+
+	apiKey2, err := testQueries.GetApiKeyByPrefix(context.Background(), apiKey1.Prefix)
+	require.NoError(t, err)
+	require.Equal(t, apiKey1.ID, apiKey2.ID)
+	require.Equal(t, apiKey1.HashedKey, apiKey2.HashedKey)
+}
+
+func TestTouchApiKey(t *testing.T) {
+	apiKey := createRandomApiKey(t)
+
+	err := testQueries.TouchApiKey(context.Background(), apiKey.ID)
+	require.NoError(t, err)
+
+	touched1, err := testQueries.GetApiKey(context.Background(), apiKey.ID)
+	require.NoError(t, err)
+	require.True(t, touched1.LastUsedAt.Valid)
+
+	// A key used again right away keeps its last use, to avoid a write per request
+	err = testQueries.TouchApiKey(context.Background(), apiKey.ID)
+	require.NoError(t, err)
+
+	touched2, err := testQueries.GetApiKey(context.Background(), apiKey.ID)
+	require.NoError(t, err)
+	require.Equal(t, touched1.LastUsedAt, touched2.LastUsedAt)
+}
+
+func TestListApiKeys(t *testing.T) {
+	apiKey := createRandomApiKey(t)
+
+	apiKeys, err := testQueries.ListApiKeys(context.Background(), ListApiKeysParams{
+		Owner:  apiKey.Owner,
+		Limit:  5,
+		Offset: 0,
+	})
+	require.NoError(t, err)
+	require.Len(t, apiKeys, 1)
+	require.Equal(t, apiKey.ID, apiKeys[0].ID)
+}
+
+func TestRevokeApiKey(t *testing.T) {
+	apiKey := createRandomApiKey(t)
+
+	revoked, err := testQueries.RevokeApiKey(context.Background(), apiKey.ID)
+	require.NoError(t, err)
+	require.True(t, revoked.RevokedAt.Valid)
+}
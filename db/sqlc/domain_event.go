@@ -0,0 +1,104 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/mativm02/bank_system/event"
+)
+
+// recordDomainEvent stores a domain event within the transaction that produced it.
+func recordDomainEvent(ctx context.Context, q *Queries, payload event.Payload, usernames []string, accountIDs []int64) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s event: %w", payload.EventType(), err)
+	}
+
+	if usernames == nil {
+		usernames = []string{}
+	}
+	if accountIDs == nil {
+		accountIDs = []int64{}
+	}
+
+	_, err = q.CreateDomainEvent(ctx, CreateDomainEventParams{
+		EventType:  string(payload.EventType()),
+		Usernames:  usernames,
+		AccountIds: accountIDs,
+		Payload:    data,
+	})
+	return err
+}
+
+// ToEvent converts the stored row into the envelope sent to the event publishers.
+func (e DomainEvent) ToEvent() event.Event {
+	return event.Event{
+		ID:         e.ID,
+		Type:       event.Type(e.EventType),
+		Usernames:  e.Usernames,
+		AccountIDs: e.AccountIds,
+		Data:       e.Payload,
+		OccurredAt: e.CreatedAt,
+	}
+}
+
+type RelayDomainEventsParams struct {
+	Limit int32
+	// Lease is how long the claimed events are skipped by the other relays.
+	// It must be longer than publishing the batch takes.
+	Lease time.Duration
+	// MaxAttempts is how many times an event can fail before the relay gives up on it.
+	MaxAttempts int32
+	// Publish sends a claimed event to the event publishers.
+	// The event is marked as published only if it succeeds, so it can be delivered more than once.
+	Publish func(event DomainEvent) error
+}
+
+type RelayDomainEventsResult struct {
+	Published []DomainEvent
+	Failed    []DomainEvent
+}
+
+// RelayDomainEvents claims a batch of unpublished domain events, publishes them one by one and
+// records the outcome. No transaction stays open while they are published, and an event that
+// fails does not hold back the others.
+func (store *SQLStore) RelayDomainEvents(ctx context.Context, arg RelayDomainEventsParams) (RelayDomainEventsResult, error) {
+	var result RelayDomainEventsResult
+
+	events, err := store.ClaimUnpublishedDomainEvents(ctx, ClaimUnpublishedDomainEventsParams{
+		LeaseSeconds: int32(arg.Lease / time.Second),
+		BatchSize:    arg.Limit,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	for _, e := range events {
+		if publishErr := arg.Publish(e); publishErr != nil {
+			failed, err := store.MarkDomainEventFailed(ctx, MarkDomainEventFailedParams{
+				ID: e.ID,
+				LastError: sql.NullString{
+					String: publishErr.Error(),
+					Valid:  true,
+				},
+				MaxAttempts: arg.MaxAttempts,
+			})
+			if err != nil {
+				return result, err
+			}
+			result.Failed = append(result.Failed, failed)
+			continue
+		}
+
+		published, err := store.MarkDomainEventPublished(ctx, e.ID)
+		if err != nil {
+			return result, err
+		}
+		result.Published = append(result.Published, published)
+	}
+
+	return result, nil
+}
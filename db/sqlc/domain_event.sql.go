@@ -0,0 +1,205 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.17.2
+// source: domain_event.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/lib/pq"
+)
+
+const claimUnpublishedDomainEvents = `-- name: ClaimUnpublishedDomainEvents :many
+UPDATE domain_events
+SET locked_until = now() + $1::int * interval '1 second'
+WHERE id IN (
+    SELECT id FROM domain_events
+    WHERE
+        published_at IS NULL
+        AND failed_at IS NULL
+        AND (locked_until IS NULL OR locked_until < now())
+    ORDER BY id
+    LIMIT $2
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, event_type, usernames, account_ids, payload, published_at, created_at, attempts, last_error, locked_until, failed_at
+`
+
+type ClaimUnpublishedDomainEventsParams struct {
+	LeaseSeconds int32 `json:"lease_seconds"`
+	BatchSize    int32 `json:"batch_size"`
+}
+
+// Claims a batch of unpublished events for the lease, so the concurrent relays skip them while they
+// are published outside of any transaction. The claim of a relay that stopped expires with the lease.
+func (q *Queries) ClaimUnpublishedDomainEvents(ctx context.Context, arg ClaimUnpublishedDomainEventsParams) ([]DomainEvent, error) {
+	rows, err := q.db.QueryContext(ctx, claimUnpublishedDomainEvents, arg.LeaseSeconds, arg.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []DomainEvent{}
+	for rows.Next() {
+		var i DomainEvent
+		if err := rows.Scan(
+			&i.ID,
+			&i.EventType,
+			pq.Array(&i.Usernames),
+			pq.Array(&i.AccountIds),
+			&i.Payload,
+			&i.PublishedAt,
+			&i.CreatedAt,
+			&i.Attempts,
+			&i.LastError,
+			&i.LockedUntil,
+			&i.FailedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const createDomainEvent = `-- name: CreateDomainEvent :one
+INSERT INTO domain_events (
+    event_type,
+    usernames,
+    account_ids,
+    payload
+) VALUES (
+    $1, $2, $3, $4
+)
+RETURNING id, event_type, usernames, account_ids, payload, published_at, created_at, attempts, last_error, locked_until, failed_at
+`
+
+type CreateDomainEventParams struct {
+	EventType  string          `json:"event_type"`
+	Usernames  []string        `json:"usernames"`
+	AccountIds []int64         `json:"account_ids"`
+	Payload    json.RawMessage `json:"payload"`
+}
+
+func (q *Queries) CreateDomainEvent(ctx context.Context, arg CreateDomainEventParams) (DomainEvent, error) {
+	row := q.db.QueryRowContext(ctx, createDomainEvent,
+		arg.EventType,
+		pq.Array(arg.Usernames),
+		pq.Array(arg.AccountIds),
+		arg.Payload,
+	)
+	var i DomainEvent
+	err := row.Scan(
+		&i.ID,
+		&i.EventType,
+		pq.Array(&i.Usernames),
+		pq.Array(&i.AccountIds),
+		&i.Payload,
+		&i.PublishedAt,
+		&i.CreatedAt,
+		&i.Attempts,
+		&i.LastError,
+		&i.LockedUntil,
+		&i.FailedAt,
+	)
+	return i, err
+}
+
+const getDomainEvent = `-- name: GetDomainEvent :one
+SELECT id, event_type, usernames, account_ids, payload, published_at, created_at, attempts, last_error, locked_until, failed_at FROM domain_events WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetDomainEvent(ctx context.Context, id int64) (DomainEvent, error) {
+	row := q.db.QueryRowContext(ctx, getDomainEvent, id)
+	var i DomainEvent
+	err := row.Scan(
+		&i.ID,
+		&i.EventType,
+		pq.Array(&i.Usernames),
+		pq.Array(&i.AccountIds),
+		&i.Payload,
+		&i.PublishedAt,
+		&i.CreatedAt,
+		&i.Attempts,
+		&i.LastError,
+		&i.LockedUntil,
+		&i.FailedAt,
+	)
+	return i, err
+}
+
+const markDomainEventFailed = `-- name: MarkDomainEventFailed :one
+UPDATE domain_events
+SET
+    attempts = attempts + 1,
+    last_error = $1,
+    locked_until = NULL,
+    failed_at = CASE WHEN attempts + 1 >= $2::int THEN now() END
+WHERE id = $3
+RETURNING id, event_type, usernames, account_ids, payload, published_at, created_at, attempts, last_error, locked_until, failed_at
+`
+
+type MarkDomainEventFailedParams struct {
+	LastError   sql.NullString `json:"last_error"`
+	MaxAttempts int32          `json:"max_attempts"`
+	ID          int64          `json:"id"`
+}
+
+// The event is given up once it has failed max_attempts times.
+func (q *Queries) MarkDomainEventFailed(ctx context.Context, arg MarkDomainEventFailedParams) (DomainEvent, error) {
+	row := q.db.QueryRowContext(ctx, markDomainEventFailed, arg.LastError, arg.MaxAttempts, arg.ID)
+	var i DomainEvent
+	err := row.Scan(
+		&i.ID,
+		&i.EventType,
+		pq.Array(&i.Usernames),
+		pq.Array(&i.AccountIds),
+		&i.Payload,
+		&i.PublishedAt,
+		&i.CreatedAt,
+		&i.Attempts,
+		&i.LastError,
+		&i.LockedUntil,
+		&i.FailedAt,
+	)
+	return i, err
+}
+
+const markDomainEventPublished = `-- name: MarkDomainEventPublished :one
+UPDATE domain_events
+SET
+    attempts = attempts + 1,
+    last_error = NULL,
+    locked_until = NULL,
+    published_at = now()
+WHERE id = $1
+RETURNING id, event_type, usernames, account_ids, payload, published_at, created_at, attempts, last_error, locked_until, failed_at
+`
+
+func (q *Queries) MarkDomainEventPublished(ctx context.Context, id int64) (DomainEvent, error) {
+	row := q.db.QueryRowContext(ctx, markDomainEventPublished, id)
+	var i DomainEvent
+	err := row.Scan(
+		&i.ID,
+		&i.EventType,
+		pq.Array(&i.Usernames),
+		pq.Array(&i.AccountIds),
+		&i.Payload,
+		&i.PublishedAt,
+		&i.CreatedAt,
+		&i.Attempts,
+		&i.LastError,
+		&i.LockedUntil,
+		&i.FailedAt,
+	)
+	return i, err
+}
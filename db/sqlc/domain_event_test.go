@@ -0,0 +1,159 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mativm02/bank_system/event"
+	"github.com/mativm02/bank_system/util"
+	"github.com/stretchr/testify/require"
+)
+
+func relayDomainEvents(t *testing.T, store Store) []event.Event {
+	var events []event.Event
+
+	_, err := store.RelayDomainEvents(context.Background(), RelayDomainEventsParams{
+		Limit:       1000,
+		Lease:       time.Minute,
+		MaxAttempts: 20,
+		Publish: func(e DomainEvent) error {
+			events = append(events, e.ToEvent())
+			return nil
+		},
+	})
+	require.NoError(t, err)
+
+	return events
+}
+
+func TestCreateAccountTxRecordsEvent(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+
+	result, err := store.CreateAccountTx(context.Background(), CreateAccountTxParams{
+		CreateAccountParams: CreateAccountParams{
+			Owner:    user.Username,
+			Balance:  0,
+			Currency: util.RandomCurrency(),
+		},
+		MaxAccounts: 1,
+	})
+	require.NoError(t, err)
+	require.True(t, util.IsValidAccountNumber(result.Account.Number))
+
+	var found bool
+	for _, e := range relayDomainEvents(t, store) {
+		if e.Type == event.TypeAccountCreated && e.HasAccount(result.Account.ID) {
+			found = true
+			require.Equal(t, []string{user.Username}, e.Usernames)
+
+			payload, err := e.Decode()
+			require.NoError(t, err)
+			require.Equal(t, &event.AccountCreated{
+				AccountID: result.Account.ID,
+				Owner:     user.Username,
+				Currency:  result.Account.Currency,
+			}, payload)
+		}
+	}
+	require.True(t, found)
+}
+
+func TestTransferTxRecordsEvent(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		CreateTransferParams: CreateTransferParams{
+			FromAccountID: account1.ID,
+			ToAccountID:   account2.ID,
+			Amount:        10,
+		},
+	})
+	require.NoError(t, err)
+
+	var found bool
+	for _, e := range relayDomainEvents(t, store) {
+		if e.Type == event.TypeTransferCompleted && e.HasAccount(account1.ID) {
+			found = true
+			require.ElementsMatch(t, []string{account1.Owner, account2.Owner}, e.Usernames)
+			require.ElementsMatch(t, []int64{account1.ID, account2.ID}, e.AccountIDs)
+
+			payload, err := e.Decode()
+			require.NoError(t, err)
+			require.Equal(t, &event.TransferCompleted{
+				TransferID:    result.Transfer.ID,
+				FromAccountID: account1.ID,
+				ToAccountID:   account2.ID,
+				Amount:        10,
+				Currency:      account1.Currency,
+			}, payload)
+		}
+	}
+	require.True(t, found)
+}
+
+func TestRelayDomainEventsFailed(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+
+	_, err := store.TransferTx(context.Background(), TransferTxParams{
+		CreateTransferParams: CreateTransferParams{
+			FromAccountID: account1.ID,
+			ToAccountID:   account2.ID,
+			Amount:        10,
+		},
+	})
+	require.NoError(t, err)
+
+	isTransfer := func(e DomainEvent) bool {
+		ev := e.ToEvent()
+		return ev.Type == event.TypeTransferCompleted && ev.HasAccount(account1.ID)
+	}
+	relay := func() RelayDomainEventsResult {
+		result, err := store.RelayDomainEvents(context.Background(), RelayDomainEventsParams{
+			Limit:       1000,
+			Lease:       time.Minute,
+			MaxAttempts: 2,
+			Publish: func(e DomainEvent) error {
+				if isTransfer(e) {
+					return errors.New("redis is down")
+				}
+				return nil
+			},
+		})
+		require.NoError(t, err)
+		return result
+	}
+	findFailed := func(result RelayDomainEventsResult) (DomainEvent, bool) {
+		for _, failed := range result.Failed {
+			if isTransfer(failed) {
+				return failed, true
+			}
+		}
+		return DomainEvent{}, false
+	}
+
+	failed, found := findFailed(relay())
+	require.True(t, found)
+	require.Equal(t, int32(1), failed.Attempts)
+	require.Equal(t, "redis is down", failed.LastError.String)
+	require.False(t, failed.PublishedAt.Valid)
+	require.False(t, failed.LockedUntil.Valid)
+	require.False(t, failed.FailedAt.Valid)
+
+	// The relay gives up on the event after the last attempt and does not claim it again.
+	failed, found = findFailed(relay())
+	require.True(t, found)
+	require.Equal(t, int32(2), failed.Attempts)
+	require.True(t, failed.FailedAt.Valid)
+
+	_, found = findFailed(relay())
+	require.False(t, found)
+}
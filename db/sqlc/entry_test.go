@@ -13,6 +13,7 @@ func createRandomEntry(t *testing.T, account Account) Entry {
 	arg := CreateEntryParams{
 		AccountID: account.ID,
 		Amount:    util.RandomInt(-100, 200),
+		Type:      EntryTypeTransfer,
 	}
 
 	entry, err := testQueries.CreateEntry(context.Background(), arg)
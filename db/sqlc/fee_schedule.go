@@ -0,0 +1,44 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Types of the entries
+const (
+	EntryTypeTransfer = "transfer"
+	EntryTypeFee      = "fee"
+)
+
+// FeeAccountNickname names the revenue accounts of the bank that receive the transfer fees.
+const FeeAccountNickname = "fees"
+
+// Fee returns the fee of a transfer of the amount: the flat fee plus the percentage of the amount,
+// rounded down. The amount is split so the percentage cannot overflow.
+func (schedule FeeSchedule) Fee(amount int64) int64 {
+	return schedule.FlatFee +
+		amount/10000*schedule.PercentageBps +
+		amount%10000*schedule.PercentageBps/10000
+}
+
+// TransferFee returns the fee of a transfer of the amount between the accounts, following the fee
+// schedule of their currency. Transfers between accounts of the same owner are free, and so are
+// the transfers in a currency without a fee schedule.
+func TransferFee(ctx context.Context, q Querier, fromAccount, toAccount Account, amount int64) (int64, error) {
+	if fromAccount.Owner == toAccount.Owner {
+		return 0, nil
+	}
+
+	schedule, err := q.GetFeeSchedule(ctx, GetFeeScheduleParams{
+		Currency: fromAccount.Currency,
+		Amount:   amount,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return schedule.Fee(amount), nil
+}
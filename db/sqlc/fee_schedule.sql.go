@@ -0,0 +1,85 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.17.2
+// source: fee_schedule.sql
+
+package db
+
+import (
+	"context"
+)
+
+const createFeeSchedule = `-- name: CreateFeeSchedule :one
+INSERT INTO fee_schedules (
+    currency,
+    min_amount,
+    flat_fee,
+    percentage_bps
+) VALUES (
+    $1, $2, $3, $4
+)
+RETURNING id, currency, min_amount, flat_fee, percentage_bps, created_at
+`
+
+type CreateFeeScheduleParams struct {
+	Currency      string `json:"currency"`
+	MinAmount     int64  `json:"min_amount"`
+	FlatFee       int64  `json:"flat_fee"`
+	PercentageBps int64  `json:"percentage_bps"`
+}
+
+func (q *Queries) CreateFeeSchedule(ctx context.Context, arg CreateFeeScheduleParams) (FeeSchedule, error) {
+	row := q.db.QueryRowContext(ctx, createFeeSchedule,
+		arg.Currency,
+		arg.MinAmount,
+		arg.FlatFee,
+		arg.PercentageBps,
+	)
+	var i FeeSchedule
+	err := row.Scan(
+		&i.ID,
+		&i.Currency,
+		&i.MinAmount,
+		&i.FlatFee,
+		&i.PercentageBps,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteFeeSchedule = `-- name: DeleteFeeSchedule :exec
+DELETE FROM fee_schedules WHERE id = $1
+`
+
+func (q *Queries) DeleteFeeSchedule(ctx context.Context, id int64) error {
+	_, err := q.db.ExecContext(ctx, deleteFeeSchedule, id)
+	return err
+}
+
+const getFeeSchedule = `-- name: GetFeeSchedule :one
+SELECT id, currency, min_amount, flat_fee, percentage_bps, created_at FROM fee_schedules
+WHERE currency = $1 AND min_amount <= $2
+ORDER BY min_amount DESC
+LIMIT 1
+`
+
+type GetFeeScheduleParams struct {
+	Currency string `json:"currency"`
+	Amount   int64  `json:"amount"`
+}
+
+// The tiers of a currency start at their min_amount, so the amount falls in the tier
+// with the highest min_amount up to the amount.
+func (q *Queries) GetFeeSchedule(ctx context.Context, arg GetFeeScheduleParams) (FeeSchedule, error) {
+	row := q.db.QueryRowContext(ctx, getFeeSchedule, arg.Currency, arg.Amount)
+	var i FeeSchedule
+	err := row.Scan(
+		&i.ID,
+		&i.Currency,
+		&i.MinAmount,
+		&i.FlatFee,
+		&i.PercentageBps,
+		&i.CreatedAt,
+	)
+	return i, err
+}
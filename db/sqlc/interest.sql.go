@@ -0,0 +1,338 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.17.2
+// source: interest.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const claimInterestAccruals = `-- name: ClaimInterestAccruals :many
+UPDATE interest_accruals
+SET posting_id = $1
+WHERE account_id = $2 AND posting_id IS NULL AND accrual_date < $3
+RETURNING amount_micros
+`
+
+type ClaimInterestAccrualsParams struct {
+	PostingID sql.NullInt64 `json:"posting_id"`
+	AccountID int64         `json:"account_id"`
+	Before    time.Time     `json:"before"`
+}
+
+// The accruals are attached to the posting, so a concurrent posting cannot claim them again.
+func (q *Queries) ClaimInterestAccruals(ctx context.Context, arg ClaimInterestAccrualsParams) ([]int64, error) {
+	rows, err := q.db.QueryContext(ctx, claimInterestAccruals, arg.PostingID, arg.AccountID, arg.Before)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []int64{}
+	for rows.Next() {
+		var amount_micros int64
+		if err := rows.Scan(&amount_micros); err != nil {
+			return nil, err
+		}
+		items = append(items, amount_micros)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const createInterestAccrual = `-- name: CreateInterestAccrual :execrows
+INSERT INTO interest_accruals (
+    account_id,
+    product_id,
+    accrual_date,
+    balance,
+    annual_rate_bps,
+    amount_micros
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+ON CONFLICT (account_id, accrual_date) DO NOTHING
+`
+
+type CreateInterestAccrualParams struct {
+	AccountID     int64     `json:"account_id"`
+	ProductID     int64     `json:"product_id"`
+	AccrualDate   time.Time `json:"accrual_date"`
+	Balance       int64     `json:"balance"`
+	AnnualRateBps int64     `json:"annual_rate_bps"`
+	AmountMicros  int64     `json:"amount_micros"`
+}
+
+// An account accrues interest once a day, so accruing a day again does nothing.
+func (q *Queries) CreateInterestAccrual(ctx context.Context, arg CreateInterestAccrualParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, createInterestAccrual,
+		arg.AccountID,
+		arg.ProductID,
+		arg.AccrualDate,
+		arg.Balance,
+		arg.AnnualRateBps,
+		arg.AmountMicros,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const createInterestPosting = `-- name: CreateInterestPosting :one
+INSERT INTO interest_postings (
+    account_id
+) VALUES (
+    $1
+)
+RETURNING id, account_id, amount, carry_micros, transfer_id, created_at
+`
+
+func (q *Queries) CreateInterestPosting(ctx context.Context, accountID int64) (InterestPosting, error) {
+	row := q.db.QueryRowContext(ctx, createInterestPosting, accountID)
+	var i InterestPosting
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.Amount,
+		&i.CarryMicros,
+		&i.TransferID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createInterestProduct = `-- name: CreateInterestProduct :one
+INSERT INTO interest_products (
+    name,
+    annual_rate_bps
+) VALUES (
+    $1, $2
+)
+RETURNING id, name, annual_rate_bps, created_at
+`
+
+type CreateInterestProductParams struct {
+	Name          string `json:"name"`
+	AnnualRateBps int64  `json:"annual_rate_bps"`
+}
+
+func (q *Queries) CreateInterestProduct(ctx context.Context, arg CreateInterestProductParams) (InterestProduct, error) {
+	row := q.db.QueryRowContext(ctx, createInterestProduct, arg.Name, arg.AnnualRateBps)
+	var i InterestProduct
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.AnnualRateBps,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getInterestProduct = `-- name: GetInterestProduct :one
+SELECT id, name, annual_rate_bps, created_at FROM interest_products WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetInterestProduct(ctx context.Context, id int64) (InterestProduct, error) {
+	row := q.db.QueryRowContext(ctx, getInterestProduct, id)
+	var i InterestProduct
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.AnnualRateBps,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getLastInterestPosting = `-- name: GetLastInterestPosting :one
+SELECT id, account_id, amount, carry_micros, transfer_id, created_at FROM interest_postings
+WHERE account_id = $1
+ORDER BY id DESC
+LIMIT 1
+`
+
+func (q *Queries) GetLastInterestPosting(ctx context.Context, accountID int64) (InterestPosting, error) {
+	row := q.db.QueryRowContext(ctx, getLastInterestPosting, accountID)
+	var i InterestPosting
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.Amount,
+		&i.CarryMicros,
+		&i.TransferID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listAccountsWithUnpostedInterest = `-- name: ListAccountsWithUnpostedInterest :many
+SELECT DISTINCT account_id FROM interest_accruals
+WHERE posting_id IS NULL AND accrual_date < $1
+ORDER BY account_id
+`
+
+func (q *Queries) ListAccountsWithUnpostedInterest(ctx context.Context, before time.Time) ([]int64, error) {
+	rows, err := q.db.QueryContext(ctx, listAccountsWithUnpostedInterest, before)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []int64{}
+	for rows.Next() {
+		var account_id int64
+		if err := rows.Scan(&account_id); err != nil {
+			return nil, err
+		}
+		items = append(items, account_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listInterestAccruals = `-- name: ListInterestAccruals :many
+SELECT id, account_id, product_id, accrual_date, balance, annual_rate_bps, amount_micros, posting_id, created_at FROM interest_accruals
+WHERE account_id = $1
+ORDER BY accrual_date DESC
+LIMIT $2
+OFFSET $3
+`
+
+type ListInterestAccrualsParams struct {
+	AccountID int64 `json:"account_id"`
+	Limit     int32 `json:"limit"`
+	Offset    int32 `json:"offset"`
+}
+
+func (q *Queries) ListInterestAccruals(ctx context.Context, arg ListInterestAccrualsParams) ([]InterestAccrual, error) {
+	rows, err := q.db.QueryContext(ctx, listInterestAccruals, arg.AccountID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []InterestAccrual{}
+	for rows.Next() {
+		var i InterestAccrual
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.ProductID,
+			&i.AccrualDate,
+			&i.Balance,
+			&i.AnnualRateBps,
+			&i.AmountMicros,
+			&i.PostingID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listInterestBearingAccounts = `-- name: ListInterestBearingAccounts :many
+SELECT
+    a.id AS account_id,
+    sum(e.amount)::bigint AS balance,
+    p.id AS product_id,
+    p.annual_rate_bps
+FROM accounts a
+JOIN interest_products p ON p.id = a.interest_product_id
+JOIN entries e ON e.account_id = a.id AND e.created_at < $1
+GROUP BY a.id, p.id
+HAVING sum(e.amount) > 0
+ORDER BY a.id
+`
+
+type ListInterestBearingAccountsRow struct {
+	AccountID     int64 `json:"account_id"`
+	Balance       int64 `json:"balance"`
+	ProductID     int64 `json:"product_id"`
+	AnnualRateBps int64 `json:"annual_rate_bps"`
+}
+
+// The balance is the sum of the entries of the account until the end of the day, so accruing
+// a past day uses the balance the account had then. Only the positive balances earn interest.
+func (q *Queries) ListInterestBearingAccounts(ctx context.Context, dayEnd time.Time) ([]ListInterestBearingAccountsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listInterestBearingAccounts, dayEnd)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListInterestBearingAccountsRow{}
+	for rows.Next() {
+		var i ListInterestBearingAccountsRow
+		if err := rows.Scan(
+			&i.AccountID,
+			&i.Balance,
+			&i.ProductID,
+			&i.AnnualRateBps,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateInterestPosting = `-- name: UpdateInterestPosting :one
+UPDATE interest_postings
+SET
+    amount = $1,
+    carry_micros = $2,
+    transfer_id = $3
+WHERE id = $4
+RETURNING id, account_id, amount, carry_micros, transfer_id, created_at
+`
+
+type UpdateInterestPostingParams struct {
+	Amount      int64         `json:"amount"`
+	CarryMicros int64         `json:"carry_micros"`
+	TransferID  sql.NullInt64 `json:"transfer_id"`
+	ID          int64         `json:"id"`
+}
+
+func (q *Queries) UpdateInterestPosting(ctx context.Context, arg UpdateInterestPostingParams) (InterestPosting, error) {
+	row := q.db.QueryRowContext(ctx, updateInterestPosting,
+		arg.Amount,
+		arg.CarryMicros,
+		arg.TransferID,
+		arg.ID,
+	)
+	var i InterestPosting
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.Amount,
+		&i.CarryMicros,
+		&i.TransferID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
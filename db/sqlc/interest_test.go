@@ -0,0 +1,90 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/mativm02/bank_system/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomInterestProduct(t *testing.T) InterestProduct {
+	arg := CreateInterestProductParams{
+		Name:          util.RandomString(12),
+		AnnualRateBps: util.RandomInt(1, 1000),
+	}
+
+	product, err := testQueries.CreateInterestProduct(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, product.ID)
+	require.Equal(t, arg.Name, product.Name)
+	require.Equal(t, arg.AnnualRateBps, product.AnnualRateBps)
+
+	return product
+}
+
+func TestAccrueAndPostInterest(t *testing.T) {
+	store := NewStore(testDB)
+	product := createRandomInterestProduct(t)
+	account := createRandomAccount(t)
+
+	account, err := testQueries.SetAccountInterestProduct(context.Background(), SetAccountInterestProductParams{
+		ID:                account.ID,
+		InterestProductID: sql.NullInt64{Int64: product.ID, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, product.ID, account.InterestProductID.Int64)
+
+	// Every day accrues 0.6 minor unit, so the first posting pays 1 and carries 0.2.
+	day := time.Date(2024, 1, 30, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 2; i++ {
+		arg := CreateInterestAccrualParams{
+			AccountID:     account.ID,
+			ProductID:     product.ID,
+			AccrualDate:   day.AddDate(0, 0, i),
+			Balance:       account.Balance,
+			AnnualRateBps: product.AnnualRateBps,
+			AmountMicros:  600000,
+		}
+		rows, err := testQueries.CreateInterestAccrual(context.Background(), arg)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), rows)
+
+		// A day accrues only once.
+		rows, err = testQueries.CreateInterestAccrual(context.Background(), arg)
+		require.NoError(t, err)
+		require.Zero(t, rows)
+	}
+
+	before := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	accountIDs, err := testQueries.ListAccountsWithUnpostedInterest(context.Background(), before)
+	require.NoError(t, err)
+	require.Contains(t, accountIDs, account.ID)
+
+	result, err := store.PostInterestTx(context.Background(), PostInterestTxParams{AccountID: account.ID, Before: before})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), result.Posting.Amount)
+	require.Equal(t, int64(200000), result.Posting.CarryMicros)
+	require.Equal(t, result.Transfer.Transfer.ID, result.Posting.TransferID.Int64)
+	require.Equal(t, account.ID, result.Transfer.ToAccount.ID)
+	require.Equal(t, account.Balance+1, result.Transfer.ToAccount.Balance)
+	require.Equal(t, SystemUsername, result.Transfer.FromAccount.Owner)
+	require.Equal(t, account.Currency, result.Transfer.FromAccount.Currency)
+
+	accruals, err := testQueries.ListInterestAccruals(context.Background(), ListInterestAccrualsParams{
+		AccountID: account.ID,
+		Limit:     5,
+		Offset:    0,
+	})
+	require.NoError(t, err)
+	require.Len(t, accruals, 2)
+	for _, accrual := range accruals {
+		require.Equal(t, result.Posting.ID, accrual.PostingID.Int64)
+	}
+
+	// The interest is only posted once.
+	_, err = store.PostInterestTx(context.Background(), PostInterestTxParams{AccountID: account.ID, Before: before})
+	require.ErrorIs(t, err, ErrNoAccruedInterest)
+}
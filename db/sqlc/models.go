@@ -5,6 +5,8 @@
 package db
 
 import (
+	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
@@ -16,6 +18,66 @@ type Account struct {
 	Balance   int64     `json:"balance"`
 	Currency  string    `json:"currency"`
 	CreatedAt time.Time `json:"created_at"`
+	// IBAN-like number with mod-97 check digits
+	Number string `json:"number"`
+	// checking, savings or business
+	Type string `json:"type"`
+	// empty when the owner has not named the account
+	Nickname          string        `json:"nickname"`
+	InterestProductID sql.NullInt64 `json:"interest_product_id"`
+}
+
+type AccountSpendingLimit struct {
+	AccountID int64 `json:"account_id"`
+	// most the account can send in 24 hours, 0 is no limit and NULL is the default limit
+	DailyLimit sql.NullInt64 `json:"daily_limit"`
+	// most the account can send in 30 days, 0 is no limit and NULL is the default limit
+	MonthlyLimit sql.NullInt64 `json:"monthly_limit"`
+	UpdatedAt    time.Time     `json:"updated_at"`
+}
+
+type ApiClient struct {
+	ID string `json:"id"`
+	// user the client acts for
+	Owner           string       `json:"owner"`
+	Name            string       `json:"name"`
+	HashedSecret    string       `json:"hashed_secret"`
+	Scopes          []string     `json:"scopes"`
+	CreatedBy       string       `json:"created_by"`
+	RevokedAt       sql.NullTime `json:"revoked_at"`
+	SecretRotatedAt time.Time    `json:"secret_rotated_at"`
+	CreatedAt       time.Time    `json:"created_at"`
+}
+
+type ApiKey struct {
+	ID    int64  `json:"id"`
+	Owner string `json:"owner"`
+	Name  string `json:"name"`
+	// public part of the key used to look it up
+	Prefix    string   `json:"prefix"`
+	HashedKey string   `json:"hashed_key"`
+	Scopes    []string `json:"scopes"`
+	// null means the key never expires
+	ExpiresAt  sql.NullTime `json:"expires_at"`
+	LastUsedAt sql.NullTime `json:"last_used_at"`
+	RevokedAt  sql.NullTime `json:"revoked_at"`
+	CreatedAt  time.Time    `json:"created_at"`
+}
+
+type DomainEvent struct {
+	ID          int64           `json:"id"`
+	EventType   string          `json:"event_type"`
+	Usernames   []string        `json:"usernames"`
+	AccountIds  []int64         `json:"account_ids"`
+	Payload     json.RawMessage `json:"payload"`
+	PublishedAt sql.NullTime    `json:"published_at"`
+	CreatedAt   time.Time       `json:"created_at"`
+	Attempts    int32           `json:"attempts"`
+	LastError   sql.NullString  `json:"last_error"`
+	// a relay claimed the event and is publishing it until then
+	LockedUntil sql.NullTime `json:"locked_until"`
+	// the relay gave up on the event after too many failed attempts
+	FailedAt sql.NullTime `json:"failed_at"`
 }
 
 type Entry struct {
@@ -24,6 +86,96 @@ type Entry struct {
 	// can be negative or positive
 	Amount    int64     `json:"amount"`
 	CreatedAt time.Time `json:"created_at"`
+	// transfer that created the entry
+	TransferID sql.NullInt64 `json:"transfer_id"`
+	// transfer or fee
+	Type string `json:"type"`
+}
+
+type FeeSchedule struct {
+	ID       int64  `json:"id"`
+	Currency string `json:"currency"`
+	// smallest amount of the tier: a transfer pays the fee of the tier with the highest min_amount up to its amount
+	MinAmount int64 `json:"min_amount"`
+	FlatFee   int64 `json:"flat_fee"`
+	// part of the amount in basis points added to the flat fee, rounded down
+	PercentageBps int64     `json:"percentage_bps"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type InterestAccrual struct {
+	ID          int64     `json:"id"`
+	AccountID   int64     `json:"account_id"`
+	ProductID   int64     `json:"product_id"`
+	AccrualDate time.Time `json:"accrual_date"`
+	// balance the interest was computed on
+	Balance       int64 `json:"balance"`
+	AnnualRateBps int64 `json:"annual_rate_bps"`
+	// interest of the day in millionths of a minor unit, rounded down
+	AmountMicros int64         `json:"amount_micros"`
+	PostingID    sql.NullInt64 `json:"posting_id"`
+	CreatedAt    time.Time     `json:"created_at"`
+}
+
+type InterestPosting struct {
+	ID        int64 `json:"id"`
+	AccountID int64 `json:"account_id"`
+	Amount    int64 `json:"amount"`
+	// accrued interest below one minor unit, carried to the next posting
+	CarryMicros int64         `json:"carry_micros"`
+	TransferID  sql.NullInt64 `json:"transfer_id"`
+	CreatedAt   time.Time     `json:"created_at"`
+}
+
+type InterestProduct struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	// annual interest rate in basis points, 250 is 2.50%
+	AnnualRateBps int64     `json:"annual_rate_bps"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type NotificationPreference struct {
+	Username string `json:"username"`
+	// always, above_threshold or never
+	TransferMode      string    `json:"transfer_mode"`
+	TransferThreshold int64     `json:"transfer_threshold"`
+	UpdatedAt         time.Time `json:"updated_at"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+type OutboxMessage struct {
+	ID          int64           `json:"id"`
+	TaskType    string          `json:"task_type"`
+	Payload     json.RawMessage `json:"payload"`
+	Queue       string          `json:"queue"`
+	MaxRetry    int32           `json:"max_retry"`
+	ProcessAt   time.Time       `json:"process_at"`
+	Attempts    int32           `json:"attempts"`
+	LastError   sql.NullString  `json:"last_error"`
+	PublishedAt sql.NullTime    `json:"published_at"`
+	CreatedAt   time.Time       `json:"created_at"`
+	// a relay claimed the message and is publishing it until then
+	LockedUntil sql.NullTime `json:"locked_until"`
+	// the relay gave up on the message after too many failed attempts
+	FailedAt sql.NullTime `json:"failed_at"`
+}
+
+type PasswordHistory struct {
+	ID             int64     `json:"id"`
+	Username       string    `json:"username"`
+	HashedPassword string    `json:"hashed_password"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type Payee struct {
+	ID        int64  `json:"id"`
+	Owner     string `json:"owner"`
+	Nickname  string `json:"nickname"`
+	AccountID int64  `json:"account_id"`
+	// currency of the account, which the transfers to the payee must use
+	Currency  string    `json:"currency"`
+	CreatedAt time.Time `json:"created_at"`
 }
 
 type Session struct {
@@ -54,6 +206,23 @@ type User struct {
 	PasswordChangedAt time.Time `json:"password_changed_at"`
 	CreatedAt         time.Time `json:"created_at"`
 	IsEmailVerified   bool      `json:"is_email_verified"`
+	Locale            string    `json:"locale"`
+	// new email waiting for verification
+	PendingEmail sql.NullString `json:"pending_email"`
+	// depositor or admin
+	Role string `json:"role"`
+	// when the last verification email was queued, which throttles the resends
+	VerifyEmailRequestedAt sql.NullTime `json:"verify_email_requested_at"`
+}
+
+type UserSpendingLimit struct {
+	Username string `json:"username"`
+	Currency string `json:"currency"`
+	// most the accounts of the user in the currency can send together in 24 hours, 0 is no limit and NULL is the default limit
+	DailyLimit sql.NullInt64 `json:"daily_limit"`
+	// most the accounts of the user in the currency can send together in 30 days, 0 is no limit and NULL is the default limit
+	MonthlyLimit sql.NullInt64 `json:"monthly_limit"`
+	UpdatedAt    time.Time     `json:"updated_at"`
 }
 
 type VerifyEmail struct {
@@ -65,3 +234,30 @@ type VerifyEmail struct {
 	CreatedAt  time.Time `json:"created_at"`
 	ExpiredAt  time.Time `json:"expired_at"`
 }
+
+type WebhookDelivery struct {
+	ID             int64           `json:"id"`
+	SubscriptionID int64           `json:"subscription_id"`
+	EventID        int64           `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	Payload        json.RawMessage `json:"payload"`
+	// pending, succeeded or dead
+	Status         string         `json:"status"`
+	Attempts       int32          `json:"attempts"`
+	ResponseStatus sql.NullInt32  `json:"response_status"`
+	LastError      sql.NullString `json:"last_error"`
+	DeliveredAt    sql.NullTime   `json:"delivered_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	CreatedAt      time.Time      `json:"created_at"`
+}
+
+type WebhookSubscription struct {
+	ID     int64  `json:"id"`
+	Owner  string `json:"owner"`
+	Url    string `json:"url"`
+	Secret string `json:"secret"`
+	// empty means every event type
+	EventTypes []string  `json:"event_types"`
+	IsActive   bool      `json:"is_active"`
+	CreatedAt  time.Time `json:"created_at"`
+}
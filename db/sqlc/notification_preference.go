@@ -0,0 +1,32 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/mativm02/bank_system/util"
+)
+
+// GetNotificationPreferenceOrDefault returns the preference of the user,
+// or the default one if the user has never changed it.
+func GetNotificationPreferenceOrDefault(ctx context.Context, store Querier, username string) (NotificationPreference, error) {
+	preference, err := store.GetNotificationPreference(ctx, username)
+	if err == sql.ErrNoRows {
+		return NotificationPreference{
+			Username:     username,
+			TransferMode: util.NotifyAlways,
+		}, nil
+	}
+	return preference, err
+}
+
+// ShouldNotifyTransfer reports whether a transfer of the given amount must be notified.
+func (preference NotificationPreference) ShouldNotifyTransfer(amount int64) bool {
+	switch preference.TransferMode {
+	case util.NotifyAlways:
+		return true
+	case util.NotifyAboveThreshold:
+		return amount > preference.TransferThreshold
+	}
+	return false
+}
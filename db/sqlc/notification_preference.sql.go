@@ -0,0 +1,62 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.17.2
+// source: notification_preference.sql
+
+package db
+
+import (
+	"context"
+)
+
+const getNotificationPreference = `-- name: GetNotificationPreference :one
+SELECT username, transfer_mode, transfer_threshold, updated_at, created_at FROM notification_preferences WHERE username = $1 LIMIT 1
+`
+
+func (q *Queries) GetNotificationPreference(ctx context.Context, username string) (NotificationPreference, error) {
+	row := q.db.QueryRowContext(ctx, getNotificationPreference, username)
+	var i NotificationPreference
+	err := row.Scan(
+		&i.Username,
+		&i.TransferMode,
+		&i.TransferThreshold,
+		&i.UpdatedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const upsertNotificationPreference = `-- name: UpsertNotificationPreference :one
+INSERT INTO notification_preferences (
+    username,
+    transfer_mode,
+    transfer_threshold
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT (username) DO UPDATE
+SET
+    transfer_mode = EXCLUDED.transfer_mode,
+    transfer_threshold = EXCLUDED.transfer_threshold,
+    updated_at = now()
+RETURNING username, transfer_mode, transfer_threshold, updated_at, created_at
+`
+
+type UpsertNotificationPreferenceParams struct {
+	Username          string `json:"username"`
+	TransferMode      string `json:"transfer_mode"`
+	TransferThreshold int64  `json:"transfer_threshold"`
+}
+
+func (q *Queries) UpsertNotificationPreference(ctx context.Context, arg UpsertNotificationPreferenceParams) (NotificationPreference, error) {
+	row := q.db.QueryRowContext(ctx, upsertNotificationPreference, arg.Username, arg.TransferMode, arg.TransferThreshold)
+	var i NotificationPreference
+	err := row.Scan(
+		&i.Username,
+		&i.TransferMode,
+		&i.TransferThreshold,
+		&i.UpdatedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,59 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mativm02/bank_system/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationPreference(t *testing.T) {
+	user := createRandomUser(t)
+
+	preference, err := GetNotificationPreferenceOrDefault(context.Background(), testQueries, user.Username)
+	require.NoError(t, err)
+	require.Equal(t, user.Username, preference.Username)
+	require.Equal(t, util.NotifyAlways, preference.TransferMode)
+	require.True(t, preference.ShouldNotifyTransfer(1))
+
+	arg := UpsertNotificationPreferenceParams{
+		Username:          user.Username,
+		TransferMode:      util.NotifyAboveThreshold,
+		TransferThreshold: 100,
+	}
+	preference, err = testQueries.UpsertNotificationPreference(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.TransferMode, preference.TransferMode)
+	require.Equal(t, arg.TransferThreshold, preference.TransferThreshold)
+	require.False(t, preference.ShouldNotifyTransfer(100))
+	require.True(t, preference.ShouldNotifyTransfer(101))
+
+	arg.TransferMode = util.NotifyNever
+	updated, err := testQueries.UpsertNotificationPreference(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, util.NotifyNever, updated.TransferMode)
+	require.Equal(t, preference.CreatedAt, updated.CreatedAt)
+	require.False(t, updated.ShouldNotifyTransfer(1000))
+}
+
+func TestShouldNotifyTransfer(t *testing.T) {
+	testCases := []struct {
+		name       string
+		preference NotificationPreference
+		amount     int64
+		notify     bool
+	}{
+		{"Always", NotificationPreference{TransferMode: util.NotifyAlways}, 1, true},
+		{"AboveThreshold", NotificationPreference{TransferMode: util.NotifyAboveThreshold, TransferThreshold: 100}, 101, true},
+		{"AtThreshold", NotificationPreference{TransferMode: util.NotifyAboveThreshold, TransferThreshold: 100}, 100, false},
+		{"Never", NotificationPreference{TransferMode: util.NotifyNever}, 1000, false},
+		{"UnknownMode", NotificationPreference{TransferMode: "sometimes"}, 1000, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.notify, tc.preference.ShouldNotifyTransfer(tc.amount))
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+type RelayOutboxParams struct {
+	Limit int32
+	// Lease is how long the claimed messages are skipped by the other relays.
+	// It must be longer than publishing the batch takes.
+	Lease time.Duration
+	// MaxAttempts is how many times a message can fail before the relay gives up on it.
+	MaxAttempts int32
+	// Publish hands a pending message over to the message broker.
+	// A message is marked as published only if Publish succeeds, so it can be delivered more than once.
+	Publish func(message OutboxMessage) error
+}
+
+type RelayOutboxResult struct {
+	Published []OutboxMessage
+	Failed    []OutboxMessage
+}
+
+// RelayOutbox claims a batch of pending outbox messages, publishes them and records the outcome.
+// The messages are claimed for the lease instead of being locked, so no transaction stays open
+// while they are published, and several relays can run at the same time.
+func (store *SQLStore) RelayOutbox(ctx context.Context, arg RelayOutboxParams) (RelayOutboxResult, error) {
+	var result RelayOutboxResult
+
+	messages, err := store.ClaimPendingOutboxMessages(ctx, ClaimPendingOutboxMessagesParams{
+		LeaseSeconds: int32(arg.Lease / time.Second),
+		BatchSize:    arg.Limit,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	for _, message := range messages {
+		if publishErr := arg.Publish(message); publishErr != nil {
+			failed, err := store.MarkOutboxMessageFailed(ctx, MarkOutboxMessageFailedParams{
+				ID: message.ID,
+				LastError: sql.NullString{
+					String: publishErr.Error(),
+					Valid:  true,
+				},
+				MaxAttempts: arg.MaxAttempts,
+			})
+			if err != nil {
+				return result, err
+			}
+			result.Failed = append(result.Failed, failed)
+			continue
+		}
+
+		published, err := store.MarkOutboxMessagePublished(ctx, message.ID)
+		if err != nil {
+			return result, err
+		}
+		result.Published = append(result.Published, published)
+	}
+
+	return result, nil
+}
+
+func createOutboxMessages(ctx context.Context, q *Queries, messages []CreateOutboxMessageParams) error {
+	for _, message := range messages {
+		if _, err := q.CreateOutboxMessage(ctx, message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
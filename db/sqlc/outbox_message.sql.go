@@ -0,0 +1,188 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.17.2
+// source: outbox_message.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+)
+
+const claimPendingOutboxMessages = `-- name: ClaimPendingOutboxMessages :many
+UPDATE outbox_messages
+SET locked_until = now() + $1::int * interval '1 second'
+WHERE id IN (
+    SELECT id FROM outbox_messages
+    WHERE
+        published_at IS NULL
+        AND failed_at IS NULL
+        AND (locked_until IS NULL OR locked_until < now())
+    ORDER BY id
+    LIMIT $2
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, task_type, payload, queue, max_retry, process_at, attempts, last_error, published_at, created_at, locked_until, failed_at
+`
+
+type ClaimPendingOutboxMessagesParams struct {
+	LeaseSeconds int32 `json:"lease_seconds"`
+	BatchSize    int32 `json:"batch_size"`
+}
+
+// Claims a batch of pending messages for the lease, so the concurrent relays skip them while they
+// are published outside of any transaction. The claim of a relay that stopped expires with the lease.
+func (q *Queries) ClaimPendingOutboxMessages(ctx context.Context, arg ClaimPendingOutboxMessagesParams) ([]OutboxMessage, error) {
+	rows, err := q.db.QueryContext(ctx, claimPendingOutboxMessages, arg.LeaseSeconds, arg.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []OutboxMessage{}
+	for rows.Next() {
+		var i OutboxMessage
+		if err := rows.Scan(
+			&i.ID,
+			&i.TaskType,
+			&i.Payload,
+			&i.Queue,
+			&i.MaxRetry,
+			&i.ProcessAt,
+			&i.Attempts,
+			&i.LastError,
+			&i.PublishedAt,
+			&i.CreatedAt,
+			&i.LockedUntil,
+			&i.FailedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const createOutboxMessage = `-- name: CreateOutboxMessage :one
+INSERT INTO outbox_messages (
+    task_type,
+    payload,
+    queue,
+    max_retry,
+    process_at
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+RETURNING id, task_type, payload, queue, max_retry, process_at, attempts, last_error, published_at, created_at, locked_until, failed_at
+`
+
+type CreateOutboxMessageParams struct {
+	TaskType  string          `json:"task_type"`
+	Payload   json.RawMessage `json:"payload"`
+	Queue     string          `json:"queue"`
+	MaxRetry  int32           `json:"max_retry"`
+	ProcessAt time.Time       `json:"process_at"`
+}
+
+func (q *Queries) CreateOutboxMessage(ctx context.Context, arg CreateOutboxMessageParams) (OutboxMessage, error) {
+	row := q.db.QueryRowContext(ctx, createOutboxMessage,
+		arg.TaskType,
+		arg.Payload,
+		arg.Queue,
+		arg.MaxRetry,
+		arg.ProcessAt,
+	)
+	var i OutboxMessage
+	err := row.Scan(
+		&i.ID,
+		&i.TaskType,
+		&i.Payload,
+		&i.Queue,
+		&i.MaxRetry,
+		&i.ProcessAt,
+		&i.Attempts,
+		&i.LastError,
+		&i.PublishedAt,
+		&i.CreatedAt,
+		&i.LockedUntil,
+		&i.FailedAt,
+	)
+	return i, err
+}
+
+const markOutboxMessageFailed = `-- name: MarkOutboxMessageFailed :one
+UPDATE outbox_messages
+SET
+    attempts = attempts + 1,
+    last_error = $1,
+    locked_until = NULL,
+    failed_at = CASE WHEN attempts + 1 >= $2::int THEN now() END
+WHERE id = $3
+RETURNING id, task_type, payload, queue, max_retry, process_at, attempts, last_error, published_at, created_at, locked_until, failed_at
+`
+
+type MarkOutboxMessageFailedParams struct {
+	LastError   sql.NullString `json:"last_error"`
+	MaxAttempts int32          `json:"max_attempts"`
+	ID          int64          `json:"id"`
+}
+
+// The message is given up once it has failed max_attempts times.
+func (q *Queries) MarkOutboxMessageFailed(ctx context.Context, arg MarkOutboxMessageFailedParams) (OutboxMessage, error) {
+	row := q.db.QueryRowContext(ctx, markOutboxMessageFailed, arg.LastError, arg.MaxAttempts, arg.ID)
+	var i OutboxMessage
+	err := row.Scan(
+		&i.ID,
+		&i.TaskType,
+		&i.Payload,
+		&i.Queue,
+		&i.MaxRetry,
+		&i.ProcessAt,
+		&i.Attempts,
+		&i.LastError,
+		&i.PublishedAt,
+		&i.CreatedAt,
+		&i.LockedUntil,
+		&i.FailedAt,
+	)
+	return i, err
+}
+
+const markOutboxMessagePublished = `-- name: MarkOutboxMessagePublished :one
+UPDATE outbox_messages
+SET
+    attempts = attempts + 1,
+    last_error = NULL,
+    locked_until = NULL,
+    published_at = now()
+WHERE id = $1
+RETURNING id, task_type, payload, queue, max_retry, process_at, attempts, last_error, published_at, created_at, locked_until, failed_at
+`
+
+func (q *Queries) MarkOutboxMessagePublished(ctx context.Context, id int64) (OutboxMessage, error) {
+	row := q.db.QueryRowContext(ctx, markOutboxMessagePublished, id)
+	var i OutboxMessage
+	err := row.Scan(
+		&i.ID,
+		&i.TaskType,
+		&i.Payload,
+		&i.Queue,
+		&i.MaxRetry,
+		&i.ProcessAt,
+		&i.Attempts,
+		&i.LastError,
+		&i.PublishedAt,
+		&i.CreatedAt,
+		&i.LockedUntil,
+		&i.FailedAt,
+	)
+	return i, err
+}
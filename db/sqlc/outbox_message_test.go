@@ -0,0 +1,150 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mativm02/bank_system/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomOutboxMessage(t *testing.T) OutboxMessage {
+	arg := CreateOutboxMessageParams{
+		TaskType:  util.RandomString(10),
+		Payload:   []byte(`{"username":"` + util.RandomOwner() + `"}`),
+		Queue:     "default",
+		MaxRetry:  3,
+		ProcessAt: time.Now(),
+	}
+
+	message, err := testQueries.CreateOutboxMessage(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, message)
+
+	require.Equal(t, arg.TaskType, message.TaskType)
+	require.JSONEq(t, string(arg.Payload), string(message.Payload))
+	require.Equal(t, arg.Queue, message.Queue)
+	require.Equal(t, arg.MaxRetry, message.MaxRetry)
+	require.WithinDuration(t, arg.ProcessAt, message.ProcessAt, time.Second)
+	require.Zero(t, message.Attempts)
+	require.False(t, message.PublishedAt.Valid)
+
+	return message
+}
+
+func TestCreateUserTxWritesOutbox(t *testing.T) {
+	store := NewStore(testDB)
+
+	hashedPassword, err := util.HashPassword(util.RandomString(6))
+	require.NoError(t, err)
+
+	taskType := util.RandomString(10)
+	result, err := store.CreateUserTx(context.Background(), CreateUserTxParams{
+		CreateUserParams: CreateUserParams{
+			Username:       util.RandomOwner(),
+			HashedPassword: hashedPassword,
+			FullName:       util.RandomOwner(),
+			Email:          util.RandomEmail(),
+		},
+		OutboxMessages: func(user User) ([]CreateOutboxMessageParams, error) {
+			return []CreateOutboxMessageParams{{
+				TaskType:  taskType,
+				Payload:   []byte(`{"username":"` + user.Username + `"}`),
+				Queue:     "critical",
+				MaxRetry:  10,
+				ProcessAt: time.Now(),
+			}}, nil
+		},
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, result.User)
+
+	relayed, err := store.RelayOutbox(context.Background(), RelayOutboxParams{
+		Limit:       1000,
+		Lease:       time.Minute,
+		MaxAttempts: 3,
+		Publish: func(message OutboxMessage) error {
+			return nil
+		},
+	})
+	require.NoError(t, err)
+
+	var found bool
+	for _, message := range relayed.Published {
+		if message.TaskType == taskType {
+			found = true
+			require.JSONEq(t, `{"username":"`+result.User.Username+`"}`, string(message.Payload))
+			require.True(t, message.PublishedAt.Valid)
+		}
+	}
+	require.True(t, found)
+}
+
+func TestCreateUserTxRollsBackOutbox(t *testing.T) {
+	store := NewStore(testDB)
+
+	username := util.RandomOwner()
+	_, err := store.CreateUserTx(context.Background(), CreateUserTxParams{
+		CreateUserParams: CreateUserParams{
+			Username:       username,
+			HashedPassword: util.RandomString(10),
+			FullName:       util.RandomOwner(),
+			Email:          util.RandomEmail(),
+		},
+		OutboxMessages: func(user User) ([]CreateOutboxMessageParams, error) {
+			return nil, errors.New("cannot build message")
+		},
+	})
+	require.Error(t, err)
+
+	_, err = store.GetUser(context.Background(), username)
+	require.Error(t, err)
+}
+
+func TestRelayOutboxFailed(t *testing.T) {
+	store := NewStore(testDB)
+	message := createRandomOutboxMessage(t)
+
+	relay := func() RelayOutboxResult {
+		result, err := store.RelayOutbox(context.Background(), RelayOutboxParams{
+			Limit:       1000,
+			Lease:       time.Minute,
+			MaxAttempts: 2,
+			Publish: func(m OutboxMessage) error {
+				if m.ID == message.ID {
+					return errors.New("redis is down")
+				}
+				return nil
+			},
+		})
+		require.NoError(t, err)
+		return result
+	}
+	findFailed := func(result RelayOutboxResult) (OutboxMessage, bool) {
+		for _, failed := range result.Failed {
+			if failed.ID == message.ID {
+				return failed, true
+			}
+		}
+		return OutboxMessage{}, false
+	}
+
+	failed, found := findFailed(relay())
+	require.True(t, found)
+	require.Equal(t, int32(1), failed.Attempts)
+	require.Equal(t, "redis is down", failed.LastError.String)
+	require.False(t, failed.PublishedAt.Valid)
+	require.False(t, failed.LockedUntil.Valid)
+	require.False(t, failed.FailedAt.Valid)
+
+	// The relay gives up on the message after the last attempt and does not claim it again.
+	failed, found = findFailed(relay())
+	require.True(t, found)
+	require.Equal(t, int32(2), failed.Attempts)
+	require.True(t, failed.FailedAt.Valid)
+
+	_, found = findFailed(relay())
+	require.False(t, found)
+}
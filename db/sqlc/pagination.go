@@ -0,0 +1,160 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// Sort orders of ListAccounts and ListTransfers
+const (
+	OrderByID        = "id"
+	OrderByBalance   = "balance"
+	OrderByAmount    = "amount"
+	OrderByCreatedAt = "created_at"
+)
+
+// SortValue returns the value of the account that ListAccounts sorts by for the given order,
+// to be used as the cursor of the next page.
+func (account Account) SortValue(orderBy string) int64 {
+	switch orderBy {
+	case OrderByBalance:
+		return account.Balance
+	case OrderByCreatedAt:
+		return account.CreatedAt.UnixMicro()
+	default:
+		return account.ID
+	}
+}
+
+// SortValue returns the value of the transfer that ListTransfers sorts by for the given order,
+// to be used as the cursor of the next page.
+func (transfer Transfer) SortValue(orderBy string) int64 {
+	if orderBy == OrderByAmount {
+		return transfer.Amount
+	}
+	return transfer.CreatedAt.UnixMicro()
+}
+
+// ListAccountsParams lists the accounts of the owner in the order, starting after the cursor:
+// the sort value and the ID of the last account of the previous page.
+type ListAccountsParams struct {
+	Owner       string         `json:"owner"`
+	Currency    sql.NullString `json:"currency"`
+	Type        sql.NullString `json:"type"`
+	CursorID    sql.NullInt64  `json:"cursor_id"`
+	SortDesc    bool           `json:"sort_desc"`
+	OrderBy     string         `json:"order_by"`
+	CursorValue sql.NullInt64  `json:"cursor_value"`
+	PageSize    int32          `json:"page_size"`
+}
+
+// ListAccounts runs the query of the sort order, so that the database can use the indexes
+// to sort and to start after the cursor.
+func (q *Queries) ListAccounts(ctx context.Context, arg ListAccountsParams) ([]Account, error) {
+	switch arg.OrderBy {
+	case OrderByBalance:
+		params := ListAccountsByBalanceParams{
+			Owner:         arg.Owner,
+			Currency:      arg.Currency,
+			Type:          arg.Type,
+			CursorID:      arg.CursorID,
+			CursorBalance: arg.CursorValue,
+			PageSize:      arg.PageSize,
+		}
+		if arg.SortDesc {
+			return q.ListAccountsByBalanceDesc(ctx, ListAccountsByBalanceDescParams(params))
+		}
+		return q.ListAccountsByBalance(ctx, params)
+	case OrderByCreatedAt:
+		params := ListAccountsByCreatedAtParams{
+			Owner:           arg.Owner,
+			Currency:        arg.Currency,
+			Type:            arg.Type,
+			CursorID:        arg.CursorID,
+			CursorCreatedAt: cursorTime(arg.CursorValue),
+			PageSize:        arg.PageSize,
+		}
+		if arg.SortDesc {
+			return q.ListAccountsByCreatedAtDesc(ctx, ListAccountsByCreatedAtDescParams(params))
+		}
+		return q.ListAccountsByCreatedAt(ctx, params)
+	default:
+		params := ListAccountsByIDParams{
+			Owner:    arg.Owner,
+			Currency: arg.Currency,
+			Type:     arg.Type,
+			CursorID: arg.CursorID,
+			PageSize: arg.PageSize,
+		}
+		if arg.SortDesc {
+			return q.ListAccountsByIDDesc(ctx, ListAccountsByIDDescParams(params))
+		}
+		return q.ListAccountsByID(ctx, params)
+	}
+}
+
+// ListTransfersParams lists the transfers from or to the accounts of the owner in the order,
+// starting after the cursor: the sort value and the ID of the last transfer of the previous page.
+type ListTransfersParams struct {
+	Owner                 string         `json:"owner"`
+	AccountID             sql.NullInt64  `json:"account_id"`
+	CounterpartyAccountID sql.NullInt64  `json:"counterparty_account_id"`
+	Currency              sql.NullString `json:"currency"`
+	StartTime             sql.NullTime   `json:"start_time"`
+	EndTime               sql.NullTime   `json:"end_time"`
+	MinAmount             sql.NullInt64  `json:"min_amount"`
+	MaxAmount             sql.NullInt64  `json:"max_amount"`
+	CursorID              sql.NullInt64  `json:"cursor_id"`
+	SortDesc              bool           `json:"sort_desc"`
+	OrderBy               string         `json:"order_by"`
+	CursorValue           sql.NullInt64  `json:"cursor_value"`
+	PageSize              int32          `json:"page_size"`
+}
+
+// ListTransfers runs the query of the sort order, so that the database can use the indexes
+// to sort and to start after the cursor.
+func (q *Queries) ListTransfers(ctx context.Context, arg ListTransfersParams) ([]Transfer, error) {
+	if arg.OrderBy == OrderByAmount {
+		params := ListTransfersByAmountParams{
+			Owner:                 arg.Owner,
+			AccountID:             arg.AccountID,
+			CounterpartyAccountID: arg.CounterpartyAccountID,
+			Currency:              arg.Currency,
+			StartTime:             arg.StartTime,
+			EndTime:               arg.EndTime,
+			MinAmount:             arg.MinAmount,
+			MaxAmount:             arg.MaxAmount,
+			CursorID:              arg.CursorID,
+			CursorAmount:          arg.CursorValue,
+			PageSize:              arg.PageSize,
+		}
+		if arg.SortDesc {
+			return q.ListTransfersByAmountDesc(ctx, ListTransfersByAmountDescParams(params))
+		}
+		return q.ListTransfersByAmount(ctx, params)
+	}
+
+	params := ListTransfersByCreatedAtParams{
+		Owner:                 arg.Owner,
+		AccountID:             arg.AccountID,
+		CounterpartyAccountID: arg.CounterpartyAccountID,
+		Currency:              arg.Currency,
+		StartTime:             arg.StartTime,
+		EndTime:               arg.EndTime,
+		MinAmount:             arg.MinAmount,
+		MaxAmount:             arg.MaxAmount,
+		CursorID:              arg.CursorID,
+		CursorCreatedAt:       cursorTime(arg.CursorValue),
+		PageSize:              arg.PageSize,
+	}
+	if arg.SortDesc {
+		return q.ListTransfersByCreatedAtDesc(ctx, ListTransfersByCreatedAtDescParams(params))
+	}
+	return q.ListTransfersByCreatedAt(ctx, params)
+}
+
+// cursorTime converts a creation time cursor, in microseconds, back to the time.
+func cursorTime(value sql.NullInt64) sql.NullTime {
+	return sql.NullTime{Time: time.UnixMicro(value.Int64), Valid: value.Valid}
+}
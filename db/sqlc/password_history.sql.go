@@ -0,0 +1,86 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.17.2
+// source: password_history.sql
+
+package db
+
+import (
+	"context"
+)
+
+const createPasswordHistory = `-- name: CreatePasswordHistory :exec
+INSERT INTO password_history (
+    username,
+    hashed_password
+) VALUES (
+    $1, $2
+)
+`
+
+type CreatePasswordHistoryParams struct {
+	Username       string `json:"username"`
+	HashedPassword string `json:"hashed_password"`
+}
+
+func (q *Queries) CreatePasswordHistory(ctx context.Context, arg CreatePasswordHistoryParams) error {
+	_, err := q.db.ExecContext(ctx, createPasswordHistory, arg.Username, arg.HashedPassword)
+	return err
+}
+
+const deleteOldPasswordHistory = `-- name: DeleteOldPasswordHistory :exec
+DELETE FROM password_history AS ph
+WHERE
+    ph.username = $1
+    AND ph.id NOT IN (
+        SELECT recent.id FROM password_history AS recent
+        WHERE recent.username = $1
+        ORDER BY recent.id DESC
+        LIMIT $2
+    )
+`
+
+type DeleteOldPasswordHistoryParams struct {
+	Username string `json:"username"`
+	Keep     int32  `json:"keep"`
+}
+
+func (q *Queries) DeleteOldPasswordHistory(ctx context.Context, arg DeleteOldPasswordHistoryParams) error {
+	_, err := q.db.ExecContext(ctx, deleteOldPasswordHistory, arg.Username, arg.Keep)
+	return err
+}
+
+const listPasswordHistory = `-- name: ListPasswordHistory :many
+SELECT hashed_password FROM password_history
+WHERE username = $1
+ORDER BY id DESC
+LIMIT $2
+`
+
+type ListPasswordHistoryParams struct {
+	Username string `json:"username"`
+	Limit    int32  `json:"limit"`
+}
+
+func (q *Queries) ListPasswordHistory(ctx context.Context, arg ListPasswordHistoryParams) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listPasswordHistory, arg.Username, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var hashed_password string
+		if err := rows.Scan(&hashed_password); err != nil {
+			return nil, err
+		}
+		items = append(items, hashed_password)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,81 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/mativm02/bank_system/util"
+	"github.com/stretchr/testify/require"
+)
+
+func changePassword(t *testing.T, store Store, username string, historySize int32) (User, []string) {
+	hashedPassword, err := util.HashPassword(util.RandomString(8))
+	require.NoError(t, err)
+
+	var checked []string
+	result, err := store.UpdateUserTx(context.Background(), UpdateUserTxParams{
+		UpdateUserParams: UpdateUserParams{
+			Username:       username,
+			HashedPassword: sql.NullString{String: hashedPassword, Valid: true},
+		},
+		PasswordHistorySize: historySize,
+		CheckPasswordHistory: func(hashes []string) error {
+			checked = hashes
+			return nil
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, hashedPassword, result.User.HashedPassword)
+
+	return result.User, checked
+}
+
+func TestUpdateUserTxPasswordHistory(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+
+	hashes := []string{user.HashedPassword}
+	for i := 0; i < 4; i++ {
+		updated, checked := changePassword(t, store, user.Username, 3)
+
+		// The current password is checked first, followed by the most recent ones.
+		expected := hashes
+		if len(expected) > 3 {
+			expected = expected[:3]
+		}
+		require.Equal(t, expected, checked)
+
+		hashes = append([]string{updated.HashedPassword}, hashes...)
+	}
+
+	history, err := testQueries.ListPasswordHistory(context.Background(), ListPasswordHistoryParams{
+		Username: user.Username,
+		Limit:    10,
+	})
+	require.NoError(t, err)
+	require.Equal(t, hashes[1:3], history)
+}
+
+func TestUpdateUserTxPasswordReused(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+
+	reused := errors.New("password reused")
+	_, err := store.UpdateUserTx(context.Background(), UpdateUserTxParams{
+		UpdateUserParams: UpdateUserParams{
+			Username:       user.Username,
+			HashedPassword: sql.NullString{String: util.RandomString(10), Valid: true},
+		},
+		PasswordHistorySize: 5,
+		CheckPasswordHistory: func(hashes []string) error {
+			return reused
+		},
+	})
+	require.ErrorIs(t, err, reused)
+
+	got, err := testQueries.GetUser(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Equal(t, user.HashedPassword, got.HashedPassword)
+}
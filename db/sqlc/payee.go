@@ -0,0 +1,59 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrCoolingOff is returned when a transfer sends more than a new destination can receive yet.
+var ErrCoolingOff = errors.New("destination is in its cooling-off period")
+
+// CoolingOff limits what an owner can send to a destination that is new to them, which limits what
+// an attacker can send to a payee they just added. A zero Period disables the cooling-off.
+type CoolingOff struct {
+	Period    time.Duration
+	MaxAmount int64
+}
+
+// checkCoolingOff returns ErrCoolingOff if the owner cannot send the amount to the account yet,
+// however the destination was given. An account of another user is new to the owner for the
+// cooling-off period after it was added as a payee or first paid by the owner, whichever came first,
+// and the owner can send it at most MaxAmount in total until then. The accounts of the owner are
+// never cooling off. The owner must be locked, so concurrent transfers cannot exceed MaxAmount together.
+func checkCoolingOff(ctx context.Context, q Querier, owner string, toAccount Account, amount int64, coolingOff CoolingOff) error {
+	if coolingOff.Period <= 0 || toAccount.Owner == owner {
+		return nil
+	}
+
+	payments, err := q.GetAccountPayments(ctx, GetAccountPaymentsParams{
+		ToAccountID: toAccount.ID,
+		Owner:       owner,
+	})
+	if err != nil {
+		return err
+	}
+
+	knownSince := payments.FirstPaidAt
+	payee, err := q.GetPayeeByAccount(ctx, GetPayeeByAccountParams{
+		Owner:     owner,
+		AccountID: toAccount.ID,
+	})
+	switch {
+	case err == nil:
+		if payee.CreatedAt.Before(knownSince) {
+			knownSince = payee.CreatedAt
+		}
+	case err != sql.ErrNoRows:
+		return err
+	}
+
+	if time.Since(knownSince) >= coolingOff.Period || payments.Total+amount <= coolingOff.MaxAmount {
+		return nil
+	}
+
+	return fmt.Errorf("%w: account [%d] was added as a payee or first paid less than %s ago and cannot receive more than %d in total yet, %d already sent",
+		ErrCoolingOff, toAccount.ID, coolingOff.Period, coolingOff.MaxAmount, payments.Total)
+}
@@ -0,0 +1,144 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.17.2
+// source: payee.sql
+
+package db
+
+import (
+	"context"
+)
+
+const createPayee = `-- name: CreatePayee :one
+INSERT INTO payees (
+    owner,
+    nickname,
+    account_id,
+    currency
+) VALUES (
+    $1, $2, $3, $4
+)
+RETURNING id, owner, nickname, account_id, currency, created_at
+`
+
+type CreatePayeeParams struct {
+	Owner     string `json:"owner"`
+	Nickname  string `json:"nickname"`
+	AccountID int64  `json:"account_id"`
+	Currency  string `json:"currency"`
+}
+
+func (q *Queries) CreatePayee(ctx context.Context, arg CreatePayeeParams) (Payee, error) {
+	row := q.db.QueryRowContext(ctx, createPayee,
+		arg.Owner,
+		arg.Nickname,
+		arg.AccountID,
+		arg.Currency,
+	)
+	var i Payee
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Nickname,
+		&i.AccountID,
+		&i.Currency,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deletePayee = `-- name: DeletePayee :exec
+DELETE FROM payees WHERE id = $1
+`
+
+func (q *Queries) DeletePayee(ctx context.Context, id int64) error {
+	_, err := q.db.ExecContext(ctx, deletePayee, id)
+	return err
+}
+
+const getPayee = `-- name: GetPayee :one
+SELECT id, owner, nickname, account_id, currency, created_at FROM payees WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetPayee(ctx context.Context, id int64) (Payee, error) {
+	row := q.db.QueryRowContext(ctx, getPayee, id)
+	var i Payee
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Nickname,
+		&i.AccountID,
+		&i.Currency,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getPayeeByAccount = `-- name: GetPayeeByAccount :one
+SELECT id, owner, nickname, account_id, currency, created_at FROM payees
+WHERE owner = $1 AND account_id = $2
+LIMIT 1
+`
+
+type GetPayeeByAccountParams struct {
+	Owner     string `json:"owner"`
+	AccountID int64  `json:"account_id"`
+}
+
+func (q *Queries) GetPayeeByAccount(ctx context.Context, arg GetPayeeByAccountParams) (Payee, error) {
+	row := q.db.QueryRowContext(ctx, getPayeeByAccount, arg.Owner, arg.AccountID)
+	var i Payee
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Nickname,
+		&i.AccountID,
+		&i.Currency,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listPayees = `-- name: ListPayees :many
+SELECT id, owner, nickname, account_id, currency, created_at FROM payees
+WHERE owner = $1
+ORDER BY nickname
+LIMIT $2
+OFFSET $3
+`
+
+type ListPayeesParams struct {
+	Owner  string `json:"owner"`
+	Limit  int32  `json:"limit"`
+	Offset int32  `json:"offset"`
+}
+
+func (q *Queries) ListPayees(ctx context.Context, arg ListPayeesParams) ([]Payee, error) {
+	rows, err := q.db.QueryContext(ctx, listPayees, arg.Owner, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Payee{}
+	for rows.Next() {
+		var i Payee
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.Nickname,
+			&i.AccountID,
+			&i.Currency,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,127 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/mativm02/bank_system/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomPayee(t *testing.T) Payee {
+	user := createRandomUser(t)
+	account := createRandomAccount(t)
+
+	arg := CreatePayeeParams{
+		Owner:     user.Username,
+		Nickname:  util.RandomOwner(),
+		AccountID: account.ID,
+		Currency:  account.Currency,
+	}
+
+	payee, err := testQueries.CreatePayee(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, payee.ID)
+	require.Equal(t, arg.Owner, payee.Owner)
+	require.Equal(t, arg.Nickname, payee.Nickname)
+	require.Equal(t, arg.AccountID, payee.AccountID)
+	require.Equal(t, arg.Currency, payee.Currency)
+	require.NotZero(t, payee.CreatedAt)
+
+	return payee
+}
+
+func TestCreatePayee(t *testing.T) {
+	payee := createRandomPayee(t)
+
+	// An account can only be saved once by the same user.
+	_, err := testQueries.CreatePayee(context.Background(), CreatePayeeParams{
+		Owner:     payee.Owner,
+		Nickname:  util.RandomOwner(),
+		AccountID: payee.AccountID,
+		Currency:  payee.Currency,
+	})
+	require.Error(t, err)
+}
+
+func TestListPayees(t *testing.T) {
+	payee := createRandomPayee(t)
+
+	payees, err := testQueries.ListPayees(context.Background(), ListPayeesParams{
+		Owner:  payee.Owner,
+		Limit:  5,
+		Offset: 0,
+	})
+	require.NoError(t, err)
+	require.Len(t, payees, 1)
+	require.Equal(t, payee.ID, payees[0].ID)
+}
+
+func TestDeletePayee(t *testing.T) {
+	payee := createRandomPayee(t)
+
+	err := testQueries.DeletePayee(context.Background(), payee.ID)
+	require.NoError(t, err)
+
+	_, err = testQueries.GetPayee(context.Background(), payee.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestTransferTxCoolingOff(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+	account3 := createRandomAccount(t)
+
+	coolingOff := CoolingOff{Period: 24 * time.Hour, MaxAmount: 100}
+	transfer := func(to Account, amount int64, coolingOff CoolingOff) error {
+		_, err := store.TransferTx(context.Background(), TransferTxParams{
+			CreateTransferParams: CreateTransferParams{
+				FromAccountID: account1.ID,
+				ToAccountID:   to.ID,
+				Amount:        amount,
+			},
+			CoolingOff: coolingOff,
+		})
+		return err
+	}
+
+	// The owner never paid the account.
+	require.ErrorIs(t, transfer(account2, 101, coolingOff), ErrCoolingOff)
+	require.NoError(t, transfer(account2, 100, CoolingOff{MaxAmount: 100}))
+
+	// The first transfer starts the cooling-off, and every transfer until it ends counts toward the maximum.
+	require.NoError(t, transfer(account3, 60, coolingOff))
+	require.ErrorIs(t, transfer(account3, 41, coolingOff), ErrCoolingOff)
+	require.NoError(t, transfer(account3, 40, coolingOff))
+	require.ErrorIs(t, transfer(account3, 1, coolingOff), ErrCoolingOff)
+	require.NoError(t, transfer(account3, 101, CoolingOff{Period: time.Nanosecond, MaxAmount: 100}))
+}
+
+func TestCheckCoolingOff(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+	owner := account1.Owner
+
+	check := func(toAccount Account, amount int64, period time.Duration) error {
+		return checkCoolingOff(context.Background(), testQueries, owner, toAccount, amount, CoolingOff{Period: period, MaxAmount: 100})
+	}
+
+	require.ErrorIs(t, check(account2, 101, 24*time.Hour), ErrCoolingOff)
+	require.NoError(t, check(account2, 100, 24*time.Hour))
+	require.NoError(t, check(account1, 101, 24*time.Hour))
+
+	// The account of a recently added payee is cooling off, even when it is named directly.
+	_, err := testQueries.CreatePayee(context.Background(), CreatePayeeParams{
+		Owner:     owner,
+		Nickname:  util.RandomOwner(),
+		AccountID: account2.ID,
+		Currency:  account2.Currency,
+	})
+	require.NoError(t, err)
+	require.ErrorIs(t, check(account2, 101, 24*time.Hour), ErrCoolingOff)
+	require.NoError(t, check(account2, 101, time.Nanosecond))
+}
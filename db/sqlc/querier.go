@@ -6,31 +6,141 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type Querier interface {
 	AddAccountBalance(ctx context.Context, arg AddAccountBalanceParams) (Account, error)
+	ApplyPendingEmail(ctx context.Context, arg ApplyPendingEmailParams) (User, error)
+	CancelPendingEmail(ctx context.Context, username string) (User, error)
+	// The accruals are attached to the posting, so a concurrent posting cannot claim them again.
+	ClaimInterestAccruals(ctx context.Context, arg ClaimInterestAccrualsParams) ([]int64, error)
+	// Claims a batch of pending messages for the lease, so the concurrent relays skip them while they
+	// are published outside of any transaction. The claim of a relay that stopped expires with the lease.
+	ClaimPendingOutboxMessages(ctx context.Context, arg ClaimPendingOutboxMessagesParams) ([]OutboxMessage, error)
+	// Claims a batch of unpublished events for the lease, so the concurrent relays skip them while they
+	// are published outside of any transaction. The claim of a relay that stopped expires with the lease.
+	ClaimUnpublishedDomainEvents(ctx context.Context, arg ClaimUnpublishedDomainEventsParams) ([]DomainEvent, error)
+	CountAccountsByOwner(ctx context.Context, owner string) (int64, error)
+	CountAccountsByType(ctx context.Context, arg CountAccountsByTypeParams) (int64, error)
 	CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error)
+	CreateApiClient(ctx context.Context, arg CreateApiClientParams) (ApiClient, error)
+	CreateApiKey(ctx context.Context, arg CreateApiKeyParams) (ApiKey, error)
+	CreateDomainEvent(ctx context.Context, arg CreateDomainEventParams) (DomainEvent, error)
 	CreateEntry(ctx context.Context, arg CreateEntryParams) (Entry, error)
+	CreateFeeSchedule(ctx context.Context, arg CreateFeeScheduleParams) (FeeSchedule, error)
+	// An account accrues interest once a day, so accruing a day again does nothing.
+	CreateInterestAccrual(ctx context.Context, arg CreateInterestAccrualParams) (int64, error)
+	CreateInterestPosting(ctx context.Context, accountID int64) (InterestPosting, error)
+	CreateInterestProduct(ctx context.Context, arg CreateInterestProductParams) (InterestProduct, error)
+	CreateOutboxMessage(ctx context.Context, arg CreateOutboxMessageParams) (OutboxMessage, error)
+	CreatePasswordHistory(ctx context.Context, arg CreatePasswordHistoryParams) error
+	CreatePayee(ctx context.Context, arg CreatePayeeParams) (Payee, error)
 	CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error)
 	CreateTransfer(ctx context.Context, arg CreateTransferParams) (Transfer, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
 	CreateVerifyEmail(ctx context.Context, arg CreateVerifyEmailParams) (VerifyEmail, error)
+	CreateWebhookDelivery(ctx context.Context, arg CreateWebhookDeliveryParams) (WebhookDelivery, error)
+	CreateWebhookSubscription(ctx context.Context, arg CreateWebhookSubscriptionParams) (WebhookSubscription, error)
 	DeleteAccount(ctx context.Context, id int64) error
+	DeleteFeeSchedule(ctx context.Context, id int64) error
+	DeleteOldPasswordHistory(ctx context.Context, arg DeleteOldPasswordHistoryParams) error
+	DeletePayee(ctx context.Context, id int64) error
+	DeleteWebhookSubscription(ctx context.Context, id int64) error
 	GetAccount(ctx context.Context, id int64) (Account, error)
+	GetAccountByNumber(ctx context.Context, number string) (Account, error)
 	GetAccountForUpdate(ctx context.Context, id int64) (Account, error)
+	// Sums what the owner sent to the account from any of their accounts and returns when they first paid it,
+	// or now if they never did.
+	GetAccountPayments(ctx context.Context, arg GetAccountPaymentsParams) (GetAccountPaymentsRow, error)
+	GetAccountSpendingLimit(ctx context.Context, accountID int64) (AccountSpendingLimit, error)
+	GetApiClient(ctx context.Context, id string) (ApiClient, error)
+	GetApiKey(ctx context.Context, id int64) (ApiKey, error)
+	GetApiKeyByPrefix(ctx context.Context, prefix string) (ApiKey, error)
+	GetDomainEvent(ctx context.Context, id int64) (DomainEvent, error)
 	GetEntry(ctx context.Context, id int64) (Entry, error)
+	// The tiers of a currency start at their min_amount, so the amount falls in the tier
+	// with the highest min_amount up to the amount.
+	GetFeeSchedule(ctx context.Context, arg GetFeeScheduleParams) (FeeSchedule, error)
+	GetInterestProduct(ctx context.Context, id int64) (InterestProduct, error)
+	GetLastInterestPosting(ctx context.Context, accountID int64) (InterestPosting, error)
+	GetNotificationPreference(ctx context.Context, username string) (NotificationPreference, error)
+	GetPayee(ctx context.Context, id int64) (Payee, error)
+	GetPayeeByAccount(ctx context.Context, arg GetPayeeByAccountParams) (Payee, error)
 	GetSession(ctx context.Context, id uuid.UUID) (Session, error)
+	// The bank has one account per currency and purpose, named by its nickname.
+	GetSystemAccount(ctx context.Context, arg GetSystemAccountParams) (Account, error)
 	GetTransfer(ctx context.Context, id int64) (Transfer, error)
 	GetUser(ctx context.Context, username string) (User, error)
-	ListAccounts(ctx context.Context, arg ListAccountsParams) ([]Account, error)
+	GetUserForUpdate(ctx context.Context, username string) (User, error)
+	GetUserSpendingLimit(ctx context.Context, arg GetUserSpendingLimitParams) (UserSpendingLimit, error)
+	GetWebhookDelivery(ctx context.Context, id int64) (WebhookDelivery, error)
+	GetWebhookSubscription(ctx context.Context, id int64) (WebhookSubscription, error)
+	InvalidateVerifyEmails(ctx context.Context, username string) error
+	ListAccountsByBalance(ctx context.Context, arg ListAccountsByBalanceParams) ([]Account, error)
+	ListAccountsByBalanceDesc(ctx context.Context, arg ListAccountsByBalanceDescParams) ([]Account, error)
+	ListAccountsByCreatedAt(ctx context.Context, arg ListAccountsByCreatedAtParams) ([]Account, error)
+	ListAccountsByCreatedAtDesc(ctx context.Context, arg ListAccountsByCreatedAtDescParams) ([]Account, error)
+	// The accounts are listed by ListAccounts, with one query per sort order so that the order
+	// and the cursor are plain columns. Keyset pagination: the page starts after the cursor, the
+	// sort value and the ID of the last account of the previous page.
+	ListAccountsByID(ctx context.Context, arg ListAccountsByIDParams) ([]Account, error)
+	ListAccountsByIDDesc(ctx context.Context, arg ListAccountsByIDDescParams) ([]Account, error)
+	ListAccountsWithUnpostedInterest(ctx context.Context, before time.Time) ([]int64, error)
+	ListApiKeys(ctx context.Context, arg ListApiKeysParams) ([]ApiKey, error)
 	ListEntries(ctx context.Context, arg ListEntriesParams) ([]Entry, error)
-	ListTransfers(ctx context.Context, arg ListTransfersParams) ([]Transfer, error)
+	ListInterestAccruals(ctx context.Context, arg ListInterestAccrualsParams) ([]InterestAccrual, error)
+	// The balance is the sum of the entries of the account until the end of the day, so accruing
+	// a past day uses the balance the account had then. Only the positive balances earn interest.
+	ListInterestBearingAccounts(ctx context.Context, dayEnd time.Time) ([]ListInterestBearingAccountsRow, error)
+	ListPasswordHistory(ctx context.Context, arg ListPasswordHistoryParams) ([]string, error)
+	ListPayees(ctx context.Context, arg ListPayeesParams) ([]Payee, error)
+	ListTransfersByAmount(ctx context.Context, arg ListTransfersByAmountParams) ([]Transfer, error)
+	ListTransfersByAmountDesc(ctx context.Context, arg ListTransfersByAmountDescParams) ([]Transfer, error)
+	// The transfers from or to the accounts of the owner are listed by ListTransfers, with one
+	// query per sort order so that the order and the cursor are plain columns. Keyset pagination:
+	// the page starts after the cursor, the sort value and the ID of the last transfer of the
+	// previous page. The transfers sent by the accounts of the owner and the ones they received
+	// from other users are read separately, each through an index on the account and the sort
+	// order, and merged. Both accounts of a transfer have the same currency.
+	ListTransfersByCreatedAt(ctx context.Context, arg ListTransfersByCreatedAtParams) ([]Transfer, error)
+	ListTransfersByCreatedAtDesc(ctx context.Context, arg ListTransfersByCreatedAtDescParams) ([]Transfer, error)
+	ListWebhookDeliveries(ctx context.Context, arg ListWebhookDeliveriesParams) ([]WebhookDelivery, error)
+	ListWebhookSubscriptions(ctx context.Context, arg ListWebhookSubscriptionsParams) ([]WebhookSubscription, error)
+	ListWebhookSubscriptionsForEvent(ctx context.Context, arg ListWebhookSubscriptionsForEventParams) ([]WebhookSubscription, error)
+	// The event is given up once it has failed max_attempts times.
+	MarkDomainEventFailed(ctx context.Context, arg MarkDomainEventFailedParams) (DomainEvent, error)
+	MarkDomainEventPublished(ctx context.Context, id int64) (DomainEvent, error)
+	// The message is given up once it has failed max_attempts times.
+	MarkOutboxMessageFailed(ctx context.Context, arg MarkOutboxMessageFailedParams) (OutboxMessage, error)
+	MarkOutboxMessagePublished(ctx context.Context, id int64) (OutboxMessage, error)
+	RecordVerifyEmailRequest(ctx context.Context, username string) (User, error)
+	RevokeApiClient(ctx context.Context, id string) (ApiClient, error)
+	RevokeApiKey(ctx context.Context, id int64) (ApiKey, error)
+	RotateApiClientSecret(ctx context.Context, arg RotateApiClientSecretParams) (ApiClient, error)
+	SetAccountInterestProduct(ctx context.Context, arg SetAccountInterestProductParams) (Account, error)
+	SetAccountSpendingLimit(ctx context.Context, arg SetAccountSpendingLimitParams) (AccountSpendingLimit, error)
+	SetUserSpendingLimit(ctx context.Context, arg SetUserSpendingLimitParams) (UserSpendingLimit, error)
+	// The spending of the rolling windows is the amount of the transfers sent since the start of the window.
+	// The transfers to the accounts of the same owner move no money out, so they are not part of it.
+	SumAccountSpending(ctx context.Context, arg SumAccountSpendingParams) (SumAccountSpendingRow, error)
+	// The transfer search aggregates the entries of the transfers, which are the movements of the
+	// accounts of the owner: a transfer between two accounts of the owner is both sent and received.
+	// The fees of the transfers are not part of the totals.
+	SumTransfersByCounterparty(ctx context.Context, arg SumTransfersByCounterpartyParams) ([]SumTransfersByCounterpartyRow, error)
+	SumTransfersByDirection(ctx context.Context, arg SumTransfersByDirectionParams) ([]SumTransfersByDirectionRow, error)
+	SumTransfersByMonth(ctx context.Context, arg SumTransfersByMonthParams) ([]SumTransfersByMonthRow, error)
+	SumUserSpending(ctx context.Context, arg SumUserSpendingParams) (SumUserSpendingRow, error)
+	TouchApiKey(ctx context.Context, id int64) error
 	UpdateAccount(ctx context.Context, arg UpdateAccountParams) (Account, error)
+	UpdateInterestPosting(ctx context.Context, arg UpdateInterestPostingParams) (InterestPosting, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error)
+	UpdateUserHashedPassword(ctx context.Context, arg UpdateUserHashedPasswordParams) error
 	UpdateVerifyEmail(ctx context.Context, arg UpdateVerifyEmailParams) (VerifyEmail, error)
+	UpdateWebhookDeliveryAttempt(ctx context.Context, arg UpdateWebhookDeliveryAttemptParams) (WebhookDelivery, error)
+	UpsertNotificationPreference(ctx context.Context, arg UpsertNotificationPreferenceParams) (NotificationPreference, error)
 }
 
 var _ Querier = (*Queries)(nil)
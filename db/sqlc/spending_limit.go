@@ -0,0 +1,148 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrSpendingLimitExceeded is returned when a transfer would send more than a spending limit allows.
+var ErrSpendingLimitExceeded = errors.New("spending limit exceeded")
+
+// Rolling windows of the spending limits
+const (
+	SpendingDay   = 24 * time.Hour
+	SpendingMonth = 30 * SpendingDay
+)
+
+// SpendingLimit caps the amount that can be sent to other owners in the rolling day and month.
+// The transfer fees are not counted, and the transfers between the accounts of the same owner are
+// neither limited nor counted. Zero means no limit.
+type SpendingLimit struct {
+	Daily   int64
+	Monthly int64
+}
+
+// SpendingLimits are the limits of an account and of its owner in the currency of the account.
+type SpendingLimits struct {
+	Account SpendingLimit
+	User    SpendingLimit
+}
+
+// Allowance is how much more can be sent under a limit. Limit and Remaining are 0 when there is no limit.
+type Allowance struct {
+	Limit     int64 `json:"limit"`
+	Spent     int64 `json:"spent"`
+	Remaining int64 `json:"remaining"`
+}
+
+func newAllowance(limit, spent int64) Allowance {
+	allowance := Allowance{Limit: limit, Spent: spent}
+	if limit > 0 && spent < limit {
+		allowance.Remaining = limit - spent
+	}
+	return allowance
+}
+
+// Allows reports whether the amount can be sent under the limit.
+func (allowance Allowance) Allows(amount int64) bool {
+	return allowance.Limit == 0 || amount <= allowance.Remaining
+}
+
+// SpendingAllowance is how much more an account can send under its own limits and the limits of its owner.
+type SpendingAllowance struct {
+	AccountDaily   Allowance `json:"account_daily"`
+	AccountMonthly Allowance `json:"account_monthly"`
+	UserDaily      Allowance `json:"user_daily"`
+	UserMonthly    Allowance `json:"user_monthly"`
+}
+
+// Check returns ErrSpendingLimitExceeded, with the limit that does not allow it, if the amount cannot be sent.
+func (allowance SpendingAllowance) Check(amount int64) error {
+	limits := []struct {
+		name      string
+		allowance Allowance
+	}{
+		{"daily account", allowance.AccountDaily},
+		{"monthly account", allowance.AccountMonthly},
+		{"daily user", allowance.UserDaily},
+		{"monthly user", allowance.UserMonthly},
+	}
+	for _, limit := range limits {
+		if !limit.allowance.Allows(amount) {
+			return fmt.Errorf("%w: the %s limit of %d allows %d more", ErrSpendingLimitExceeded, limit.name, limit.allowance.Limit, limit.allowance.Remaining)
+		}
+	}
+	return nil
+}
+
+// GetSpendingAllowance returns how much more the account can send. The limits set by the admins
+// for the account and for its owner in its currency replace the defaults.
+func GetSpendingAllowance(ctx context.Context, q Querier, account Account, defaults SpendingLimits) (SpendingAllowance, error) {
+	limits, err := spendingLimits(ctx, q, account, defaults)
+	if err != nil {
+		return SpendingAllowance{}, err
+	}
+
+	now := time.Now()
+	dayStart := now.Add(-SpendingDay)
+	monthStart := now.Add(-SpendingMonth)
+
+	accountSpending, err := q.SumAccountSpending(ctx, SumAccountSpendingParams{
+		AccountID:  account.ID,
+		DayStart:   dayStart,
+		MonthStart: monthStart,
+	})
+	if err != nil {
+		return SpendingAllowance{}, err
+	}
+	userSpending, err := q.SumUserSpending(ctx, SumUserSpendingParams{
+		Owner:      account.Owner,
+		Currency:   account.Currency,
+		DayStart:   dayStart,
+		MonthStart: monthStart,
+	})
+	if err != nil {
+		return SpendingAllowance{}, err
+	}
+
+	return SpendingAllowance{
+		AccountDaily:   newAllowance(limits.Account.Daily, accountSpending.DailyTotal),
+		AccountMonthly: newAllowance(limits.Account.Monthly, accountSpending.MonthlyTotal),
+		UserDaily:      newAllowance(limits.User.Daily, userSpending.DailyTotal),
+		UserMonthly:    newAllowance(limits.User.Monthly, userSpending.MonthlyTotal),
+	}, nil
+}
+
+func spendingLimits(ctx context.Context, q Querier, account Account, defaults SpendingLimits) (SpendingLimits, error) {
+	limits := defaults
+
+	accountLimit, err := q.GetAccountSpendingLimit(ctx, account.ID)
+	if err != nil && err != sql.ErrNoRows {
+		return limits, err
+	}
+	limits.Account = overrideSpendingLimit(limits.Account, accountLimit.DailyLimit, accountLimit.MonthlyLimit)
+
+	userLimit, err := q.GetUserSpendingLimit(ctx, GetUserSpendingLimitParams{
+		Username: account.Owner,
+		Currency: account.Currency,
+	})
+	if err != nil && err != sql.ErrNoRows {
+		return limits, err
+	}
+	limits.User = overrideSpendingLimit(limits.User, userLimit.DailyLimit, userLimit.MonthlyLimit)
+
+	return limits, nil
+}
+
+func overrideSpendingLimit(limit SpendingLimit, daily, monthly sql.NullInt64) SpendingLimit {
+	if daily.Valid {
+		limit.Daily = daily.Int64
+	}
+	if monthly.Valid {
+		limit.Monthly = monthly.Int64
+	}
+	return limit
+}
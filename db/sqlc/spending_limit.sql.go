@@ -0,0 +1,192 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.17.2
+// source: spending_limit.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const getAccountSpendingLimit = `-- name: GetAccountSpendingLimit :one
+SELECT account_id, daily_limit, monthly_limit, updated_at FROM account_spending_limits WHERE account_id = $1 LIMIT 1
+`
+
+func (q *Queries) GetAccountSpendingLimit(ctx context.Context, accountID int64) (AccountSpendingLimit, error) {
+	row := q.db.QueryRowContext(ctx, getAccountSpendingLimit, accountID)
+	var i AccountSpendingLimit
+	err := row.Scan(
+		&i.AccountID,
+		&i.DailyLimit,
+		&i.MonthlyLimit,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getUserSpendingLimit = `-- name: GetUserSpendingLimit :one
+SELECT username, currency, daily_limit, monthly_limit, updated_at FROM user_spending_limits WHERE username = $1 AND currency = $2 LIMIT 1
+`
+
+type GetUserSpendingLimitParams struct {
+	Username string `json:"username"`
+	Currency string `json:"currency"`
+}
+
+func (q *Queries) GetUserSpendingLimit(ctx context.Context, arg GetUserSpendingLimitParams) (UserSpendingLimit, error) {
+	row := q.db.QueryRowContext(ctx, getUserSpendingLimit, arg.Username, arg.Currency)
+	var i UserSpendingLimit
+	err := row.Scan(
+		&i.Username,
+		&i.Currency,
+		&i.DailyLimit,
+		&i.MonthlyLimit,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const setAccountSpendingLimit = `-- name: SetAccountSpendingLimit :one
+INSERT INTO account_spending_limits (
+    account_id,
+    daily_limit,
+    monthly_limit
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT (account_id) DO UPDATE
+SET daily_limit = EXCLUDED.daily_limit, monthly_limit = EXCLUDED.monthly_limit, updated_at = now()
+RETURNING account_id, daily_limit, monthly_limit, updated_at
+`
+
+type SetAccountSpendingLimitParams struct {
+	AccountID    int64         `json:"account_id"`
+	DailyLimit   sql.NullInt64 `json:"daily_limit"`
+	MonthlyLimit sql.NullInt64 `json:"monthly_limit"`
+}
+
+func (q *Queries) SetAccountSpendingLimit(ctx context.Context, arg SetAccountSpendingLimitParams) (AccountSpendingLimit, error) {
+	row := q.db.QueryRowContext(ctx, setAccountSpendingLimit, arg.AccountID, arg.DailyLimit, arg.MonthlyLimit)
+	var i AccountSpendingLimit
+	err := row.Scan(
+		&i.AccountID,
+		&i.DailyLimit,
+		&i.MonthlyLimit,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const setUserSpendingLimit = `-- name: SetUserSpendingLimit :one
+INSERT INTO user_spending_limits (
+    username,
+    currency,
+    daily_limit,
+    monthly_limit
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT (username, currency) DO UPDATE
+SET daily_limit = EXCLUDED.daily_limit, monthly_limit = EXCLUDED.monthly_limit, updated_at = now()
+RETURNING username, currency, daily_limit, monthly_limit, updated_at
+`
+
+type SetUserSpendingLimitParams struct {
+	Username     string        `json:"username"`
+	Currency     string        `json:"currency"`
+	DailyLimit   sql.NullInt64 `json:"daily_limit"`
+	MonthlyLimit sql.NullInt64 `json:"monthly_limit"`
+}
+
+func (q *Queries) SetUserSpendingLimit(ctx context.Context, arg SetUserSpendingLimitParams) (UserSpendingLimit, error) {
+	row := q.db.QueryRowContext(ctx, setUserSpendingLimit,
+		arg.Username,
+		arg.Currency,
+		arg.DailyLimit,
+		arg.MonthlyLimit,
+	)
+	var i UserSpendingLimit
+	err := row.Scan(
+		&i.Username,
+		&i.Currency,
+		&i.DailyLimit,
+		&i.MonthlyLimit,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const sumAccountSpending = `-- name: SumAccountSpending :one
+
+SELECT
+    COALESCE(sum(t.amount) FILTER (WHERE t.created_at >= $1), 0)::bigint AS daily_total,
+    COALESCE(sum(t.amount), 0)::bigint AS monthly_total
+FROM transfers t
+JOIN accounts fa ON fa.id = t.from_account_id
+JOIN accounts ta ON ta.id = t.to_account_id
+WHERE
+    t.from_account_id = $2
+    AND ta.owner <> fa.owner
+    AND t.created_at >= $3
+`
+
+type SumAccountSpendingParams struct {
+	DayStart   time.Time `json:"day_start"`
+	AccountID  int64     `json:"account_id"`
+	MonthStart time.Time `json:"month_start"`
+}
+
+type SumAccountSpendingRow struct {
+	DailyTotal   int64 `json:"daily_total"`
+	MonthlyTotal int64 `json:"monthly_total"`
+}
+
+// The spending of the rolling windows is the amount of the transfers sent since the start of the window.
+// The transfers to the accounts of the same owner move no money out, so they are not part of it.
+func (q *Queries) SumAccountSpending(ctx context.Context, arg SumAccountSpendingParams) (SumAccountSpendingRow, error) {
+	row := q.db.QueryRowContext(ctx, sumAccountSpending, arg.DayStart, arg.AccountID, arg.MonthStart)
+	var i SumAccountSpendingRow
+	err := row.Scan(&i.DailyTotal, &i.MonthlyTotal)
+	return i, err
+}
+
+const sumUserSpending = `-- name: SumUserSpending :one
+SELECT
+    COALESCE(sum(t.amount) FILTER (WHERE t.created_at >= $1), 0)::bigint AS daily_total,
+    COALESCE(sum(t.amount), 0)::bigint AS monthly_total
+FROM transfers t
+JOIN accounts fa ON fa.id = t.from_account_id
+JOIN accounts ta ON ta.id = t.to_account_id
+WHERE
+    fa.owner = $2
+    AND fa.currency = $3
+    AND ta.owner <> fa.owner
+    AND t.created_at >= $4
+`
+
+type SumUserSpendingParams struct {
+	DayStart   time.Time `json:"day_start"`
+	Owner      string    `json:"owner"`
+	Currency   string    `json:"currency"`
+	MonthStart time.Time `json:"month_start"`
+}
+
+type SumUserSpendingRow struct {
+	DailyTotal   int64 `json:"daily_total"`
+	MonthlyTotal int64 `json:"monthly_total"`
+}
+
+func (q *Queries) SumUserSpending(ctx context.Context, arg SumUserSpendingParams) (SumUserSpendingRow, error) {
+	row := q.db.QueryRowContext(ctx, sumUserSpending,
+		arg.DayStart,
+		arg.Owner,
+		arg.Currency,
+		arg.MonthStart,
+	)
+	var i SumUserSpendingRow
+	err := row.Scan(&i.DailyTotal, &i.MonthlyTotal)
+	return i, err
+}
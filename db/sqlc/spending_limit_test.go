@@ -0,0 +1,80 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/mativm02/bank_system/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransferTxSpendingLimit(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+	account3, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    account1.Owner,
+		Currency: account1.Currency,
+		Number:   util.RandomAccountNumber(),
+		Type:     util.SavingsAccount,
+	})
+	require.NoError(t, err)
+
+	limits := SpendingLimits{
+		Account: SpendingLimit{Daily: 100, Monthly: 1000},
+	}
+	transfer := func(from, to Account, amount int64) error {
+		_, err := store.TransferTx(context.Background(), TransferTxParams{
+			CreateTransferParams: CreateTransferParams{
+				FromAccountID: from.ID,
+				ToAccountID:   to.ID,
+				Amount:        amount,
+			},
+			Limits: limits,
+		})
+		return err
+	}
+
+	require.NoError(t, transfer(account1, account2, 60))
+
+	err = transfer(account1, account2, 50)
+	require.ErrorIs(t, err, ErrSpendingLimitExceeded)
+
+	// The transfers between the accounts of the same owner are not limited and do not count.
+	require.NoError(t, transfer(account1, account3, 500))
+
+	allowance, err := GetSpendingAllowance(context.Background(), store, account1, limits)
+	require.NoError(t, err)
+	require.Equal(t, Allowance{Limit: 100, Spent: 60, Remaining: 40}, allowance.AccountDaily)
+	require.Equal(t, Allowance{Limit: 1000, Spent: 60, Remaining: 940}, allowance.AccountMonthly)
+	require.Equal(t, Allowance{Spent: 60}, allowance.UserDaily)
+
+	// An admin lifts the daily limit of the account and keeps the default monthly limit.
+	_, err = store.SetAccountSpendingLimit(context.Background(), SetAccountSpendingLimitParams{
+		AccountID:  account1.ID,
+		DailyLimit: sql.NullInt64{Int64: 0, Valid: true},
+	})
+	require.NoError(t, err)
+	require.NoError(t, transfer(account1, account2, 50))
+
+	err = transfer(account1, account2, 1000)
+	require.ErrorIs(t, err, ErrSpendingLimitExceeded)
+
+	// The user limit covers all the accounts of the owner in the currency.
+	_, err = store.SetUserSpendingLimit(context.Background(), SetUserSpendingLimitParams{
+		Username:   account1.Owner,
+		Currency:   account1.Currency,
+		DailyLimit: sql.NullInt64{Int64: 150, Valid: true},
+	})
+	require.NoError(t, err)
+
+	err = transfer(account3, account2, 50)
+	require.ErrorIs(t, err, ErrSpendingLimitExceeded)
+	require.NoError(t, transfer(account3, account2, 40))
+
+	allowance, err = GetSpendingAllowance(context.Background(), store, account3, limits)
+	require.NoError(t, err)
+	require.Equal(t, Allowance{Limit: 150, Spent: 150}, allowance.UserDaily)
+}
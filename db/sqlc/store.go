@@ -8,9 +8,17 @@ import (
 
 // Store provides all functions to execute database queries.
 type Store interface {
-	TransferTx(ctx context.Context, arg CreateTransferParams) (TransferTxResults, error)
+	TransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResults, error)
+	CreateAccountTx(ctx context.Context, arg CreateAccountTxParams) (CreateAccountTxResult, error)
 	CreateUserTx(ctx context.Context, arg CreateUserTxParams) (CreateUserTxResult, error)
 	VerifyEmailTx(ctx context.Context, arg VerifyEmailTxParams) (VerifyEmailTxResult, error)
+	ResendVerifyEmailTx(ctx context.Context, arg ResendVerifyEmailTxParams) (ResendVerifyEmailTxResult, error)
+	UpdateUserTx(ctx context.Context, arg UpdateUserTxParams) (UpdateUserTxResult, error)
+	RelayOutbox(ctx context.Context, arg RelayOutboxParams) (RelayOutboxResult, error)
+	RelayDomainEvents(ctx context.Context, arg RelayDomainEventsParams) (RelayDomainEventsResult, error)
+	PostInterestTx(ctx context.Context, arg PostInterestTxParams) (PostInterestTxResult, error)
+	ListAccounts(ctx context.Context, arg ListAccountsParams) ([]Account, error)
+	ListTransfers(ctx context.Context, arg ListTransfersParams) ([]Transfer, error)
 	Querier
 }
 
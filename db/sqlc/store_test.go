@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/mativm02/bank_system/util"
 	"github.com/stretchr/testify/require"
 )
 
@@ -24,10 +25,12 @@ func TestTransferTx(t *testing.T) {
 
 	for i := 0; i < n; i++ {
 		go func() {
-			result, err := store.TransferTx(context.Background(), CreateTransferParams{
-				FromAccountID: account1.ID,
-				ToAccountID:   account2.ID,
-				Amount:        amount,
+			result, err := store.TransferTx(context.Background(), TransferTxParams{
+				CreateTransferParams: CreateTransferParams{
+					FromAccountID: account1.ID,
+					ToAccountID:   account2.ID,
+					Amount:        amount,
+				},
 			})
 
 			errs <- err
@@ -56,6 +59,7 @@ func TestTransferTx(t *testing.T) {
 		require.NotEmpty(t, fromEntry)
 		require.Equal(t, account1.ID, fromEntry.AccountID)
 		require.Equal(t, -amount, fromEntry.Amount)
+		require.Equal(t, transfer.ID, fromEntry.TransferID.Int64)
 		require.NotZero(t, fromEntry.ID)
 		require.NotZero(t, fromEntry.CreatedAt)
 
@@ -63,6 +67,7 @@ func TestTransferTx(t *testing.T) {
 		require.NotEmpty(t, toEntry)
 		require.Equal(t, account2.ID, toEntry.AccountID)
 		require.Equal(t, amount, toEntry.Amount)
+		require.Equal(t, transfer.ID, toEntry.TransferID.Int64)
 		require.NotZero(t, toEntry.ID)
 		require.NotZero(t, toEntry.CreatedAt)
 
@@ -121,10 +126,12 @@ func TestTransferTxDeadlock(t *testing.T) {
 		}
 
 		go func() {
-			_, err := store.TransferTx(context.Background(), CreateTransferParams{
-				FromAccountID: fromAccountID,
-				ToAccountID:   toAccountID,
-				Amount:        amount,
+			_, err := store.TransferTx(context.Background(), TransferTxParams{
+				CreateTransferParams: CreateTransferParams{
+					FromAccountID: fromAccountID,
+					ToAccountID:   toAccountID,
+					Amount:        amount,
+				},
 			})
 
 			errs <- err
@@ -146,3 +153,73 @@ func TestTransferTxDeadlock(t *testing.T) {
 	require.Equal(t, account1.Balance, updatedAccount1.Balance)
 	require.Equal(t, account2.Balance, updatedAccount2.Balance)
 }
+
+func TestTransferTxFee(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+
+	// The tier starts above the amounts of the other tests, so they stay free.
+	schedule, err := testQueries.CreateFeeSchedule(context.Background(), CreateFeeScheduleParams{
+		Currency:      account1.Currency,
+		MinAmount:     util.RandomInt(1000000000, 2000000000),
+		FlatFee:       5,
+		PercentageBps: 150,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, testQueries.DeleteFeeSchedule(context.Background(), schedule.ID))
+	})
+
+	feeAccount, err := testQueries.GetSystemAccount(context.Background(), GetSystemAccountParams{
+		Owner:    SystemUsername,
+		Currency: account1.Currency,
+		Nickname: FeeAccountNickname,
+	})
+	require.NoError(t, err)
+
+	amount := schedule.MinAmount + 1234
+	fee := schedule.Fee(amount)
+	require.Equal(t, 5+amount*150/10000, fee)
+
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		CreateTransferParams: CreateTransferParams{
+			FromAccountID: account1.ID,
+			ToAccountID:   account2.ID,
+			Amount:        amount,
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, fee, result.Fee)
+	require.Equal(t, account1.ID, result.FeeEntry.AccountID)
+	require.Equal(t, -fee, result.FeeEntry.Amount)
+	require.Equal(t, EntryTypeFee, result.FeeEntry.Type)
+	require.Equal(t, result.Transfer.ID, result.FeeEntry.TransferID.Int64)
+	require.Equal(t, account1.Balance-amount-fee, result.FromAccount.Balance)
+	require.Equal(t, account2.Balance+amount, result.ToAccount.Balance)
+
+	updatedFeeAccount, err := testQueries.GetAccount(context.Background(), feeAccount.ID)
+	require.NoError(t, err)
+	require.Equal(t, feeAccount.Balance+fee, updatedFeeAccount.Balance)
+
+	// The transfers between the accounts of the same owner are free.
+	account3, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    account1.Owner,
+		Currency: account1.Currency,
+		Number:   util.RandomAccountNumber(),
+		Type:     util.SavingsAccount,
+	})
+	require.NoError(t, err)
+
+	result, err = store.TransferTx(context.Background(), TransferTxParams{
+		CreateTransferParams: CreateTransferParams{
+			FromAccountID: account1.ID,
+			ToAccountID:   account3.ID,
+			Amount:        amount,
+		},
+	})
+	require.NoError(t, err)
+	require.Zero(t, result.Fee)
+	require.Empty(t, result.FeeEntry)
+}
@@ -7,6 +7,8 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"time"
 )
 
 const createTransfer = `-- name: CreateTransfer :one
@@ -38,6 +40,36 @@ func (q *Queries) CreateTransfer(ctx context.Context, arg CreateTransferParams)
 	return i, err
 }
 
+const getAccountPayments = `-- name: GetAccountPayments :one
+SELECT
+    COALESCE(min(t.created_at), now())::timestamptz AS first_paid_at,
+    COALESCE(sum(t.amount), 0)::bigint AS total
+FROM transfers t
+JOIN accounts fa ON fa.id = t.from_account_id
+WHERE
+    t.to_account_id = $1
+    AND fa.owner = $2
+`
+
+type GetAccountPaymentsParams struct {
+	ToAccountID int64  `json:"to_account_id"`
+	Owner       string `json:"owner"`
+}
+
+type GetAccountPaymentsRow struct {
+	FirstPaidAt time.Time `json:"first_paid_at"`
+	Total       int64     `json:"total"`
+}
+
+// Sums what the owner sent to the account from any of their accounts and returns when they first paid it,
+// or now if they never did.
+func (q *Queries) GetAccountPayments(ctx context.Context, arg GetAccountPaymentsParams) (GetAccountPaymentsRow, error) {
+	row := q.db.QueryRowContext(ctx, getAccountPayments, arg.ToAccountID, arg.Owner)
+	var i GetAccountPaymentsRow
+	err := row.Scan(&i.FirstPaidAt, &i.Total)
+	return i, err
+}
+
 const getTransfer = `-- name: GetTransfer :one
 SELECT id, from_account_id, to_account_id, amount, created_at FROM transfers
 WHERE id = $1 LIMIT 1
@@ -56,29 +88,384 @@ func (q *Queries) GetTransfer(ctx context.Context, id int64) (Transfer, error) {
 	return i, err
 }
 
-const listTransfers = `-- name: ListTransfers :many
-SELECT id, from_account_id, to_account_id, amount, created_at FROM transfers
-WHERE 
-    from_account_id = $1 OR
-    to_account_id = $2
-ORDER BY id
-LIMIT $3
-OFFSET $4
+const listTransfersByAmount = `-- name: ListTransfersByAmount :many
+WITH owned AS (
+    SELECT id FROM accounts
+    WHERE
+        owner = $2
+        AND ($3::varchar IS NULL OR currency = $3)
+)
+(
+    SELECT id, from_account_id, to_account_id, amount, created_at FROM transfers
+    WHERE
+        from_account_id IN (SELECT id FROM owned)
+        AND (
+            $4::bigint IS NULL
+            OR to_account_id = $4
+            OR (from_account_id = $4 AND to_account_id IN (SELECT id FROM owned))
+        )
+        AND ($5::bigint IS NULL OR from_account_id = $5 OR to_account_id = $5)
+        AND ($6::timestamptz IS NULL OR created_at >= $6)
+        AND ($7::timestamptz IS NULL OR created_at < $7)
+        AND ($8::bigint IS NULL OR amount >= $8)
+        AND ($9::bigint IS NULL OR amount <= $9)
+        AND ($10::bigint IS NULL OR (amount, id) > ($11::bigint, $10::bigint))
+    ORDER BY amount, id
+    LIMIT $1
+)
+UNION ALL
+(
+    SELECT id, from_account_id, to_account_id, amount, created_at FROM transfers
+    WHERE
+        to_account_id IN (SELECT id FROM owned)
+        AND from_account_id NOT IN (SELECT id FROM owned)
+        AND ($4::bigint IS NULL OR from_account_id = $4)
+        AND ($5::bigint IS NULL OR from_account_id = $5 OR to_account_id = $5)
+        AND ($6::timestamptz IS NULL OR created_at >= $6)
+        AND ($7::timestamptz IS NULL OR created_at < $7)
+        AND ($8::bigint IS NULL OR amount >= $8)
+        AND ($9::bigint IS NULL OR amount <= $9)
+        AND ($10::bigint IS NULL OR (amount, id) > ($11::bigint, $10::bigint))
+    ORDER BY amount, id
+    LIMIT $1
+)
+ORDER BY amount, id
+LIMIT $1
 `
 
-type ListTransfersParams struct {
-	FromAccountID int64 `json:"from_account_id"`
-	ToAccountID   int64 `json:"to_account_id"`
-	Limit         int32 `json:"limit"`
-	Offset        int32 `json:"offset"`
+type ListTransfersByAmountParams struct {
+	PageSize              int32          `json:"page_size"`
+	Owner                 string         `json:"owner"`
+	Currency              sql.NullString `json:"currency"`
+	CounterpartyAccountID sql.NullInt64  `json:"counterparty_account_id"`
+	AccountID             sql.NullInt64  `json:"account_id"`
+	StartTime             sql.NullTime   `json:"start_time"`
+	EndTime               sql.NullTime   `json:"end_time"`
+	MinAmount             sql.NullInt64  `json:"min_amount"`
+	MaxAmount             sql.NullInt64  `json:"max_amount"`
+	CursorID              sql.NullInt64  `json:"cursor_id"`
+	CursorAmount          sql.NullInt64  `json:"cursor_amount"`
+}
+
+func (q *Queries) ListTransfersByAmount(ctx context.Context, arg ListTransfersByAmountParams) ([]Transfer, error) {
+	rows, err := q.db.QueryContext(ctx, listTransfersByAmount,
+		arg.PageSize,
+		arg.Owner,
+		arg.Currency,
+		arg.CounterpartyAccountID,
+		arg.AccountID,
+		arg.StartTime,
+		arg.EndTime,
+		arg.MinAmount,
+		arg.MaxAmount,
+		arg.CursorID,
+		arg.CursorAmount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Transfer{}
+	for rows.Next() {
+		var i Transfer
+		if err := rows.Scan(
+			&i.ID,
+			&i.FromAccountID,
+			&i.ToAccountID,
+			&i.Amount,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTransfersByAmountDesc = `-- name: ListTransfersByAmountDesc :many
+WITH owned AS (
+    SELECT id FROM accounts
+    WHERE
+        owner = $2
+        AND ($3::varchar IS NULL OR currency = $3)
+)
+(
+    SELECT id, from_account_id, to_account_id, amount, created_at FROM transfers
+    WHERE
+        from_account_id IN (SELECT id FROM owned)
+        AND (
+            $4::bigint IS NULL
+            OR to_account_id = $4
+            OR (from_account_id = $4 AND to_account_id IN (SELECT id FROM owned))
+        )
+        AND ($5::bigint IS NULL OR from_account_id = $5 OR to_account_id = $5)
+        AND ($6::timestamptz IS NULL OR created_at >= $6)
+        AND ($7::timestamptz IS NULL OR created_at < $7)
+        AND ($8::bigint IS NULL OR amount >= $8)
+        AND ($9::bigint IS NULL OR amount <= $9)
+        AND ($10::bigint IS NULL OR (amount, id) < ($11::bigint, $10::bigint))
+    ORDER BY amount DESC, id DESC
+    LIMIT $1
+)
+UNION ALL
+(
+    SELECT id, from_account_id, to_account_id, amount, created_at FROM transfers
+    WHERE
+        to_account_id IN (SELECT id FROM owned)
+        AND from_account_id NOT IN (SELECT id FROM owned)
+        AND ($4::bigint IS NULL OR from_account_id = $4)
+        AND ($5::bigint IS NULL OR from_account_id = $5 OR to_account_id = $5)
+        AND ($6::timestamptz IS NULL OR created_at >= $6)
+        AND ($7::timestamptz IS NULL OR created_at < $7)
+        AND ($8::bigint IS NULL OR amount >= $8)
+        AND ($9::bigint IS NULL OR amount <= $9)
+        AND ($10::bigint IS NULL OR (amount, id) < ($11::bigint, $10::bigint))
+    ORDER BY amount DESC, id DESC
+    LIMIT $1
+)
+ORDER BY amount DESC, id DESC
+LIMIT $1
+`
+
+type ListTransfersByAmountDescParams struct {
+	PageSize              int32          `json:"page_size"`
+	Owner                 string         `json:"owner"`
+	Currency              sql.NullString `json:"currency"`
+	CounterpartyAccountID sql.NullInt64  `json:"counterparty_account_id"`
+	AccountID             sql.NullInt64  `json:"account_id"`
+	StartTime             sql.NullTime   `json:"start_time"`
+	EndTime               sql.NullTime   `json:"end_time"`
+	MinAmount             sql.NullInt64  `json:"min_amount"`
+	MaxAmount             sql.NullInt64  `json:"max_amount"`
+	CursorID              sql.NullInt64  `json:"cursor_id"`
+	CursorAmount          sql.NullInt64  `json:"cursor_amount"`
+}
+
+func (q *Queries) ListTransfersByAmountDesc(ctx context.Context, arg ListTransfersByAmountDescParams) ([]Transfer, error) {
+	rows, err := q.db.QueryContext(ctx, listTransfersByAmountDesc,
+		arg.PageSize,
+		arg.Owner,
+		arg.Currency,
+		arg.CounterpartyAccountID,
+		arg.AccountID,
+		arg.StartTime,
+		arg.EndTime,
+		arg.MinAmount,
+		arg.MaxAmount,
+		arg.CursorID,
+		arg.CursorAmount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Transfer{}
+	for rows.Next() {
+		var i Transfer
+		if err := rows.Scan(
+			&i.ID,
+			&i.FromAccountID,
+			&i.ToAccountID,
+			&i.Amount,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTransfersByCreatedAt = `-- name: ListTransfersByCreatedAt :many
+WITH owned AS (
+    SELECT id FROM accounts
+    WHERE
+        owner = $2
+        AND ($3::varchar IS NULL OR currency = $3)
+)
+(
+    SELECT id, from_account_id, to_account_id, amount, created_at FROM transfers
+    WHERE
+        from_account_id IN (SELECT id FROM owned)
+        AND (
+            $4::bigint IS NULL
+            OR to_account_id = $4
+            OR (from_account_id = $4 AND to_account_id IN (SELECT id FROM owned))
+        )
+        AND ($5::bigint IS NULL OR from_account_id = $5 OR to_account_id = $5)
+        AND ($6::timestamptz IS NULL OR created_at >= $6)
+        AND ($7::timestamptz IS NULL OR created_at < $7)
+        AND ($8::bigint IS NULL OR amount >= $8)
+        AND ($9::bigint IS NULL OR amount <= $9)
+        AND ($10::bigint IS NULL OR (created_at, id) > ($11::timestamptz, $10::bigint))
+    ORDER BY created_at, id
+    LIMIT $1
+)
+UNION ALL
+(
+    SELECT id, from_account_id, to_account_id, amount, created_at FROM transfers
+    WHERE
+        to_account_id IN (SELECT id FROM owned)
+        AND from_account_id NOT IN (SELECT id FROM owned)
+        AND ($4::bigint IS NULL OR from_account_id = $4)
+        AND ($5::bigint IS NULL OR from_account_id = $5 OR to_account_id = $5)
+        AND ($6::timestamptz IS NULL OR created_at >= $6)
+        AND ($7::timestamptz IS NULL OR created_at < $7)
+        AND ($8::bigint IS NULL OR amount >= $8)
+        AND ($9::bigint IS NULL OR amount <= $9)
+        AND ($10::bigint IS NULL OR (created_at, id) > ($11::timestamptz, $10::bigint))
+    ORDER BY created_at, id
+    LIMIT $1
+)
+ORDER BY created_at, id
+LIMIT $1
+`
+
+type ListTransfersByCreatedAtParams struct {
+	PageSize              int32          `json:"page_size"`
+	Owner                 string         `json:"owner"`
+	Currency              sql.NullString `json:"currency"`
+	CounterpartyAccountID sql.NullInt64  `json:"counterparty_account_id"`
+	AccountID             sql.NullInt64  `json:"account_id"`
+	StartTime             sql.NullTime   `json:"start_time"`
+	EndTime               sql.NullTime   `json:"end_time"`
+	MinAmount             sql.NullInt64  `json:"min_amount"`
+	MaxAmount             sql.NullInt64  `json:"max_amount"`
+	CursorID              sql.NullInt64  `json:"cursor_id"`
+	CursorCreatedAt       sql.NullTime   `json:"cursor_created_at"`
+}
+
+// The transfers from or to the accounts of the owner are listed by ListTransfers, with one
+// query per sort order so that the order and the cursor are plain columns. Keyset pagination:
+// the page starts after the cursor, the sort value and the ID of the last transfer of the
+// previous page. The transfers sent by the accounts of the owner and the ones they received
+// from other users are read separately, each through an index on the account and the sort
+// order, and merged. Both accounts of a transfer have the same currency.
+func (q *Queries) ListTransfersByCreatedAt(ctx context.Context, arg ListTransfersByCreatedAtParams) ([]Transfer, error) {
+	rows, err := q.db.QueryContext(ctx, listTransfersByCreatedAt,
+		arg.PageSize,
+		arg.Owner,
+		arg.Currency,
+		arg.CounterpartyAccountID,
+		arg.AccountID,
+		arg.StartTime,
+		arg.EndTime,
+		arg.MinAmount,
+		arg.MaxAmount,
+		arg.CursorID,
+		arg.CursorCreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Transfer{}
+	for rows.Next() {
+		var i Transfer
+		if err := rows.Scan(
+			&i.ID,
+			&i.FromAccountID,
+			&i.ToAccountID,
+			&i.Amount,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTransfersByCreatedAtDesc = `-- name: ListTransfersByCreatedAtDesc :many
+WITH owned AS (
+    SELECT id FROM accounts
+    WHERE
+        owner = $2
+        AND ($3::varchar IS NULL OR currency = $3)
+)
+(
+    SELECT id, from_account_id, to_account_id, amount, created_at FROM transfers
+    WHERE
+        from_account_id IN (SELECT id FROM owned)
+        AND (
+            $4::bigint IS NULL
+            OR to_account_id = $4
+            OR (from_account_id = $4 AND to_account_id IN (SELECT id FROM owned))
+        )
+        AND ($5::bigint IS NULL OR from_account_id = $5 OR to_account_id = $5)
+        AND ($6::timestamptz IS NULL OR created_at >= $6)
+        AND ($7::timestamptz IS NULL OR created_at < $7)
+        AND ($8::bigint IS NULL OR amount >= $8)
+        AND ($9::bigint IS NULL OR amount <= $9)
+        AND ($10::bigint IS NULL OR (created_at, id) < ($11::timestamptz, $10::bigint))
+    ORDER BY created_at DESC, id DESC
+    LIMIT $1
+)
+UNION ALL
+(
+    SELECT id, from_account_id, to_account_id, amount, created_at FROM transfers
+    WHERE
+        to_account_id IN (SELECT id FROM owned)
+        AND from_account_id NOT IN (SELECT id FROM owned)
+        AND ($4::bigint IS NULL OR from_account_id = $4)
+        AND ($5::bigint IS NULL OR from_account_id = $5 OR to_account_id = $5)
+        AND ($6::timestamptz IS NULL OR created_at >= $6)
+        AND ($7::timestamptz IS NULL OR created_at < $7)
+        AND ($8::bigint IS NULL OR amount >= $8)
+        AND ($9::bigint IS NULL OR amount <= $9)
+        AND ($10::bigint IS NULL OR (created_at, id) < ($11::timestamptz, $10::bigint))
+    ORDER BY created_at DESC, id DESC
+    LIMIT $1
+)
+ORDER BY created_at DESC, id DESC
+LIMIT $1
+`
+
+type ListTransfersByCreatedAtDescParams struct {
+	PageSize              int32          `json:"page_size"`
+	Owner                 string         `json:"owner"`
+	Currency              sql.NullString `json:"currency"`
+	CounterpartyAccountID sql.NullInt64  `json:"counterparty_account_id"`
+	AccountID             sql.NullInt64  `json:"account_id"`
+	StartTime             sql.NullTime   `json:"start_time"`
+	EndTime               sql.NullTime   `json:"end_time"`
+	MinAmount             sql.NullInt64  `json:"min_amount"`
+	MaxAmount             sql.NullInt64  `json:"max_amount"`
+	CursorID              sql.NullInt64  `json:"cursor_id"`
+	CursorCreatedAt       sql.NullTime   `json:"cursor_created_at"`
 }
 
-func (q *Queries) ListTransfers(ctx context.Context, arg ListTransfersParams) ([]Transfer, error) {
-	rows, err := q.db.QueryContext(ctx, listTransfers,
-		arg.FromAccountID,
-		arg.ToAccountID,
-		arg.Limit,
-		arg.Offset,
+func (q *Queries) ListTransfersByCreatedAtDesc(ctx context.Context, arg ListTransfersByCreatedAtDescParams) ([]Transfer, error) {
+	rows, err := q.db.QueryContext(ctx, listTransfersByCreatedAtDesc,
+		arg.PageSize,
+		arg.Owner,
+		arg.Currency,
+		arg.CounterpartyAccountID,
+		arg.AccountID,
+		arg.StartTime,
+		arg.EndTime,
+		arg.MinAmount,
+		arg.MaxAmount,
+		arg.CursorID,
+		arg.CursorCreatedAt,
 	)
 	if err != nil {
 		return nil, err
@@ -106,3 +493,241 @@ func (q *Queries) ListTransfers(ctx context.Context, arg ListTransfersParams) ([
 	}
 	return items, nil
 }
+
+const sumTransfersByCounterparty = `-- name: SumTransfersByCounterparty :many
+
+SELECT
+    CASE WHEN e.account_id = t.from_account_id THEN t.to_account_id ELSE t.from_account_id END::bigint AS counterparty_account_id,
+    CASE WHEN e.amount < 0 THEN 'sent' ELSE 'received' END::varchar AS direction,
+    a.currency,
+    count(*) AS transfer_count,
+    sum(abs(e.amount))::bigint AS total_amount
+FROM entries e
+JOIN transfers t ON t.id = e.transfer_id
+JOIN accounts a ON a.id = e.account_id
+WHERE
+    a.owner = $1
+    AND e.type = 'transfer'
+    AND e.created_at >= $2
+    AND e.created_at < $3
+    AND ($4::bigint IS NULL OR e.account_id = $4)
+    AND ($5::bigint IS NULL OR CASE WHEN e.account_id = t.from_account_id THEN t.to_account_id ELSE t.from_account_id END = $5)
+    AND ($6::varchar IS NULL OR a.currency = $6)
+    AND ($7::varchar IS NULL OR ($7 = 'sent') = (e.amount < 0))
+GROUP BY 1, 2, a.currency
+ORDER BY total_amount DESC, counterparty_account_id, direction
+LIMIT $8
+`
+
+type SumTransfersByCounterpartyParams struct {
+	Owner                 string         `json:"owner"`
+	StartTime             time.Time      `json:"start_time"`
+	EndTime               time.Time      `json:"end_time"`
+	AccountID             sql.NullInt64  `json:"account_id"`
+	CounterpartyAccountID sql.NullInt64  `json:"counterparty_account_id"`
+	Currency              sql.NullString `json:"currency"`
+	Direction             sql.NullString `json:"direction"`
+	MaxGroups             int32          `json:"max_groups"`
+}
+
+type SumTransfersByCounterpartyRow struct {
+	CounterpartyAccountID int64  `json:"counterparty_account_id"`
+	Direction             string `json:"direction"`
+	Currency              string `json:"currency"`
+	TransferCount         int64  `json:"transfer_count"`
+	TotalAmount           int64  `json:"total_amount"`
+}
+
+// The transfer search aggregates the entries of the transfers, which are the movements of the
+// accounts of the owner: a transfer between two accounts of the owner is both sent and received.
+// The fees of the transfers are not part of the totals.
+func (q *Queries) SumTransfersByCounterparty(ctx context.Context, arg SumTransfersByCounterpartyParams) ([]SumTransfersByCounterpartyRow, error) {
+	rows, err := q.db.QueryContext(ctx, sumTransfersByCounterparty,
+		arg.Owner,
+		arg.StartTime,
+		arg.EndTime,
+		arg.AccountID,
+		arg.CounterpartyAccountID,
+		arg.Currency,
+		arg.Direction,
+		arg.MaxGroups,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SumTransfersByCounterpartyRow{}
+	for rows.Next() {
+		var i SumTransfersByCounterpartyRow
+		if err := rows.Scan(
+			&i.CounterpartyAccountID,
+			&i.Direction,
+			&i.Currency,
+			&i.TransferCount,
+			&i.TotalAmount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const sumTransfersByDirection = `-- name: SumTransfersByDirection :many
+SELECT
+    CASE WHEN e.amount < 0 THEN 'sent' ELSE 'received' END::varchar AS direction,
+    a.currency,
+    count(*) AS transfer_count,
+    sum(abs(e.amount))::bigint AS total_amount
+FROM entries e
+JOIN transfers t ON t.id = e.transfer_id
+JOIN accounts a ON a.id = e.account_id
+WHERE
+    a.owner = $1
+    AND e.type = 'transfer'
+    AND e.created_at >= $2
+    AND e.created_at < $3
+    AND ($4::bigint IS NULL OR e.account_id = $4)
+    AND ($5::bigint IS NULL OR CASE WHEN e.account_id = t.from_account_id THEN t.to_account_id ELSE t.from_account_id END = $5)
+    AND ($6::varchar IS NULL OR a.currency = $6)
+    AND ($7::varchar IS NULL OR ($7 = 'sent') = (e.amount < 0))
+GROUP BY 1, a.currency
+ORDER BY direction, a.currency
+`
+
+type SumTransfersByDirectionParams struct {
+	Owner                 string         `json:"owner"`
+	StartTime             time.Time      `json:"start_time"`
+	EndTime               time.Time      `json:"end_time"`
+	AccountID             sql.NullInt64  `json:"account_id"`
+	CounterpartyAccountID sql.NullInt64  `json:"counterparty_account_id"`
+	Currency              sql.NullString `json:"currency"`
+	Direction             sql.NullString `json:"direction"`
+}
+
+type SumTransfersByDirectionRow struct {
+	Direction     string `json:"direction"`
+	Currency      string `json:"currency"`
+	TransferCount int64  `json:"transfer_count"`
+	TotalAmount   int64  `json:"total_amount"`
+}
+
+func (q *Queries) SumTransfersByDirection(ctx context.Context, arg SumTransfersByDirectionParams) ([]SumTransfersByDirectionRow, error) {
+	rows, err := q.db.QueryContext(ctx, sumTransfersByDirection,
+		arg.Owner,
+		arg.StartTime,
+		arg.EndTime,
+		arg.AccountID,
+		arg.CounterpartyAccountID,
+		arg.Currency,
+		arg.Direction,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SumTransfersByDirectionRow{}
+	for rows.Next() {
+		var i SumTransfersByDirectionRow
+		if err := rows.Scan(
+			&i.Direction,
+			&i.Currency,
+			&i.TransferCount,
+			&i.TotalAmount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const sumTransfersByMonth = `-- name: SumTransfersByMonth :many
+SELECT
+    (date_trunc('month', e.created_at AT TIME ZONE 'UTC') AT TIME ZONE 'UTC')::timestamptz AS month,
+    CASE WHEN e.amount < 0 THEN 'sent' ELSE 'received' END::varchar AS direction,
+    a.currency,
+    count(*) AS transfer_count,
+    sum(abs(e.amount))::bigint AS total_amount
+FROM entries e
+JOIN transfers t ON t.id = e.transfer_id
+JOIN accounts a ON a.id = e.account_id
+WHERE
+    a.owner = $1
+    AND e.type = 'transfer'
+    AND e.created_at >= $2
+    AND e.created_at < $3
+    AND ($4::bigint IS NULL OR e.account_id = $4)
+    AND ($5::bigint IS NULL OR CASE WHEN e.account_id = t.from_account_id THEN t.to_account_id ELSE t.from_account_id END = $5)
+    AND ($6::varchar IS NULL OR a.currency = $6)
+    AND ($7::varchar IS NULL OR ($7 = 'sent') = (e.amount < 0))
+GROUP BY 1, 2, a.currency
+ORDER BY month, direction, a.currency
+`
+
+type SumTransfersByMonthParams struct {
+	Owner                 string         `json:"owner"`
+	StartTime             time.Time      `json:"start_time"`
+	EndTime               time.Time      `json:"end_time"`
+	AccountID             sql.NullInt64  `json:"account_id"`
+	CounterpartyAccountID sql.NullInt64  `json:"counterparty_account_id"`
+	Currency              sql.NullString `json:"currency"`
+	Direction             sql.NullString `json:"direction"`
+}
+
+type SumTransfersByMonthRow struct {
+	Month         time.Time `json:"month"`
+	Direction     string    `json:"direction"`
+	Currency      string    `json:"currency"`
+	TransferCount int64     `json:"transfer_count"`
+	TotalAmount   int64     `json:"total_amount"`
+}
+
+func (q *Queries) SumTransfersByMonth(ctx context.Context, arg SumTransfersByMonthParams) ([]SumTransfersByMonthRow, error) {
+	rows, err := q.db.QueryContext(ctx, sumTransfersByMonth,
+		arg.Owner,
+		arg.StartTime,
+		arg.EndTime,
+		arg.AccountID,
+		arg.CounterpartyAccountID,
+		arg.Currency,
+		arg.Direction,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SumTransfersByMonthRow{}
+	for rows.Next() {
+		var i SumTransfersByMonthRow
+		if err := rows.Scan(
+			&i.Month,
+			&i.Direction,
+			&i.Currency,
+			&i.TransferCount,
+			&i.TotalAmount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,8 @@
+package db
+
+// Directions of the transfers aggregated by the SumTransfers queries,
+// from the point of view of the owner of the accounts.
+const (
+	DirectionSent     = "sent"
+	DirectionReceived = "received"
+)
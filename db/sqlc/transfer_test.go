@@ -2,6 +2,7 @@ package db
 
 import (
 	"context"
+	"database/sql"
 	"testing"
 	"time"
 
@@ -57,17 +58,144 @@ func TestListTransfers(t *testing.T) {
 	}
 
 	arg := ListTransfersParams{
-		FromAccountID: account1.ID,
-		ToAccountID:   account2.ID,
-		Limit:         5,
-		Offset:        5,
+		Owner:    account1.Owner,
+		OrderBy:  OrderByCreatedAt,
+		SortDesc: true,
+		PageSize: 5,
 	}
 
-	transfers, err := testQueries.ListTransfers(context.Background(), arg)
+	firstPage, err := testQueries.ListTransfers(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, firstPage, 5)
+
+	last := firstPage[len(firstPage)-1]
+	arg.CursorValue = sql.NullInt64{Int64: last.SortValue(arg.OrderBy), Valid: true}
+	arg.CursorID = sql.NullInt64{Int64: last.ID, Valid: true}
+
+	secondPage, err := testQueries.ListTransfers(context.Background(), arg)
 	require.NoError(t, err)
-	require.Len(t, transfers, 5)
+	require.Len(t, secondPage, 5)
 
-	for _, transfer := range transfers {
+	for _, transfer := range append(firstPage, secondPage...) {
 		require.NotEmpty(t, transfer)
+		require.Equal(t, account1.ID, transfer.FromAccountID)
+		require.Equal(t, account2.ID, transfer.ToAccountID)
+	}
+	require.NotEqual(t, firstPage[0].ID, secondPage[0].ID)
+	require.True(t, last.ID > secondPage[0].ID)
+}
+
+func TestListTransfersByAmount(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+	for i := 0; i < 6; i++ {
+		createRandomTransfer(t, account1, account2)
+	}
+
+	arg := ListTransfersParams{
+		Owner:    account1.Owner,
+		OrderBy:  OrderByAmount,
+		PageSize: 3,
+	}
+
+	firstPage, err := testQueries.ListTransfers(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, firstPage, 3)
+
+	last := firstPage[len(firstPage)-1]
+	arg.CursorValue = sql.NullInt64{Int64: last.SortValue(arg.OrderBy), Valid: true}
+	arg.CursorID = sql.NullInt64{Int64: last.ID, Valid: true}
+
+	secondPage, err := testQueries.ListTransfers(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, secondPage, 3)
+
+	transfers := append(firstPage, secondPage...)
+	for i := 1; i < len(transfers); i++ {
+		previous, transfer := transfers[i-1], transfers[i]
+		require.True(t, previous.Amount < transfer.Amount || (previous.Amount == transfer.Amount && previous.ID < transfer.ID))
+	}
+}
+
+func TestListTransfersFilters(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+	account3 := createRandomAccount(t)
+	transfer := createRandomTransfer(t, account1, account2)
+	createRandomTransfer(t, account1, account3)
+
+	transfers, err := testQueries.ListTransfers(context.Background(), ListTransfersParams{
+		Owner:                 account1.Owner,
+		CounterpartyAccountID: sql.NullInt64{Int64: account2.ID, Valid: true},
+		MinAmount:             sql.NullInt64{Int64: transfer.Amount, Valid: true},
+		MaxAmount:             sql.NullInt64{Int64: transfer.Amount, Valid: true},
+		OrderBy:               OrderByAmount,
+		PageSize:              5,
+	})
+	require.NoError(t, err)
+	require.Len(t, transfers, 1)
+	require.Equal(t, transfer.ID, transfers[0].ID)
+
+	// The transfers between the accounts of other owners are not listed.
+	transfers, err = testQueries.ListTransfers(context.Background(), ListTransfersParams{
+		Owner:                 account3.Owner,
+		CounterpartyAccountID: sql.NullInt64{Int64: account2.ID, Valid: true},
+		OrderBy:               OrderByCreatedAt,
+		PageSize:              5,
+	})
+	require.NoError(t, err)
+	require.Empty(t, transfers)
+}
+
+func TestSumTransfers(t *testing.T) {
+	store := NewStore(testDB)
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+
+	for _, arg := range []CreateTransferParams{
+		{FromAccountID: account1.ID, ToAccountID: account2.ID, Amount: 10},
+		{FromAccountID: account1.ID, ToAccountID: account2.ID, Amount: 20},
+		{FromAccountID: account2.ID, ToAccountID: account1.ID, Amount: 5},
+	} {
+		_, err := store.TransferTx(context.Background(), TransferTxParams{CreateTransferParams: arg})
+		require.NoError(t, err)
+	}
+
+	startTime := time.Now().Add(-time.Hour)
+	endTime := time.Now().Add(time.Hour)
+
+	byDirection, err := testQueries.SumTransfersByDirection(context.Background(), SumTransfersByDirectionParams{
+		Owner:     account1.Owner,
+		StartTime: startTime,
+		EndTime:   endTime,
+	})
+	require.NoError(t, err)
+	require.Len(t, byDirection, 2)
+	require.Equal(t, SumTransfersByDirectionRow{Direction: "received", Currency: account1.Currency, TransferCount: 1, TotalAmount: 5}, byDirection[0])
+	require.Equal(t, SumTransfersByDirectionRow{Direction: "sent", Currency: account1.Currency, TransferCount: 2, TotalAmount: 30}, byDirection[1])
+
+	byCounterparty, err := testQueries.SumTransfersByCounterparty(context.Background(), SumTransfersByCounterpartyParams{
+		Owner:                 account1.Owner,
+		StartTime:             startTime,
+		EndTime:               endTime,
+		CounterpartyAccountID: sql.NullInt64{Int64: account2.ID, Valid: true},
+		Direction:             sql.NullString{String: "sent", Valid: true},
+		MaxGroups:             10,
+	})
+	require.NoError(t, err)
+	require.Len(t, byCounterparty, 1)
+	require.Equal(t, account2.ID, byCounterparty[0].CounterpartyAccountID)
+	require.Equal(t, int64(30), byCounterparty[0].TotalAmount)
+
+	byMonth, err := testQueries.SumTransfersByMonth(context.Background(), SumTransfersByMonthParams{
+		Owner:     account1.Owner,
+		StartTime: startTime,
+		EndTime:   endTime,
+		AccountID: sql.NullInt64{Int64: account1.ID, Valid: true},
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, byMonth)
+	for _, row := range byMonth {
+		require.Equal(t, 1, row.Month.UTC().Day())
 	}
 }
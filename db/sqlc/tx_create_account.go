@@ -0,0 +1,87 @@
+package db
+
+import (
+	"context"
+	"errors"
+
+	"github.com/mativm02/bank_system/event"
+	"github.com/mativm02/bank_system/util"
+)
+
+// ErrAccountLimitReached is returned when the user already has the maximum number of accounts
+// of the type in the currency.
+var ErrAccountLimitReached = errors.New("maximum number of accounts of this type reached for this currency")
+
+// ErrEmailNotVerified is returned when a user whose email is not verified opens a second account.
+var ErrEmailNotVerified = errors.New("opening more than one account requires a verified email")
+
+type CreateAccountTxParams struct {
+	CreateAccountParams
+	// MaxAccounts is how many accounts of the type the owner can have in the currency.
+	MaxAccounts int64
+}
+
+type CreateAccountTxResult struct {
+	Account Account `json:"account"`
+}
+
+// CreateAccountTx creates an account and records the AccountCreated event.
+// The account gets a new account number unless the params have one, and is a checking account by default.
+// The owner is locked while the accounts are counted, so concurrent requests cannot exceed the limit
+// nor open more than one account before the email of the owner is verified.
+func (store *SQLStore) CreateAccountTx(ctx context.Context, arg CreateAccountTxParams) (CreateAccountTxResult, error) {
+	var result CreateAccountTxResult
+
+	if arg.Number == "" {
+		number, err := util.NewAccountNumber()
+		if err != nil {
+			return result, err
+		}
+		arg.Number = number
+	}
+	if arg.Type == "" {
+		arg.Type = util.CheckingAccount
+	}
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		user, err := q.GetUserForUpdate(ctx, arg.Owner)
+		if err != nil {
+			return err
+		}
+
+		if !user.IsEmailVerified {
+			count, err := q.CountAccountsByOwner(ctx, arg.Owner)
+			if err != nil {
+				return err
+			}
+			if count > 0 {
+				return ErrEmailNotVerified
+			}
+		}
+
+		count, err := q.CountAccountsByType(ctx, CountAccountsByTypeParams{
+			Owner:    arg.Owner,
+			Currency: arg.Currency,
+			Type:     arg.Type,
+		})
+		if err != nil {
+			return err
+		}
+		if count >= arg.MaxAccounts {
+			return ErrAccountLimitReached
+		}
+
+		result.Account, err = q.CreateAccount(ctx, arg.CreateAccountParams)
+		if err != nil {
+			return err
+		}
+
+		return recordDomainEvent(ctx, q, event.AccountCreated{
+			AccountID: result.Account.ID,
+			Owner:     result.Account.Owner,
+			Currency:  result.Account.Currency,
+		}, []string{result.Account.Owner}, []int64{result.Account.ID})
+	})
+
+	return result, err
+}
@@ -1,10 +1,16 @@
 package db
 
-import "context"
+import (
+	"context"
+
+	"github.com/mativm02/bank_system/event"
+)
 
 type CreateUserTxParams struct {
 	CreateUserParams
-	AfterCreate func(user User) error
+	// OutboxMessages returns the tasks to enqueue once the user is created.
+	// They are stored in the same transaction, so they are only relayed if the user is committed.
+	OutboxMessages func(user User) ([]CreateOutboxMessageParams, error)
 }
 
 type CreateUserTxResult struct {
@@ -22,7 +28,30 @@ func (store *SQLStore) CreateUserTx(ctx context.Context, arg CreateUserTxParams)
 			return err
 		}
 
-		return arg.AfterCreate(result.User)
+		err = recordDomainEvent(ctx, q, event.UserCreated{
+			Username: result.User.Username,
+			Email:    result.User.Email,
+		}, []string{result.User.Username}, nil)
+		if err != nil {
+			return err
+		}
+
+		if arg.OutboxMessages == nil {
+			return nil
+		}
+
+		// The verification email sent at sign up counts for the resend throttle.
+		result.User, err = q.RecordVerifyEmailRequest(ctx, result.User.Username)
+		if err != nil {
+			return err
+		}
+
+		messages, err := arg.OutboxMessages(result.User)
+		if err != nil {
+			return err
+		}
+
+		return createOutboxMessages(ctx, q, messages)
 	})
 
 	return result, err
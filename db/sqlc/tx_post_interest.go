@@ -0,0 +1,108 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/mativm02/bank_system/util"
+)
+
+const (
+	// SystemUsername owns the accounts of the bank. It is not a valid username, so nobody can sign up with it.
+	SystemUsername = "simple-bank"
+	// InterestAccountNickname names the accounts of the bank that pay the interest.
+	InterestAccountNickname = "interest"
+)
+
+// ErrNoAccruedInterest is returned when the account has no accrued interest to post.
+var ErrNoAccruedInterest = errors.New("no accrued interest to post")
+
+type PostInterestTxParams struct {
+	AccountID int64
+	// Before excludes the interest accrued on that date and later.
+	Before time.Time
+}
+
+type PostInterestTxResult struct {
+	Posting InterestPosting `json:"posting"`
+	// Transfer is empty when the accrued interest is less than one minor unit.
+	Transfer TransferTxResults `json:"transfer"`
+}
+
+// PostInterestTx pays the interest accrued by the account from the interest account of the bank
+// in its currency, without fee. The interest is paid in whole minor units; the remainder is carried to the next posting.
+func (store *SQLStore) PostInterestTx(ctx context.Context, arg PostInterestTxParams) (PostInterestTxResult, error) {
+	var result PostInterestTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var carryMicros int64
+		lastPosting, err := q.GetLastInterestPosting(ctx, arg.AccountID)
+		if err == nil {
+			carryMicros = lastPosting.CarryMicros
+		} else if err != sql.ErrNoRows {
+			return err
+		}
+
+		result.Posting, err = q.CreateInterestPosting(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+
+		accruals, err := q.ClaimInterestAccruals(ctx, ClaimInterestAccrualsParams{
+			PostingID: sql.NullInt64{Int64: result.Posting.ID, Valid: true},
+			AccountID: arg.AccountID,
+			Before:    arg.Before,
+		})
+		if err != nil {
+			return err
+		}
+		if len(accruals) == 0 {
+			return ErrNoAccruedInterest
+		}
+
+		micros := carryMicros
+		for _, amountMicros := range accruals {
+			micros += amountMicros
+		}
+		amount, carryMicros := util.SplitInterest(micros)
+
+		var transferID sql.NullInt64
+		if amount > 0 {
+			account, err := q.GetAccount(ctx, arg.AccountID)
+			if err != nil {
+				return err
+			}
+
+			bankAccount, err := q.GetSystemAccount(ctx, GetSystemAccountParams{
+				Owner:    SystemUsername,
+				Currency: account.Currency,
+				Nickname: InterestAccountNickname,
+			})
+			if err != nil {
+				return err
+			}
+
+			result.Transfer, err = transfer(ctx, q, CreateTransferParams{
+				FromAccountID: bankAccount.ID,
+				ToAccountID:   account.ID,
+				Amount:        amount,
+			}, 0)
+			if err != nil {
+				return err
+			}
+			transferID = sql.NullInt64{Int64: result.Transfer.Transfer.ID, Valid: true}
+		}
+
+		result.Posting, err = q.UpdateInterestPosting(ctx, UpdateInterestPostingParams{
+			ID:          result.Posting.ID,
+			Amount:      amount,
+			CarryMicros: carryMicros,
+			TransferID:  transferID,
+		})
+		return err
+	})
+
+	return result, err
+}
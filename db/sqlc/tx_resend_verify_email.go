@@ -0,0 +1,70 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrEmailAlreadyVerified = errors.New("email is already verified")
+	ErrVerifyEmailThrottled = errors.New("a verification email was requested recently")
+)
+
+type ResendVerifyEmailTxParams struct {
+	Username string
+	// ResendInterval is the minimum time between two verification emails.
+	ResendInterval time.Duration
+	// OutboxMessages returns the tasks that send the new verification email.
+	OutboxMessages func(user User) ([]CreateOutboxMessageParams, error)
+}
+
+type ResendVerifyEmailTxResult struct {
+	User User
+}
+
+// ResendVerifyEmailTx invalidates the unused verification codes of the user
+// and queues a new verification email, unless one was requested within the resend interval.
+// A user with a pending email change can always ask for a new code for the pending email.
+func (store *SQLStore) ResendVerifyEmailTx(ctx context.Context, arg ResendVerifyEmailTxParams) (ResendVerifyEmailTxResult, error) {
+	var result ResendVerifyEmailTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		// Locking the user serializes concurrent requests, and the time of the request is recorded
+		// before the lock is released, so they cannot all pass the throttle.
+		result.User, err = q.GetUserForUpdate(ctx, arg.Username)
+		if err != nil {
+			return err
+		}
+
+		if result.User.IsEmailVerified && !result.User.PendingEmail.Valid {
+			return ErrEmailAlreadyVerified
+		}
+
+		requestedAt := result.User.VerifyEmailRequestedAt
+		if requestedAt.Valid && time.Since(requestedAt.Time) < arg.ResendInterval {
+			return ErrVerifyEmailThrottled
+		}
+
+		result.User, err = q.RecordVerifyEmailRequest(ctx, arg.Username)
+		if err != nil {
+			return err
+		}
+
+		err = q.InvalidateVerifyEmails(ctx, arg.Username)
+		if err != nil {
+			return err
+		}
+
+		messages, err := arg.OutboxMessages(result.User)
+		if err != nil {
+			return err
+		}
+
+		return createOutboxMessages(ctx, q, messages)
+	})
+
+	return result, err
+}
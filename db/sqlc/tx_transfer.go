@@ -1,6 +1,19 @@
 package db
 
-import "context"
+import (
+	"context"
+	"database/sql"
+
+	"github.com/mativm02/bank_system/event"
+)
+
+type TransferTxParams struct {
+	CreateTransferParams
+	// Limits are the default spending limits of the sender, used unless an admin has set others.
+	Limits SpendingLimits
+	// CoolingOff limits what the sender can send to a destination that is new to them.
+	CoolingOff CoolingOff
+}
 
 type TransferTxResults struct {
 	Transfer    Transfer `json:"transfer"`
@@ -8,48 +21,160 @@ type TransferTxResults struct {
 	ToAccount   Account  `json:"to_account"`
 	FromEntry   Entry    `json:"from_entry"`
 	ToEntry     Entry    `json:"to_entry"`
+	// Fee is paid by the sender on top of the amount. FeeEntry is empty when there is no fee.
+	Fee      int64 `json:"fee"`
+	FeeEntry Entry `json:"fee_entry"`
 }
 
 // TransferTx performs a transfer from one account to another.
 // It creates a transfer record and updates account balances within a database transaction.
-func (store *SQLStore) TransferTx(ctx context.Context, arg CreateTransferParams) (TransferTxResults, error) {
+// The sender also pays the fee of the transfer to the revenue account of the bank in the currency.
+// A transfer to another owner fails with ErrSpendingLimitExceeded when its amount, without the fee,
+// exceeds a spending limit of the sender, and with ErrCoolingOff when the destination is new to the
+// sender and cannot receive that much yet. The transfers between the accounts of the same owner are
+// not limited. The sender is locked while its spending is summed, so concurrent transfers cannot
+// exceed the limits or the cooling-off together.
+func (store *SQLStore) TransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResults, error) {
 	var result TransferTxResults
 
 	err := store.execTx(ctx, func(q *Queries) error {
-		var err error
-
-		result.Transfer, err = q.CreateTransfer(ctx, arg)
+		fromAccount, err := q.GetAccount(ctx, arg.FromAccountID)
 		if err != nil {
 			return err
 		}
-
-		result.FromEntry, err = q.CreateEntry(ctx, CreateEntryParams{
-			AccountID: arg.FromAccountID,
-			Amount:    -arg.Amount,
-		})
+		toAccount, err := q.GetAccount(ctx, arg.ToAccountID)
 		if err != nil {
 			return err
 		}
-		result.ToEntry, err = q.CreateEntry(ctx, CreateEntryParams{
-			AccountID: arg.ToAccountID,
-			Amount:    arg.Amount,
-		})
+
+		if fromAccount.Owner != toAccount.Owner {
+			_, err = q.GetUserForUpdate(ctx, fromAccount.Owner)
+			if err != nil {
+				return err
+			}
+
+			allowance, err := GetSpendingAllowance(ctx, q, fromAccount, arg.Limits)
+			if err != nil {
+				return err
+			}
+			err = allowance.Check(arg.Amount)
+			if err != nil {
+				return err
+			}
+
+			err = checkCoolingOff(ctx, q, fromAccount.Owner, toAccount, arg.Amount, arg.CoolingOff)
+			if err != nil {
+				return err
+			}
+		}
+
+		fee, err := TransferFee(ctx, q, fromAccount, toAccount, arg.Amount)
 		if err != nil {
 			return err
 		}
-		if arg.FromAccountID < arg.ToAccountID {
-			result.FromAccount, result.ToAccount, err = addMoney(ctx, q, arg.FromAccountID, -arg.Amount, arg.ToAccountID, arg.Amount)
-		} else {
-			result.ToAccount, result.FromAccount, err = addMoney(ctx, q, arg.ToAccountID, arg.Amount, arg.FromAccountID, -arg.Amount)
+
+		result, err = transfer(ctx, q, arg.CreateTransferParams, fee)
+		return err
+	})
+
+	return result, err
+}
+
+// transfer moves the money between the accounts within the transaction of q,
+// so other transactions can pay money through the same ledger as TransferTx.
+// A positive fee is debited from the sender and credited to the revenue account of the bank.
+func transfer(ctx context.Context, q *Queries, arg CreateTransferParams, fee int64) (TransferTxResults, error) {
+	result := TransferTxResults{Fee: fee}
+	var err error
+
+	result.Transfer, err = q.CreateTransfer(ctx, arg)
+	if err != nil {
+		return result, err
+	}
+
+	result.FromEntry, err = q.CreateEntry(ctx, CreateEntryParams{
+		AccountID:  arg.FromAccountID,
+		Amount:     -arg.Amount,
+		TransferID: sql.NullInt64{Int64: result.Transfer.ID, Valid: true},
+		Type:       EntryTypeTransfer,
+	})
+	if err != nil {
+		return result, err
+	}
+	result.ToEntry, err = q.CreateEntry(ctx, CreateEntryParams{
+		AccountID:  arg.ToAccountID,
+		Amount:     arg.Amount,
+		TransferID: sql.NullInt64{Int64: result.Transfer.ID, Valid: true},
+		Type:       EntryTypeTransfer,
+	})
+	if err != nil {
+		return result, err
+	}
+	if fee > 0 {
+		result.FeeEntry, err = q.CreateEntry(ctx, CreateEntryParams{
+			AccountID:  arg.FromAccountID,
+			Amount:     -fee,
+			TransferID: sql.NullInt64{Int64: result.Transfer.ID, Valid: true},
+			Type:       EntryTypeFee,
+		})
+		if err != nil {
+			return result, err
 		}
+	}
+
+	if arg.FromAccountID < arg.ToAccountID {
+		result.FromAccount, result.ToAccount, err = addMoney(ctx, q, arg.FromAccountID, -arg.Amount-fee, arg.ToAccountID, arg.Amount)
+	} else {
+		result.ToAccount, result.FromAccount, err = addMoney(ctx, q, arg.ToAccountID, arg.Amount, arg.FromAccountID, -arg.Amount-fee)
+	}
+	if err != nil {
+		return result, err
+	}
+
+	if fee > 0 {
+		err = payFee(ctx, q, result.FromAccount.Currency, fee, result.Transfer.ID)
 		if err != nil {
-			return err
+			return result, err
 		}
+	}
+
+	err = recordDomainEvent(ctx, q, event.TransferCompleted{
+		TransferID:    result.Transfer.ID,
+		FromAccountID: result.Transfer.FromAccountID,
+		ToAccountID:   result.Transfer.ToAccountID,
+		Amount:        result.Transfer.Amount,
+		Currency:      result.FromAccount.Currency,
+	}, []string{result.FromAccount.Owner, result.ToAccount.Owner}, []int64{result.FromAccount.ID, result.ToAccount.ID})
+	return result, err
+}
+
+// payFee credits the fee of a transfer to the revenue account of the bank.
+// The revenue account is updated after the accounts of the transfer, in every transfer.
+func payFee(ctx context.Context, q *Queries, currency string, fee int64, transferID int64) error {
+	feeAccount, err := q.GetSystemAccount(ctx, GetSystemAccountParams{
+		Owner:    SystemUsername,
+		Currency: currency,
+		Nickname: FeeAccountNickname,
+	})
+	if err != nil {
+		return err
+	}
 
-		return nil
+	_, err = q.CreateEntry(ctx, CreateEntryParams{
+		AccountID:  feeAccount.ID,
+		Amount:     fee,
+		TransferID: sql.NullInt64{Int64: transferID, Valid: true},
+		Type:       EntryTypeFee,
 	})
+	if err != nil {
+		return err
+	}
 
-	return result, err
+	_, err = q.AddAccountBalance(ctx, AddAccountBalanceParams{
+		ID:     feeAccount.ID,
+		Amount: fee,
+	})
+	return err
 }
 
 func addMoney(ctx context.Context, q *Queries, accountID1, amount1, accountID2, amount2 int64) (account1, account2 Account, err error) {
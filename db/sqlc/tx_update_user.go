@@ -0,0 +1,132 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+type UpdateUserTxParams struct {
+	UpdateUserParams
+	// EmailChangeMessages returns the tasks that verify the new email and notify the old one.
+	// A new email is not applied by UpdateUserTx: it is staged as pending until VerifyEmailTx confirms it.
+	// Submitting the current email again cancels the pending change.
+	EmailChangeMessages func(user User, oldEmail string) ([]CreateOutboxMessageParams, error)
+	// PasswordHistorySize is how many passwords, including the current one, a new password is checked against.
+	// Zero disables the password history.
+	PasswordHistorySize int32
+	// CheckPasswordHistory returns an error if the new password matches one of the given hashes,
+	// which are sorted from the most recent.
+	CheckPasswordHistory func(hashes []string) error
+}
+
+type UpdateUserTxResult struct {
+	User                 User
+	EmailChangeRequested bool
+}
+
+func (store *SQLStore) UpdateUserTx(ctx context.Context, arg UpdateUserTxParams) (UpdateUserTxResult, error) {
+	var result UpdateUserTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		user, err := q.GetUserForUpdate(ctx, arg.Username)
+		if err != nil {
+			return err
+		}
+
+		changePassword := arg.HashedPassword.Valid && arg.PasswordHistorySize > 0
+		if changePassword {
+			err = checkPasswordHistory(ctx, q, user, arg)
+			if err != nil {
+				return err
+			}
+		}
+
+		params := arg.UpdateUserParams
+		params.Email = sql.NullString{}
+		cancelEmailChange := false
+		if arg.Email.Valid {
+			if arg.Email.String != user.Email {
+				params.PendingEmail = arg.Email
+				result.EmailChangeRequested = true
+			} else {
+				cancelEmailChange = user.PendingEmail.Valid
+			}
+		}
+
+		result.User, err = q.UpdateUser(ctx, params)
+		if err != nil {
+			return err
+		}
+
+		if changePassword {
+			err = recordPasswordHistory(ctx, q, user, arg.PasswordHistorySize)
+			if err != nil {
+				return err
+			}
+		}
+
+		if !result.EmailChangeRequested && !cancelEmailChange {
+			return nil
+		}
+
+		// The codes sent to a previous pending email must not be usable anymore.
+		err = q.InvalidateVerifyEmails(ctx, arg.Username)
+		if err != nil {
+			return err
+		}
+
+		if cancelEmailChange {
+			result.User, err = q.CancelPendingEmail(ctx, arg.Username)
+			return err
+		}
+
+		result.User, err = q.RecordVerifyEmailRequest(ctx, arg.Username)
+		if err != nil {
+			return err
+		}
+
+		messages, err := arg.EmailChangeMessages(result.User, user.Email)
+		if err != nil {
+			return err
+		}
+
+		return createOutboxMessages(ctx, q, messages)
+	})
+
+	return result, err
+}
+
+func checkPasswordHistory(ctx context.Context, q *Queries, user User, arg UpdateUserTxParams) error {
+	hashes := []string{user.HashedPassword}
+	if arg.PasswordHistorySize > 1 {
+		previous, err := q.ListPasswordHistory(ctx, ListPasswordHistoryParams{
+			Username: user.Username,
+			Limit:    arg.PasswordHistorySize - 1,
+		})
+		if err != nil {
+			return err
+		}
+		hashes = append(hashes, previous...)
+	}
+
+	if arg.CheckPasswordHistory == nil {
+		return nil
+	}
+	return arg.CheckPasswordHistory(hashes)
+}
+
+// recordPasswordHistory keeps the replaced password, dropping the ones that fell out of the history.
+func recordPasswordHistory(ctx context.Context, q *Queries, user User, size int32) error {
+	err := q.CreatePasswordHistory(ctx, CreatePasswordHistoryParams{
+		Username:       user.Username,
+		HashedPassword: user.HashedPassword,
+	})
+	if err != nil {
+		return err
+	}
+
+	return q.DeleteOldPasswordHistory(ctx, DeleteOldPasswordHistoryParams{
+		Username: user.Username,
+		Keep:     size - 1,
+	})
+}
@@ -3,8 +3,14 @@ package db
 import (
 	"context"
 	"database/sql"
+	"errors"
+
+	"github.com/mativm02/bank_system/event"
 )
 
+// ErrVerifyEmailOutdated is returned for a code sent to an email that the user no longer uses.
+var ErrVerifyEmailOutdated = errors.New("the email of the verification code is outdated")
+
 type VerifyEmailTxParams struct {
 	EmailID    int64
 	SecretCode string
@@ -29,15 +35,37 @@ func (store *SQLStore) VerifyEmailTx(ctx context.Context, arg VerifyEmailTxParam
 			return err
 		}
 
-		result.User, err = q.UpdateUser(ctx, UpdateUserParams{
-			Username: result.VerifyEmail.Username,
-			IsEmailVerified: sql.NullBool{
-				Bool:  true,
-				Valid: true,
-			},
-		})
+		user, err := q.GetUserForUpdate(ctx, result.VerifyEmail.Username)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case result.VerifyEmail.Email == user.Email:
+			result.User, err = q.UpdateUser(ctx, UpdateUserParams{
+				Username: user.Username,
+				IsEmailVerified: sql.NullBool{
+					Bool:  true,
+					Valid: true,
+				},
+			})
+		case user.PendingEmail.Valid && result.VerifyEmail.Email == user.PendingEmail.String:
+			// The code confirms an email change: the new email replaces the current one.
+			result.User, err = q.ApplyPendingEmail(ctx, ApplyPendingEmailParams{
+				Username: user.Username,
+				Email:    result.VerifyEmail.Email,
+			})
+		default:
+			return ErrVerifyEmailOutdated
+		}
+		if err != nil {
+			return err
+		}
 
-		return err
+		return recordDomainEvent(ctx, q, event.UserVerified{
+			Username: result.User.Username,
+			Email:    result.VerifyEmail.Email,
+		}, []string{result.User.Username}, nil)
 	})
 
 	return result, err
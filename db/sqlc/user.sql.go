@@ -10,6 +10,68 @@ import (
 	"database/sql"
 )
 
+const applyPendingEmail = `-- name: ApplyPendingEmail :one
+UPDATE users
+SET
+    email = pending_email,
+    pending_email = NULL,
+    is_email_verified = TRUE
+WHERE
+    username = $1
+    AND pending_email = $2::varchar
+RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, locale, pending_email, role, verify_email_requested_at
+`
+
+type ApplyPendingEmailParams struct {
+	Username string `json:"username"`
+	Email    string `json:"email"`
+}
+
+func (q *Queries) ApplyPendingEmail(ctx context.Context, arg ApplyPendingEmailParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, applyPendingEmail, arg.Username, arg.Email)
+	var i User
+	err := row.Scan(
+		&i.Username,
+		&i.HashedPassword,
+		&i.FullName,
+		&i.Email,
+		&i.PasswordChangedAt,
+		&i.CreatedAt,
+		&i.IsEmailVerified,
+		&i.Locale,
+		&i.PendingEmail,
+		&i.Role,
+		&i.VerifyEmailRequestedAt,
+	)
+	return i, err
+}
+
+const cancelPendingEmail = `-- name: CancelPendingEmail :one
+UPDATE users
+SET pending_email = NULL
+WHERE username = $1
+RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, locale, pending_email, role, verify_email_requested_at
+`
+
+func (q *Queries) CancelPendingEmail(ctx context.Context, username string) (User, error) {
+	row := q.db.QueryRowContext(ctx, cancelPendingEmail, username)
+	var i User
+	err := row.Scan(
+		&i.Username,
+		&i.HashedPassword,
+		&i.FullName,
+		&i.Email,
+		&i.PasswordChangedAt,
+		&i.CreatedAt,
+		&i.IsEmailVerified,
+		&i.Locale,
+		&i.PendingEmail,
+		&i.Role,
+		&i.VerifyEmailRequestedAt,
+	)
+	return i, err
+}
+
 const createUser = `-- name: CreateUser :one
 INSERT INTO users (
     username,
@@ -19,7 +81,7 @@ INSERT INTO users (
 ) VALUES (
     $1, $2, $3, $4
 )
-RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified
+RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, locale, pending_email, role, verify_email_requested_at
 `
 
 type CreateUserParams struct {
@@ -45,12 +107,16 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.PasswordChangedAt,
 		&i.CreatedAt,
 		&i.IsEmailVerified,
+		&i.Locale,
+		&i.PendingEmail,
+		&i.Role,
+		&i.VerifyEmailRequestedAt,
 	)
 	return i, err
 }
 
 const getUser = `-- name: GetUser :one
-SELECT username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified FROM users WHERE username = $1 LIMIT 1
+SELECT username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, locale, pending_email, role, verify_email_requested_at FROM users WHERE username = $1 LIMIT 1
 `
 
 func (q *Queries) GetUser(ctx context.Context, username string) (User, error) {
@@ -64,6 +130,59 @@ func (q *Queries) GetUser(ctx context.Context, username string) (User, error) {
 		&i.PasswordChangedAt,
 		&i.CreatedAt,
 		&i.IsEmailVerified,
+		&i.Locale,
+		&i.PendingEmail,
+		&i.Role,
+		&i.VerifyEmailRequestedAt,
+	)
+	return i, err
+}
+
+const getUserForUpdate = `-- name: GetUserForUpdate :one
+SELECT username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, locale, pending_email, role, verify_email_requested_at FROM users WHERE username = $1 LIMIT 1 FOR NO KEY UPDATE
+`
+
+func (q *Queries) GetUserForUpdate(ctx context.Context, username string) (User, error) {
+	row := q.db.QueryRowContext(ctx, getUserForUpdate, username)
+	var i User
+	err := row.Scan(
+		&i.Username,
+		&i.HashedPassword,
+		&i.FullName,
+		&i.Email,
+		&i.PasswordChangedAt,
+		&i.CreatedAt,
+		&i.IsEmailVerified,
+		&i.Locale,
+		&i.PendingEmail,
+		&i.Role,
+		&i.VerifyEmailRequestedAt,
+	)
+	return i, err
+}
+
+const recordVerifyEmailRequest = `-- name: RecordVerifyEmailRequest :one
+UPDATE users
+SET verify_email_requested_at = now()
+WHERE username = $1
+RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, locale, pending_email, role, verify_email_requested_at
+`
+
+func (q *Queries) RecordVerifyEmailRequest(ctx context.Context, username string) (User, error) {
+	row := q.db.QueryRowContext(ctx, recordVerifyEmailRequest, username)
+	var i User
+	err := row.Scan(
+		&i.Username,
+		&i.HashedPassword,
+		&i.FullName,
+		&i.Email,
+		&i.PasswordChangedAt,
+		&i.CreatedAt,
+		&i.IsEmailVerified,
+		&i.Locale,
+		&i.PendingEmail,
+		&i.Role,
+		&i.VerifyEmailRequestedAt,
 	)
 	return i, err
 }
@@ -75,9 +194,11 @@ SET
     password_changed_at = COALESCE($2, password_changed_at),
     full_name = COALESCE($3, full_name),
     email = COALESCE($4, email),
-    is_email_verified = COALESCE($5, is_email_verified)
-WHERE username = $6
-RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified
+    is_email_verified = COALESCE($5, is_email_verified),
+    locale = COALESCE($6, locale),
+    pending_email = COALESCE($7, pending_email)
+WHERE username = $8
+RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, locale, pending_email, role, verify_email_requested_at
 `
 
 type UpdateUserParams struct {
@@ -86,6 +207,8 @@ type UpdateUserParams struct {
 	FullName          sql.NullString `json:"full_name"`
 	Email             sql.NullString `json:"email"`
 	IsEmailVerified   sql.NullBool   `json:"is_email_verified"`
+	Locale            sql.NullString `json:"locale"`
+	PendingEmail      sql.NullString `json:"pending_email"`
 	Username          string         `json:"username"`
 }
 
@@ -96,6 +219,8 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		arg.FullName,
 		arg.Email,
 		arg.IsEmailVerified,
+		arg.Locale,
+		arg.PendingEmail,
 		arg.Username,
 	)
 	var i User
@@ -107,6 +232,30 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		&i.PasswordChangedAt,
 		&i.CreatedAt,
 		&i.IsEmailVerified,
+		&i.Locale,
+		&i.PendingEmail,
+		&i.Role,
+		&i.VerifyEmailRequestedAt,
 	)
 	return i, err
 }
+
+const updateUserHashedPassword = `-- name: UpdateUserHashedPassword :exec
+UPDATE users
+SET
+    hashed_password = $1
+WHERE
+    username = $2
+    AND hashed_password = $3
+`
+
+type UpdateUserHashedPasswordParams struct {
+	HashedPassword    string `json:"hashed_password"`
+	Username          string `json:"username"`
+	OldHashedPassword string `json:"old_hashed_password"`
+}
+
+func (q *Queries) UpdateUserHashedPassword(ctx context.Context, arg UpdateUserHashedPasswordParams) error {
+	_, err := q.db.ExecContext(ctx, updateUserHashedPassword, arg.HashedPassword, arg.Username, arg.OldHashedPassword)
+	return err
+}
@@ -7,27 +7,35 @@ package db
 
 import (
 	"context"
+	"time"
 )
 
 const createVerifyEmail = `-- name: CreateVerifyEmail :one
 INSERT INTO verify_emails (
     username,
     email,
-    secret_code
+    secret_code,
+    expired_at
 ) VALUES (
-    $1, $2, $3
+    $1, $2, $3, $4
 )
 RETURNING id, username, email, secret_code, is_used, created_at, expired_at
 `
 
 type CreateVerifyEmailParams struct {
-	Username   string `json:"username"`
-	Email      string `json:"email"`
-	SecretCode string `json:"secret_code"`
+	Username   string    `json:"username"`
+	Email      string    `json:"email"`
+	SecretCode string    `json:"secret_code"`
+	ExpiredAt  time.Time `json:"expired_at"`
 }
 
 func (q *Queries) CreateVerifyEmail(ctx context.Context, arg CreateVerifyEmailParams) (VerifyEmail, error) {
-	row := q.db.QueryRowContext(ctx, createVerifyEmail, arg.Username, arg.Email, arg.SecretCode)
+	row := q.db.QueryRowContext(ctx, createVerifyEmail,
+		arg.Username,
+		arg.Email,
+		arg.SecretCode,
+		arg.ExpiredAt,
+	)
 	var i VerifyEmail
 	err := row.Scan(
 		&i.ID,
@@ -41,6 +49,21 @@ func (q *Queries) CreateVerifyEmail(ctx context.Context, arg CreateVerifyEmailPa
 	return i, err
 }
 
+const invalidateVerifyEmails = `-- name: InvalidateVerifyEmails :exec
+UPDATE verify_emails
+SET
+    expired_at = now()
+WHERE
+    username = $1
+    AND is_used = FALSE
+    AND expired_at > now()
+`
+
+func (q *Queries) InvalidateVerifyEmails(ctx context.Context, username string) error {
+	_, err := q.db.ExecContext(ctx, invalidateVerifyEmails, username)
+	return err
+}
+
 const updateVerifyEmail = `-- name: UpdateVerifyEmail :one
 UPDATE verify_emails
 SET
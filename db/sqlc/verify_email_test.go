@@ -0,0 +1,174 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/mativm02/bank_system/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomVerifyEmail(t *testing.T, user User) VerifyEmail {
+	arg := CreateVerifyEmailParams{
+		Username:   user.Username,
+		Email:      user.Email,
+		SecretCode: util.RandomString(32),
+		ExpiredAt:  time.Now().Add(time.Hour),
+	}
+
+	verifyEmail, err := testQueries.CreateVerifyEmail(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, verifyEmail)
+
+	require.Equal(t, arg.Username, verifyEmail.Username)
+	require.Equal(t, arg.Email, verifyEmail.Email)
+	require.Equal(t, arg.SecretCode, verifyEmail.SecretCode)
+	require.False(t, verifyEmail.IsUsed)
+	require.WithinDuration(t, arg.ExpiredAt, verifyEmail.ExpiredAt, time.Second)
+
+	return verifyEmail
+}
+
+func TestResendVerifyEmailTx(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+	verifyEmail := createRandomVerifyEmail(t, user)
+
+	resend := func(interval time.Duration) error {
+		_, err := store.ResendVerifyEmailTx(context.Background(), ResendVerifyEmailTxParams{
+			Username:       user.Username,
+			ResendInterval: interval,
+			OutboxMessages: func(user User) ([]CreateOutboxMessageParams, error) {
+				return nil, nil
+			},
+		})
+		return err
+	}
+
+	err := resend(time.Hour)
+	require.NoError(t, err)
+
+	// The request is throttled before the worker creates the verification email.
+	err = resend(time.Hour)
+	require.ErrorIs(t, err, ErrVerifyEmailThrottled)
+
+	err = resend(0)
+	require.NoError(t, err)
+
+	// The previous code can no longer be used.
+	_, err = testQueries.UpdateVerifyEmail(context.Background(), UpdateVerifyEmailParams{
+		ID:         verifyEmail.ID,
+		SecretCode: verifyEmail.SecretCode,
+	})
+	require.Error(t, err)
+}
+
+func TestResendVerifyEmailTxVerified(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+	verifyEmail := createRandomVerifyEmail(t, user)
+
+	_, err := store.VerifyEmailTx(context.Background(), VerifyEmailTxParams{
+		EmailID:    verifyEmail.ID,
+		SecretCode: verifyEmail.SecretCode,
+	})
+	require.NoError(t, err)
+
+	_, err = store.ResendVerifyEmailTx(context.Background(), ResendVerifyEmailTxParams{
+		Username: user.Username,
+		OutboxMessages: func(user User) ([]CreateOutboxMessageParams, error) {
+			return nil, nil
+		},
+	})
+	require.ErrorIs(t, err, ErrEmailAlreadyVerified)
+}
+
+func TestEmailChange(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+	newEmail := util.RandomEmail()
+
+	var oldEmail string
+	result, err := store.UpdateUserTx(context.Background(), UpdateUserTxParams{
+		UpdateUserParams: UpdateUserParams{
+			Username: user.Username,
+			Email:    sql.NullString{String: newEmail, Valid: true},
+		},
+		EmailChangeMessages: func(user User, email string) ([]CreateOutboxMessageParams, error) {
+			oldEmail = email
+			return nil, nil
+		},
+	})
+	require.NoError(t, err)
+	require.True(t, result.EmailChangeRequested)
+	require.Equal(t, user.Email, oldEmail)
+
+	// The email is only staged until it is verified.
+	require.Equal(t, user.Email, result.User.Email)
+	require.Equal(t, newEmail, result.User.PendingEmail.String)
+
+	verifyEmail := createRandomVerifyEmail(t, User{Username: user.Username, Email: newEmail})
+	verified, err := store.VerifyEmailTx(context.Background(), VerifyEmailTxParams{
+		EmailID:    verifyEmail.ID,
+		SecretCode: verifyEmail.SecretCode,
+	})
+	require.NoError(t, err)
+	require.Equal(t, newEmail, verified.User.Email)
+	require.False(t, verified.User.PendingEmail.Valid)
+	require.True(t, verified.User.IsEmailVerified)
+}
+
+func TestEmailChangeCanceled(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+
+	updateEmail := func(email string) UpdateUserTxResult {
+		result, err := store.UpdateUserTx(context.Background(), UpdateUserTxParams{
+			UpdateUserParams: UpdateUserParams{
+				Username: user.Username,
+				Email:    sql.NullString{String: email, Valid: true},
+			},
+			EmailChangeMessages: func(user User, email string) ([]CreateOutboxMessageParams, error) {
+				return nil, nil
+			},
+		})
+		require.NoError(t, err)
+		return result
+	}
+
+	newEmail := util.RandomEmail()
+	result := updateEmail(newEmail)
+	require.True(t, result.EmailChangeRequested)
+	verifyEmail := createRandomVerifyEmail(t, User{Username: user.Username, Email: newEmail})
+
+	// Submitting the current email again cancels the pending change.
+	result = updateEmail(user.Email)
+	require.False(t, result.EmailChangeRequested)
+	require.Equal(t, user.Email, result.User.Email)
+	require.False(t, result.User.PendingEmail.Valid)
+
+	// The code sent to the pending email can no longer be used.
+	_, err := store.VerifyEmailTx(context.Background(), VerifyEmailTxParams{
+		EmailID:    verifyEmail.ID,
+		SecretCode: verifyEmail.SecretCode,
+	})
+	require.Error(t, err)
+
+	user, err = testQueries.GetUser(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.False(t, user.PendingEmail.Valid)
+}
+
+func TestVerifyEmailTxOutdated(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+
+	verifyEmail := createRandomVerifyEmail(t, User{Username: user.Username, Email: util.RandomEmail()})
+	_, err := store.VerifyEmailTx(context.Background(), VerifyEmailTxParams{
+		EmailID:    verifyEmail.ID,
+		SecretCode: verifyEmail.SecretCode,
+	})
+	require.ErrorIs(t, err, ErrVerifyEmailOutdated)
+}
@@ -0,0 +1,338 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.17.2
+// source: webhook.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/lib/pq"
+)
+
+const createWebhookDelivery = `-- name: CreateWebhookDelivery :one
+INSERT INTO webhook_deliveries (
+    subscription_id,
+    event_id,
+    event_type,
+    payload
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT (subscription_id, event_id) DO UPDATE
+SET subscription_id = EXCLUDED.subscription_id
+RETURNING id, subscription_id, event_id, event_type, payload, status, attempts, response_status, last_error, delivered_at, updated_at, created_at
+`
+
+type CreateWebhookDeliveryParams struct {
+	SubscriptionID int64           `json:"subscription_id"`
+	EventID        int64           `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	Payload        json.RawMessage `json:"payload"`
+}
+
+func (q *Queries) CreateWebhookDelivery(ctx context.Context, arg CreateWebhookDeliveryParams) (WebhookDelivery, error) {
+	row := q.db.QueryRowContext(ctx, createWebhookDelivery,
+		arg.SubscriptionID,
+		arg.EventID,
+		arg.EventType,
+		arg.Payload,
+	)
+	var i WebhookDelivery
+	err := row.Scan(
+		&i.ID,
+		&i.SubscriptionID,
+		&i.EventID,
+		&i.EventType,
+		&i.Payload,
+		&i.Status,
+		&i.Attempts,
+		&i.ResponseStatus,
+		&i.LastError,
+		&i.DeliveredAt,
+		&i.UpdatedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createWebhookSubscription = `-- name: CreateWebhookSubscription :one
+INSERT INTO webhook_subscriptions (
+    owner,
+    url,
+    secret,
+    event_types
+) VALUES (
+    $1, $2, $3, $4
+)
+RETURNING id, owner, url, secret, event_types, is_active, created_at
+`
+
+type CreateWebhookSubscriptionParams struct {
+	Owner      string   `json:"owner"`
+	Url        string   `json:"url"`
+	Secret     string   `json:"secret"`
+	EventTypes []string `json:"event_types"`
+}
+
+func (q *Queries) CreateWebhookSubscription(ctx context.Context, arg CreateWebhookSubscriptionParams) (WebhookSubscription, error) {
+	row := q.db.QueryRowContext(ctx, createWebhookSubscription,
+		arg.Owner,
+		arg.Url,
+		arg.Secret,
+		pq.Array(arg.EventTypes),
+	)
+	var i WebhookSubscription
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Url,
+		&i.Secret,
+		pq.Array(&i.EventTypes),
+		&i.IsActive,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteWebhookSubscription = `-- name: DeleteWebhookSubscription :exec
+DELETE FROM webhook_subscriptions WHERE id = $1
+`
+
+func (q *Queries) DeleteWebhookSubscription(ctx context.Context, id int64) error {
+	_, err := q.db.ExecContext(ctx, deleteWebhookSubscription, id)
+	return err
+}
+
+const getWebhookDelivery = `-- name: GetWebhookDelivery :one
+SELECT id, subscription_id, event_id, event_type, payload, status, attempts, response_status, last_error, delivered_at, updated_at, created_at FROM webhook_deliveries WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetWebhookDelivery(ctx context.Context, id int64) (WebhookDelivery, error) {
+	row := q.db.QueryRowContext(ctx, getWebhookDelivery, id)
+	var i WebhookDelivery
+	err := row.Scan(
+		&i.ID,
+		&i.SubscriptionID,
+		&i.EventID,
+		&i.EventType,
+		&i.Payload,
+		&i.Status,
+		&i.Attempts,
+		&i.ResponseStatus,
+		&i.LastError,
+		&i.DeliveredAt,
+		&i.UpdatedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getWebhookSubscription = `-- name: GetWebhookSubscription :one
+SELECT id, owner, url, secret, event_types, is_active, created_at FROM webhook_subscriptions WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetWebhookSubscription(ctx context.Context, id int64) (WebhookSubscription, error) {
+	row := q.db.QueryRowContext(ctx, getWebhookSubscription, id)
+	var i WebhookSubscription
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Url,
+		&i.Secret,
+		pq.Array(&i.EventTypes),
+		&i.IsActive,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listWebhookDeliveries = `-- name: ListWebhookDeliveries :many
+SELECT id, subscription_id, event_id, event_type, payload, status, attempts, response_status, last_error, delivered_at, updated_at, created_at FROM webhook_deliveries
+WHERE subscription_id = $1
+ORDER BY id DESC
+LIMIT $2
+OFFSET $3
+`
+
+type ListWebhookDeliveriesParams struct {
+	SubscriptionID int64 `json:"subscription_id"`
+	Limit          int32 `json:"limit"`
+	Offset         int32 `json:"offset"`
+}
+
+func (q *Queries) ListWebhookDeliveries(ctx context.Context, arg ListWebhookDeliveriesParams) ([]WebhookDelivery, error) {
+	rows, err := q.db.QueryContext(ctx, listWebhookDeliveries, arg.SubscriptionID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []WebhookDelivery{}
+	for rows.Next() {
+		var i WebhookDelivery
+		if err := rows.Scan(
+			&i.ID,
+			&i.SubscriptionID,
+			&i.EventID,
+			&i.EventType,
+			&i.Payload,
+			&i.Status,
+			&i.Attempts,
+			&i.ResponseStatus,
+			&i.LastError,
+			&i.DeliveredAt,
+			&i.UpdatedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWebhookSubscriptions = `-- name: ListWebhookSubscriptions :many
+SELECT id, owner, url, secret, event_types, is_active, created_at FROM webhook_subscriptions
+WHERE owner = $1
+ORDER BY id
+LIMIT $2
+OFFSET $3
+`
+
+type ListWebhookSubscriptionsParams struct {
+	Owner  string `json:"owner"`
+	Limit  int32  `json:"limit"`
+	Offset int32  `json:"offset"`
+}
+
+func (q *Queries) ListWebhookSubscriptions(ctx context.Context, arg ListWebhookSubscriptionsParams) ([]WebhookSubscription, error) {
+	rows, err := q.db.QueryContext(ctx, listWebhookSubscriptions, arg.Owner, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []WebhookSubscription{}
+	for rows.Next() {
+		var i WebhookSubscription
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.Url,
+			&i.Secret,
+			pq.Array(&i.EventTypes),
+			&i.IsActive,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWebhookSubscriptionsForEvent = `-- name: ListWebhookSubscriptionsForEvent :many
+SELECT id, owner, url, secret, event_types, is_active, created_at FROM webhook_subscriptions
+WHERE
+    is_active = TRUE
+    AND owner = ANY($1::varchar[])
+    AND (cardinality(event_types) = 0 OR $2::varchar = ANY(event_types))
+ORDER BY id
+`
+
+type ListWebhookSubscriptionsForEventParams struct {
+	Usernames []string `json:"usernames"`
+	EventType string   `json:"event_type"`
+}
+
+func (q *Queries) ListWebhookSubscriptionsForEvent(ctx context.Context, arg ListWebhookSubscriptionsForEventParams) ([]WebhookSubscription, error) {
+	rows, err := q.db.QueryContext(ctx, listWebhookSubscriptionsForEvent, pq.Array(arg.Usernames), arg.EventType)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []WebhookSubscription{}
+	for rows.Next() {
+		var i WebhookSubscription
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.Url,
+			&i.Secret,
+			pq.Array(&i.EventTypes),
+			&i.IsActive,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateWebhookDeliveryAttempt = `-- name: UpdateWebhookDeliveryAttempt :one
+UPDATE webhook_deliveries
+SET
+    status = $2,
+    attempts = attempts + 1,
+    response_status = $3,
+    last_error = $4,
+    delivered_at = $5,
+    updated_at = now()
+WHERE id = $1
+RETURNING id, subscription_id, event_id, event_type, payload, status, attempts, response_status, last_error, delivered_at, updated_at, created_at
+`
+
+type UpdateWebhookDeliveryAttemptParams struct {
+	ID             int64          `json:"id"`
+	Status         string         `json:"status"`
+	ResponseStatus sql.NullInt32  `json:"response_status"`
+	LastError      sql.NullString `json:"last_error"`
+	DeliveredAt    sql.NullTime   `json:"delivered_at"`
+}
+
+func (q *Queries) UpdateWebhookDeliveryAttempt(ctx context.Context, arg UpdateWebhookDeliveryAttemptParams) (WebhookDelivery, error) {
+	row := q.db.QueryRowContext(ctx, updateWebhookDeliveryAttempt,
+		arg.ID,
+		arg.Status,
+		arg.ResponseStatus,
+		arg.LastError,
+		arg.DeliveredAt,
+	)
+	var i WebhookDelivery
+	err := row.Scan(
+		&i.ID,
+		&i.SubscriptionID,
+		&i.EventID,
+		&i.EventType,
+		&i.Payload,
+		&i.Status,
+		&i.Attempts,
+		&i.ResponseStatus,
+		&i.LastError,
+		&i.DeliveredAt,
+		&i.UpdatedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,119 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/mativm02/bank_system/event"
+	"github.com/mativm02/bank_system/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomWebhookSubscription(t *testing.T, owner string, eventTypes []string) WebhookSubscription {
+	arg := CreateWebhookSubscriptionParams{
+		Owner:      owner,
+		Url:        "https://example.com/" + util.RandomString(8),
+		Secret:     util.RandomString(32),
+		EventTypes: eventTypes,
+	}
+
+	subscription, err := testQueries.CreateWebhookSubscription(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, subscription)
+
+	require.Equal(t, arg.Owner, subscription.Owner)
+	require.Equal(t, arg.Url, subscription.Url)
+	require.Equal(t, arg.Secret, subscription.Secret)
+	require.Equal(t, arg.EventTypes, subscription.EventTypes)
+	require.True(t, subscription.IsActive)
+	require.NotZero(t, subscription.CreatedAt)
+
+	return subscription
+}
+
+func TestListWebhookSubscriptionsForEvent(t *testing.T) {
+	user := createRandomUser(t)
+	all := createRandomWebhookSubscription(t, user.Username, []string{})
+	transfers := createRandomWebhookSubscription(t, user.Username, []string{string(event.TypeTransferCompleted)})
+	createRandomWebhookSubscription(t, user.Username, []string{string(event.TypeAccountCreated)})
+
+	subscriptions, err := testQueries.ListWebhookSubscriptionsForEvent(context.Background(), ListWebhookSubscriptionsForEventParams{
+		Usernames: []string{user.Username},
+		EventType: string(event.TypeTransferCompleted),
+	})
+	require.NoError(t, err)
+	require.Len(t, subscriptions, 2)
+	require.Equal(t, all.ID, subscriptions[0].ID)
+	require.Equal(t, transfers.ID, subscriptions[1].ID)
+}
+
+func TestWebhookDelivery(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+	subscription := createRandomWebhookSubscription(t, user.Username, []string{})
+
+	result, err := store.CreateAccountTx(context.Background(), CreateAccountTxParams{
+		CreateAccountParams: CreateAccountParams{
+			Owner:    user.Username,
+			Balance:  0,
+			Currency: util.RandomCurrency(),
+		},
+		MaxAccounts: 1,
+	})
+	require.NoError(t, err)
+	account := result.Account
+
+	var domainEvent DomainEvent
+	for _, e := range relayDomainEvents(t, store) {
+		if e.Type == event.TypeAccountCreated && e.HasAccount(account.ID) {
+			domainEvent, err = testQueries.GetDomainEvent(context.Background(), e.ID)
+			require.NoError(t, err)
+		}
+	}
+	require.NotZero(t, domainEvent.ID)
+
+	arg := CreateWebhookDeliveryParams{
+		SubscriptionID: subscription.ID,
+		EventID:        domainEvent.ID,
+		EventType:      domainEvent.EventType,
+		Payload:        domainEvent.Payload,
+	}
+	delivery, err := testQueries.CreateWebhookDelivery(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, "pending", delivery.Status)
+	require.Zero(t, delivery.Attempts)
+
+	// Creating the delivery again returns the existing row.
+	duplicate, err := testQueries.CreateWebhookDelivery(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, delivery.ID, duplicate.ID)
+
+	updated, err := testQueries.UpdateWebhookDeliveryAttempt(context.Background(), UpdateWebhookDeliveryAttemptParams{
+		ID:             delivery.ID,
+		Status:         "succeeded",
+		ResponseStatus: sql.NullInt32{Int32: 204, Valid: true},
+		DeliveredAt:    sql.NullTime{Time: time.Now(), Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "succeeded", updated.Status)
+	require.Equal(t, int32(1), updated.Attempts)
+	require.Equal(t, int32(204), updated.ResponseStatus.Int32)
+	require.True(t, updated.DeliveredAt.Valid)
+
+	deliveries, err := testQueries.ListWebhookDeliveries(context.Background(), ListWebhookDeliveriesParams{
+		SubscriptionID: subscription.ID,
+		Limit:          5,
+		Offset:         0,
+	})
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	require.Equal(t, delivery.ID, deliveries[0].ID)
+
+	err = testQueries.DeleteWebhookSubscription(context.Background(), subscription.ID)
+	require.NoError(t, err)
+
+	_, err = testQueries.GetWebhookDelivery(context.Background(), delivery.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
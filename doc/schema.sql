@@ -7,14 +7,22 @@ CREATE TABLE "accounts" (
   "owner" varchar NOT NULL,
   "balance" bigint NOT NULL,
   "currency" varchar NOT NULL,
-  "created_at" timestamptz NOT NULL DEFAULT 'now()'
+  "created_at" timestamptz NOT NULL DEFAULT 'now()',
+  "number" varchar UNIQUE NOT NULL,
+  "type" varchar NOT NULL DEFAULT 'checking',
+  "nickname" varchar NOT NULL DEFAULT '',
+  "interest_product_id" bigint,
+  "locale" varchar NOT NULL DEFAULT 'en',
+  "pending_email" varchar
 );
 
 CREATE TABLE "entries" (
   "id" BIGSERIAL PRIMARY KEY,
   "account_id" bigint NOT NULL,
   "amount" bigint NOT NULL,
-  "created_at" timestamptz NOT NULL DEFAULT 'now()'
+  "created_at" timestamptz NOT NULL DEFAULT 'now()',
+  "transfer_id" bigint,
+  "type" varchar NOT NULL DEFAULT 'transfer'
 );
 
 CREATE TABLE "transfers" (
@@ -32,7 +40,11 @@ CREATE TABLE "users" (
   "email" varchar NOT NULL,
   "is_email_verified" boolean NOT NULL DEFAULT false,
   "password_changed_at" timestamptz NOT NULL DEFAULT '0001-01-01 00:00:00Z',
-  "created_at" timestamptz NOT NULL DEFAULT 'now()'
+  "created_at" timestamptz NOT NULL DEFAULT 'now()',
+  "locale" varchar NOT NULL DEFAULT 'en',
+  "pending_email" varchar,
+  "role" varchar NOT NULL DEFAULT 'depositor',
+  "verify_email_requested_at" timestamptz
 );
 
 CREATE TABLE "verify_emails" (
@@ -45,22 +57,286 @@ CREATE TABLE "verify_emails" (
   "expired_at" timestamptz NOT NULL DEFAULT 'now() + interval 15 minutes'
 );
 
+CREATE TABLE "outbox_messages" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "task_type" varchar NOT NULL,
+  "payload" jsonb NOT NULL,
+  "queue" varchar NOT NULL,
+  "max_retry" int NOT NULL DEFAULT 0,
+  "process_at" timestamptz NOT NULL DEFAULT (now()),
+  "attempts" int NOT NULL DEFAULT 0,
+  "last_error" varchar,
+  "published_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "locked_until" timestamptz,
+  "failed_at" timestamptz
+);
+
+CREATE TABLE "domain_events" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "event_type" varchar NOT NULL,
+  "usernames" varchar[] NOT NULL DEFAULT '{}',
+  "account_ids" bigint[] NOT NULL DEFAULT '{}',
+  "payload" jsonb NOT NULL,
+  "published_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "attempts" int NOT NULL DEFAULT 0,
+  "last_error" varchar,
+  "locked_until" timestamptz,
+  "failed_at" timestamptz
+);
+
+CREATE TABLE "webhook_subscriptions" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "url" varchar NOT NULL,
+  "secret" varchar NOT NULL,
+  "event_types" varchar[] NOT NULL DEFAULT '{}',
+  "is_active" boolean NOT NULL DEFAULT true,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "webhook_deliveries" (
+  "id" bigserial PRIMARY KEY,
+  "subscription_id" bigint NOT NULL,
+  "event_id" bigint NOT NULL,
+  "event_type" varchar NOT NULL,
+  "payload" jsonb NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "attempts" int NOT NULL DEFAULT 0,
+  "response_status" int,
+  "last_error" varchar,
+  "delivered_at" timestamptz,
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "notification_preferences" (
+  "username" varchar PRIMARY KEY,
+  "transfer_mode" varchar NOT NULL DEFAULT 'always',
+  "transfer_threshold" bigint NOT NULL DEFAULT 0,
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "password_history" (
+  "id" bigserial PRIMARY KEY,
+  "username" varchar NOT NULL,
+  "hashed_password" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "api_clients" (
+  "id" varchar PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "name" varchar NOT NULL,
+  "hashed_secret" varchar NOT NULL,
+  "scopes" varchar[] NOT NULL,
+  "created_by" varchar NOT NULL,
+  "revoked_at" timestamptz,
+  "secret_rotated_at" timestamptz NOT NULL DEFAULT (now()),
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "api_keys" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "name" varchar NOT NULL,
+  "prefix" varchar UNIQUE NOT NULL,
+  "hashed_key" varchar NOT NULL,
+  "scopes" varchar[] NOT NULL,
+  "expires_at" timestamptz,
+  "last_used_at" timestamptz,
+  "revoked_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "payees" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "nickname" varchar NOT NULL,
+  "account_id" bigint NOT NULL,
+  "currency" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "interest_products" (
+  "id" bigserial PRIMARY KEY,
+  "name" varchar UNIQUE NOT NULL,
+  "annual_rate_bps" bigint NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "interest_postings" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL DEFAULT 0,
+  "carry_micros" bigint NOT NULL DEFAULT 0,
+  "transfer_id" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "interest_accruals" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "product_id" bigint NOT NULL,
+  "accrual_date" date NOT NULL,
+  "balance" bigint NOT NULL,
+  "annual_rate_bps" bigint NOT NULL,
+  "amount_micros" bigint NOT NULL,
+  "posting_id" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "fee_schedules" (
+  "id" bigserial PRIMARY KEY,
+  "currency" varchar NOT NULL,
+  "min_amount" bigint NOT NULL DEFAULT 0,
+  "flat_fee" bigint NOT NULL DEFAULT 0,
+  "percentage_bps" bigint NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "account_spending_limits" (
+  "account_id" bigint PRIMARY KEY,
+  "daily_limit" bigint,
+  "monthly_limit" bigint,
+  "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "user_spending_limits" (
+  "username" varchar NOT NULL,
+  "currency" varchar NOT NULL,
+  "daily_limit" bigint,
+  "monthly_limit" bigint,
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("username", "currency")
+);
+
 CREATE INDEX ON "accounts" ("owner");
 
-CREATE UNIQUE INDEX ON "accounts" ("owner", "currency");
+CREATE INDEX ON "accounts" ("owner", "currency", "type");
+
+CREATE INDEX ON "accounts" ("owner", "id");
+
+CREATE INDEX ON "accounts" ("owner", "balance", "id");
+
+CREATE INDEX ON "accounts" ("owner", "created_at", "id");
 
 CREATE INDEX ON "entries" ("account_id");
 
+CREATE INDEX ON "entries" ("account_id", "created_at");
+
+CREATE INDEX ON "entries" ("transfer_id");
+
 CREATE INDEX ON "transfers" ("from_account_id");
 
 CREATE INDEX ON "transfers" ("to_account_id");
 
 CREATE INDEX ON "transfers" ("from_account_id", "to_account_id");
 
+CREATE INDEX ON "transfers" ("from_account_id", "created_at");
+
+CREATE INDEX ON "transfers" ("from_account_id", "created_at", "id");
+
+CREATE INDEX ON "transfers" ("to_account_id", "created_at", "id");
+
+CREATE INDEX ON "transfers" ("from_account_id", "amount", "id");
+
+CREATE INDEX ON "transfers" ("to_account_id", "amount", "id");
+
+CREATE INDEX ON "outbox_messages" ("id") WHERE "published_at" IS NULL AND "failed_at" IS NULL;
+
+CREATE INDEX ON "domain_events" ("id") WHERE "published_at" IS NULL AND "failed_at" IS NULL;
+
+CREATE INDEX ON "domain_events" USING GIN ("account_ids");
+
+CREATE INDEX ON "webhook_subscriptions" ("owner");
+
+CREATE UNIQUE INDEX ON "webhook_deliveries" ("subscription_id", "event_id");
+
+CREATE INDEX ON "password_history" ("username", "id");
+
+CREATE INDEX ON "api_clients" ("owner");
+
+CREATE INDEX ON "api_keys" ("owner");
+
+CREATE UNIQUE INDEX ON "payees" ("owner", "nickname");
+
+CREATE UNIQUE INDEX ON "payees" ("owner", "account_id");
+
+CREATE UNIQUE INDEX ON "accounts" ("number");
+
+CREATE INDEX ON "interest_postings" ("account_id", "id");
+
+CREATE UNIQUE INDEX ON "interest_accruals" ("account_id", "accrual_date");
+
+CREATE INDEX ON "interest_accruals" ("account_id") WHERE "posting_id" IS NULL;
+
+CREATE UNIQUE INDEX ON "fee_schedules" ("currency", "min_amount");
+
+COMMENT ON COLUMN "users"."pending_email" IS 'new email waiting for verification';
+
+COMMENT ON COLUMN "users"."role" IS 'depositor or admin';
+
 COMMENT ON COLUMN "entries"."amount" IS 'can be negative or positive';
 
+COMMENT ON COLUMN "entries"."transfer_id" IS 'transfer that created the entry';
+
 COMMENT ON COLUMN "transfers"."amount" IS 'must be positive';
 
+COMMENT ON COLUMN "webhook_subscriptions"."event_types" IS 'empty means every event type';
+
+COMMENT ON COLUMN "webhook_deliveries"."status" IS 'pending, succeeded or dead';
+
+COMMENT ON COLUMN "notification_preferences"."transfer_mode" IS 'always, above_threshold or never';
+
+COMMENT ON COLUMN "api_clients"."owner" IS 'user the client acts for';
+
+COMMENT ON COLUMN "api_keys"."prefix" IS 'public part of the key used to look it up';
+
+COMMENT ON COLUMN "api_keys"."expires_at" IS 'null means the key never expires';
+
+COMMENT ON COLUMN "payees"."currency" IS 'currency of the account, which the transfers to the payee must use';
+
+COMMENT ON COLUMN "accounts"."number" IS 'IBAN-like number with mod-97 check digits';
+
+COMMENT ON COLUMN "accounts"."type" IS 'checking, savings or business';
+
+COMMENT ON COLUMN "accounts"."nickname" IS 'empty when the owner has not named the account';
+
+COMMENT ON COLUMN "interest_products"."annual_rate_bps" IS 'annual interest rate in basis points, 250 is 2.50%';
+
+COMMENT ON COLUMN "interest_postings"."carry_micros" IS 'accrued interest below one minor unit, carried to the next posting';
+
+COMMENT ON COLUMN "interest_accruals"."balance" IS 'balance the interest was computed on';
+
+COMMENT ON COLUMN "interest_accruals"."amount_micros" IS 'interest of the day in millionths of a minor unit, rounded down';
+
+COMMENT ON COLUMN "fee_schedules"."min_amount" IS 'smallest amount of the tier: a transfer pays the fee of the tier with the highest min_amount up to its amount';
+
+COMMENT ON COLUMN "fee_schedules"."percentage_bps" IS 'part of the amount in basis points added to the flat fee, rounded down';
+
+COMMENT ON COLUMN "entries"."type" IS 'transfer or fee';
+
+COMMENT ON COLUMN "account_spending_limits"."daily_limit" IS 'most the account can send in 24 hours, 0 is no limit and NULL is the default limit';
+
+COMMENT ON COLUMN "account_spending_limits"."monthly_limit" IS 'most the account can send in 30 days, 0 is no limit and NULL is the default limit';
+
+COMMENT ON COLUMN "user_spending_limits"."daily_limit" IS 'most the accounts of the user in the currency can send together in 24 hours, 0 is no limit and NULL is the default limit';
+
+COMMENT ON COLUMN "user_spending_limits"."monthly_limit" IS 'most the accounts of the user in the currency can send together in 30 days, 0 is no limit and NULL is the default limit';
+
+COMMENT ON COLUMN "users"."verify_email_requested_at" IS 'when the last verification email was queued, which throttles the resends';
+
+COMMENT ON COLUMN "outbox_messages"."locked_until" IS 'a relay claimed the message and is publishing it until then';
+
+COMMENT ON COLUMN "outbox_messages"."failed_at" IS 'the relay gave up on the message after too many failed attempts';
+
+COMMENT ON COLUMN "domain_events"."locked_until" IS 'a relay claimed the event and is publishing it until then';
+
+COMMENT ON COLUMN "domain_events"."failed_at" IS 'the relay gave up on the event after too many failed attempts';
+
 ALTER TABLE "accounts" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
 
 ALTER TABLE "verify_emails" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
@@ -70,3 +346,41 @@ ALTER TABLE "entries" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id"
 ALTER TABLE "transfers" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
 
 ALTER TABLE "transfers" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "webhook_subscriptions" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "webhook_deliveries" ADD FOREIGN KEY ("subscription_id") REFERENCES "webhook_subscriptions" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "webhook_deliveries" ADD FOREIGN KEY ("event_id") REFERENCES "domain_events" ("id");
+
+ALTER TABLE "notification_preferences" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "password_history" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "api_clients" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "api_clients" ADD FOREIGN KEY ("created_by") REFERENCES "users" ("username");
+
+ALTER TABLE "api_keys" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "entries" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "payees" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "payees" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "accounts" ADD FOREIGN KEY ("interest_product_id") REFERENCES "interest_products" ("id");
+
+ALTER TABLE "interest_postings" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "interest_postings" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "interest_accruals" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "interest_accruals" ADD FOREIGN KEY ("product_id") REFERENCES "interest_products" ("id");
+
+ALTER TABLE "interest_accruals" ADD FOREIGN KEY ("posting_id") REFERENCES "interest_postings" ("id");
+
+ALTER TABLE "account_spending_limits" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "user_spending_limits" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
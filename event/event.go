@@ -0,0 +1,125 @@
+package event
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Type identifies the kind of a domain event.
+type Type string
+
+const (
+	TypeUserCreated       Type = "user.created"
+	TypeUserVerified      Type = "user.verified"
+	TypeAccountCreated    Type = "account.created"
+	TypeTransferCompleted Type = "transfer.completed"
+)
+
+// IsSupportedType checks if the event type is known.
+func IsSupportedType(eventType string) bool {
+	switch Type(eventType) {
+	case TypeUserCreated, TypeUserVerified, TypeAccountCreated, TypeTransferCompleted:
+		return true
+	}
+	return false
+}
+
+// Event is the envelope published for every domain event.
+// Usernames and AccountIDs list who the event is about, so consumers can filter without decoding Data.
+type Event struct {
+	ID         int64           `json:"id"`
+	Type       Type            `json:"type"`
+	Usernames  []string        `json:"usernames"`
+	AccountIDs []int64         `json:"account_ids"`
+	Data       json.RawMessage `json:"data"`
+	OccurredAt time.Time       `json:"occurred_at"`
+}
+
+// Payload is implemented by the typed data of every domain event.
+type Payload interface {
+	EventType() Type
+}
+
+type UserCreated struct {
+	Username string `json:"username"`
+	Email    string `json:"email"`
+}
+
+func (UserCreated) EventType() Type { return TypeUserCreated }
+
+type UserVerified struct {
+	Username string `json:"username"`
+	Email    string `json:"email"`
+}
+
+func (UserVerified) EventType() Type { return TypeUserVerified }
+
+type AccountCreated struct {
+	AccountID int64  `json:"account_id"`
+	Owner     string `json:"owner"`
+	Currency  string `json:"currency"`
+}
+
+func (AccountCreated) EventType() Type { return TypeAccountCreated }
+
+type TransferCompleted struct {
+	TransferID    int64  `json:"transfer_id"`
+	FromAccountID int64  `json:"from_account_id"`
+	ToAccountID   int64  `json:"to_account_id"`
+	Amount        int64  `json:"amount"`
+	Currency      string `json:"currency"`
+}
+
+func (TransferCompleted) EventType() Type { return TypeTransferCompleted }
+
+// IsAccountEvent reports whether the event describes a change to one or more accounts.
+func (e Event) IsAccountEvent() bool {
+	switch e.Type {
+	case TypeAccountCreated, TypeTransferCompleted:
+		return true
+	}
+	return false
+}
+
+// HasAccount reports whether the event concerns the given account.
+func (e Event) HasAccount(accountID int64) bool {
+	for _, id := range e.AccountIDs {
+		if id == accountID {
+			return true
+		}
+	}
+	return false
+}
+
+// HasUsername reports whether the event concerns the given user.
+func (e Event) HasUsername(username string) bool {
+	for _, name := range e.Usernames {
+		if name == username {
+			return true
+		}
+	}
+	return false
+}
+
+// Decode returns the typed payload of the event.
+func (e Event) Decode() (Payload, error) {
+	var payload Payload
+	switch e.Type {
+	case TypeUserCreated:
+		payload = &UserCreated{}
+	case TypeUserVerified:
+		payload = &UserVerified{}
+	case TypeAccountCreated:
+		payload = &AccountCreated{}
+	case TypeTransferCompleted:
+		payload = &TransferCompleted{}
+	default:
+		return nil, fmt.Errorf("unknown event type %q", e.Type)
+	}
+
+	if err := json.Unmarshal(e.Data, payload); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s event: %w", e.Type, err)
+	}
+	return payload, nil
+}
@@ -0,0 +1,65 @@
+package event
+
+import (
+	"context"
+	"sync"
+)
+
+const subscriptionBufferSize = 64
+
+// MemoryBroker keeps events in memory. It is meant for tests and single-process setups.
+type MemoryBroker struct {
+	mu          sync.Mutex
+	published   []Event
+	subscribers map[chan Event]struct{}
+}
+
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{
+		subscribers: make(map[chan Event]struct{}),
+	}
+}
+
+// Publish records the events and delivers them to every current subscriber.
+// Subscribers whose buffer is full miss the events instead of blocking the publisher.
+func (broker *MemoryBroker) Publish(ctx context.Context, events ...Event) error {
+	broker.mu.Lock()
+	defer broker.mu.Unlock()
+
+	broker.published = append(broker.published, events...)
+	for ch := range broker.subscribers {
+		for _, e := range events {
+			select {
+			case ch <- e:
+			default:
+			}
+		}
+	}
+	return nil
+}
+
+func (broker *MemoryBroker) Subscribe(ctx context.Context) (<-chan Event, error) {
+	ch := make(chan Event, subscriptionBufferSize)
+
+	broker.mu.Lock()
+	broker.subscribers[ch] = struct{}{}
+	broker.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		broker.mu.Lock()
+		delete(broker.subscribers, ch)
+		close(ch)
+		broker.mu.Unlock()
+	}()
+
+	return ch, nil
+}
+
+// Published returns every event published so far.
+func (broker *MemoryBroker) Published() []Event {
+	broker.mu.Lock()
+	defer broker.mu.Unlock()
+
+	return append([]Event(nil), broker.published...)
+}
@@ -0,0 +1,70 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mativm02/bank_system/util"
+	"github.com/stretchr/testify/require"
+)
+
+func randomTransferCompletedEvent(t *testing.T) Event {
+	payload := TransferCompleted{
+		TransferID:    util.RandomInt(1, 1000),
+		FromAccountID: util.RandomInt(1, 1000),
+		ToAccountID:   util.RandomInt(1, 1000),
+		Amount:        util.RandomMoney(),
+		Currency:      util.RandomCurrency(),
+	}
+
+	data, err := json.Marshal(payload)
+	require.NoError(t, err)
+
+	return Event{
+		ID:         util.RandomInt(1, 1000),
+		Type:       payload.EventType(),
+		Usernames:  []string{util.RandomOwner(), util.RandomOwner()},
+		AccountIDs: []int64{payload.FromAccountID, payload.ToAccountID},
+		Data:       data,
+		OccurredAt: time.Now(),
+	}
+}
+
+func TestMemoryBroker(t *testing.T) {
+	broker := NewMemoryBroker()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events, err := broker.Subscribe(ctx)
+	require.NoError(t, err)
+
+	e := randomTransferCompletedEvent(t)
+	err = broker.Publish(context.Background(), e)
+	require.NoError(t, err)
+
+	received := <-events
+	require.Equal(t, e, received)
+	require.Equal(t, []Event{e}, broker.Published())
+
+	payload, err := received.Decode()
+	require.NoError(t, err)
+	transfer, ok := payload.(*TransferCompleted)
+	require.True(t, ok)
+	require.True(t, received.HasAccount(transfer.FromAccountID))
+	require.True(t, received.HasAccount(transfer.ToAccountID))
+	require.True(t, received.HasUsername(e.Usernames[0]))
+	require.True(t, received.IsAccountEvent())
+
+	cancel()
+	_, ok = <-events
+	require.False(t, ok)
+}
+
+func TestDecodeUnknownEvent(t *testing.T) {
+	e := Event{Type: "unknown", Data: []byte(`{}`)}
+
+	payload, err := e.Decode()
+	require.Error(t, err)
+	require.Nil(t, payload)
+}
@@ -0,0 +1,20 @@
+package event
+
+import "context"
+
+// Publisher sends domain events to the interested consumers.
+type Publisher interface {
+	Publish(ctx context.Context, events ...Event) error
+}
+
+// Subscriber streams the events published from the moment of the subscription
+// until the context is canceled, at which point the channel is closed.
+type Subscriber interface {
+	Subscribe(ctx context.Context) (<-chan Event, error)
+}
+
+// Broker is both a Publisher and a Subscriber.
+type Broker interface {
+	Publisher
+	Subscriber
+}
@@ -0,0 +1,111 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// DefaultStream is the Redis stream that holds the domain events.
+	DefaultStream = "bank:events"
+
+	redisEventField  = "event"
+	redisStreamLimit = 100000
+	redisReadBlock   = 5 * time.Second
+)
+
+// RedisBroker publishes domain events to a Redis stream.
+type RedisBroker struct {
+	client *redis.Client
+	stream string
+}
+
+func NewRedisBroker(client *redis.Client, stream string) Broker {
+	return &RedisBroker{
+		client: client,
+		stream: stream,
+	}
+}
+
+func (broker *RedisBroker) Publish(ctx context.Context, events ...Event) error {
+	for _, e := range events {
+		data, err := json.Marshal(e)
+		if err != nil {
+			return fmt.Errorf("failed to marshal event: %w", err)
+		}
+
+		err = broker.client.XAdd(ctx, &redis.XAddArgs{
+			Stream: broker.stream,
+			MaxLen: redisStreamLimit,
+			Approx: true,
+			Values: map[string]interface{}{redisEventField: data},
+		}).Err()
+		if err != nil {
+			return fmt.Errorf("failed to add event to stream: %w", err)
+		}
+	}
+	return nil
+}
+
+// Subscribe reads the stream from its current end, so only new events are delivered.
+func (broker *RedisBroker) Subscribe(ctx context.Context) (<-chan Event, error) {
+	ch := make(chan Event, subscriptionBufferSize)
+
+	go func() {
+		defer close(ch)
+
+		lastID := "$"
+		for ctx.Err() == nil {
+			streams, err := broker.client.XRead(ctx, &redis.XReadArgs{
+				Streams: []string{broker.stream, lastID},
+				Block:   redisReadBlock,
+			}).Result()
+			if err != nil {
+				if errors.Is(err, redis.Nil) || ctx.Err() != nil {
+					continue
+				}
+				log.Error().Err(err).Str("stream", broker.stream).Msg("failed to read event stream")
+				time.Sleep(time.Second)
+				continue
+			}
+
+			for _, stream := range streams {
+				for _, message := range stream.Messages {
+					lastID = message.ID
+
+					e, err := decodeRedisEvent(message)
+					if err != nil {
+						log.Error().Err(err).Str("id", message.ID).Msg("skipping invalid event")
+						continue
+					}
+
+					select {
+					case ch <- e:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
+func decodeRedisEvent(message redis.XMessage) (Event, error) {
+	var e Event
+
+	data, ok := message.Values[redisEventField].(string)
+	if !ok {
+		return e, fmt.Errorf("missing %q field", redisEventField)
+	}
+
+	err := json.Unmarshal([]byte(data), &e)
+	return e, err
+}
@@ -2,11 +2,18 @@ package gapi
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
+	"github.com/mativm02/bank_system/apikey"
+	"github.com/mativm02/bank_system/pb"
 	"github.com/mativm02/bank_system/token"
+	"github.com/mativm02/bank_system/util"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -14,7 +21,133 @@ const (
 	authorizationBearer = "bearer"
 )
 
-func (server *Server) authorizeUser(ctx context.Context) (*token.Payload, error) {
+// errPermissionDenied means that the caller is authenticated but not allowed to call the RPC.
+var errPermissionDenied = errors.New("permission denied")
+
+// methodPolicy describes who is allowed to call an RPC.
+type methodPolicy struct {
+	// public RPCs can be called without credentials.
+	public bool
+	// role is required to call the RPC when it is set. API clients and API keys have no role.
+	role string
+	// scope lets the API clients and API keys call the RPC. Without a scope,
+	// the RPC can only be called with a user access token.
+	scope string
+}
+
+// methodPolicies has the policy of every RPC of the server. RPCs missing from the map
+// are denied to everyone, so a new RPC must be given a policy before it can be called.
+var methodPolicies = map[string]methodPolicy{
+	pb.SimpleBank_CreateUser_FullMethodName:                    {public: true},
+	pb.SimpleBank_LoginUser_FullMethodName:                     {public: true},
+	pb.SimpleBank_VerifyEmail_FullMethodName:                   {public: true},
+	pb.SimpleBank_CreateClientToken_FullMethodName:             {public: true},
+	pb.SimpleBank_UpdateUser_FullMethodName:                    {scope: token.ScopeUsersWrite},
+	pb.SimpleBank_ResendVerifyEmail_FullMethodName:             {scope: token.ScopeUsersWrite},
+	pb.SimpleBank_GetNotificationPreferences_FullMethodName:    {scope: token.ScopeUsersRead},
+	pb.SimpleBank_UpdateNotificationPreferences_FullMethodName: {scope: token.ScopeUsersWrite},
+	pb.SimpleBank_WatchAccountEvents_FullMethodName:            {scope: token.ScopeAccountsRead},
+	pb.SimpleBank_ListAccounts_FullMethodName:                  {scope: token.ScopeAccountsRead},
+	pb.SimpleBank_ListTransfers_FullMethodName:                 {scope: token.ScopeTransfersRead},
+	pb.SimpleBank_SearchTransfers_FullMethodName:               {scope: token.ScopeTransfersRead},
+	// Only users can add payees. Adding a payee does not skip the cooling-off, which limits the transfers
+	// to any account of another user that was added or first paid recently.
+	pb.SimpleBank_CreatePayee_FullMethodName:               {},
+	pb.SimpleBank_ListPayees_FullMethodName:                {scope: token.ScopeTransfersRead},
+	pb.SimpleBank_DeletePayee_FullMethodName:               {},
+	pb.SimpleBank_CreateInterestProduct_FullMethodName:     {role: util.AdminRole},
+	pb.SimpleBank_SetAccountInterestProduct_FullMethodName: {role: util.AdminRole},
+	pb.SimpleBank_ListInterestAccruals_FullMethodName:      {scope: token.ScopeAccountsRead},
+	pb.SimpleBank_SetAccountSpendingLimit_FullMethodName:   {role: util.AdminRole},
+	pb.SimpleBank_SetUserSpendingLimit_FullMethodName:      {role: util.AdminRole},
+	pb.SimpleBank_CreateWebhook_FullMethodName:             {scope: token.ScopeWebhooksWrite},
+	pb.SimpleBank_ListWebhooks_FullMethodName:              {scope: token.ScopeWebhooksRead},
+	pb.SimpleBank_DeleteWebhook_FullMethodName:             {scope: token.ScopeWebhooksWrite},
+	pb.SimpleBank_ListWebhookDeliveries_FullMethodName:     {scope: token.ScopeWebhooksRead},
+	pb.SimpleBank_CreateApiKey_FullMethodName:              {},
+	pb.SimpleBank_ListApiKeys_FullMethodName:               {},
+	pb.SimpleBank_RevokeApiKey_FullMethodName:              {},
+	pb.SimpleBank_CreateApiClient_FullMethodName:           {role: util.AdminRole},
+	pb.SimpleBank_RotateApiClientSecret_FullMethodName:     {role: util.AdminRole},
+	pb.SimpleBank_RevokeApiClient_FullMethodName:           {role: util.AdminRole},
+
+	// Server reflection lets the gRPC clients explore the methods of the server.
+	"/grpc.reflection.v1.ServerReflection/ServerReflectionInfo":      {public: true},
+	"/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo": {public: true},
+}
+
+type authPayloadKey struct{}
+
+// UnaryAuthInterceptor enforces the policy of the unary RPCs.
+func (server *Server) UnaryAuthInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := server.authorizeMethod(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// StreamAuthInterceptor enforces the policy of the streaming RPCs.
+func (server *Server) StreamAuthInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := server.authorizeMethod(stream.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &contextServerStream{ServerStream: stream, ctx: ctx})
+}
+
+// authorizeMethod checks that the caller is allowed to call the RPC and
+// returns a context holding its payload, unless the RPC is public.
+func (server *Server) authorizeMethod(ctx context.Context, method string) (context.Context, error) {
+	policy, ok := methodPolicies[method]
+	if !ok {
+		return nil, status.Errorf(codes.PermissionDenied, "no authorization policy for %s", method)
+	}
+
+	if policy.public {
+		return ctx, nil
+	}
+
+	payload, err := server.authenticate(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if err := authorize(payload, policy); err != nil {
+		return nil, authorizationError(err)
+	}
+
+	return context.WithValue(ctx, authPayloadKey{}, payload), nil
+}
+
+// authorize checks the role and the scope required by the policy.
+func authorize(payload *token.Payload, policy methodPolicy) error {
+	if policy.role != "" && payload.Role != policy.role {
+		return fmt.Errorf("%w: %s role required", errPermissionDenied, policy.role)
+	}
+
+	if payload.IsScoped() {
+		if policy.scope == "" {
+			return fmt.Errorf("%w: a user access token is required", errPermissionDenied)
+		}
+		if !payload.HasScope(policy.scope) {
+			return fmt.Errorf("%w: token does not grant the %s scope", errPermissionDenied, policy.scope)
+		}
+	}
+
+	return nil
+}
+
+// authorizationPayload returns the payload of the caller stored by the auth interceptors.
+func authorizationPayload(ctx context.Context) (*token.Payload, error) {
+	payload, ok := ctx.Value(authPayloadKey{}).(*token.Payload)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "missing authorization payload")
+	}
+	return payload, nil
+}
+
+func (server *Server) authenticate(ctx context.Context) (*token.Payload, error) {
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
 		return nil, fmt.Errorf("missing context metadata")
@@ -36,8 +169,21 @@ func (server *Server) authorizeUser(ctx context.Context) (*token.Payload, error)
 		return nil, fmt.Errorf("invalid authorization type")
 	}
 
-	tokenString := fields[1]
-	payload, err := server.tokenMaker.VerifyToken(tokenString)
+	credential := fields[1]
+	if apikey.IsKey(credential) {
+		payload, err := server.apiKeys.Authenticate(ctx, credential)
+		if err != nil {
+			return nil, fmt.Errorf("invalid API key: %w", err)
+		}
+		return payload, nil
+	}
+
+	payload, err := server.tokenMaker.VerifyToken(credential)
+	if err != nil {
+		return nil, fmt.Errorf("invalid token: %w", err)
+	}
+
+	err = server.apiKeys.VerifyClient(ctx, payload)
 	if err != nil {
 		return nil, fmt.Errorf("invalid token: %w", err)
 	}
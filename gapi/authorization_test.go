@@ -0,0 +1,172 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/mativm02/bank_system/db/mock"
+	db "github.com/mativm02/bank_system/db/sqlc"
+	"github.com/mativm02/bank_system/pb"
+	"github.com/mativm02/bank_system/token"
+	"github.com/mativm02/bank_system/util"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func newTestServer(t *testing.T, store db.Store) *Server {
+	config := util.Config{
+		TokenSymmetricKey:   util.RandomString(32),
+		PageTokenKey:        util.RandomString(32),
+		AccessTokenDuration: time.Minute,
+	}
+
+	server, err := NewServer(config, store, nil, nil)
+	require.NoError(t, err)
+
+	return server
+}
+
+func TestMethodPolicies(t *testing.T) {
+	for _, method := range pb.SimpleBank_ServiceDesc.Methods {
+		fullMethod := fmt.Sprintf("/%s/%s", pb.SimpleBank_ServiceDesc.ServiceName, method.MethodName)
+		require.Contains(t, methodPolicies, fullMethod)
+	}
+
+	for _, stream := range pb.SimpleBank_ServiceDesc.Streams {
+		fullMethod := fmt.Sprintf("/%s/%s", pb.SimpleBank_ServiceDesc.ServiceName, stream.StreamName)
+		require.Contains(t, methodPolicies, fullMethod)
+	}
+}
+
+func TestUnaryAuthInterceptor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	username := util.RandomOwner()
+	activeClient := db.ApiClient{ID: util.RandomString(12), Owner: username, SecretRotatedAt: time.Now().Add(-time.Hour)}
+	revokedClient := db.ApiClient{ID: util.RandomString(12), Owner: username, SecretRotatedAt: time.Now().Add(-time.Hour),
+		RevokedAt: sql.NullTime{Time: time.Now(), Valid: true}}
+	rotatedClient := db.ApiClient{ID: util.RandomString(12), Owner: username, SecretRotatedAt: time.Now().Add(time.Minute)}
+
+	store := mockdb.NewMockStore(ctrl)
+	for _, client := range []db.ApiClient{activeClient, revokedClient, rotatedClient} {
+		store.EXPECT().GetApiClient(gomock.Any(), gomock.Eq(client.ID)).AnyTimes().Return(client, nil)
+	}
+	server := newTestServer(t, store)
+
+	userToken := func(role string) string {
+		accessToken, _, err := server.tokenMaker.CreateToken(username, role, time.Minute)
+		require.NoError(t, err)
+		return accessToken
+	}
+	clientToken := func(client db.ApiClient, scopes ...string) string {
+		accessToken, _, err := server.tokenMaker.CreateClientToken(client.ID, username, scopes, time.Minute)
+		require.NoError(t, err)
+		return accessToken
+	}
+
+	testCases := []struct {
+		name        string
+		method      string
+		accessToken string
+		code        codes.Code
+	}{
+		{
+			name:   "Public",
+			method: pb.SimpleBank_LoginUser_FullMethodName,
+			code:   codes.OK,
+		},
+		{
+			name:   "NoAuthorization",
+			method: pb.SimpleBank_UpdateUser_FullMethodName,
+			code:   codes.Unauthenticated,
+		},
+		{
+			name:        "UserToken",
+			method:      pb.SimpleBank_UpdateUser_FullMethodName,
+			accessToken: userToken(util.DepositorRole),
+			code:        codes.OK,
+		},
+		{
+			name:        "ClientTokenWithScope",
+			method:      pb.SimpleBank_UpdateUser_FullMethodName,
+			accessToken: clientToken(activeClient, token.ScopeUsersWrite),
+			code:        codes.OK,
+		},
+		{
+			name:        "ClientTokenWithoutScope",
+			method:      pb.SimpleBank_UpdateUser_FullMethodName,
+			accessToken: clientToken(activeClient, token.ScopeUsersRead),
+			code:        codes.PermissionDenied,
+		},
+		{
+			name:        "RevokedClientToken",
+			method:      pb.SimpleBank_UpdateUser_FullMethodName,
+			accessToken: clientToken(revokedClient, token.ScopeUsersWrite),
+			code:        codes.Unauthenticated,
+		},
+		{
+			name:        "ClientTokenIssuedBeforeRotation",
+			method:      pb.SimpleBank_UpdateUser_FullMethodName,
+			accessToken: clientToken(rotatedClient, token.ScopeUsersWrite),
+			code:        codes.Unauthenticated,
+		},
+		{
+			name:        "ClientTokenOnUserOnlyMethod",
+			method:      pb.SimpleBank_CreateApiKey_FullMethodName,
+			accessToken: clientToken(activeClient, token.ScopeUsersWrite),
+			code:        codes.PermissionDenied,
+		},
+		{
+			name:        "Admin",
+			method:      pb.SimpleBank_CreateApiClient_FullMethodName,
+			accessToken: userToken(util.AdminRole),
+			code:        codes.OK,
+		},
+		{
+			name:        "NotAdmin",
+			method:      pb.SimpleBank_CreateApiClient_FullMethodName,
+			accessToken: userToken(util.DepositorRole),
+			code:        codes.PermissionDenied,
+		},
+		{
+			name:        "UnknownMethod",
+			method:      "/pb.SimpleBank/Unknown",
+			accessToken: userToken(util.AdminRole),
+			code:        codes.PermissionDenied,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.accessToken != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(authorizationHeader, "Bearer "+tc.accessToken))
+			}
+
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				policy := methodPolicies[tc.method]
+				payload, err := authorizationPayload(ctx)
+				if policy.public {
+					require.Error(t, err)
+					return nil, nil
+				}
+				require.NoError(t, err)
+				require.Equal(t, username, payload.Username)
+				return nil, nil
+			}
+
+			_, err := server.UnaryAuthInterceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tc.method}, handler)
+			require.Equal(t, tc.code, status.Code(err))
+		})
+	}
+}
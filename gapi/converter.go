@@ -1,7 +1,9 @@
 package gapi
 
 import (
+	"github.com/mativm02/bank_system/apikey"
 	db "github.com/mativm02/bank_system/db/sqlc"
+	"github.com/mativm02/bank_system/event"
 	"github.com/mativm02/bank_system/pb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -13,5 +15,213 @@ func convertUser(user db.User) *pb.User {
 		Email:             user.Email,
 		PasswordChangedAt: timestamppb.New(user.PasswordChangedAt),
 		CreatedAt:         timestamppb.New(user.CreatedAt),
+		Locale:            user.Locale,
+		PendingEmail:      user.PendingEmail.String,
+		IsEmailVerified:   user.IsEmailVerified,
+		Role:              user.Role,
 	}
 }
+
+func convertAccount(account db.Account) *pb.Account {
+	return &pb.Account{
+		Id:        account.ID,
+		Owner:     account.Owner,
+		Balance:   account.Balance,
+		Currency:  account.Currency,
+		CreatedAt: timestamppb.New(account.CreatedAt),
+		Number:    account.Number,
+		Type:      account.Type,
+		Nickname:  account.Nickname,
+		// 0 when the account earns no interest
+		InterestProductId: account.InterestProductID.Int64,
+	}
+}
+
+func convertTransfer(transfer db.Transfer) *pb.Transfer {
+	return &pb.Transfer{
+		Id:            transfer.ID,
+		FromAccountId: transfer.FromAccountID,
+		ToAccountId:   transfer.ToAccountID,
+		Amount:        transfer.Amount,
+		CreatedAt:     timestamppb.New(transfer.CreatedAt),
+	}
+}
+
+func convertPayee(payee db.Payee) *pb.Payee {
+	return &pb.Payee{
+		Id:        payee.ID,
+		Nickname:  payee.Nickname,
+		AccountId: payee.AccountID,
+		Currency:  payee.Currency,
+		CreatedAt: timestamppb.New(payee.CreatedAt),
+	}
+}
+
+func convertInterestProduct(product db.InterestProduct) *pb.InterestProduct {
+	return &pb.InterestProduct{
+		Id:            product.ID,
+		Name:          product.Name,
+		AnnualRateBps: product.AnnualRateBps,
+		CreatedAt:     timestamppb.New(product.CreatedAt),
+	}
+}
+
+func convertInterestAccrual(accrual db.InterestAccrual) *pb.InterestAccrual {
+	return &pb.InterestAccrual{
+		Id:            accrual.ID,
+		AccountId:     accrual.AccountID,
+		ProductId:     accrual.ProductID,
+		AccrualDate:   accrual.AccrualDate.Format("2006-01-02"),
+		Balance:       accrual.Balance,
+		AnnualRateBps: accrual.AnnualRateBps,
+		AmountMicros:  accrual.AmountMicros,
+		Posted:        accrual.PostingID.Valid,
+	}
+}
+
+func convertAccountSpendingLimit(limit db.AccountSpendingLimit) *pb.AccountSpendingLimit {
+	rsp := &pb.AccountSpendingLimit{
+		AccountId: limit.AccountID,
+		UpdatedAt: timestamppb.New(limit.UpdatedAt),
+	}
+
+	if limit.DailyLimit.Valid {
+		rsp.DailyLimit = &limit.DailyLimit.Int64
+	}
+	if limit.MonthlyLimit.Valid {
+		rsp.MonthlyLimit = &limit.MonthlyLimit.Int64
+	}
+	return rsp
+}
+
+func convertUserSpendingLimit(limit db.UserSpendingLimit) *pb.UserSpendingLimit {
+	rsp := &pb.UserSpendingLimit{
+		Username:  limit.Username,
+		Currency:  limit.Currency,
+		UpdatedAt: timestamppb.New(limit.UpdatedAt),
+	}
+
+	if limit.DailyLimit.Valid {
+		rsp.DailyLimit = &limit.DailyLimit.Int64
+	}
+	if limit.MonthlyLimit.Valid {
+		rsp.MonthlyLimit = &limit.MonthlyLimit.Int64
+	}
+	return rsp
+}
+
+func convertAccountEvent(e event.Event) (*pb.AccountEvent, error) {
+	payload, err := e.Decode()
+	if err != nil {
+		return nil, err
+	}
+
+	accountEvent := &pb.AccountEvent{
+		Id:         e.ID,
+		Type:       string(e.Type),
+		AccountIds: e.AccountIDs,
+		OccurredAt: timestamppb.New(e.OccurredAt),
+	}
+
+	switch data := payload.(type) {
+	case *event.AccountCreated:
+		accountEvent.Data = &pb.AccountEvent_AccountCreated{
+			AccountCreated: &pb.AccountCreatedEvent{
+				AccountId: data.AccountID,
+				Currency:  data.Currency,
+			},
+		}
+	case *event.TransferCompleted:
+		accountEvent.Data = &pb.AccountEvent_TransferCompleted{
+			TransferCompleted: &pb.TransferCompletedEvent{
+				TransferId:    data.TransferID,
+				FromAccountId: data.FromAccountID,
+				ToAccountId:   data.ToAccountID,
+				Amount:        data.Amount,
+				Currency:      data.Currency,
+			},
+		}
+	}
+
+	return accountEvent, nil
+}
+
+func convertWebhook(subscription db.WebhookSubscription) *pb.Webhook {
+	return &pb.Webhook{
+		Id:         subscription.ID,
+		Url:        subscription.Url,
+		EventTypes: subscription.EventTypes,
+		IsActive:   subscription.IsActive,
+		CreatedAt:  timestamppb.New(subscription.CreatedAt),
+	}
+}
+
+func convertWebhookDelivery(delivery db.WebhookDelivery) *pb.WebhookDelivery {
+	rsp := &pb.WebhookDelivery{
+		Id:             delivery.ID,
+		WebhookId:      delivery.SubscriptionID,
+		EventId:        delivery.EventID,
+		EventType:      delivery.EventType,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		ResponseStatus: delivery.ResponseStatus.Int32,
+		LastError:      delivery.LastError.String,
+		UpdatedAt:      timestamppb.New(delivery.UpdatedAt),
+		CreatedAt:      timestamppb.New(delivery.CreatedAt),
+	}
+
+	if delivery.DeliveredAt.Valid {
+		rsp.DeliveredAt = timestamppb.New(delivery.DeliveredAt.Time)
+	}
+	return rsp
+}
+
+func convertNotificationPreference(preference db.NotificationPreference) *pb.NotificationPreferences {
+	rsp := &pb.NotificationPreferences{
+		TransferMode:      preference.TransferMode,
+		TransferThreshold: preference.TransferThreshold,
+	}
+
+	if !preference.UpdatedAt.IsZero() {
+		rsp.UpdatedAt = timestamppb.New(preference.UpdatedAt)
+	}
+	return rsp
+}
+
+func convertApiClient(client db.ApiClient) *pb.ApiClient {
+	rsp := &pb.ApiClient{
+		ClientId:        client.ID,
+		Owner:           client.Owner,
+		Name:            client.Name,
+		Scopes:          client.Scopes,
+		CreatedBy:       client.CreatedBy,
+		SecretRotatedAt: timestamppb.New(client.SecretRotatedAt),
+		CreatedAt:       timestamppb.New(client.CreatedAt),
+	}
+
+	if client.RevokedAt.Valid {
+		rsp.RevokedAt = timestamppb.New(client.RevokedAt.Time)
+	}
+	return rsp
+}
+
+func convertApiKey(apiKey db.ApiKey) *pb.ApiKey {
+	rsp := &pb.ApiKey{
+		Id:        apiKey.ID,
+		Name:      apiKey.Name,
+		Prefix:    apikey.Prefix + apiKey.Prefix,
+		Scopes:    apiKey.Scopes,
+		CreatedAt: timestamppb.New(apiKey.CreatedAt),
+	}
+
+	if apiKey.ExpiresAt.Valid {
+		rsp.ExpiresAt = timestamppb.New(apiKey.ExpiresAt.Time)
+	}
+	if apiKey.LastUsedAt.Valid {
+		rsp.LastUsedAt = timestamppb.New(apiKey.LastUsedAt.Time)
+	}
+	if apiKey.RevokedAt.Valid {
+		rsp.RevokedAt = timestamppb.New(apiKey.RevokedAt.Time)
+	}
+	return rsp
+}
@@ -1,6 +1,10 @@
 package gapi
 
 import (
+	"database/sql"
+	"errors"
+
+	"github.com/mativm02/bank_system/policy"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -28,3 +32,49 @@ func invalidArgumentError(violations []*errdetails.BadRequest_FieldViolation) er
 func unauthenticatedError(err error) error {
 	return status.Errorf(codes.Unauthenticated, "unauthroized: %v", err)
 }
+
+// authorizationError converts the error of authorize: callers with valid
+// credentials that are not allowed to call the RPC are PermissionDenied, the others are Unauthenticated.
+func authorizationError(err error) error {
+	if errors.Is(err, errPermissionDenied) {
+		return status.Errorf(codes.PermissionDenied, "forbidden: %v", err)
+	}
+	return unauthenticatedError(err)
+}
+
+// policyError converts the error of a policy check into a FailedPrecondition status
+// carrying the reason, so clients can prompt the user to fix it.
+func policyError(err error) error {
+	var policyErr *policy.Error
+	if !errors.As(err, &policyErr) {
+		if errors.Is(err, sql.ErrNoRows) {
+			return status.Errorf(codes.NotFound, "user not found: %v", err)
+		}
+		return status.Errorf(codes.Internal, "cannot check policy: %v", err)
+	}
+
+	errorInfo := &errdetails.ErrorInfo{
+		Reason: policyErr.Reason,
+		Domain: policy.Domain,
+		Metadata: map[string]string{
+			"operation": string(policyErr.Operation),
+		},
+	}
+	preconditionFailure := &errdetails.PreconditionFailure{
+		Violations: []*errdetails.PreconditionFailure_Violation{
+			{
+				Type:        policyErr.Reason,
+				Subject:     "users/" + policyErr.Username,
+				Description: policyErr.Error(),
+			},
+		},
+	}
+
+	statusFailed := status.New(codes.FailedPrecondition, policyErr.Error())
+	statusDetails, detailsErr := statusFailed.WithDetails(errorInfo, preconditionFailure)
+	if detailsErr != nil {
+		return statusFailed.Err()
+	}
+
+	return statusDetails.Err()
+}
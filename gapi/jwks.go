@@ -0,0 +1,33 @@
+package gapi
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/mativm02/bank_system/token"
+)
+
+// JWKSPath is where the gateway publishes the public keys of the access tokens.
+const JWKSPath = "/.well-known/jwks.json"
+
+// JWKSHandler publishes the public keys that verify the tokens of the server, so other services
+// can check them without holding the signing key. The set is empty for symmetric token makers.
+func (server *Server) JWKSHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		set := token.JSONWebKeySet{Keys: []token.JSONWebKey{}}
+		if maker, ok := server.tokenMaker.(token.PublicKeyMaker); ok {
+			set = maker.JWKS()
+		}
+
+		w.Header().Set("Content-Type", "application/jwk-set+json")
+		// Verifiers cache the keys, so a new key must be published before it signs tokens.
+		w.Header().Set("Cache-Control", "public, max-age=300")
+		json.NewEncoder(w).Encode(set)
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/mativm02/bank_system/redact"
 	"github.com/rs/zerolog/log"
 
 	"google.golang.org/grpc"
@@ -21,9 +22,9 @@ func GrpcLogger(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo
 		statusCode = st.Code()
 	}
 
-	logger := log.Info()
+	logger := log.Ctx(ctx).Info()
 	if err != nil {
-		logger = log.Error().Err(err)
+		logger = log.Ctx(ctx).Error().Err(err)
 	}
 
 	logger.Str("protocol", "grpc").
@@ -51,9 +52,11 @@ func (rec *ResponseRecorder) Write(b []byte) (int, error) {
 	return rec.ResponseWriter.Write(b)
 }
 
-func HttpLogger(handler http.Handler) http.Handler {
+// HttpLogger logs the HTTP requests. The sensitive query parameters and the sensitive fields
+// of the response bodies logged on errors are redacted.
+func HttpLogger(handler http.Handler, redactor *redact.Redactor) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		logger := log.Info()
+		logger := log.Ctx(r.Context()).Info()
 		startTime := time.Now()
 
 		rec := &ResponseRecorder{
@@ -65,13 +68,13 @@ func HttpLogger(handler http.Handler) http.Handler {
 		duration := time.Since(startTime)
 
 		if rec.StatusCode != http.StatusOK {
-			logger = log.Error().Bytes("body", rec.Body)
+			logger = log.Ctx(r.Context()).Error().Bytes("body", redactor.JSON(rec.Body))
 		}
 
 		logger.
 			Str("protocol", "http").
 			Str("method", r.Method).
-			Str("path", r.RequestURI).
+			Str("path", redactor.URL(r.RequestURI)).
 			Int("status_code", rec.StatusCode).
 			Str("status_text", http.StatusText(rec.StatusCode)).
 			Dur("duration", duration).
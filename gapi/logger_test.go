@@ -0,0 +1,90 @@
+package gapi
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mativm02/bank_system/redact"
+	"github.com/mativm02/bank_system/util"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHttpLoggerRedaction(t *testing.T) {
+	password := util.RandomString(12)
+	accessToken := "v2.local." + util.RandomString(32)
+	refreshToken := "v2.local." + util.RandomString(32)
+	secretCode := util.RandomString(32)
+	email := util.RandomEmail()
+
+	testCases := []struct {
+		name       string
+		method     string
+		target     string
+		body       string
+		statusCode int
+		rspBody    string
+		secrets    []string
+	}{
+		{
+			name:       "LoginUser",
+			method:     http.MethodPost,
+			target:     "/v1/login_user",
+			body:       `{"username":"alice","password":"` + password + `"}`,
+			statusCode: http.StatusOK,
+			rspBody:    `{"user":{"email":"` + email + `"},"access_token":"` + accessToken + `","refresh_token":"` + refreshToken + `"}`,
+			secrets:    []string{password, accessToken, refreshToken, email},
+		},
+		{
+			name:       "LoginUserError",
+			method:     http.MethodPost,
+			target:     "/v1/login_user",
+			body:       `{"username":"alice","password":"` + password + `"}`,
+			statusCode: http.StatusUnauthorized,
+			rspBody:    `{"code":16,"message":"incorrect password","access_token":"` + accessToken + `","email":"` + email + `"}`,
+			secrets:    []string{password, accessToken, email},
+		},
+		{
+			name:       "VerifyEmail",
+			method:     http.MethodGet,
+			target:     "/v1/verify_email?id=1&secret_code=" + secretCode,
+			statusCode: http.StatusOK,
+			rspBody:    `{"is_verified":true}`,
+			secrets:    []string{secretCode},
+		},
+		{
+			name:       "VerifyEmailError",
+			method:     http.MethodGet,
+			target:     "/v1/verify_email?id=1&secret_code=" + secretCode,
+			statusCode: http.StatusBadRequest,
+			rspBody:    `{"code":3,"message":"invalid parameters","secret_code":"` + secretCode + `"}`,
+			secrets:    []string{secretCode},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			var logs bytes.Buffer
+			logger := zerolog.New(&logs)
+
+			handler := HttpLogger(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.statusCode)
+				_, _ = w.Write([]byte(tc.rspBody))
+			}), redact.NewRedactor(util.Config{LogRedactionLevel: redact.LevelStrict}))
+
+			request := httptest.NewRequest(tc.method, tc.target, strings.NewReader(tc.body))
+			request = request.WithContext(logger.WithContext(request.Context()))
+			handler.ServeHTTP(httptest.NewRecorder(), request)
+
+			require.NotEmpty(t, logs.String())
+			for _, secret := range tc.secrets {
+				require.NotContains(t, logs.String(), secret)
+			}
+		})
+	}
+}
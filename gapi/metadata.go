@@ -21,8 +21,18 @@ type Metadata struct {
 func (server *Server) extractMetadata(ctx context.Context) *Metadata {
 	mtdt := &Metadata{}
 
+	// Getting gRPC information
+	if p, ok := peer.FromContext(ctx); ok {
+		mtdt.ClientIP = p.Addr.String()
+	}
+
 	if md, ok := metadata.FromIncomingContext(ctx); ok {
-		// Getting HTTP information
+		// Getting gRPC information
+		if userAgents := md.Get(userAgentHeader); len(userAgents) > 0 {
+			mtdt.UserAgent = userAgents[0]
+		}
+
+		// Getting HTTP information, which replaces the one of the gateway calling the gRPC server
 		if userAgents := md.Get(grpcGatewayUserAgentHeader); len(userAgents) > 0 {
 			mtdt.UserAgent = userAgents[0]
 		}
@@ -31,17 +41,6 @@ func (server *Server) extractMetadata(ctx context.Context) *Metadata {
 		if ips := md.Get(xForwardedForHeader); len(ips) > 0 {
 			mtdt.ClientIP = ips[0]
 		}
-
-		// Getting gRPC information
-		if userAgents := md.Get(userAgentHeader); len(userAgents) > 0 {
-			mtdt.UserAgent = userAgents[0]
-		}
-
-	}
-
-	// Getting gRPC information
-	if p, ok := peer.FromContext(ctx); ok {
-		mtdt.ClientIP = p.Addr.String()
 	}
 
 	return mtdt
@@ -0,0 +1,69 @@
+package gapi
+
+import (
+	"context"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GrpcRecovery turns a panic in a unary RPC into an Internal error instead of crashing the server.
+func GrpcRecovery(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = recoverError(ctx, info.FullMethod, p)
+		}
+	}()
+
+	return handler(ctx, req)
+}
+
+// GrpcStreamRecovery turns a panic in a streaming RPC into an Internal error instead of crashing the server.
+func GrpcStreamRecovery(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = recoverError(stream.Context(), info.FullMethod, p)
+		}
+	}()
+
+	return handler(srv, stream)
+}
+
+func recoverError(ctx context.Context, method string, p interface{}) error {
+	log.Ctx(ctx).Error().
+		Str("method", method).
+		Interface("panic", p).
+		Bytes("stack", debug.Stack()).
+		Msg("recovered from panic")
+	return status.Errorf(codes.Internal, "internal error")
+}
+
+// HttpRecovery turns a panic in the gateway into a 500 response instead of crashing the server.
+func HttpRecovery(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			if p := recover(); p != nil {
+				if p == http.ErrAbortHandler {
+					panic(p)
+				}
+
+				log.Ctx(r.Context()).Error().
+					Str("method", r.Method).
+					Str("path", r.RequestURI).
+					Interface("panic", p).
+					Bytes("stack", debug.Stack()).
+					Msg("recovered from panic")
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusInternalServerError)
+				_, _ = w.Write([]byte(`{"code":13,"message":"internal error"}`))
+			}
+		}()
+
+		handler.ServeHTTP(w, r)
+	})
+}
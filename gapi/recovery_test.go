@@ -0,0 +1,37 @@
+package gapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGrpcRecovery(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/pb.SimpleBank/Test"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		panic("boom")
+	}
+
+	rsp, err := GrpcRecovery(context.Background(), nil, info, handler)
+	require.Nil(t, rsp)
+	require.Equal(t, codes.Internal, status.Code(err))
+}
+
+func TestHttpRecovery(t *testing.T) {
+	handler := HttpRecovery(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/v1/test", nil)
+	handler.ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusInternalServerError, recorder.Code)
+	require.JSONEq(t, `{"code":13,"message":"internal error"}`, recorder.Body.String())
+}
@@ -0,0 +1,71 @@
+package gapi
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/mativm02/bank_system/requestid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// GrpcRequestID propagates the x-request-id of the caller, or generates one, into the context
+// and the zerolog logger of the unary RPCs, and returns it in the response header.
+func GrpcRequestID(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	id := incomingRequestID(ctx)
+	ctx = requestid.NewContext(ctx, id)
+
+	// The header is sent with the response, or with the trailers when the RPC fails.
+	_ = grpc.SetHeader(ctx, metadata.Pairs(requestid.Header, id))
+	return handler(ctx, req)
+}
+
+// GrpcStreamRequestID is GrpcRequestID for the streaming RPCs.
+func GrpcStreamRequestID(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	id := incomingRequestID(stream.Context())
+	ctx := requestid.NewContext(stream.Context(), id)
+
+	_ = stream.SetHeader(metadata.Pairs(requestid.Header, id))
+	return handler(srv, &contextServerStream{ServerStream: stream, ctx: ctx})
+}
+
+func incomingRequestID(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get(requestid.Header); len(ids) > 0 && requestid.Valid(ids[0]) {
+			return ids[0]
+		}
+	}
+	return requestid.New()
+}
+
+// HttpRequestID propagates the X-Request-Id of the HTTP request, or generates one,
+// and returns it in the response. The gateway forwards it to the gRPC server with
+// GatewayRequestIDMetadata.
+func HttpRequestID(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(requestid.Header)
+		if !requestid.Valid(id) {
+			id = requestid.New()
+			r.Header.Set(requestid.Header, id)
+		}
+
+		w.Header().Set(requestid.Header, id)
+		handler.ServeHTTP(w, r.WithContext(requestid.NewContext(r.Context(), id)))
+	})
+}
+
+// GatewayRequestIDMetadata is a metadata annotator of the gateway that forwards the request ID
+// set by HttpRequestID, since the gateway only forwards the standard HTTP headers.
+func GatewayRequestIDMetadata(ctx context.Context, r *http.Request) metadata.MD {
+	return metadata.Pairs(requestid.Header, r.Header.Get(requestid.Header))
+}
+
+// contextServerStream replaces the context of a server stream.
+type contextServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (stream *contextServerStream) Context() context.Context {
+	return stream.ctx
+}
@@ -0,0 +1,58 @@
+package gapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mativm02/bank_system/requestid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestGrpcRequestID(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/pb.SimpleBank/Test"}
+
+	var id string
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		id = requestid.FromContext(ctx)
+		return nil, nil
+	}
+
+	// The request ID of the caller is propagated
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(requestid.Header, "req-123"))
+	_, err := GrpcRequestID(ctx, nil, info, handler)
+	require.NoError(t, err)
+	require.Equal(t, "req-123", id)
+
+	// An invalid request ID is replaced
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(requestid.Header, "req 123"))
+	_, err = GrpcRequestID(ctx, nil, info, handler)
+	require.NoError(t, err)
+	require.NotEqual(t, "req 123", id)
+	require.True(t, requestid.Valid(id))
+}
+
+func TestHttpRequestID(t *testing.T) {
+	var id string
+	handler := HttpRequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id = requestid.FromContext(r.Context())
+		md := GatewayRequestIDMetadata(r.Context(), r)
+		require.Equal(t, []string{id}, md.Get(requestid.Header))
+	}))
+
+	// A request ID is generated when the client does not send one
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/v1/test", nil))
+	require.NotEmpty(t, id)
+	require.Equal(t, id, recorder.Header().Get(requestid.Header))
+
+	recorder = httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/v1/test", nil)
+	request.Header.Set(requestid.Header, "req-123")
+	handler.ServeHTTP(recorder, request)
+	require.Equal(t, "req-123", id)
+	require.Equal(t, "req-123", recorder.Header().Get(requestid.Header))
+}
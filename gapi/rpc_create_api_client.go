@@ -0,0 +1,89 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lib/pq"
+	db "github.com/mativm02/bank_system/db/sqlc"
+	"github.com/mativm02/bank_system/pb"
+	"github.com/mativm02/bank_system/util"
+	"github.com/mativm02/bank_system/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	apiClientIDPrefix     = "sbc_"
+	apiClientIDSize       = 12
+	apiClientSecretPrefix = "sbcs_"
+	apiClientSecretSize   = 32
+)
+
+func (server *Server) CreateApiClient(ctx context.Context, req *pb.CreateApiClientRequest) (*pb.CreateApiClientResponse, error) {
+	authPayload, err := authorizationPayload(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateCreateApiClientRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	clientID, err := util.GenerateSecret(apiClientIDPrefix, apiClientIDSize)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot generate client ID: %v", err)
+	}
+
+	secret, err := util.GenerateSecret(apiClientSecretPrefix, apiClientSecretSize)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot generate client secret: %v", err)
+	}
+
+	client, err := server.store.CreateApiClient(ctx, db.CreateApiClientParams{
+		ID:           clientID,
+		Owner:        req.GetOwner(),
+		Name:         req.GetName(),
+		HashedSecret: util.HashSecret(secret),
+		Scopes:       req.GetScopes(),
+		CreatedBy:    authPayload.Username,
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code.Name() {
+			case "foreign_key_violation":
+				return nil, status.Errorf(codes.NotFound, "owner not found: %v", err)
+			}
+		}
+		return nil, status.Errorf(codes.Internal, "cannot create API client: %v", err)
+	}
+
+	rsp := &pb.CreateApiClientResponse{
+		ApiClient:    convertApiClient(client),
+		ClientSecret: secret,
+	}
+	return rsp, nil
+}
+
+func validateCreateApiClientRequest(req *pb.CreateApiClientRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateUsername(req.GetOwner()); err != nil {
+		violations = append(violations, fieldViolation("owner", err))
+	}
+
+	if err := val.ValidateString(req.GetName(), 3, 100); err != nil {
+		violations = append(violations, fieldViolation("name", err))
+	}
+
+	if len(req.GetScopes()) == 0 {
+		violations = append(violations, fieldViolation("scopes", fmt.Errorf("must contain at least one scope")))
+	}
+
+	for _, scope := range req.GetScopes() {
+		if err := val.ValidateScope(scope); err != nil {
+			violations = append(violations, fieldViolation("scopes", err))
+		}
+	}
+	return
+}
@@ -0,0 +1,80 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/mativm02/bank_system/apikey"
+	db "github.com/mativm02/bank_system/db/sqlc"
+	"github.com/mativm02/bank_system/pb"
+	"github.com/mativm02/bank_system/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) CreateApiKey(ctx context.Context, req *pb.CreateApiKeyRequest) (*pb.CreateApiKeyResponse, error) {
+	authPayload, err := authorizationPayload(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateCreateApiKeyRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	key, prefix, hashedKey, err := apikey.Generate()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot generate API key: %v", err)
+	}
+
+	arg := db.CreateApiKeyParams{
+		Owner:     authPayload.Username,
+		Name:      req.GetName(),
+		Prefix:    prefix,
+		HashedKey: hashedKey,
+		Scopes:    req.GetScopes(),
+	}
+	if req.ExpiresAt != nil {
+		arg.ExpiresAt = sql.NullTime{Time: req.GetExpiresAt().AsTime(), Valid: true}
+	}
+
+	apiKey, err := server.store.CreateApiKey(ctx, arg)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot create API key: %v", err)
+	}
+
+	rsp := &pb.CreateApiKeyResponse{
+		ApiKey: convertApiKey(apiKey),
+		Key:    key,
+	}
+	return rsp, nil
+}
+
+func validateCreateApiKeyRequest(req *pb.CreateApiKeyRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateString(req.GetName(), 3, 100); err != nil {
+		violations = append(violations, fieldViolation("name", err))
+	}
+
+	if len(req.GetScopes()) == 0 {
+		violations = append(violations, fieldViolation("scopes", fmt.Errorf("must contain at least one scope")))
+	}
+
+	for _, scope := range req.GetScopes() {
+		if err := val.ValidateScope(scope); err != nil {
+			violations = append(violations, fieldViolation("scopes", err))
+		}
+	}
+
+	if req.ExpiresAt != nil {
+		if err := req.GetExpiresAt().CheckValid(); err != nil {
+			violations = append(violations, fieldViolation("expires_at", err))
+		} else if !req.GetExpiresAt().AsTime().After(time.Now()) {
+			violations = append(violations, fieldViolation("expires_at", fmt.Errorf("must be in the future")))
+		}
+	}
+	return
+}
@@ -0,0 +1,103 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/mativm02/bank_system/pb"
+	"github.com/mativm02/bank_system/token"
+	"github.com/mativm02/bank_system/util"
+	"github.com/mativm02/bank_system/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	grantTypeClientCredentials = "client_credentials"
+	tokenTypeBearer            = "Bearer"
+)
+
+// CreateClientToken implements the OAuth2 client credentials grant: it issues an access token
+// acting for the owner of the API client, limited to the requested scopes or all the allowed ones.
+func (server *Server) CreateClientToken(ctx context.Context, req *pb.CreateClientTokenRequest) (*pb.CreateClientTokenResponse, error) {
+	violations := validateCreateClientTokenRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	client, err := server.store.GetApiClient(ctx, req.GetClientId())
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Errorf(codes.Unauthenticated, "invalid client credentials")
+		}
+		return nil, status.Errorf(codes.Internal, "cannot get API client: %v", err)
+	}
+
+	if client.RevokedAt.Valid || !util.CheckSecret(req.GetClientSecret(), client.HashedSecret) {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid client credentials")
+	}
+
+	scopes := client.Scopes
+	if req.GetScope() != "" {
+		scopes = token.ParseScope(req.GetScope())
+		for _, scope := range scopes {
+			if !containsString(client.Scopes, scope) {
+				return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{
+					fieldViolation("scope", fmt.Errorf("scope %q is not allowed for the client", scope)),
+				})
+			}
+		}
+	}
+
+	accessToken, accessPayload, err := server.tokenMaker.CreateClientToken(
+		client.ID,
+		client.Owner,
+		scopes,
+		server.config.AccessTokenDuration,
+	)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot create access token: %v", err)
+	}
+
+	rsp := &pb.CreateClientTokenResponse{
+		AccessToken:          accessToken,
+		TokenType:            tokenTypeBearer,
+		ExpiresIn:            int64(server.config.AccessTokenDuration.Seconds()),
+		Scope:                token.FormatScope(scopes),
+		AccessTokenExpiresAt: timestamppb.New(accessPayload.ExpiredAt),
+	}
+	return rsp, nil
+}
+
+func validateCreateClientTokenRequest(req *pb.CreateClientTokenRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if req.GetGrantType() != grantTypeClientCredentials {
+		violations = append(violations, fieldViolation("grant_type", fmt.Errorf("must be %s", grantTypeClientCredentials)))
+	}
+
+	if err := val.ValidateClientID(req.GetClientId()); err != nil {
+		violations = append(violations, fieldViolation("client_id", err))
+	}
+
+	if err := val.ValidateString(req.GetClientSecret(), 1, 128); err != nil {
+		violations = append(violations, fieldViolation("client_secret", err))
+	}
+
+	for _, scope := range token.ParseScope(req.GetScope()) {
+		if err := val.ValidateScope(scope); err != nil {
+			violations = append(violations, fieldViolation("scope", err))
+		}
+	}
+	return
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,55 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/lib/pq"
+	db "github.com/mativm02/bank_system/db/sqlc"
+	"github.com/mativm02/bank_system/pb"
+	"github.com/mativm02/bank_system/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) CreateInterestProduct(ctx context.Context, req *pb.CreateInterestProductRequest) (*pb.CreateInterestProductResponse, error) {
+	_, err := authorizationPayload(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateCreateInterestProductRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	product, err := server.store.CreateInterestProduct(ctx, db.CreateInterestProductParams{
+		Name:          req.GetName(),
+		AnnualRateBps: req.GetAnnualRateBps(),
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code.Name() {
+			case "unique_violation":
+				return nil, status.Errorf(codes.AlreadyExists, "interest product name already exists: %v", err)
+			}
+		}
+		return nil, status.Errorf(codes.Internal, "cannot create interest product: %v", err)
+	}
+
+	rsp := &pb.CreateInterestProductResponse{
+		Product: convertInterestProduct(product),
+	}
+	return rsp, nil
+}
+
+func validateCreateInterestProductRequest(req *pb.CreateInterestProductRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateString(req.GetName(), 3, 100); err != nil {
+		violations = append(violations, fieldViolation("name", err))
+	}
+
+	if err := val.ValidateAnnualRateBps(req.GetAnnualRateBps()); err != nil {
+		violations = append(violations, fieldViolation("annual_rate_bps", err))
+	}
+	return
+}
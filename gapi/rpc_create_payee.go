@@ -0,0 +1,66 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
+	db "github.com/mativm02/bank_system/db/sqlc"
+	"github.com/mativm02/bank_system/pb"
+	"github.com/mativm02/bank_system/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) CreatePayee(ctx context.Context, req *pb.CreatePayeeRequest) (*pb.CreatePayeeResponse, error) {
+	authPayload, err := authorizationPayload(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateCreatePayeeRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	account, err := server.store.GetAccount(ctx, req.GetAccountId())
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Errorf(codes.NotFound, "account not found")
+		}
+		return nil, status.Errorf(codes.Internal, "cannot get account: %v", err)
+	}
+
+	payee, err := server.store.CreatePayee(ctx, db.CreatePayeeParams{
+		Owner:     authPayload.Username,
+		Nickname:  req.GetNickname(),
+		AccountID: account.ID,
+		Currency:  account.Currency,
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code.Name() {
+			case "unique_violation":
+				return nil, status.Errorf(codes.AlreadyExists, "payee nickname/account already exists: %v", err)
+			}
+		}
+		return nil, status.Errorf(codes.Internal, "cannot create payee: %v", err)
+	}
+
+	rsp := &pb.CreatePayeeResponse{
+		Payee: convertPayee(payee),
+	}
+	return rsp, nil
+}
+
+func validateCreatePayeeRequest(req *pb.CreatePayeeRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateNickname(req.GetNickname()); err != nil {
+		violations = append(violations, fieldViolation("nickname", err))
+	}
+
+	if err := val.ValidateAccountID(req.GetAccountId()); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+	return
+}
@@ -2,13 +2,10 @@ package gapi
 
 import (
 	"context"
-	"time"
 
-	"github.com/hibiken/asynq"
 	"github.com/lib/pq"
 	db "github.com/mativm02/bank_system/db/sqlc"
 	"github.com/mativm02/bank_system/pb"
-	"github.com/mativm02/bank_system/util"
 	"github.com/mativm02/bank_system/val"
 	"github.com/mativm02/bank_system/worker"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
@@ -17,12 +14,12 @@ import (
 )
 
 func (server *Server) CreateUser(ctx context.Context, req *pb.CreateUserRequest) (*pb.CreateUserResponse, error) {
-	violations := validateCreateUserRequest(req)
+	violations := validateCreateUserRequest(req, server.passwordPolicy)
 	if violations != nil {
 		return nil, invalidArgumentError(violations)
 	}
 
-	hashedPassword, err := util.HashPassword(req.GetPassword())
+	hashedPassword, err := server.passwordHasher.Hash(req.GetPassword())
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "cannot hash password: %v", err)
 	}
@@ -34,15 +31,7 @@ func (server *Server) CreateUser(ctx context.Context, req *pb.CreateUserRequest)
 			FullName:       req.GetFullName(),
 			Email:          req.GetEmail(),
 		},
-		AfterCreate: func(user db.User) error {
-			opts := []asynq.Option{
-				asynq.MaxRetry(10),
-				asynq.Timeout(10),
-				asynq.ProcessIn(10 * time.Second), // process task in 10 seconds
-				asynq.Queue(worker.QueueCritical),
-			}
-			return server.taskDistributor.DistributeTaskSendVerifyEmail(ctx, &worker.PayloadSendVerifyEmail{Username: user.Username}, opts...)
-		},
+		OutboxMessages: sendVerifyEmailMessages(ctx),
 	}
 
 	result, err := server.store.CreateUserTx(ctx, arg)
@@ -63,12 +52,12 @@ func (server *Server) CreateUser(ctx context.Context, req *pb.CreateUserRequest)
 	return rsp, nil
 }
 
-func validateCreateUserRequest(req *pb.CreateUserRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+func validateCreateUserRequest(req *pb.CreateUserRequest, passwordPolicy val.PasswordPolicy) (violations []*errdetails.BadRequest_FieldViolation) {
 	if err := val.ValidateUsername(req.Username); err != nil {
 		violations = append(violations, fieldViolation("username", err))
 	}
 
-	if err := val.ValidatePassword(req.Password); err != nil {
+	for _, err := range passwordPolicy.Validate(req.Password, req.Username) {
 		violations = append(violations, fieldViolation("password", err))
 	}
 
@@ -81,3 +70,19 @@ func validateCreateUserRequest(req *pb.CreateUserRequest) (violations []*errdeta
 	}
 	return
 }
+
+// sendVerifyEmailMessages returns a function building the outbox message that sends a verification
+// email to the user, or to the pending email when the user asked to change it.
+func sendVerifyEmailMessages(ctx context.Context) func(user db.User) ([]db.CreateOutboxMessageParams, error) {
+	return func(user db.User) ([]db.CreateOutboxMessageParams, error) {
+		payload := &worker.PayloadSendVerifyEmail{
+			Username: user.Username,
+			Email:    user.PendingEmail.String,
+		}
+		message, err := worker.NewOutboxMessage(ctx, worker.TaskSendVerifyEmail, payload, worker.QueueCritical, 10, 0)
+		if err != nil {
+			return nil, err
+		}
+		return []db.CreateOutboxMessageParams{message}, nil
+	}
+}
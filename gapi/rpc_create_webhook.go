@@ -0,0 +1,66 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+
+	db "github.com/mativm02/bank_system/db/sqlc"
+	"github.com/mativm02/bank_system/event"
+	"github.com/mativm02/bank_system/pb"
+	"github.com/mativm02/bank_system/val"
+	"github.com/mativm02/bank_system/webhook"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) CreateWebhook(ctx context.Context, req *pb.CreateWebhookRequest) (*pb.CreateWebhookResponse, error) {
+	authPayload, err := authorizationPayload(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateCreateWebhookRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	secret, err := webhook.GenerateSecret()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot generate webhook secret: %v", err)
+	}
+
+	eventTypes := req.GetEventTypes()
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+
+	subscription, err := server.store.CreateWebhookSubscription(ctx, db.CreateWebhookSubscriptionParams{
+		Owner:      authPayload.Username,
+		Url:        req.GetUrl(),
+		Secret:     secret,
+		EventTypes: eventTypes,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot create webhook: %v", err)
+	}
+
+	rsp := &pb.CreateWebhookResponse{
+		Webhook: convertWebhook(subscription),
+		Secret:  secret,
+	}
+	return rsp, nil
+}
+
+func validateCreateWebhookRequest(req *pb.CreateWebhookRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateWebhookURL(req.GetUrl()); err != nil {
+		violations = append(violations, fieldViolation("url", err))
+	}
+
+	for _, eventType := range req.GetEventTypes() {
+		if !event.IsSupportedType(eventType) {
+			violations = append(violations, fieldViolation("event_types", fmt.Errorf("unsupported event type %q", eventType)))
+		}
+	}
+	return
+}
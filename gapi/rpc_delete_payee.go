@@ -0,0 +1,52 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/mativm02/bank_system/pb"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) DeletePayee(ctx context.Context, req *pb.DeletePayeeRequest) (*pb.DeletePayeeResponse, error) {
+	authPayload, err := authorizationPayload(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateDeletePayeeRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	payee, err := server.store.GetPayee(ctx, req.GetId())
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Errorf(codes.NotFound, "payee not found")
+		}
+		return nil, status.Errorf(codes.Internal, "cannot get payee: %v", err)
+	}
+
+	if payee.Owner != authPayload.Username {
+		return nil, status.Errorf(codes.PermissionDenied, "payee does not belong to the authenticated user")
+	}
+
+	err = server.store.DeletePayee(ctx, payee.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot delete payee: %v", err)
+	}
+
+	return &pb.DeletePayeeResponse{}, nil
+}
+
+func validateDeletePayeeRequest(req *pb.DeletePayeeRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if req.GetId() <= 0 {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       "id",
+			Description: "must be a positive number",
+		})
+	}
+	return
+}
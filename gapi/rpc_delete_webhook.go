@@ -0,0 +1,63 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+
+	db "github.com/mativm02/bank_system/db/sqlc"
+	"github.com/mativm02/bank_system/pb"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) DeleteWebhook(ctx context.Context, req *pb.DeleteWebhookRequest) (*pb.DeleteWebhookResponse, error) {
+	authPayload, err := authorizationPayload(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateDeleteWebhookRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	subscription, err := server.getOwnedWebhook(ctx, req.GetId(), authPayload.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	err = server.store.DeleteWebhookSubscription(ctx, subscription.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot delete webhook: %v", err)
+	}
+
+	return &pb.DeleteWebhookResponse{}, nil
+}
+
+// getOwnedWebhook returns the subscription if it belongs to the user.
+func (server *Server) getOwnedWebhook(ctx context.Context, id int64, username string) (db.WebhookSubscription, error) {
+	subscription, err := server.store.GetWebhookSubscription(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return subscription, status.Errorf(codes.NotFound, "webhook not found: %v", err)
+		}
+		return subscription, status.Errorf(codes.Internal, "cannot get webhook: %v", err)
+	}
+
+	if subscription.Owner != username {
+		return subscription, status.Errorf(codes.PermissionDenied, "webhook does not belong to the authenticated user")
+	}
+
+	return subscription, nil
+}
+
+func validateDeleteWebhookRequest(req *pb.DeleteWebhookRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if req.GetId() <= 0 {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       "id",
+			Description: "must be a positive number",
+		})
+	}
+	return
+}
@@ -0,0 +1,27 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/mativm02/bank_system/db/sqlc"
+	"github.com/mativm02/bank_system/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) GetNotificationPreferences(ctx context.Context, req *pb.GetNotificationPreferencesRequest) (*pb.GetNotificationPreferencesResponse, error) {
+	authPayload, err := authorizationPayload(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	preference, err := db.GetNotificationPreferenceOrDefault(ctx, server.store, authPayload.Username)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot get notification preferences: %v", err)
+	}
+
+	rsp := &pb.GetNotificationPreferencesResponse{
+		Preferences: convertNotificationPreference(preference),
+	}
+	return rsp, nil
+}
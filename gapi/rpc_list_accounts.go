@@ -0,0 +1,90 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+
+	db "github.com/mativm02/bank_system/db/sqlc"
+	"github.com/mativm02/bank_system/page"
+	"github.com/mativm02/bank_system/pb"
+	"github.com/mativm02/bank_system/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	accountOrderFields  = []string{db.OrderByID, db.OrderByBalance, db.OrderByCreatedAt}
+	defaultAccountOrder = page.Order{Field: db.OrderByID}
+)
+
+func (server *Server) ListAccounts(ctx context.Context, req *pb.ListAccountsRequest) (*pb.ListAccountsResponse, error) {
+	authPayload, err := authorizationPayload(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateListAccountsRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	order, _ := page.ParseOrderBy(req.GetOrderBy(), accountOrderFields, defaultAccountOrder)
+	arg := db.ListAccountsParams{
+		Owner:    authPayload.Username,
+		Currency: sql.NullString{String: req.GetCurrency(), Valid: req.Currency != nil},
+		Type:     sql.NullString{String: req.GetType(), Valid: req.Type != nil},
+		OrderBy:  order.Field,
+		SortDesc: order.Desc,
+	}
+
+	query := page.Fingerprint(arg)
+	arg.CursorValue, arg.CursorID, err = server.pageTokens.Cursor(req.GetPageToken(), query)
+	if err != nil {
+		return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("page_token", err)})
+	}
+
+	// One more account is fetched to know whether there is a next page.
+	pageSize := page.Size(req.GetPageSize())
+	arg.PageSize = pageSize + 1
+	accounts, err := server.store.ListAccounts(ctx, arg)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot list accounts: %v", err)
+	}
+
+	rsp := &pb.ListAccountsResponse{}
+	if len(accounts) > int(pageSize) {
+		accounts = accounts[:pageSize]
+		last := accounts[pageSize-1]
+		rsp.NextPageToken = server.pageTokens.Encode(page.Token{Value: last.SortValue(order.Field), ID: last.ID, Query: query})
+	}
+	for _, account := range accounts {
+		rsp.Accounts = append(rsp.Accounts, convertAccount(account))
+	}
+	return rsp, nil
+}
+
+func validateListAccountsRequest(req *pb.ListAccountsRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if req.GetPageSize() != 0 {
+		if err := val.ValidatePageSize(req.GetPageSize()); err != nil {
+			violations = append(violations, fieldViolation("page_size", err))
+		}
+	}
+
+	if req.Currency != nil {
+		if err := val.ValidateCurrency(req.GetCurrency()); err != nil {
+			violations = append(violations, fieldViolation("currency", err))
+		}
+	}
+
+	if req.Type != nil {
+		if err := val.ValidateAccountType(req.GetType()); err != nil {
+			violations = append(violations, fieldViolation("type", err))
+		}
+	}
+
+	if _, err := page.ParseOrderBy(req.GetOrderBy(), accountOrderFields, defaultAccountOrder); err != nil {
+		violations = append(violations, fieldViolation("order_by", err))
+	}
+	return
+}
@@ -0,0 +1,50 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/mativm02/bank_system/db/sqlc"
+	"github.com/mativm02/bank_system/pb"
+	"github.com/mativm02/bank_system/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ListApiKeys(ctx context.Context, req *pb.ListApiKeysRequest) (*pb.ListApiKeysResponse, error) {
+	authPayload, err := authorizationPayload(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateListApiKeysRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	apiKeys, err := server.store.ListApiKeys(ctx, db.ListApiKeysParams{
+		Owner:  authPayload.Username,
+		Limit:  req.GetPageSize(),
+		Offset: (req.GetPageId() - 1) * req.GetPageSize(),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot list API keys: %v", err)
+	}
+
+	rsp := &pb.ListApiKeysResponse{}
+	for _, apiKey := range apiKeys {
+		rsp.ApiKeys = append(rsp.ApiKeys, convertApiKey(apiKey))
+	}
+	return rsp, nil
+}
+
+func validateListApiKeysRequest(req *pb.ListApiKeysRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidatePageID(req.GetPageId()); err != nil {
+		violations = append(violations, fieldViolation("page_id", err))
+	}
+
+	if err := val.ValidatePageSize(req.GetPageSize()); err != nil {
+		violations = append(violations, fieldViolation("page_size", err))
+	}
+	return
+}
@@ -0,0 +1,67 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+
+	db "github.com/mativm02/bank_system/db/sqlc"
+	"github.com/mativm02/bank_system/pb"
+	"github.com/mativm02/bank_system/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ListInterestAccruals(ctx context.Context, req *pb.ListInterestAccrualsRequest) (*pb.ListInterestAccrualsResponse, error) {
+	authPayload, err := authorizationPayload(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateListInterestAccrualsRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	account, err := server.store.GetAccount(ctx, req.GetAccountId())
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Errorf(codes.NotFound, "account not found")
+		}
+		return nil, status.Errorf(codes.Internal, "cannot get account: %v", err)
+	}
+
+	if account.Owner != authPayload.Username {
+		return nil, status.Errorf(codes.PermissionDenied, "account does not belong to the authenticated user")
+	}
+
+	accruals, err := server.store.ListInterestAccruals(ctx, db.ListInterestAccrualsParams{
+		AccountID: account.ID,
+		Limit:     req.GetPageSize(),
+		Offset:    (req.GetPageId() - 1) * req.GetPageSize(),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot list interest accruals: %v", err)
+	}
+
+	rsp := &pb.ListInterestAccrualsResponse{}
+	for _, accrual := range accruals {
+		rsp.Accruals = append(rsp.Accruals, convertInterestAccrual(accrual))
+	}
+	return rsp, nil
+}
+
+func validateListInterestAccrualsRequest(req *pb.ListInterestAccrualsRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateAccountID(req.GetAccountId()); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+
+	if err := val.ValidatePageID(req.GetPageId()); err != nil {
+		violations = append(violations, fieldViolation("page_id", err))
+	}
+
+	if err := val.ValidatePageSize(req.GetPageSize()); err != nil {
+		violations = append(violations, fieldViolation("page_size", err))
+	}
+	return
+}
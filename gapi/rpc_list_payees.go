@@ -0,0 +1,50 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/mativm02/bank_system/db/sqlc"
+	"github.com/mativm02/bank_system/pb"
+	"github.com/mativm02/bank_system/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ListPayees(ctx context.Context, req *pb.ListPayeesRequest) (*pb.ListPayeesResponse, error) {
+	authPayload, err := authorizationPayload(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateListPayeesRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	payees, err := server.store.ListPayees(ctx, db.ListPayeesParams{
+		Owner:  authPayload.Username,
+		Limit:  req.GetPageSize(),
+		Offset: (req.GetPageId() - 1) * req.GetPageSize(),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot list payees: %v", err)
+	}
+
+	rsp := &pb.ListPayeesResponse{}
+	for _, payee := range payees {
+		rsp.Payees = append(rsp.Payees, convertPayee(payee))
+	}
+	return rsp, nil
+}
+
+func validateListPayeesRequest(req *pb.ListPayeesRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidatePageID(req.GetPageId()); err != nil {
+		violations = append(violations, fieldViolation("page_id", err))
+	}
+
+	if err := val.ValidatePageSize(req.GetPageSize()); err != nil {
+		violations = append(violations, fieldViolation("page_size", err))
+	}
+	return
+}
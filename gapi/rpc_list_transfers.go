@@ -0,0 +1,120 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	db "github.com/mativm02/bank_system/db/sqlc"
+	"github.com/mativm02/bank_system/page"
+	"github.com/mativm02/bank_system/pb"
+	"github.com/mativm02/bank_system/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	transferOrderFields  = []string{db.OrderByCreatedAt, db.OrderByAmount}
+	defaultTransferOrder = page.Order{Field: db.OrderByCreatedAt, Desc: true}
+)
+
+func (server *Server) ListTransfers(ctx context.Context, req *pb.ListTransfersRequest) (*pb.ListTransfersResponse, error) {
+	authPayload, err := authorizationPayload(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateListTransfersRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	order, _ := page.ParseOrderBy(req.GetOrderBy(), transferOrderFields, defaultTransferOrder)
+	arg := db.ListTransfersParams{
+		Owner:                 authPayload.Username,
+		AccountID:             sql.NullInt64{Int64: req.GetAccountId(), Valid: req.AccountId != nil},
+		CounterpartyAccountID: sql.NullInt64{Int64: req.GetCounterpartyAccountId(), Valid: req.CounterpartyAccountId != nil},
+		Currency:              sql.NullString{String: req.GetCurrency(), Valid: req.Currency != nil},
+		StartTime:             sql.NullTime{Time: req.GetStartTime().AsTime(), Valid: req.StartTime != nil},
+		EndTime:               sql.NullTime{Time: req.GetEndTime().AsTime(), Valid: req.EndTime != nil},
+		MinAmount:             sql.NullInt64{Int64: req.GetMinAmount(), Valid: req.MinAmount != nil},
+		MaxAmount:             sql.NullInt64{Int64: req.GetMaxAmount(), Valid: req.MaxAmount != nil},
+		OrderBy:               order.Field,
+		SortDesc:              order.Desc,
+	}
+
+	query := page.Fingerprint(arg)
+	arg.CursorValue, arg.CursorID, err = server.pageTokens.Cursor(req.GetPageToken(), query)
+	if err != nil {
+		return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("page_token", err)})
+	}
+
+	// One more transfer is fetched to know whether there is a next page.
+	pageSize := page.Size(req.GetPageSize())
+	arg.PageSize = pageSize + 1
+	transfers, err := server.store.ListTransfers(ctx, arg)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot list transfers: %v", err)
+	}
+
+	rsp := &pb.ListTransfersResponse{}
+	if len(transfers) > int(pageSize) {
+		transfers = transfers[:pageSize]
+		last := transfers[pageSize-1]
+		rsp.NextPageToken = server.pageTokens.Encode(page.Token{Value: last.SortValue(order.Field), ID: last.ID, Query: query})
+	}
+	for _, transfer := range transfers {
+		rsp.Transfers = append(rsp.Transfers, convertTransfer(transfer))
+	}
+	return rsp, nil
+}
+
+func validateListTransfersRequest(req *pb.ListTransfersRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if req.GetPageSize() != 0 {
+		if err := val.ValidatePageSize(req.GetPageSize()); err != nil {
+			violations = append(violations, fieldViolation("page_size", err))
+		}
+	}
+
+	if req.AccountId != nil {
+		if err := val.ValidateAccountID(req.GetAccountId()); err != nil {
+			violations = append(violations, fieldViolation("account_id", err))
+		}
+	}
+
+	if req.CounterpartyAccountId != nil {
+		if err := val.ValidateAccountID(req.GetCounterpartyAccountId()); err != nil {
+			violations = append(violations, fieldViolation("counterparty_account_id", err))
+		}
+	}
+
+	if req.Currency != nil {
+		if err := val.ValidateCurrency(req.GetCurrency()); err != nil {
+			violations = append(violations, fieldViolation("currency", err))
+		}
+	}
+
+	if req.StartTime != nil && req.EndTime != nil && !req.GetEndTime().AsTime().After(req.GetStartTime().AsTime()) {
+		violations = append(violations, fieldViolation("end_time", fmt.Errorf("must be after start_time")))
+	}
+
+	if req.MinAmount != nil {
+		if err := val.ValidateAmount(req.GetMinAmount()); err != nil {
+			violations = append(violations, fieldViolation("min_amount", err))
+		}
+	}
+
+	if req.MaxAmount != nil {
+		if err := val.ValidateAmount(req.GetMaxAmount()); err != nil {
+			violations = append(violations, fieldViolation("max_amount", err))
+		} else if req.MinAmount != nil && req.GetMaxAmount() < req.GetMinAmount() {
+			violations = append(violations, fieldViolation("max_amount", fmt.Errorf("must not be less than min_amount")))
+		}
+	}
+
+	if _, err := page.ParseOrderBy(req.GetOrderBy(), transferOrderFields, defaultTransferOrder); err != nil {
+		violations = append(violations, fieldViolation("order_by", err))
+	}
+	return
+}
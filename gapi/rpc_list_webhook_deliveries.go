@@ -0,0 +1,62 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/mativm02/bank_system/db/sqlc"
+	"github.com/mativm02/bank_system/pb"
+	"github.com/mativm02/bank_system/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ListWebhookDeliveries(ctx context.Context, req *pb.ListWebhookDeliveriesRequest) (*pb.ListWebhookDeliveriesResponse, error) {
+	authPayload, err := authorizationPayload(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateListWebhookDeliveriesRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	subscription, err := server.getOwnedWebhook(ctx, req.GetWebhookId(), authPayload.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries, err := server.store.ListWebhookDeliveries(ctx, db.ListWebhookDeliveriesParams{
+		SubscriptionID: subscription.ID,
+		Limit:          req.GetPageSize(),
+		Offset:         (req.GetPageId() - 1) * req.GetPageSize(),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot list webhook deliveries: %v", err)
+	}
+
+	rsp := &pb.ListWebhookDeliveriesResponse{}
+	for _, delivery := range deliveries {
+		rsp.Deliveries = append(rsp.Deliveries, convertWebhookDelivery(delivery))
+	}
+	return rsp, nil
+}
+
+func validateListWebhookDeliveriesRequest(req *pb.ListWebhookDeliveriesRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if req.GetWebhookId() <= 0 {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       "webhook_id",
+			Description: "must be a positive number",
+		})
+	}
+
+	if err := val.ValidatePageID(req.GetPageId()); err != nil {
+		violations = append(violations, fieldViolation("page_id", err))
+	}
+
+	if err := val.ValidatePageSize(req.GetPageSize()); err != nil {
+		violations = append(violations, fieldViolation("page_size", err))
+	}
+	return
+}
@@ -0,0 +1,50 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/mativm02/bank_system/db/sqlc"
+	"github.com/mativm02/bank_system/pb"
+	"github.com/mativm02/bank_system/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ListWebhooks(ctx context.Context, req *pb.ListWebhooksRequest) (*pb.ListWebhooksResponse, error) {
+	authPayload, err := authorizationPayload(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateListWebhooksRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	subscriptions, err := server.store.ListWebhookSubscriptions(ctx, db.ListWebhookSubscriptionsParams{
+		Owner:  authPayload.Username,
+		Limit:  req.GetPageSize(),
+		Offset: (req.GetPageId() - 1) * req.GetPageSize(),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot list webhooks: %v", err)
+	}
+
+	rsp := &pb.ListWebhooksResponse{}
+	for _, subscription := range subscriptions {
+		rsp.Webhooks = append(rsp.Webhooks, convertWebhook(subscription))
+	}
+	return rsp, nil
+}
+
+func validateListWebhooksRequest(req *pb.ListWebhooksRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidatePageID(req.GetPageId()); err != nil {
+		violations = append(violations, fieldViolation("page_id", err))
+	}
+
+	if err := val.ValidatePageSize(req.GetPageSize()); err != nil {
+		violations = append(violations, fieldViolation("page_size", err))
+	}
+	return
+}
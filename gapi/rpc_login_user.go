@@ -8,6 +8,7 @@ import (
 	"github.com/mativm02/bank_system/pb"
 	"github.com/mativm02/bank_system/util"
 	"github.com/mativm02/bank_system/val"
+	"github.com/rs/zerolog/log"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -32,8 +33,13 @@ func (server *Server) LoginUser(ctx context.Context, req *pb.LoginUserRequest) (
 		return nil, status.Errorf(codes.Unauthenticated, "invalid password: %v", err)
 	}
 
+	if server.passwordHasher.NeedsRehash(user.HashedPassword) {
+		server.rehashPassword(ctx, user, req.GetPassword())
+	}
+
 	accessToken, accessPayload, err := server.tokenMaker.CreateToken(
 		user.Username,
+		user.Role,
 		server.config.AccessTokenDuration,
 	)
 	if err != nil {
@@ -42,6 +48,7 @@ func (server *Server) LoginUser(ctx context.Context, req *pb.LoginUserRequest) (
 
 	refreshToken, refreshPayload, err := server.tokenMaker.CreateToken(
 		user.Username,
+		user.Role,
 		server.config.RefreshTokenDuration,
 	)
 	if err != nil {
@@ -75,6 +82,22 @@ func (server *Server) LoginUser(ctx context.Context, req *pb.LoginUserRequest) (
 	return rsp, nil
 }
 
+// rehashPassword replaces an outdated hash of the password of the user.
+// A failure is only logged: the user is authenticated and the hash is upgraded on a later login.
+func (server *Server) rehashPassword(ctx context.Context, user db.User, password string) {
+	hashedPassword, err := server.passwordHasher.Hash(password)
+	if err == nil {
+		err = server.store.UpdateUserHashedPassword(ctx, db.UpdateUserHashedPasswordParams{
+			Username:          user.Username,
+			HashedPassword:    hashedPassword,
+			OldHashedPassword: user.HashedPassword,
+		})
+	}
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("username", user.Username).Msg("cannot rehash password")
+	}
+}
+
 func validateLoginRequest(req *pb.LoginUserRequest) (violations []*errdetails.BadRequest_FieldViolation) {
 	if err := val.ValidateUsername(req.Username); err != nil {
 		violations = append(violations, fieldViolation("username", err))
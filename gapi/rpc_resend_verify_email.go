@@ -0,0 +1,41 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	db "github.com/mativm02/bank_system/db/sqlc"
+	"github.com/mativm02/bank_system/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ResendVerifyEmail(ctx context.Context, req *pb.ResendVerifyEmailRequest) (*pb.ResendVerifyEmailResponse, error) {
+	authPayload, err := authorizationPayload(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = server.store.ResendVerifyEmailTx(ctx, db.ResendVerifyEmailTxParams{
+		Username:       authPayload.Username,
+		ResendInterval: server.config.VerifyEmailResendInterval,
+		OutboxMessages: sendVerifyEmailMessages(ctx),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, status.Errorf(codes.NotFound, "user not found: %v", err)
+		case errors.Is(err, db.ErrEmailAlreadyVerified):
+			return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+		case errors.Is(err, db.ErrVerifyEmailThrottled):
+			return nil, status.Errorf(codes.ResourceExhausted, "please wait %s between verification emails", server.config.VerifyEmailResendInterval)
+		}
+		return nil, status.Errorf(codes.Internal, "cannot resend verify email: %v", err)
+	}
+
+	rsp := &pb.ResendVerifyEmailResponse{
+		IsSent: true,
+	}
+	return rsp, nil
+}
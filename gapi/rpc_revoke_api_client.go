@@ -0,0 +1,41 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/mativm02/bank_system/pb"
+	"github.com/mativm02/bank_system/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RevokeApiClient stops the client from getting new access tokens.
+// The tokens it already has are rejected from then on too.
+func (server *Server) RevokeApiClient(ctx context.Context, req *pb.RevokeApiClientRequest) (*pb.RevokeApiClientResponse, error) {
+	violations := validateRevokeApiClientRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	client, err := server.store.RevokeApiClient(ctx, req.GetClientId())
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Errorf(codes.NotFound, "API client not found: %v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "cannot revoke API client: %v", err)
+	}
+
+	rsp := &pb.RevokeApiClientResponse{
+		ApiClient: convertApiClient(client),
+	}
+	return rsp, nil
+}
+
+func validateRevokeApiClientRequest(req *pb.RevokeApiClientRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateClientID(req.GetClientId()); err != nil {
+		violations = append(violations, fieldViolation("client_id", err))
+	}
+	return
+}
@@ -0,0 +1,55 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/mativm02/bank_system/pb"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) RevokeApiKey(ctx context.Context, req *pb.RevokeApiKeyRequest) (*pb.RevokeApiKeyResponse, error) {
+	authPayload, err := authorizationPayload(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateRevokeApiKeyRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	apiKey, err := server.store.GetApiKey(ctx, req.GetId())
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Errorf(codes.NotFound, "API key not found: %v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "cannot get API key: %v", err)
+	}
+
+	if apiKey.Owner != authPayload.Username {
+		return nil, status.Errorf(codes.PermissionDenied, "API key does not belong to the authenticated user")
+	}
+
+	apiKey, err = server.store.RevokeApiKey(ctx, apiKey.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot revoke API key: %v", err)
+	}
+
+	rsp := &pb.RevokeApiKeyResponse{
+		ApiKey: convertApiKey(apiKey),
+	}
+	return rsp, nil
+}
+
+func validateRevokeApiKeyRequest(req *pb.RevokeApiKeyRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if req.GetId() <= 0 {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       "id",
+			Description: "must be a positive number",
+		})
+	}
+	return
+}
@@ -0,0 +1,69 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+
+	db "github.com/mativm02/bank_system/db/sqlc"
+	"github.com/mativm02/bank_system/pb"
+	"github.com/mativm02/bank_system/util"
+	"github.com/mativm02/bank_system/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RotateApiClientSecret replaces the secret of the client.
+// The tokens issued before are rejected from then on, so a leaked secret stops working at once.
+func (server *Server) RotateApiClientSecret(ctx context.Context, req *pb.RotateApiClientSecretRequest) (*pb.RotateApiClientSecretResponse, error) {
+	violations := validateRotateApiClientSecretRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	client, err := server.getApiClient(ctx, req.GetClientId())
+	if err != nil {
+		return nil, err
+	}
+
+	if client.RevokedAt.Valid {
+		return nil, status.Errorf(codes.FailedPrecondition, "API client is revoked")
+	}
+
+	secret, err := util.GenerateSecret(apiClientSecretPrefix, apiClientSecretSize)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot generate client secret: %v", err)
+	}
+
+	client, err = server.store.RotateApiClientSecret(ctx, db.RotateApiClientSecretParams{
+		ID:           client.ID,
+		HashedSecret: util.HashSecret(secret),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot rotate client secret: %v", err)
+	}
+
+	rsp := &pb.RotateApiClientSecretResponse{
+		ApiClient:    convertApiClient(client),
+		ClientSecret: secret,
+	}
+	return rsp, nil
+}
+
+func (server *Server) getApiClient(ctx context.Context, clientID string) (db.ApiClient, error) {
+	client, err := server.store.GetApiClient(ctx, clientID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return client, status.Errorf(codes.NotFound, "API client not found: %v", err)
+		}
+		return client, status.Errorf(codes.Internal, "cannot get API client: %v", err)
+	}
+	return client, nil
+}
+
+func validateRotateApiClientSecretRequest(req *pb.RotateApiClientSecretRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateClientID(req.GetClientId()); err != nil {
+		violations = append(violations, fieldViolation("client_id", err))
+	}
+	return
+}
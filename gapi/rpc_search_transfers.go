@@ -0,0 +1,162 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	db "github.com/mativm02/bank_system/db/sqlc"
+	"github.com/mativm02/bank_system/pb"
+	"github.com/mativm02/bank_system/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Groupings of the transfer search
+const (
+	groupByCounterparty = "counterparty"
+	groupByMonth        = "month"
+	groupByDirection    = "direction"
+)
+
+const (
+	// defaultTransferSearchMaxSpan is used when TRANSFER_SEARCH_MAX_SPAN is not set, a leap year.
+	defaultTransferSearchMaxSpan = 366 * 24 * time.Hour
+	// maxCounterpartyGroups limits the counterparties returned, the ones with the highest totals.
+	maxCounterpartyGroups = 100
+)
+
+func (server *Server) SearchTransfers(ctx context.Context, req *pb.SearchTransfersRequest) (*pb.SearchTransfersResponse, error) {
+	authPayload, err := authorizationPayload(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateSearchTransfersRequest(req, server.transferSearchMaxSpan())
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	arg := db.SumTransfersByDirectionParams{
+		Owner:                 authPayload.Username,
+		StartTime:             req.GetStartTime().AsTime(),
+		EndTime:               req.GetEndTime().AsTime(),
+		AccountID:             sql.NullInt64{Int64: req.GetAccountId(), Valid: req.AccountId != nil},
+		CounterpartyAccountID: sql.NullInt64{Int64: req.GetCounterpartyAccountId(), Valid: req.CounterpartyAccountId != nil},
+		Currency:              sql.NullString{String: req.GetCurrency(), Valid: req.Currency != nil},
+		Direction:             sql.NullString{String: req.GetDirection(), Valid: req.Direction != nil},
+	}
+
+	rsp := &pb.SearchTransfersResponse{GroupBy: req.GetGroupBy()}
+	switch req.GetGroupBy() {
+	case groupByCounterparty:
+		rows, err := server.store.SumTransfersByCounterparty(ctx, db.SumTransfersByCounterpartyParams{
+			Owner:                 arg.Owner,
+			StartTime:             arg.StartTime,
+			EndTime:               arg.EndTime,
+			AccountID:             arg.AccountID,
+			CounterpartyAccountID: arg.CounterpartyAccountID,
+			Currency:              arg.Currency,
+			Direction:             arg.Direction,
+			MaxGroups:             maxCounterpartyGroups,
+		})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "cannot search transfers: %v", err)
+		}
+		for _, row := range rows {
+			rsp.Aggregates = append(rsp.Aggregates, &pb.TransferAggregate{
+				CounterpartyAccountId: row.CounterpartyAccountID,
+				Direction:             row.Direction,
+				Currency:              row.Currency,
+				TransferCount:         row.TransferCount,
+				TotalAmount:           row.TotalAmount,
+			})
+		}
+	case groupByMonth:
+		rows, err := server.store.SumTransfersByMonth(ctx, db.SumTransfersByMonthParams(arg))
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "cannot search transfers: %v", err)
+		}
+		for _, row := range rows {
+			rsp.Aggregates = append(rsp.Aggregates, &pb.TransferAggregate{
+				Month:         timestamppb.New(row.Month),
+				Direction:     row.Direction,
+				Currency:      row.Currency,
+				TransferCount: row.TransferCount,
+				TotalAmount:   row.TotalAmount,
+			})
+		}
+	default:
+		rsp.GroupBy = groupByDirection
+		rows, err := server.store.SumTransfersByDirection(ctx, arg)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "cannot search transfers: %v", err)
+		}
+		for _, row := range rows {
+			rsp.Aggregates = append(rsp.Aggregates, &pb.TransferAggregate{
+				Direction:     row.Direction,
+				Currency:      row.Currency,
+				TransferCount: row.TransferCount,
+				TotalAmount:   row.TotalAmount,
+			})
+		}
+	}
+
+	return rsp, nil
+}
+
+func (server *Server) transferSearchMaxSpan() time.Duration {
+	if server.config.TransferSearchMaxSpan > 0 {
+		return server.config.TransferSearchMaxSpan
+	}
+	return defaultTransferSearchMaxSpan
+}
+
+func validateSearchTransfersRequest(req *pb.SearchTransfersRequest, maxSpan time.Duration) (violations []*errdetails.BadRequest_FieldViolation) {
+	if req.StartTime == nil {
+		violations = append(violations, fieldViolation("start_time", fmt.Errorf("must be set")))
+	}
+
+	if req.EndTime == nil {
+		violations = append(violations, fieldViolation("end_time", fmt.Errorf("must be set")))
+	} else if req.StartTime != nil {
+		span := req.GetEndTime().AsTime().Sub(req.GetStartTime().AsTime())
+		if span <= 0 {
+			violations = append(violations, fieldViolation("end_time", fmt.Errorf("must be after start_time")))
+		} else if span > maxSpan {
+			violations = append(violations, fieldViolation("end_time", fmt.Errorf("must be at most %s after start_time", maxSpan)))
+		}
+	}
+
+	switch req.GetGroupBy() {
+	case "", groupByCounterparty, groupByMonth, groupByDirection:
+	default:
+		violations = append(violations, fieldViolation("group_by", fmt.Errorf("must be %s, %s or %s", groupByCounterparty, groupByMonth, groupByDirection)))
+	}
+
+	if req.AccountId != nil {
+		if err := val.ValidateAccountID(req.GetAccountId()); err != nil {
+			violations = append(violations, fieldViolation("account_id", err))
+		}
+	}
+
+	if req.CounterpartyAccountId != nil {
+		if err := val.ValidateAccountID(req.GetCounterpartyAccountId()); err != nil {
+			violations = append(violations, fieldViolation("counterparty_account_id", err))
+		}
+	}
+
+	if req.Currency != nil {
+		if err := val.ValidateCurrency(req.GetCurrency()); err != nil {
+			violations = append(violations, fieldViolation("currency", err))
+		}
+	}
+
+	if req.Direction != nil && req.GetDirection() != db.DirectionSent && req.GetDirection() != db.DirectionReceived {
+		violations = append(violations, fieldViolation("direction", fmt.Errorf("must be %s or %s", db.DirectionSent, db.DirectionReceived)))
+	}
+	return
+}
@@ -0,0 +1,147 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/mativm02/bank_system/db/mock"
+	db "github.com/mativm02/bank_system/db/sqlc"
+	"github.com/mativm02/bank_system/pb"
+	"github.com/mativm02/bank_system/token"
+	"github.com/mativm02/bank_system/util"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestSearchTransfers(t *testing.T) {
+	username := util.RandomOwner()
+	startTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	endTime := startTime.AddDate(0, 3, 0)
+	counterpartyID := util.RandomInt(1, 1000)
+
+	testCases := []struct {
+		name       string
+		req        *pb.SearchTransfersRequest
+		buildStubs func(store *mockdb.MockStore)
+		check      func(t *testing.T, rsp *pb.SearchTransfersResponse, err error)
+	}{
+		{
+			name: "ByCounterparty",
+			req: &pb.SearchTransfersRequest{
+				StartTime:             timestamppb.New(startTime),
+				EndTime:               timestamppb.New(endTime),
+				GroupBy:               groupByCounterparty,
+				CounterpartyAccountId: proto.Int64(counterpartyID),
+				Direction:             proto.String(db.DirectionSent),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.SumTransfersByCounterpartyParams{
+					Owner:                 username,
+					StartTime:             startTime,
+					EndTime:               endTime,
+					CounterpartyAccountID: sql.NullInt64{Int64: counterpartyID, Valid: true},
+					Direction:             sql.NullString{String: db.DirectionSent, Valid: true},
+					MaxGroups:             maxCounterpartyGroups,
+				}
+				store.EXPECT().SumTransfersByCounterparty(gomock.Any(), gomock.Eq(arg)).Times(1).
+					Return([]db.SumTransfersByCounterpartyRow{{
+						CounterpartyAccountID: counterpartyID,
+						Direction:             db.DirectionSent,
+						Currency:              util.USD,
+						TransferCount:         2,
+						TotalAmount:           30,
+					}}, nil)
+			},
+			check: func(t *testing.T, rsp *pb.SearchTransfersResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, groupByCounterparty, rsp.GetGroupBy())
+				require.Len(t, rsp.GetAggregates(), 1)
+				require.Equal(t, counterpartyID, rsp.GetAggregates()[0].GetCounterpartyAccountId())
+				require.Equal(t, int64(30), rsp.GetAggregates()[0].GetTotalAmount())
+			},
+		},
+		{
+			name: "ByMonth",
+			req: &pb.SearchTransfersRequest{
+				StartTime: timestamppb.New(startTime),
+				EndTime:   timestamppb.New(endTime),
+				GroupBy:   groupByMonth,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SumTransfersByMonth(gomock.Any(), gomock.Any()).Times(1).
+					Return([]db.SumTransfersByMonthRow{{Month: startTime, Direction: db.DirectionReceived, Currency: util.EUR, TransferCount: 1, TotalAmount: 5}}, nil)
+			},
+			check: func(t *testing.T, rsp *pb.SearchTransfersResponse, err error) {
+				require.NoError(t, err)
+				require.Len(t, rsp.GetAggregates(), 1)
+				require.Equal(t, startTime, rsp.GetAggregates()[0].GetMonth().AsTime())
+			},
+		},
+		{
+			name: "DefaultGroupBy",
+			req: &pb.SearchTransfersRequest{
+				StartTime: timestamppb.New(startTime),
+				EndTime:   timestamppb.New(endTime),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SumTransfersByDirection(gomock.Any(), gomock.Any()).Times(1).Return([]db.SumTransfersByDirectionRow{}, nil)
+			},
+			check: func(t *testing.T, rsp *pb.SearchTransfersResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, groupByDirection, rsp.GetGroupBy())
+			},
+		},
+		{
+			name: "SpanTooLong",
+			req: &pb.SearchTransfersRequest{
+				StartTime: timestamppb.New(startTime),
+				EndTime:   timestamppb.New(startTime.Add(defaultTransferSearchMaxSpan + time.Second)),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SumTransfersByDirection(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, rsp *pb.SearchTransfersResponse, err error) {
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+		{
+			name: "InvalidArguments",
+			req: &pb.SearchTransfersRequest{
+				StartTime: timestamppb.New(endTime),
+				EndTime:   timestamppb.New(startTime),
+				GroupBy:   "week",
+				Direction: proto.String("both"),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SumTransfersByDirection(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, rsp *pb.SearchTransfersResponse, err error) {
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			payload, err := token.NewPayload(username, util.DepositorRole, time.Minute)
+			require.NoError(t, err)
+			ctx := context.WithValue(context.Background(), authPayloadKey{}, payload)
+
+			rsp, err := server.SearchTransfers(ctx, tc.req)
+			tc.check(t, rsp, err)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+
+	db "github.com/mativm02/bank_system/db/sqlc"
+	"github.com/mativm02/bank_system/pb"
+	"github.com/mativm02/bank_system/util"
+	"github.com/mativm02/bank_system/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) SetAccountInterestProduct(ctx context.Context, req *pb.SetAccountInterestProductRequest) (*pb.SetAccountInterestProductResponse, error) {
+	_, err := authorizationPayload(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateSetAccountInterestProductRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	account, err := server.store.GetAccount(ctx, req.GetAccountId())
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Errorf(codes.NotFound, "account not found")
+		}
+		return nil, status.Errorf(codes.Internal, "cannot get account: %v", err)
+	}
+
+	// Detaching the product stops the interest of any account.
+	if req.GetProductId() != 0 {
+		if account.Type != util.SavingsAccount {
+			return nil, status.Errorf(codes.FailedPrecondition, "only savings accounts can earn interest")
+		}
+
+		_, err = server.store.GetInterestProduct(ctx, req.GetProductId())
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil, status.Errorf(codes.NotFound, "interest product not found")
+			}
+			return nil, status.Errorf(codes.Internal, "cannot get interest product: %v", err)
+		}
+	}
+
+	account, err = server.store.SetAccountInterestProduct(ctx, db.SetAccountInterestProductParams{
+		ID:                account.ID,
+		InterestProductID: sql.NullInt64{Int64: req.GetProductId(), Valid: req.GetProductId() != 0},
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot set account interest product: %v", err)
+	}
+
+	rsp := &pb.SetAccountInterestProductResponse{
+		Account: convertAccount(account),
+	}
+	return rsp, nil
+}
+
+func validateSetAccountInterestProductRequest(req *pb.SetAccountInterestProductRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateAccountID(req.GetAccountId()); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+
+	if req.GetProductId() < 0 {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       "product_id",
+			Description: "must not be negative",
+		})
+	}
+	return
+}
@@ -0,0 +1,127 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/mativm02/bank_system/db/mock"
+	db "github.com/mativm02/bank_system/db/sqlc"
+	"github.com/mativm02/bank_system/pb"
+	"github.com/mativm02/bank_system/token"
+	"github.com/mativm02/bank_system/util"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSetAccountInterestProduct(t *testing.T) {
+	savings := db.Account{
+		ID:       util.RandomInt(1, 1000),
+		Owner:    util.RandomOwner(),
+		Currency: util.USD,
+		Type:     util.SavingsAccount,
+	}
+	checking := savings
+	checking.Type = util.CheckingAccount
+	product := db.InterestProduct{
+		ID:            util.RandomInt(1, 1000),
+		Name:          "Easy saver",
+		AnnualRateBps: 250,
+	}
+
+	testCases := []struct {
+		name       string
+		req        *pb.SetAccountInterestProductRequest
+		buildStubs func(store *mockdb.MockStore)
+		check      func(t *testing.T, rsp *pb.SetAccountInterestProductResponse, err error)
+	}{
+		{
+			name: "OK",
+			req:  &pb.SetAccountInterestProductRequest{AccountId: savings.ID, ProductId: product.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(savings.ID)).Times(1).Return(savings, nil)
+				store.EXPECT().GetInterestProduct(gomock.Any(), gomock.Eq(product.ID)).Times(1).Return(product, nil)
+
+				updated := savings
+				updated.InterestProductID = sql.NullInt64{Int64: product.ID, Valid: true}
+				arg := db.SetAccountInterestProductParams{
+					ID:                savings.ID,
+					InterestProductID: updated.InterestProductID,
+				}
+				store.EXPECT().SetAccountInterestProduct(gomock.Any(), gomock.Eq(arg)).Times(1).Return(updated, nil)
+			},
+			check: func(t *testing.T, rsp *pb.SetAccountInterestProductResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, product.ID, rsp.GetAccount().GetInterestProductId())
+			},
+		},
+		{
+			name: "Detach",
+			req:  &pb.SetAccountInterestProductRequest{AccountId: checking.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(checking.ID)).Times(1).Return(checking, nil)
+				store.EXPECT().GetInterestProduct(gomock.Any(), gomock.Any()).Times(0)
+				arg := db.SetAccountInterestProductParams{ID: checking.ID}
+				store.EXPECT().SetAccountInterestProduct(gomock.Any(), gomock.Eq(arg)).Times(1).Return(checking, nil)
+			},
+			check: func(t *testing.T, rsp *pb.SetAccountInterestProductResponse, err error) {
+				require.NoError(t, err)
+				require.Zero(t, rsp.GetAccount().GetInterestProductId())
+			},
+		},
+		{
+			name: "NotSavingsAccount",
+			req:  &pb.SetAccountInterestProductRequest{AccountId: checking.ID, ProductId: product.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(checking.ID)).Times(1).Return(checking, nil)
+				store.EXPECT().SetAccountInterestProduct(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, rsp *pb.SetAccountInterestProductResponse, err error) {
+				require.Equal(t, codes.FailedPrecondition, status.Code(err))
+			},
+		},
+		{
+			name: "ProductNotFound",
+			req:  &pb.SetAccountInterestProductRequest{AccountId: savings.ID, ProductId: product.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(savings.ID)).Times(1).Return(savings, nil)
+				store.EXPECT().GetInterestProduct(gomock.Any(), gomock.Eq(product.ID)).Times(1).Return(db.InterestProduct{}, sql.ErrNoRows)
+				store.EXPECT().SetAccountInterestProduct(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, rsp *pb.SetAccountInterestProductResponse, err error) {
+				require.Equal(t, codes.NotFound, status.Code(err))
+			},
+		},
+		{
+			name: "InvalidProductID",
+			req:  &pb.SetAccountInterestProductRequest{AccountId: savings.ID, ProductId: -1},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, rsp *pb.SetAccountInterestProductResponse, err error) {
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			payload, err := token.NewPayload(util.RandomOwner(), util.AdminRole, time.Minute)
+			require.NoError(t, err)
+			ctx := context.WithValue(context.Background(), authPayloadKey{}, payload)
+
+			rsp, err := server.SetAccountInterestProduct(ctx, tc.req)
+			tc.check(t, rsp, err)
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+
+	db "github.com/mativm02/bank_system/db/sqlc"
+	"github.com/mativm02/bank_system/pb"
+	"github.com/mativm02/bank_system/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) SetAccountSpendingLimit(ctx context.Context, req *pb.SetAccountSpendingLimitRequest) (*pb.SetAccountSpendingLimitResponse, error) {
+	_, err := authorizationPayload(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateSetAccountSpendingLimitRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	_, err = server.store.GetAccount(ctx, req.GetAccountId())
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Errorf(codes.NotFound, "account not found")
+		}
+		return nil, status.Errorf(codes.Internal, "cannot get account: %v", err)
+	}
+
+	limit, err := server.store.SetAccountSpendingLimit(ctx, db.SetAccountSpendingLimitParams{
+		AccountID:    req.GetAccountId(),
+		DailyLimit:   sql.NullInt64{Int64: req.GetDailyLimit(), Valid: req.DailyLimit != nil},
+		MonthlyLimit: sql.NullInt64{Int64: req.GetMonthlyLimit(), Valid: req.MonthlyLimit != nil},
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot set account spending limit: %v", err)
+	}
+
+	rsp := &pb.SetAccountSpendingLimitResponse{
+		Limit: convertAccountSpendingLimit(limit),
+	}
+	return rsp, nil
+}
+
+func validateSetAccountSpendingLimitRequest(req *pb.SetAccountSpendingLimitRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateAccountID(req.GetAccountId()); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+
+	if err := val.ValidateSpendingLimit(req.GetDailyLimit()); err != nil {
+		violations = append(violations, fieldViolation("daily_limit", err))
+	}
+
+	if err := val.ValidateSpendingLimit(req.GetMonthlyLimit()); err != nil {
+		violations = append(violations, fieldViolation("monthly_limit", err))
+	}
+	return
+}
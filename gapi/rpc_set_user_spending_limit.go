@@ -0,0 +1,67 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+
+	db "github.com/mativm02/bank_system/db/sqlc"
+	"github.com/mativm02/bank_system/pb"
+	"github.com/mativm02/bank_system/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) SetUserSpendingLimit(ctx context.Context, req *pb.SetUserSpendingLimitRequest) (*pb.SetUserSpendingLimitResponse, error) {
+	_, err := authorizationPayload(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateSetUserSpendingLimitRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	_, err = server.store.GetUser(ctx, req.GetUsername())
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
+		return nil, status.Errorf(codes.Internal, "cannot get user: %v", err)
+	}
+
+	limit, err := server.store.SetUserSpendingLimit(ctx, db.SetUserSpendingLimitParams{
+		Username:     req.GetUsername(),
+		Currency:     req.GetCurrency(),
+		DailyLimit:   sql.NullInt64{Int64: req.GetDailyLimit(), Valid: req.DailyLimit != nil},
+		MonthlyLimit: sql.NullInt64{Int64: req.GetMonthlyLimit(), Valid: req.MonthlyLimit != nil},
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot set user spending limit: %v", err)
+	}
+
+	rsp := &pb.SetUserSpendingLimitResponse{
+		Limit: convertUserSpendingLimit(limit),
+	}
+	return rsp, nil
+}
+
+func validateSetUserSpendingLimitRequest(req *pb.SetUserSpendingLimitRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateUsername(req.GetUsername()); err != nil {
+		violations = append(violations, fieldViolation("username", err))
+	}
+
+	if err := val.ValidateCurrency(req.GetCurrency()); err != nil {
+		violations = append(violations, fieldViolation("currency", err))
+	}
+
+	if err := val.ValidateSpendingLimit(req.GetDailyLimit()); err != nil {
+		violations = append(violations, fieldViolation("daily_limit", err))
+	}
+
+	if err := val.ValidateSpendingLimit(req.GetMonthlyLimit()); err != nil {
+		violations = append(violations, fieldViolation("monthly_limit", err))
+	}
+	return
+}
@@ -0,0 +1,50 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+
+	db "github.com/mativm02/bank_system/db/sqlc"
+	"github.com/mativm02/bank_system/pb"
+	"github.com/mativm02/bank_system/util"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) UpdateNotificationPreferences(ctx context.Context, req *pb.UpdateNotificationPreferencesRequest) (*pb.UpdateNotificationPreferencesResponse, error) {
+	authPayload, err := authorizationPayload(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateUpdateNotificationPreferencesRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	preference, err := server.store.UpsertNotificationPreference(ctx, db.UpsertNotificationPreferenceParams{
+		Username:          authPayload.Username,
+		TransferMode:      req.GetTransferMode(),
+		TransferThreshold: req.GetTransferThreshold(),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot update notification preferences: %v", err)
+	}
+
+	rsp := &pb.UpdateNotificationPreferencesResponse{
+		Preferences: convertNotificationPreference(preference),
+	}
+	return rsp, nil
+}
+
+func validateUpdateNotificationPreferencesRequest(req *pb.UpdateNotificationPreferencesRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if !util.IsSupportedNotificationMode(req.GetTransferMode()) {
+		violations = append(violations, fieldViolation("transfer_mode", fmt.Errorf("must be one of %s, %s or %s", util.NotifyAlways, util.NotifyAboveThreshold, util.NotifyNever)))
+	}
+
+	if req.GetTransferThreshold() < 0 {
+		violations = append(violations, fieldViolation("transfer_threshold", fmt.Errorf("must not be negative")))
+	}
+	return
+}
@@ -3,46 +3,68 @@ package gapi
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"time"
 
 	db "github.com/mativm02/bank_system/db/sqlc"
 	"github.com/mativm02/bank_system/pb"
-	"github.com/mativm02/bank_system/util"
+	"github.com/mativm02/bank_system/policy"
 	"github.com/mativm02/bank_system/val"
+	"github.com/mativm02/bank_system/worker"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
 func (server *Server) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb.UpdateUserResponse, error) {
-	authPayload, err := server.authorizeUser(ctx)
+	authPayload, err := authorizationPayload(ctx)
 	if err != nil {
-		return nil, unauthenticatedError(err)
+		return nil, err
 	}
 
 	if authPayload.Username != req.GetUsername() {
 		return nil, status.Errorf(codes.PermissionDenied, "cannot update other users")
 	}
 
-	violations := validateUpdateUserRequest(req)
+	violations := validateUpdateUserRequest(req, server.passwordPolicy)
 	if violations != nil {
 		return nil, invalidArgumentError(violations)
 	}
 
-	arg := db.UpdateUserParams{
-		Username: req.GetUsername(),
-		FullName: sql.NullString{
-			String: req.GetFullName(),
-			Valid:  req.FullName != nil,
-		},
-		Email: sql.NullString{
-			String: req.GetEmail(),
-			Valid:  req.Email != nil,
+	if req.Password != nil {
+		if authPayload.IsScoped() {
+			return nil, status.Errorf(codes.PermissionDenied, "API clients and API keys cannot change the password")
+		}
+		if err := server.policy.Check(ctx, authPayload.Username, policy.ChangePassword); err != nil {
+			return nil, policyError(err)
+		}
+	}
+
+	if req.Email != nil && authPayload.IsScoped() {
+		return nil, status.Errorf(codes.PermissionDenied, "API clients and API keys cannot change the email")
+	}
+
+	arg := db.UpdateUserTxParams{
+		UpdateUserParams: db.UpdateUserParams{
+			Username: req.GetUsername(),
+			FullName: sql.NullString{
+				String: req.GetFullName(),
+				Valid:  req.FullName != nil,
+			},
+			Email: sql.NullString{
+				String: req.GetEmail(),
+				Valid:  req.Email != nil,
+			},
+			Locale: sql.NullString{
+				String: req.GetLocale(),
+				Valid:  req.Locale != nil,
+			},
 		},
+		EmailChangeMessages: emailChangeMessages(ctx),
 	}
 
 	if req.Password != nil {
-		hashedPassword, err := util.HashPassword(req.GetPassword())
+		hashedPassword, err := server.passwordHasher.Hash(req.GetPassword())
 		if err != nil {
 			return nil, status.Errorf(codes.Internal, "cannot hash password: %v", err)
 		}
@@ -55,29 +77,37 @@ func (server *Server) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest)
 			Time:  time.Now(),
 			Valid: true,
 		}
+
+		arg.PasswordHistorySize = server.passwordPolicy.HistorySize
+		arg.CheckPasswordHistory = func(hashes []string) error {
+			return server.passwordPolicy.CheckHistory(req.GetPassword(), hashes)
+		}
 	}
 
-	user, err := server.store.UpdateUser(ctx, arg)
+	result, err := server.store.UpdateUserTx(ctx, arg)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, status.Errorf(codes.NotFound, "user not found: %v", err)
 		}
+		if errors.Is(err, val.ErrPasswordReused) {
+			return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("password", err)})
+		}
 		return nil, status.Errorf(codes.Internal, "cannot create user: %v", err)
 	}
 
 	rsp := &pb.UpdateUserResponse{
-		User: convertUser(user),
+		User: convertUser(result.User),
 	}
 	return rsp, nil
 }
 
-func validateUpdateUserRequest(req *pb.UpdateUserRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+func validateUpdateUserRequest(req *pb.UpdateUserRequest, passwordPolicy val.PasswordPolicy) (violations []*errdetails.BadRequest_FieldViolation) {
 	if err := val.ValidateUsername(req.Username); err != nil {
 		violations = append(violations, fieldViolation("username", err))
 	}
 
 	if req.Password != nil {
-		if err := val.ValidatePassword(req.GetPassword()); err != nil {
+		for _, err := range passwordPolicy.Validate(req.GetPassword(), req.GetUsername()) {
 			violations = append(violations, fieldViolation("password", err))
 		}
 	}
@@ -94,5 +124,36 @@ func validateUpdateUserRequest(req *pb.UpdateUserRequest) (violations []*errdeta
 		}
 	}
 
+	if req.Locale != nil {
+		if err := val.ValidateLocale(req.GetLocale()); err != nil {
+			violations = append(violations, fieldViolation("locale", err))
+		}
+	}
+
 	return
 }
+
+// emailChangeMessages returns a function building the outbox messages that verify the new email
+// of the user and warn the old email about the change.
+func emailChangeMessages(ctx context.Context) func(user db.User, oldEmail string) ([]db.CreateOutboxMessageParams, error) {
+	return func(user db.User, oldEmail string) ([]db.CreateOutboxMessageParams, error) {
+		verify, err := worker.NewOutboxMessage(ctx, worker.TaskSendVerifyEmail, &worker.PayloadSendVerifyEmail{
+			Username: user.Username,
+			Email:    user.PendingEmail.String,
+		}, worker.QueueCritical, 10, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		notice, err := worker.NewOutboxMessage(ctx, worker.TaskSendEmailChangeNotice, &worker.PayloadSendEmailChangeNotice{
+			Username: user.Username,
+			OldEmail: oldEmail,
+			NewEmail: user.PendingEmail.String,
+		}, worker.QueueCritical, 10, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		return []db.CreateOutboxMessageParams{verify, notice}, nil
+	}
+}
@@ -2,6 +2,7 @@ package gapi
 
 import (
 	"context"
+	"errors"
 
 	db "github.com/mativm02/bank_system/db/sqlc"
 	"github.com/mativm02/bank_system/pb"
@@ -22,6 +23,9 @@ func (server *Server) VerifyEmail(ctx context.Context, req *pb.VerifyEmailReques
 		SecretCode: req.SecretCode,
 	})
 	if err != nil {
+		if errors.Is(err, db.ErrVerifyEmailOutdated) {
+			return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+		}
 		return nil, status.Errorf(codes.Internal, "cannot verify email: %v", err)
 	}
 
@@ -0,0 +1,92 @@
+package gapi
+
+import (
+	"database/sql"
+
+	"github.com/mativm02/bank_system/event"
+	"github.com/mativm02/bank_system/pb"
+	"github.com/mativm02/bank_system/val"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) WatchAccountEvents(req *pb.WatchAccountEventsRequest, stream pb.SimpleBank_WatchAccountEventsServer) error {
+	ctx := stream.Context()
+
+	authPayload, err := authorizationPayload(ctx)
+	if err != nil {
+		return err
+	}
+
+	violations := validateWatchAccountEventsRequest(req)
+	if violations != nil {
+		return invalidArgumentError(violations)
+	}
+
+	for _, accountID := range req.GetAccountIds() {
+		account, err := server.store.GetAccount(ctx, accountID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return status.Errorf(codes.NotFound, "account %d not found", accountID)
+			}
+			return status.Errorf(codes.Internal, "cannot get account: %v", err)
+		}
+
+		if account.Owner != authPayload.Username {
+			return status.Errorf(codes.PermissionDenied, "account %d does not belong to the authenticated user", accountID)
+		}
+	}
+
+	events, err := server.eventSubscriber.Subscribe(ctx)
+	if err != nil {
+		return status.Errorf(codes.Unavailable, "cannot subscribe to account events: %v", err)
+	}
+
+	for e := range events {
+		if !matchAccountEvent(e, authPayload.Username, req.GetAccountIds()) {
+			continue
+		}
+
+		accountEvent, err := convertAccountEvent(e)
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Int64("id", e.ID).Msg("cannot convert account event")
+			continue
+		}
+
+		if err := stream.Send(accountEvent); err != nil {
+			return err
+		}
+	}
+
+	return ctx.Err()
+}
+
+// matchAccountEvent keeps the account events of the requested accounts,
+// or of every account of the user when no account is requested.
+func matchAccountEvent(e event.Event, username string, accountIDs []int64) bool {
+	if !e.IsAccountEvent() {
+		return false
+	}
+
+	if len(accountIDs) == 0 {
+		return e.HasUsername(username)
+	}
+
+	for _, accountID := range accountIDs {
+		if e.HasAccount(accountID) {
+			return true
+		}
+	}
+	return false
+}
+
+func validateWatchAccountEventsRequest(req *pb.WatchAccountEventsRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	for _, accountID := range req.GetAccountIds() {
+		if err := val.ValidateAccountID(accountID); err != nil {
+			violations = append(violations, fieldViolation("account_ids", err))
+		}
+	}
+	return
+}
@@ -3,10 +3,15 @@ package gapi
 import (
 	"fmt"
 
+	"github.com/mativm02/bank_system/apikey"
 	db "github.com/mativm02/bank_system/db/sqlc"
+	"github.com/mativm02/bank_system/event"
+	"github.com/mativm02/bank_system/page"
 	"github.com/mativm02/bank_system/pb"
+	"github.com/mativm02/bank_system/policy"
 	"github.com/mativm02/bank_system/token"
 	"github.com/mativm02/bank_system/util"
+	"github.com/mativm02/bank_system/val"
 	"github.com/mativm02/bank_system/worker"
 )
 
@@ -17,18 +22,38 @@ type Server struct {
 	tokenMaker      token.Maker // It will allow us to access the token maker.
 	config          util.Config // It will allow us to access the configuration.
 	taskDistributor worker.TaskDistributor
+	eventSubscriber event.Subscriber
+	policy          *policy.Checker
+	passwordHasher  *util.PasswordHasher
+	passwordPolicy  val.PasswordPolicy
+	apiKeys         *apikey.Authenticator
+	pageTokens      *page.Signer
 }
 
-func NewServer(config util.Config, store db.Store, taskDistributor worker.TaskDistributor) (*Server, error) {
-	tokenMaker, err := token.NewPasetoMaker(config.TokenSymmetricKey)
+func NewServer(config util.Config, store db.Store, taskDistributor worker.TaskDistributor, eventSubscriber event.Subscriber) (*Server, error) {
+	tokenMaker, err := token.NewMaker(config)
 	if err != nil {
 		return nil, fmt.Errorf("cannot create token maker: %w", err)
 	}
+	passwordHasher, err := util.NewPasswordHasher(config)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create password hasher: %w", err)
+	}
+	pageTokens, err := page.NewSigner(config.PageTokenKey)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create page token signer: %w", err)
+	}
 	server := &Server{
 		store:           store,
 		tokenMaker:      tokenMaker,
 		config:          config,
 		taskDistributor: taskDistributor,
+		eventSubscriber: eventSubscriber,
+		policy:          policy.NewChecker(store),
+		passwordHasher:  passwordHasher,
+		passwordPolicy:  val.NewPasswordPolicy(config),
+		apiKeys:         apikey.NewAuthenticator(store),
+		pageTokens:      pageTokens,
 	}
 
 	return server, nil
@@ -0,0 +1,53 @@
+package mail
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/mativm02/bank_system/util"
+)
+
+// FileSender writes every email as an .eml file to a directory instead of sending it.
+// It is meant for local development, where the files can be opened with any mail client.
+type FileSender struct {
+	senderName       string
+	fromEmailAddress string
+	dir              string
+}
+
+func NewFileSender(name, fromEmailAddress, dir string) (EmailSender, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create email directory: %w", err)
+	}
+
+	return &FileSender{
+		senderName:       name,
+		fromEmailAddress: fromEmailAddress,
+		dir:              dir,
+	}, nil
+}
+
+func (sender *FileSender) SendEmail(subject, content string, to, cc, bcc, attachFiles []string) error {
+	return sender.SendMessage(Message{Subject: subject, HTML: content}, to, cc, bcc, attachFiles)
+}
+
+func (sender *FileSender) SendMessage(message Message, to, cc, bcc, attachFiles []string) error {
+	e, err := newEmail(formatAddress(sender.senderName, sender.fromEmailAddress), message, to, cc, bcc, attachFiles)
+	if err != nil {
+		return err
+	}
+
+	raw, err := e.Bytes()
+	if err != nil {
+		return fmt.Errorf("failed to encode email: %w", err)
+	}
+
+	name := fmt.Sprintf("%s-%s.eml", time.Now().UTC().Format("20060102T150405.000000000"), util.RandomString(6))
+	err = os.WriteFile(filepath.Join(sender.dir, name), raw, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to write email: %w", err)
+	}
+	return nil
+}
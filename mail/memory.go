@@ -0,0 +1,58 @@
+package mail
+
+import "sync"
+
+// SentEmail is an email captured by the MemorySender.
+type SentEmail struct {
+	Message     Message
+	To          []string
+	Cc          []string
+	Bcc         []string
+	AttachFiles []string
+}
+
+// MemorySender keeps the emails in memory instead of sending them, so tests can inspect them.
+type MemorySender struct {
+	mutex  sync.Mutex
+	emails []SentEmail
+}
+
+func NewMemorySender() *MemorySender {
+	return &MemorySender{}
+}
+
+func (sender *MemorySender) SendEmail(subject, content string, to, cc, bcc, attachFiles []string) error {
+	return sender.SendMessage(Message{Subject: subject, HTML: content}, to, cc, bcc, attachFiles)
+}
+
+func (sender *MemorySender) SendMessage(message Message, to, cc, bcc, attachFiles []string) error {
+	sender.mutex.Lock()
+	defer sender.mutex.Unlock()
+
+	sender.emails = append(sender.emails, SentEmail{
+		Message:     message,
+		To:          to,
+		Cc:          cc,
+		Bcc:         bcc,
+		AttachFiles: attachFiles,
+	})
+	return nil
+}
+
+// Emails returns the emails sent so far.
+func (sender *MemorySender) Emails() []SentEmail {
+	sender.mutex.Lock()
+	defer sender.mutex.Unlock()
+
+	emails := make([]SentEmail, len(sender.emails))
+	copy(emails, sender.emails)
+	return emails
+}
+
+// Reset forgets the emails sent so far.
+func (sender *MemorySender) Reset() {
+	sender.mutex.Lock()
+	defer sender.mutex.Unlock()
+
+	sender.emails = nil
+}
@@ -12,8 +12,20 @@ const (
 	smtpServerAddress = "smtp.gmail.com:587"
 )
 
+const (
+	// SenderGmail sends the emails through Gmail
+	SenderGmail = "gmail"
+	// SenderSMTP sends the emails through a configurable SMTP server
+	SenderSMTP = "smtp"
+	// SenderFile writes the emails to a directory
+	SenderFile = "file"
+	// SenderMemory keeps the emails in memory
+	SenderMemory = "memory"
+)
+
 type EmailSender interface {
 	SendEmail(subject, content string, to, cc, bcc, attachFiles []string) error
+	SendMessage(message Message, to, cc, bcc, attachFiles []string) error
 }
 
 type GmailSender struct {
@@ -31,10 +43,30 @@ func NewGmailSender(name, fromEmailAddress, fromEmailPassword string) EmailSende
 }
 
 func (sender *GmailSender) SendEmail(subject, content string, to, cc, bcc, attachFiles []string) error {
+	return sender.SendMessage(Message{Subject: subject, HTML: content}, to, cc, bcc, attachFiles)
+}
+
+// SendMessage sends a message as multipart/alternative when it has both a text and an HTML part.
+func (sender *GmailSender) SendMessage(message Message, to, cc, bcc, attachFiles []string) error {
+	e, err := newEmail(formatAddress(sender.senderName, sender.fromEmailAddress), message, to, cc, bcc, attachFiles)
+	if err != nil {
+		return err
+	}
+
+	smtpAuth := smtp.PlainAuth("", sender.fromEmailAddress, sender.fromEmailPassword, smtpAuthAddress)
+	return e.Send(smtpServerAddress, smtpAuth)
+}
+
+func newEmail(from string, message Message, to, cc, bcc, attachFiles []string) (*email.Email, error) {
 	e := email.NewEmail()
-	e.From = fmt.Sprintf("%s <%s>", sender.senderName, sender.fromEmailAddress)
-	e.Subject = subject
-	e.HTML = []byte(content)
+	e.From = from
+	e.Subject = message.Subject
+	if message.Text != "" {
+		e.Text = []byte(message.Text)
+	}
+	if message.HTML != "" {
+		e.HTML = []byte(message.HTML)
+	}
 	e.To = to
 	e.Cc = cc
 	e.Bcc = bcc
@@ -42,10 +74,13 @@ func (sender *GmailSender) SendEmail(subject, content string, to, cc, bcc, attac
 	for _, attachFile := range attachFiles {
 		_, err := e.AttachFile(attachFile)
 		if err != nil {
-			return fmt.Errorf("failed to attach file %s: %w", attachFile, err)
+			return nil, fmt.Errorf("failed to attach file %s: %w", attachFile, err)
 		}
 	}
 
-	smtpAuth := smtp.PlainAuth("", sender.fromEmailAddress, sender.fromEmailPassword, smtpAuthAddress)
-	return e.Send(smtpServerAddress, smtpAuth)
+	return e, nil
+}
+
+func formatAddress(name, address string) string {
+	return fmt.Sprintf("%s <%s>", name, address)
 }
@@ -1,31 +1,50 @@
 package mail
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
-	"github.com/mativm02/bank_system/util"
 	"github.com/stretchr/testify/require"
 )
 
-func TestSendEmailWithGmail(t *testing.T) {
-	if testing.Short() {
-		t.Skip("skipping TestSendEmailWithGmail test in short mode")
+func TestSendEmailWithFileSender(t *testing.T) {
+	dir := t.TempDir()
+
+	sender, err := NewFileSender("Simple Bank", "bank@example.com", dir)
+	require.NoError(t, err)
+
+	message := Message{
+		Subject: "Test subject",
+		Text:    "Test content",
+		HTML:    "<h1>Test content</h1>",
 	}
-	config, err := util.LoadConfig("..")
+	err = sender.SendMessage(message, []string{"to@example.com"}, nil, nil, nil)
 	require.NoError(t, err)
 
-	sender := NewGmailSender(config.EmailSenderName, config.EmailSenderAddress, config.EmailSenderPassword)
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
 
-	subject := "Test subject"
-	content := `
-	<h1>Test content</h1>
-	<p>This is a test message from <a href="github.com/mativm02"> Tech School </a></p>
-	`
+	raw, err := os.ReadFile(files[0])
+	require.NoError(t, err)
+	require.Contains(t, string(raw), "From: \"Simple Bank\" <bank@example.com>")
+	require.Contains(t, string(raw), "To: <to@example.com>")
+	require.Contains(t, string(raw), "Subject: Test subject")
+}
 
-	to := []string{"mancute2010@gmail.com"}
-	attachFiles := []string{"../README.md"}
+func TestSendEmailWithMemorySender(t *testing.T) {
+	sender := NewMemorySender()
 
-	err = sender.SendEmail(subject, content, to, nil, nil, attachFiles)
+	err := sender.SendEmail("Test subject", "<h1>Test content</h1>", []string{"to@example.com"}, nil, nil, nil)
 	require.NoError(t, err)
 
+	emails := sender.Emails()
+	require.Len(t, emails, 1)
+	require.Equal(t, "Test subject", emails[0].Message.Subject)
+	require.Equal(t, "<h1>Test content</h1>", emails[0].Message.HTML)
+	require.Equal(t, []string{"to@example.com"}, emails[0].To)
+
+	sender.Reset()
+	require.Empty(t, sender.Emails())
 }
@@ -0,0 +1,222 @@
+package mail
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"time"
+)
+
+const (
+	// SMTPTLSNone sends the email over a plain connection
+	SMTPTLSNone = "none"
+	// SMTPTLSStartTLS upgrades the connection with STARTTLS, which the server must support
+	SMTPTLSStartTLS = "starttls"
+	// SMTPTLSImplicit connects with TLS from the start, usually on port 465
+	SMTPTLSImplicit = "tls"
+
+	// SMTPAuthNone does not authenticate
+	SMTPAuthNone = "none"
+	// SMTPAuthPlain authenticates with the PLAIN mechanism
+	SMTPAuthPlain = "plain"
+	// SMTPAuthLogin authenticates with the LOGIN mechanism
+	SMTPAuthLogin = "login"
+	// SMTPAuthCRAMMD5 authenticates with the CRAM-MD5 mechanism
+	SMTPAuthCRAMMD5 = "cram-md5"
+)
+
+const (
+	smtpDialTimeout = 10 * time.Second
+	// smtpTimeout bounds the whole session, so a server that stops answering cannot block the worker.
+	smtpTimeout = time.Minute
+)
+
+// SMTPConfig contains the settings of an SMTP server.
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	TLSMode  string
+	AuthMode string
+	Username string
+	Password string
+}
+
+// SMTPSender sends emails through any SMTP server.
+type SMTPSender struct {
+	senderName       string
+	fromEmailAddress string
+	config           SMTPConfig
+	timeout          time.Duration
+}
+
+func NewSMTPSender(name, fromEmailAddress string, config SMTPConfig) (EmailSender, error) {
+	if config.Host == "" || config.Port <= 0 {
+		return nil, fmt.Errorf("invalid smtp address %s:%d", config.Host, config.Port)
+	}
+
+	switch config.TLSMode {
+	case SMTPTLSNone, SMTPTLSStartTLS, SMTPTLSImplicit:
+	default:
+		return nil, fmt.Errorf("unsupported smtp tls mode %q", config.TLSMode)
+	}
+
+	switch config.AuthMode {
+	case SMTPAuthNone, SMTPAuthPlain, SMTPAuthLogin, SMTPAuthCRAMMD5:
+	default:
+		return nil, fmt.Errorf("unsupported smtp auth mode %q", config.AuthMode)
+	}
+
+	return &SMTPSender{
+		senderName:       name,
+		fromEmailAddress: fromEmailAddress,
+		config:           config,
+		timeout:          smtpTimeout,
+	}, nil
+}
+
+func (sender *SMTPSender) SendEmail(subject, content string, to, cc, bcc, attachFiles []string) error {
+	return sender.SendMessage(Message{Subject: subject, HTML: content}, to, cc, bcc, attachFiles)
+}
+
+func (sender *SMTPSender) SendMessage(message Message, to, cc, bcc, attachFiles []string) error {
+	e, err := newEmail(formatAddress(sender.senderName, sender.fromEmailAddress), message, to, cc, bcc, attachFiles)
+	if err != nil {
+		return err
+	}
+
+	raw, err := e.Bytes()
+	if err != nil {
+		return fmt.Errorf("failed to encode email: %w", err)
+	}
+
+	recipients := make([]string, 0, len(to)+len(cc)+len(bcc))
+	recipients = append(recipients, to...)
+	recipients = append(recipients, cc...)
+	recipients = append(recipients, bcc...)
+
+	return sender.send(recipients, raw)
+}
+
+func (sender *SMTPSender) send(recipients []string, raw []byte) error {
+	if len(recipients) == 0 {
+		return errors.New("no recipients")
+	}
+
+	addr := net.JoinHostPort(sender.config.Host, strconv.Itoa(sender.config.Port))
+	tlsConfig := &tls.Config{ServerName: sender.config.Host}
+	dialer := &net.Dialer{Timeout: smtpDialTimeout}
+
+	var conn net.Conn
+	var err error
+	if sender.config.TLSMode == SMTPTLSImplicit {
+		conn, err = tls.DialWithDialer(dialer, "tcp", addr, tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", addr)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to connect to smtp server: %w", err)
+	}
+	if err = conn.SetDeadline(time.Now().Add(sender.timeout)); err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to set smtp deadline: %w", err)
+	}
+
+	client, err := smtp.NewClient(conn, sender.config.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to create smtp client: %w", err)
+	}
+	defer client.Close()
+
+	if sender.config.TLSMode == SMTPTLSStartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return errors.New("smtp server does not support STARTTLS")
+		}
+		if err = client.StartTLS(tlsConfig); err != nil {
+			return fmt.Errorf("failed to start tls: %w", err)
+		}
+	}
+
+	if auth := sender.auth(); auth != nil {
+		if err = client.Auth(auth); err != nil {
+			return fmt.Errorf("failed to authenticate: %w", err)
+		}
+	}
+
+	if err = client.Mail(sender.fromEmailAddress); err != nil {
+		return fmt.Errorf("failed to set sender: %w", err)
+	}
+	for _, recipient := range recipients {
+		if err = client.Rcpt(recipient); err != nil {
+			return fmt.Errorf("failed to add recipient %s: %w", recipient, err)
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("failed to start data: %w", err)
+	}
+	if _, err = w.Write(raw); err != nil {
+		return fmt.Errorf("failed to write email: %w", err)
+	}
+	if err = w.Close(); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	return client.Quit()
+}
+
+func (sender *SMTPSender) auth() smtp.Auth {
+	switch sender.config.AuthMode {
+	case SMTPAuthPlain:
+		return smtp.PlainAuth("", sender.config.Username, sender.config.Password, sender.config.Host)
+	case SMTPAuthLogin:
+		return &loginAuth{
+			username: sender.config.Username,
+			password: sender.config.Password,
+			host:     sender.config.Host,
+		}
+	case SMTPAuthCRAMMD5:
+		return smtp.CRAMMD5Auth(sender.config.Username, sender.config.Password)
+	}
+	return nil
+}
+
+// loginAuth implements the LOGIN mechanism, which net/smtp does not provide.
+// Like smtp.PlainAuth, it refuses to send the credentials over an unencrypted connection.
+type loginAuth struct {
+	username string
+	password string
+	host     string
+}
+
+func (auth *loginAuth) Start(server *smtp.ServerInfo) (string, []byte, error) {
+	if !server.TLS && !isLocalhost(server.Name) {
+		return "", nil, errors.New("unencrypted connection")
+	}
+	if server.Name != auth.host {
+		return "", nil, errors.New("wrong host name")
+	}
+	return "LOGIN", nil, nil
+}
+
+func (auth *loginAuth) Next(fromServer []byte, more bool) ([]byte, error) {
+	if !more {
+		return nil, nil
+	}
+
+	switch string(fromServer) {
+	case "Username:":
+		return []byte(auth.username), nil
+	case "Password:":
+		return []byte(auth.password), nil
+	}
+	return nil, fmt.Errorf("unexpected server challenge %q", fromServer)
+}
+
+func isLocalhost(name string) bool {
+	return name == "localhost" || name == "127.0.0.1" || name == "::1"
+}
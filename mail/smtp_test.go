@@ -0,0 +1,201 @@
+package mail
+
+import (
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type receivedEmail struct {
+	from       string
+	recipients []string
+	data       string
+}
+
+// startFakeSMTPServer accepts one SMTP session, without TLS or authentication.
+func startFakeSMTPServer(t *testing.T, extensions ...string) (int, <-chan receivedEmail) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	received := make(chan receivedEmail, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		text := textproto.NewConn(conn)
+		var email receivedEmail
+
+		text.PrintfLine("220 localhost ESMTP")
+		for {
+			line, err := text.ReadLine()
+			if err != nil {
+				return
+			}
+
+			command := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+			switch command {
+			case "EHLO", "HELO":
+				replies := append([]string{"localhost"}, extensions...)
+				for i, reply := range replies {
+					separator := "-"
+					if i == len(replies)-1 {
+						separator = " "
+					}
+					text.PrintfLine("250%s%s", separator, reply)
+				}
+			case "MAIL":
+				email.from = strings.Trim(strings.TrimPrefix(line, "MAIL FROM:"), "<>")
+				text.PrintfLine("250 OK")
+			case "RCPT":
+				email.recipients = append(email.recipients, strings.Trim(strings.TrimPrefix(line, "RCPT TO:"), "<>"))
+				text.PrintfLine("250 OK")
+			case "DATA":
+				text.PrintfLine("354 Go ahead")
+				data, err := text.ReadDotBytes()
+				if err != nil {
+					return
+				}
+				email.data = string(data)
+				text.PrintfLine("250 OK")
+			case "QUIT":
+				text.PrintfLine("221 Bye")
+				received <- email
+				return
+			default:
+				text.PrintfLine("502 Not implemented")
+			}
+		}
+	}()
+
+	return listener.Addr().(*net.TCPAddr).Port, received
+}
+
+func TestSMTPSender(t *testing.T) {
+	port, received := startFakeSMTPServer(t)
+
+	sender, err := NewSMTPSender("Simple Bank", "bank@example.com", SMTPConfig{
+		Host:     "127.0.0.1",
+		Port:     port,
+		TLSMode:  SMTPTLSNone,
+		AuthMode: SMTPAuthNone,
+	})
+	require.NoError(t, err)
+
+	message := Message{
+		Subject: "Test subject",
+		Text:    "Test content",
+		HTML:    "<h1>Test content</h1>",
+	}
+	err = sender.SendMessage(message, []string{"to@example.com"}, []string{"cc@example.com"}, []string{"bcc@example.com"}, nil)
+	require.NoError(t, err)
+
+	email := <-received
+	require.Equal(t, "bank@example.com", email.from)
+	require.Equal(t, []string{"to@example.com", "cc@example.com", "bcc@example.com"}, email.recipients)
+	require.Contains(t, email.data, "Subject: Test subject")
+	require.Contains(t, email.data, "multipart/alternative")
+	require.Contains(t, email.data, "Test content")
+	require.NotContains(t, email.data, "bcc@example.com")
+}
+
+func TestSMTPSenderRequiresStartTLS(t *testing.T) {
+	port, _ := startFakeSMTPServer(t)
+
+	sender, err := NewSMTPSender("Simple Bank", "bank@example.com", SMTPConfig{
+		Host:     "127.0.0.1",
+		Port:     port,
+		TLSMode:  SMTPTLSStartTLS,
+		AuthMode: SMTPAuthNone,
+	})
+	require.NoError(t, err)
+
+	err = sender.SendEmail("Test subject", "Test content", []string{"to@example.com"}, nil, nil, nil)
+	require.ErrorContains(t, err, "STARTTLS")
+}
+
+func TestSMTPSenderTimeout(t *testing.T) {
+	// The server accepts the connection but never greets the client.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+	accepted := make(chan net.Conn, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err == nil {
+			accepted <- conn
+		}
+	}()
+
+	sender, err := NewSMTPSender("Simple Bank", "bank@example.com", SMTPConfig{
+		Host:     "127.0.0.1",
+		Port:     listener.Addr().(*net.TCPAddr).Port,
+		TLSMode:  SMTPTLSNone,
+		AuthMode: SMTPAuthNone,
+	})
+	require.NoError(t, err)
+	sender.(*SMTPSender).timeout = 100 * time.Millisecond
+
+	err = sender.SendEmail("Test subject", "Test content", []string{"to@example.com"}, nil, nil, nil)
+	var netErr net.Error
+	require.ErrorAs(t, err, &netErr)
+	require.True(t, netErr.Timeout())
+
+	(<-accepted).Close()
+}
+
+func TestNewSMTPSenderInvalidConfig(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config SMTPConfig
+	}{
+		{
+			name:   "MissingHost",
+			config: SMTPConfig{Port: 25, TLSMode: SMTPTLSNone, AuthMode: SMTPAuthNone},
+		},
+		{
+			name:   "InvalidTLSMode",
+			config: SMTPConfig{Host: "localhost", Port: 25, TLSMode: "ssl", AuthMode: SMTPAuthNone},
+		},
+		{
+			name:   "InvalidAuthMode",
+			config: SMTPConfig{Host: "localhost", Port: 25, TLSMode: SMTPTLSNone, AuthMode: "xoauth2"},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewSMTPSender("Simple Bank", "bank@example.com", tc.config)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestLoginAuth(t *testing.T) {
+	auth := &loginAuth{username: "user", password: "secret", host: "localhost"}
+
+	mechanism, _, err := auth.Start(&smtp.ServerInfo{Name: "localhost"})
+	require.NoError(t, err)
+	require.Equal(t, "LOGIN", mechanism)
+
+	rsp, err := auth.Next([]byte("Username:"), true)
+	require.NoError(t, err)
+	require.Equal(t, "user", string(rsp))
+
+	rsp, err = auth.Next([]byte("Password:"), true)
+	require.NoError(t, err)
+	require.Equal(t, "secret", string(rsp))
+
+	_, _, err = (&loginAuth{host: "smtp.example.com"}).Start(&smtp.ServerInfo{Name: "smtp.example.com"})
+	require.Error(t, err)
+}
@@ -0,0 +1,108 @@
+package mail
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"path"
+	"strings"
+	texttemplate "text/template"
+)
+
+const layoutTemplate = "templates/layout.html"
+
+//go:embed templates
+var templateFS embed.FS
+
+// Message is an email rendered from a template, with a plain text and an HTML alternative.
+type Message struct {
+	Subject string
+	Text    string
+	HTML    string
+}
+
+type emailTemplate struct {
+	text *texttemplate.Template
+	html *htmltemplate.Template
+}
+
+// Templates renders the embedded email templates.
+// Each template lives in templates/<locale>/<name>.txt, which also defines the "subject",
+// and templates/<locale>/<name>.html, which defines the "content" of the HTML layout.
+type Templates struct {
+	defaultLocale string
+	templates     map[string]emailTemplate
+}
+
+// NewTemplates parses the embedded templates. Templates missing in a locale
+// are rendered in the default locale.
+func NewTemplates(defaultLocale string) (*Templates, error) {
+	files, err := fs.Glob(templateFS, "templates/*/*.txt")
+	if err != nil {
+		return nil, err
+	}
+
+	templates := make(map[string]emailTemplate, len(files))
+	for _, textFile := range files {
+		htmlFile := strings.TrimSuffix(textFile, ".txt") + ".html"
+
+		text, err := texttemplate.ParseFS(templateFS, textFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", textFile, err)
+		}
+		if text.Lookup("subject") == nil {
+			return nil, fmt.Errorf("%s does not define a subject", textFile)
+		}
+
+		html, err := htmltemplate.ParseFS(templateFS, layoutTemplate, htmlFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", htmlFile, err)
+		}
+
+		locale := path.Base(path.Dir(textFile))
+		name := strings.TrimSuffix(path.Base(textFile), ".txt")
+		templates[templateKey(locale, name)] = emailTemplate{
+			text: text,
+			html: html,
+		}
+	}
+
+	return &Templates{
+		defaultLocale: defaultLocale,
+		templates:     templates,
+	}, nil
+}
+
+// Render renders the named template in the given locale.
+func (t *Templates) Render(name, locale string, data interface{}) (Message, error) {
+	tmpl, ok := t.templates[templateKey(locale, name)]
+	if !ok {
+		tmpl, ok = t.templates[templateKey(t.defaultLocale, name)]
+		if !ok {
+			return Message{}, fmt.Errorf("email template %q not found", name)
+		}
+	}
+
+	var subject, text, html bytes.Buffer
+	if err := tmpl.text.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return Message{}, fmt.Errorf("failed to render subject of %s: %w", name, err)
+	}
+	if err := tmpl.text.Execute(&text, data); err != nil {
+		return Message{}, fmt.Errorf("failed to render text of %s: %w", name, err)
+	}
+	if err := tmpl.html.Execute(&html, data); err != nil {
+		return Message{}, fmt.Errorf("failed to render html of %s: %w", name, err)
+	}
+
+	return Message{
+		Subject: strings.TrimSpace(subject.String()),
+		Text:    strings.TrimSpace(text.String()) + "\n",
+		HTML:    html.String(),
+	}, nil
+}
+
+func templateKey(locale, name string) string {
+	return locale + "/" + name
+}
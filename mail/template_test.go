@@ -0,0 +1,61 @@
+package mail
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type verifyEmailData struct {
+	FullName  string
+	VerifyURL string
+}
+
+func TestRenderTemplate(t *testing.T) {
+	templates, err := NewTemplates("en")
+	require.NoError(t, err)
+
+	data := verifyEmailData{
+		FullName:  "Jane <Doe>",
+		VerifyURL: "https://bank.example.com/v1/verify_email?id=1&secret_code=abc",
+	}
+
+	message, err := templates.Render("verify_email", "en", data)
+	require.NoError(t, err)
+	require.Equal(t, "Welcome to Bank System", message.Subject)
+	require.Contains(t, message.Text, "Hello Jane <Doe>,")
+	require.Contains(t, message.Text, data.VerifyURL)
+	require.Contains(t, message.HTML, "Hello Jane &lt;Doe&gt;,")
+	require.Contains(t, message.HTML, `href="https://bank.example.com/v1/verify_email?id=1&amp;secret_code=abc"`)
+
+	message, err = templates.Render("verify_email", "es", data)
+	require.NoError(t, err)
+	require.Equal(t, "Bienvenido a Bank System", message.Subject)
+	require.Contains(t, message.Text, "Hola Jane <Doe>,")
+}
+
+func TestRenderTemplateFallback(t *testing.T) {
+	templates, err := NewTemplates("en")
+	require.NoError(t, err)
+
+	message, err := templates.Render("verify_email", "fr", verifyEmailData{FullName: "Jane"})
+	require.NoError(t, err)
+	require.Equal(t, "Welcome to Bank System", message.Subject)
+
+	_, err = templates.Render("unknown", "en", nil)
+	require.Error(t, err)
+}
+
+func TestTemplatesHaveEveryLocale(t *testing.T) {
+	templates, err := NewTemplates("en")
+	require.NoError(t, err)
+
+	for key := range templates.templates {
+		name := path.Base(key)
+		for _, locale := range []string{"en", "es"} {
+			_, ok := templates.templates[templateKey(locale, name)]
+			require.True(t, ok, "template %s is missing in locale %s", name, locale)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"net"
 	"net/http"
 	"os"
@@ -17,13 +18,17 @@ import (
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/mativm02/bank_system/api"
 	db "github.com/mativm02/bank_system/db/sqlc"
+	"github.com/mativm02/bank_system/event"
 	"github.com/mativm02/bank_system/gapi"
 	"github.com/mativm02/bank_system/mail"
 	"github.com/mativm02/bank_system/pb"
+	"github.com/mativm02/bank_system/redact"
 	"github.com/mativm02/bank_system/util"
 	"github.com/mativm02/bank_system/worker"
 	"github.com/rakyll/statik/fs"
+	"github.com/redis/go-redis/v9"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/reflection"
 	"google.golang.org/protobuf/encoding/protojson"
 
@@ -40,6 +45,8 @@ func main() {
 	if config.Environment == "development" {
 		log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stderr})
 	}
+	// log.Ctx falls back to the global logger when the context has no logger
+	zerolog.DefaultContextLogger = &log.Logger
 
 	conn, err := sql.Open(config.DBDriver, config.DBSource)
 	if err != nil {
@@ -60,10 +67,14 @@ func main() {
 		Addr: config.RedisAddress,
 	}
 
-	taskDistributor := worker.NewRedisTaskDistributor(redisOpt)
-	go runTaskProcessor(config, redisOpt, store)
-	go runGatewayServer(config, store, taskDistributor)
-	runGrpcServer(config, store, taskDistributor)
+	taskDistributor := worker.NewRedisTaskDistributor(redisOpt, redact.NewRedactor(config))
+	eventBroker := event.NewRedisBroker(redis.NewClient(&redis.Options{Addr: config.RedisAddress}), event.DefaultStream)
+	go runTaskProcessor(config, redisOpt, store, taskDistributor)
+	go runScheduler(config, redisOpt)
+	go runOutboxRelay(config, store, taskDistributor)
+	go runEventRelay(config, store, eventBroker, taskDistributor)
+	go runGatewayServer(config, store, taskDistributor, eventBroker)
+	runGrpcServer(config, store, taskDistributor, eventBroker)
 }
 
 func runDBMigration(migrationURL string, dbSource string) {
@@ -83,16 +94,71 @@ func runDBMigration(migrationURL string, dbSource string) {
 	log.Info().Msg("migration completed successfully")
 }
 
-func runTaskProcessor(config util.Config, redisOpt asynq.RedisClientOpt, store db.Store) {
-	mailer := mail.NewGmailSender(config.EmailSenderName, config.EmailSenderAddress, config.EmailSenderPassword)
-	taskProcessor := worker.NewRedisTaskProcessor(redisOpt, store, mailer)
+func runTaskProcessor(config util.Config, redisOpt asynq.RedisClientOpt, store db.Store, taskDistributor worker.TaskDistributor) {
+	mailer, err := newEmailSender(config)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot create email sender")
+	}
+
+	templates, err := mail.NewTemplates(util.EN)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot load email templates")
+	}
+
+	taskProcessor := worker.NewRedisTaskProcessor(config, redisOpt, store, mailer, templates, taskDistributor)
 	log.Info().Msg("starting task processor")
-	err := taskProcessor.Start()
+	err = taskProcessor.Start()
 	if err != nil {
 		log.Fatal().Err(err).Msg("cannot start task processor")
 	}
 }
 
+func runScheduler(config util.Config, redisOpt asynq.RedisClientOpt) {
+	scheduler, err := worker.NewScheduler(config, redisOpt)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot create scheduler")
+	}
+
+	log.Info().Msg("starting scheduler")
+	err = scheduler.Run()
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot start scheduler")
+	}
+}
+
+func newEmailSender(config util.Config) (mail.EmailSender, error) {
+	switch config.EmailSenderType {
+	case mail.SenderGmail, "":
+		return mail.NewGmailSender(config.EmailSenderName, config.EmailSenderAddress, config.EmailSenderPassword), nil
+	case mail.SenderSMTP:
+		return mail.NewSMTPSender(config.EmailSenderName, config.EmailSenderAddress, mail.SMTPConfig{
+			Host:     config.SMTPHost,
+			Port:     config.SMTPPort,
+			TLSMode:  config.SMTPTLSMode,
+			AuthMode: config.SMTPAuthMode,
+			Username: config.SMTPUsername,
+			Password: config.SMTPPassword,
+		})
+	case mail.SenderFile:
+		return mail.NewFileSender(config.EmailSenderName, config.EmailSenderAddress, config.EmailDropDir)
+	case mail.SenderMemory:
+		return mail.NewMemorySender(), nil
+	}
+	return nil, fmt.Errorf("unsupported email sender type %q", config.EmailSenderType)
+}
+
+func runOutboxRelay(config util.Config, store db.Store, taskDistributor worker.TaskDistributor) {
+	relay := worker.NewOutboxRelay(store, taskDistributor, config.OutboxRelayInterval)
+	log.Info().Msg("starting outbox relay")
+	relay.Start(context.Background())
+}
+
+func runEventRelay(config util.Config, store db.Store, publisher event.Publisher, taskDistributor worker.TaskDistributor) {
+	relay := worker.NewEventRelay(store, publisher, taskDistributor, config.OutboxRelayInterval)
+	log.Info().Msg("starting event relay")
+	relay.Start(context.Background())
+}
+
 func runGinServer(config util.Config, store db.Store) {
 	server, err := api.NewServer(config, store)
 	if err != nil {
@@ -105,13 +171,15 @@ func runGinServer(config util.Config, store db.Store) {
 	}
 }
 
-func runGrpcServer(config util.Config, store db.Store, taskDistributor worker.TaskDistributor) {
-	server, err := gapi.NewServer(config, store, taskDistributor)
+func runGrpcServer(config util.Config, store db.Store, taskDistributor worker.TaskDistributor, eventSubscriber event.Subscriber) {
+	server, err := gapi.NewServer(config, store, taskDistributor, eventSubscriber)
 	if err != nil {
 		log.Fatal().Err(err).Msg("cannot create server")
 	}
-	grpcLogger := grpc.UnaryInterceptor(gapi.GrpcLogger)
-	grpcServer := grpc.NewServer(grpcLogger)
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(gapi.GrpcRequestID, gapi.GrpcLogger, gapi.GrpcRecovery, server.UnaryAuthInterceptor),
+		grpc.ChainStreamInterceptor(gapi.GrpcStreamRequestID, gapi.GrpcStreamRecovery, server.StreamAuthInterceptor),
+	)
 	pb.RegisterSimpleBankServer(grpcServer, server)
 
 	// Allowing the gRPC client to explore the server's methods and how to call them.
@@ -129,8 +197,8 @@ func runGrpcServer(config util.Config, store db.Store, taskDistributor worker.Ta
 	}
 }
 
-func runGatewayServer(config util.Config, store db.Store, taskDistributor worker.TaskDistributor) {
-	server, err := gapi.NewServer(config, store, taskDistributor)
+func runGatewayServer(config util.Config, store db.Store, taskDistributor worker.TaskDistributor, eventSubscriber event.Subscriber) {
+	server, err := gapi.NewServer(config, store, taskDistributor, eventSubscriber)
 	if err != nil {
 		log.Fatal().Err(err).Msg("cannot create server")
 	}
@@ -144,18 +212,22 @@ func runGatewayServer(config util.Config, store db.Store, taskDistributor worker
 		},
 	})
 
-	grpcMux := runtime.NewServeMux(jsonOption)
+	grpcMux := runtime.NewServeMux(jsonOption, runtime.WithMetadata(gapi.GatewayRequestIDMetadata))
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	err = pb.RegisterSimpleBankHandlerServer(ctx, grpcMux, server)
+	// The gateway calls the gRPC server rather than the handlers directly,
+	// so its requests go through the same interceptors.
+	dialOptions := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	err = pb.RegisterSimpleBankHandlerFromEndpoint(ctx, grpcMux, config.GRPCServerAddress, dialOptions)
 	if err != nil {
 		log.Fatal().Err(err).Msg("cannot register gateway server")
 	}
 
 	mux := http.NewServeMux()
 	mux.Handle("/", grpcMux)
+	mux.Handle(gapi.JWKSPath, server.JWKSHandler())
 
 	statikFS, err := fs.New()
 	if err != nil {
@@ -171,7 +243,7 @@ func runGatewayServer(config util.Config, store db.Store, taskDistributor worker
 	}
 	log.Info().Msgf("starting HTTP Gateway server on %s", config.HTTPServerAddress)
 
-	handler := gapi.HttpLogger(mux)
+	handler := gapi.HttpRequestID(gapi.HttpLogger(gapi.HttpRecovery(mux), redact.NewRedactor(config)))
 	err = http.Serve(listener, handler)
 	if err != nil {
 		log.Fatal().Err(err).Msg("cannot serve")
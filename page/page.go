@@ -0,0 +1,149 @@
+package page
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// DefaultSize is the size of the pages when the request does not set one.
+const DefaultSize = 10
+
+const minKeySize = 32
+
+var ErrTokenInvalid = errors.New("page token is invalid")
+
+// Token is the position of a page in a listing sorted by a value then by ID: the page starts
+// after the item with this value and ID. The query is the fingerprint of the filters and of the
+// sort order, so a token cannot be used to continue another listing.
+type Token struct {
+	Value int64  `json:"v"`
+	ID    int64  `json:"i"`
+	Query string `json:"q"`
+}
+
+// Signer encodes the page tokens and signs them with HMAC-SHA256, so they are opaque to the
+// clients and cannot be forged to read past the filters of the listing.
+type Signer struct {
+	key []byte
+}
+
+// NewSigner creates a new Signer with the given key
+func NewSigner(key string) (*Signer, error) {
+	if len(key) < minKeySize {
+		return nil, fmt.Errorf("invalid key size: must be at least %d characters", minKeySize)
+	}
+	return &Signer{key: []byte(key)}, nil
+}
+
+// Encode returns the opaque page token for the given position
+func (signer *Signer) Encode(token Token) string {
+	data, _ := json.Marshal(token)
+	return base64.RawURLEncoding.EncodeToString(data) + "." + base64.RawURLEncoding.EncodeToString(signer.sign(data))
+}
+
+// Decode checks the signature of the page token and that it was issued for the same query
+func (signer *Signer) Decode(pageToken string, query string) (Token, error) {
+	encodedData, encodedSignature, ok := strings.Cut(pageToken, ".")
+	if !ok {
+		return Token{}, ErrTokenInvalid
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(encodedData)
+	if err != nil {
+		return Token{}, ErrTokenInvalid
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil || !hmac.Equal(signature, signer.sign(data)) {
+		return Token{}, ErrTokenInvalid
+	}
+
+	var token Token
+	if err := json.Unmarshal(data, &token); err != nil || token.Query != query {
+		return Token{}, ErrTokenInvalid
+	}
+	return token, nil
+}
+
+// Cursor returns the position after which the page of the token starts.
+// The position is not valid for the first page, when the token is empty.
+func (signer *Signer) Cursor(pageToken string, query string) (value sql.NullInt64, id sql.NullInt64, err error) {
+	if pageToken == "" {
+		return value, id, nil
+	}
+
+	token, err := signer.Decode(pageToken, query)
+	if err != nil {
+		return value, id, err
+	}
+	return sql.NullInt64{Int64: token.Value, Valid: true}, sql.NullInt64{Int64: token.ID, Valid: true}, nil
+}
+
+func (signer *Signer) sign(data []byte) []byte {
+	mac := hmac.New(sha256.New, signer.key)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+// Size returns the size of the page, DefaultSize when the request does not set it.
+func Size(pageSize int32) int32 {
+	if pageSize == 0 {
+		return DefaultSize
+	}
+	return pageSize
+}
+
+// Fingerprint returns a short digest of the parameters of a listing, to be stored in its page tokens.
+func Fingerprint(params ...interface{}) string {
+	data, _ := json.Marshal(params)
+	sum := sha256.Sum256(data)
+	return base64.RawURLEncoding.EncodeToString(sum[:12])
+}
+
+// Order is a sort order of a listing.
+type Order struct {
+	Field string
+	Desc  bool
+}
+
+// ParseOrderBy parses an AIP-132 order_by value, a field name optionally followed by "asc" or "desc".
+// An empty value returns the default order.
+func ParseOrderBy(orderBy string, fields []string, defaultOrder Order) (Order, error) {
+	parts := strings.Fields(orderBy)
+	if len(parts) == 0 {
+		return defaultOrder, nil
+	}
+	if len(parts) > 2 {
+		return Order{}, fmt.Errorf("must be a field name optionally followed by asc or desc")
+	}
+
+	order := Order{Field: parts[0]}
+	if !contains(fields, order.Field) {
+		return Order{}, fmt.Errorf("must be one of %s", strings.Join(fields, ", "))
+	}
+
+	if len(parts) == 2 {
+		switch strings.ToLower(parts[1]) {
+		case "asc":
+		case "desc":
+			order.Desc = true
+		default:
+			return Order{}, fmt.Errorf("direction must be asc or desc")
+		}
+	}
+	return order, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,112 @@
+package page
+
+import (
+	"testing"
+
+	"github.com/mativm02/bank_system/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSigner(t *testing.T) {
+	signer, err := NewSigner(util.RandomString(32))
+	require.NoError(t, err)
+
+	query := Fingerprint("owner", "USD", "created_at", true)
+	token := Token{Value: 1700000000000000, ID: 42, Query: query}
+
+	pageToken := signer.Encode(token)
+	require.NotEmpty(t, pageToken)
+
+	decoded, err := signer.Decode(pageToken, query)
+	require.NoError(t, err)
+	require.Equal(t, token, decoded)
+
+	// The token cannot continue a listing with other parameters.
+	_, err = signer.Decode(pageToken, Fingerprint("owner", "EUR", "created_at", true))
+	require.ErrorIs(t, err, ErrTokenInvalid)
+
+	// The token is rejected by a signer with another key.
+	otherSigner, err := NewSigner(util.RandomString(32))
+	require.NoError(t, err)
+	_, err = otherSigner.Decode(pageToken, query)
+	require.ErrorIs(t, err, ErrTokenInvalid)
+}
+
+func TestSignerCursor(t *testing.T) {
+	signer, err := NewSigner(util.RandomString(32))
+	require.NoError(t, err)
+
+	value, id, err := signer.Cursor("", "query")
+	require.NoError(t, err)
+	require.False(t, value.Valid)
+	require.False(t, id.Valid)
+
+	pageToken := signer.Encode(Token{Value: 100, ID: 7, Query: "query"})
+	value, id, err = signer.Cursor(pageToken, "query")
+	require.NoError(t, err)
+	require.True(t, value.Valid)
+	require.Equal(t, int64(100), value.Int64)
+	require.True(t, id.Valid)
+	require.Equal(t, int64(7), id.Int64)
+
+	_, _, err = signer.Cursor(pageToken, "other query")
+	require.ErrorIs(t, err, ErrTokenInvalid)
+}
+
+func TestSignerTamperedToken(t *testing.T) {
+	signer, err := NewSigner(util.RandomString(32))
+	require.NoError(t, err)
+
+	query := Fingerprint("owner")
+	forged := Token{Value: 0, ID: 1, Query: query}
+	otherSigner, err := NewSigner(util.RandomString(32))
+	require.NoError(t, err)
+
+	for _, pageToken := range []string{
+		"",
+		"abc",
+		"abc.def",
+		otherSigner.Encode(forged),
+	} {
+		_, err := signer.Decode(pageToken, query)
+		require.ErrorIs(t, err, ErrTokenInvalid)
+	}
+}
+
+func TestNewSignerInvalidKey(t *testing.T) {
+	signer, err := NewSigner(util.RandomString(31))
+	require.Error(t, err)
+	require.Nil(t, signer)
+}
+
+func TestParseOrderBy(t *testing.T) {
+	fields := []string{"created_at", "amount"}
+	defaultOrder := Order{Field: "created_at", Desc: true}
+
+	testCases := []struct {
+		orderBy string
+		order   Order
+		isValid bool
+	}{
+		{"", defaultOrder, true},
+		{"amount", Order{Field: "amount"}, true},
+		{"amount asc", Order{Field: "amount"}, true},
+		{"amount desc", Order{Field: "amount", Desc: true}, true},
+		{" created_at DESC ", Order{Field: "created_at", Desc: true}, true},
+		{"balance", Order{}, false},
+		{"amount down", Order{}, false},
+		{"amount desc id", Order{}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.orderBy, func(t *testing.T) {
+			order, err := ParseOrderBy(tc.orderBy, fields, defaultOrder)
+			if !tc.isValid {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.order, order)
+		})
+	}
+}
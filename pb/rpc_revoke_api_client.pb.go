@@ -0,0 +1,213 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.30.0
+// 	protoc        v3.21.12
+// source: rpc_revoke_api_client.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type RevokeApiClientRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ClientId string `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+}
+
+func (x *RevokeApiClientRequest) Reset() {
+	*x = RevokeApiClientRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_revoke_api_client_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RevokeApiClientRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeApiClientRequest) ProtoMessage() {}
+
+func (x *RevokeApiClientRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_revoke_api_client_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeApiClientRequest.ProtoReflect.Descriptor instead.
+func (*RevokeApiClientRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_revoke_api_client_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RevokeApiClientRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+type RevokeApiClientResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ApiClient *ApiClient `protobuf:"bytes,1,opt,name=api_client,json=apiClient,proto3" json:"api_client,omitempty"`
+}
+
+func (x *RevokeApiClientResponse) Reset() {
+	*x = RevokeApiClientResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_revoke_api_client_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RevokeApiClientResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeApiClientResponse) ProtoMessage() {}
+
+func (x *RevokeApiClientResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_revoke_api_client_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeApiClientResponse.ProtoReflect.Descriptor instead.
+func (*RevokeApiClientResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_revoke_api_client_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RevokeApiClientResponse) GetApiClient() *ApiClient {
+	if x != nil {
+		return x.ApiClient
+	}
+	return nil
+}
+
+var File_rpc_revoke_api_client_proto protoreflect.FileDescriptor
+
+var file_rpc_revoke_api_client_proto_rawDesc = []byte{
+	0x0a, 0x1b, 0x72, 0x70, 0x63, 0x5f, 0x72, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x5f, 0x61, 0x70, 0x69,
+	0x5f, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02, 0x70,
+	0x62, 0x1a, 0x10, 0x61, 0x70, 0x69, 0x5f, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x22, 0x35, 0x0a, 0x16, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x41, 0x70, 0x69,
+	0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a,
+	0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x22, 0x47, 0x0a, 0x17, 0x52, 0x65,
+	0x76, 0x6f, 0x6b, 0x65, 0x41, 0x70, 0x69, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x0a, 0x61, 0x70, 0x69, 0x5f, 0x63, 0x6c, 0x69,
+	0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70, 0x62, 0x2e, 0x41,
+	0x70, 0x69, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x52, 0x09, 0x61, 0x70, 0x69, 0x43, 0x6c, 0x69,
+	0x65, 0x6e, 0x74, 0x42, 0x23, 0x5a, 0x21, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2f, 0x6d, 0x61, 0x74, 0x69, 0x76, 0x6d, 0x30, 0x32, 0x2f, 0x73, 0x69, 0x6d, 0x70, 0x6c,
+	0x65, 0x62, 0x61, 0x6e, 0x6b, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_rpc_revoke_api_client_proto_rawDescOnce sync.Once
+	file_rpc_revoke_api_client_proto_rawDescData = file_rpc_revoke_api_client_proto_rawDesc
+)
+
+func file_rpc_revoke_api_client_proto_rawDescGZIP() []byte {
+	file_rpc_revoke_api_client_proto_rawDescOnce.Do(func() {
+		file_rpc_revoke_api_client_proto_rawDescData = protoimpl.X.CompressGZIP(file_rpc_revoke_api_client_proto_rawDescData)
+	})
+	return file_rpc_revoke_api_client_proto_rawDescData
+}
+
+var file_rpc_revoke_api_client_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_revoke_api_client_proto_goTypes = []interface{}{
+	(*RevokeApiClientRequest)(nil),  // 0: pb.RevokeApiClientRequest
+	(*RevokeApiClientResponse)(nil), // 1: pb.RevokeApiClientResponse
+	(*ApiClient)(nil),               // 2: pb.ApiClient
+}
+var file_rpc_revoke_api_client_proto_depIdxs = []int32{
+	2, // 0: pb.RevokeApiClientResponse.api_client:type_name -> pb.ApiClient
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_rpc_revoke_api_client_proto_init() }
+func file_rpc_revoke_api_client_proto_init() {
+	if File_rpc_revoke_api_client_proto != nil {
+		return
+	}
+	file_api_client_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_rpc_revoke_api_client_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RevokeApiClientRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_revoke_api_client_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RevokeApiClientResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_rpc_revoke_api_client_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_revoke_api_client_proto_goTypes,
+		DependencyIndexes: file_rpc_revoke_api_client_proto_depIdxs,
+		MessageInfos:      file_rpc_revoke_api_client_proto_msgTypes,
+	}.Build()
+	File_rpc_revoke_api_client_proto = out.File
+	file_rpc_revoke_api_client_proto_rawDesc = nil
+	file_rpc_revoke_api_client_proto_goTypes = nil
+	file_rpc_revoke_api_client_proto_depIdxs = nil
+}
@@ -0,0 +1,241 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.30.0
+// 	protoc        v3.21.12
+// source: rpc_set_account_spending_limit.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SetAccountSpendingLimitRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AccountId int64 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	// The limits that are not set go back to the configured defaults. 0 is no limit.
+	DailyLimit   *int64 `protobuf:"varint,2,opt,name=daily_limit,json=dailyLimit,proto3,oneof" json:"daily_limit,omitempty"`
+	MonthlyLimit *int64 `protobuf:"varint,3,opt,name=monthly_limit,json=monthlyLimit,proto3,oneof" json:"monthly_limit,omitempty"`
+}
+
+func (x *SetAccountSpendingLimitRequest) Reset() {
+	*x = SetAccountSpendingLimitRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_set_account_spending_limit_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetAccountSpendingLimitRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetAccountSpendingLimitRequest) ProtoMessage() {}
+
+func (x *SetAccountSpendingLimitRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_set_account_spending_limit_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetAccountSpendingLimitRequest.ProtoReflect.Descriptor instead.
+func (*SetAccountSpendingLimitRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_set_account_spending_limit_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SetAccountSpendingLimitRequest) GetAccountId() int64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *SetAccountSpendingLimitRequest) GetDailyLimit() int64 {
+	if x != nil && x.DailyLimit != nil {
+		return *x.DailyLimit
+	}
+	return 0
+}
+
+func (x *SetAccountSpendingLimitRequest) GetMonthlyLimit() int64 {
+	if x != nil && x.MonthlyLimit != nil {
+		return *x.MonthlyLimit
+	}
+	return 0
+}
+
+type SetAccountSpendingLimitResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Limit *AccountSpendingLimit `protobuf:"bytes,1,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *SetAccountSpendingLimitResponse) Reset() {
+	*x = SetAccountSpendingLimitResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_set_account_spending_limit_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetAccountSpendingLimitResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetAccountSpendingLimitResponse) ProtoMessage() {}
+
+func (x *SetAccountSpendingLimitResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_set_account_spending_limit_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetAccountSpendingLimitResponse.ProtoReflect.Descriptor instead.
+func (*SetAccountSpendingLimitResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_set_account_spending_limit_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SetAccountSpendingLimitResponse) GetLimit() *AccountSpendingLimit {
+	if x != nil {
+		return x.Limit
+	}
+	return nil
+}
+
+var File_rpc_set_account_spending_limit_proto protoreflect.FileDescriptor
+
+var file_rpc_set_account_spending_limit_proto_rawDesc = []byte{
+	0x0a, 0x24, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x74, 0x5f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x5f, 0x73, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02, 0x70, 0x62, 0x1a, 0x14, 0x73, 0x70, 0x65, 0x6e,
+	0x64, 0x69, 0x6e, 0x67, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x22, 0xb1, 0x01, 0x0a, 0x1e, 0x53, 0x65, 0x74, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x53,
+	0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x49, 0x64, 0x12, 0x24, 0x0a, 0x0b, 0x64, 0x61, 0x69, 0x6c, 0x79, 0x5f, 0x6c, 0x69, 0x6d, 0x69,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x0a, 0x64, 0x61, 0x69, 0x6c, 0x79,
+	0x4c, 0x69, 0x6d, 0x69, 0x74, 0x88, 0x01, 0x01, 0x12, 0x28, 0x0a, 0x0d, 0x6d, 0x6f, 0x6e, 0x74,
+	0x68, 0x6c, 0x79, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x48,
+	0x01, 0x52, 0x0c, 0x6d, 0x6f, 0x6e, 0x74, 0x68, 0x6c, 0x79, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x88,
+	0x01, 0x01, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x64, 0x61, 0x69, 0x6c, 0x79, 0x5f, 0x6c, 0x69, 0x6d,
+	0x69, 0x74, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x6d, 0x6f, 0x6e, 0x74, 0x68, 0x6c, 0x79, 0x5f, 0x6c,
+	0x69, 0x6d, 0x69, 0x74, 0x22, 0x51, 0x0a, 0x1f, 0x53, 0x65, 0x74, 0x41, 0x63, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x53, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x70, 0x62, 0x2e, 0x41, 0x63, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x53, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x4c, 0x69, 0x6d, 0x69, 0x74,
+	0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x42, 0x23, 0x5a, 0x21, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6d, 0x61, 0x74, 0x69, 0x76, 0x6d, 0x30, 0x32, 0x2f, 0x73,
+	0x69, 0x6d, 0x70, 0x6c, 0x65, 0x62, 0x61, 0x6e, 0x6b, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_rpc_set_account_spending_limit_proto_rawDescOnce sync.Once
+	file_rpc_set_account_spending_limit_proto_rawDescData = file_rpc_set_account_spending_limit_proto_rawDesc
+)
+
+func file_rpc_set_account_spending_limit_proto_rawDescGZIP() []byte {
+	file_rpc_set_account_spending_limit_proto_rawDescOnce.Do(func() {
+		file_rpc_set_account_spending_limit_proto_rawDescData = protoimpl.X.CompressGZIP(file_rpc_set_account_spending_limit_proto_rawDescData)
+	})
+	return file_rpc_set_account_spending_limit_proto_rawDescData
+}
+
+var file_rpc_set_account_spending_limit_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_set_account_spending_limit_proto_goTypes = []interface{}{
+	(*SetAccountSpendingLimitRequest)(nil),  // 0: pb.SetAccountSpendingLimitRequest
+	(*SetAccountSpendingLimitResponse)(nil), // 1: pb.SetAccountSpendingLimitResponse
+	(*AccountSpendingLimit)(nil),            // 2: pb.AccountSpendingLimit
+}
+var file_rpc_set_account_spending_limit_proto_depIdxs = []int32{
+	2, // 0: pb.SetAccountSpendingLimitResponse.limit:type_name -> pb.AccountSpendingLimit
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_rpc_set_account_spending_limit_proto_init() }
+func file_rpc_set_account_spending_limit_proto_init() {
+	if File_rpc_set_account_spending_limit_proto != nil {
+		return
+	}
+	file_spending_limit_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_rpc_set_account_spending_limit_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetAccountSpendingLimitRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_set_account_spending_limit_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetAccountSpendingLimitResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_rpc_set_account_spending_limit_proto_msgTypes[0].OneofWrappers = []interface{}{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_rpc_set_account_spending_limit_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_set_account_spending_limit_proto_goTypes,
+		DependencyIndexes: file_rpc_set_account_spending_limit_proto_depIdxs,
+		MessageInfos:      file_rpc_set_account_spending_limit_proto_msgTypes,
+	}.Build()
+	File_rpc_set_account_spending_limit_proto = out.File
+	file_rpc_set_account_spending_limit_proto_rawDesc = nil
+	file_rpc_set_account_spending_limit_proto_goTypes = nil
+	file_rpc_set_account_spending_limit_proto_depIdxs = nil
+}
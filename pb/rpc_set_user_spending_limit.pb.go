@@ -0,0 +1,251 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.30.0
+// 	protoc        v3.21.12
+// source: rpc_set_user_spending_limit.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SetUserSpendingLimitRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Username string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	// The limit covers the accounts of the user in the currency together.
+	Currency string `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	// The limits that are not set go back to the configured defaults. 0 is no limit.
+	DailyLimit   *int64 `protobuf:"varint,3,opt,name=daily_limit,json=dailyLimit,proto3,oneof" json:"daily_limit,omitempty"`
+	MonthlyLimit *int64 `protobuf:"varint,4,opt,name=monthly_limit,json=monthlyLimit,proto3,oneof" json:"monthly_limit,omitempty"`
+}
+
+func (x *SetUserSpendingLimitRequest) Reset() {
+	*x = SetUserSpendingLimitRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_set_user_spending_limit_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetUserSpendingLimitRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetUserSpendingLimitRequest) ProtoMessage() {}
+
+func (x *SetUserSpendingLimitRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_set_user_spending_limit_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetUserSpendingLimitRequest.ProtoReflect.Descriptor instead.
+func (*SetUserSpendingLimitRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_set_user_spending_limit_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SetUserSpendingLimitRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *SetUserSpendingLimitRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *SetUserSpendingLimitRequest) GetDailyLimit() int64 {
+	if x != nil && x.DailyLimit != nil {
+		return *x.DailyLimit
+	}
+	return 0
+}
+
+func (x *SetUserSpendingLimitRequest) GetMonthlyLimit() int64 {
+	if x != nil && x.MonthlyLimit != nil {
+		return *x.MonthlyLimit
+	}
+	return 0
+}
+
+type SetUserSpendingLimitResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Limit *UserSpendingLimit `protobuf:"bytes,1,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *SetUserSpendingLimitResponse) Reset() {
+	*x = SetUserSpendingLimitResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_set_user_spending_limit_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetUserSpendingLimitResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetUserSpendingLimitResponse) ProtoMessage() {}
+
+func (x *SetUserSpendingLimitResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_set_user_spending_limit_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetUserSpendingLimitResponse.ProtoReflect.Descriptor instead.
+func (*SetUserSpendingLimitResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_set_user_spending_limit_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SetUserSpendingLimitResponse) GetLimit() *UserSpendingLimit {
+	if x != nil {
+		return x.Limit
+	}
+	return nil
+}
+
+var File_rpc_set_user_spending_limit_proto protoreflect.FileDescriptor
+
+var file_rpc_set_user_spending_limit_proto_rawDesc = []byte{
+	0x0a, 0x21, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x74, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x73,
+	0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x12, 0x02, 0x70, 0x62, 0x1a, 0x14, 0x73, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e,
+	0x67, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xc7, 0x01,
+	0x0a, 0x1b, 0x53, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x53, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e,
+	0x67, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a,
+	0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x75, 0x72,
+	0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x75, 0x72,
+	0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x24, 0x0a, 0x0b, 0x64, 0x61, 0x69, 0x6c, 0x79, 0x5f, 0x6c,
+	0x69, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x0a, 0x64, 0x61,
+	0x69, 0x6c, 0x79, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x88, 0x01, 0x01, 0x12, 0x28, 0x0a, 0x0d, 0x6d,
+	0x6f, 0x6e, 0x74, 0x68, 0x6c, 0x79, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x03, 0x48, 0x01, 0x52, 0x0c, 0x6d, 0x6f, 0x6e, 0x74, 0x68, 0x6c, 0x79, 0x4c, 0x69, 0x6d,
+	0x69, 0x74, 0x88, 0x01, 0x01, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x64, 0x61, 0x69, 0x6c, 0x79, 0x5f,
+	0x6c, 0x69, 0x6d, 0x69, 0x74, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x6d, 0x6f, 0x6e, 0x74, 0x68, 0x6c,
+	0x79, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x4b, 0x0a, 0x1c, 0x53, 0x65, 0x74, 0x55, 0x73,
+	0x65, 0x72, 0x53, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x62, 0x2e, 0x55, 0x73, 0x65, 0x72,
+	0x53, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x52, 0x05, 0x6c,
+	0x69, 0x6d, 0x69, 0x74, 0x42, 0x23, 0x5a, 0x21, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2f, 0x6d, 0x61, 0x74, 0x69, 0x76, 0x6d, 0x30, 0x32, 0x2f, 0x73, 0x69, 0x6d, 0x70,
+	0x6c, 0x65, 0x62, 0x61, 0x6e, 0x6b, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
+}
+
+var (
+	file_rpc_set_user_spending_limit_proto_rawDescOnce sync.Once
+	file_rpc_set_user_spending_limit_proto_rawDescData = file_rpc_set_user_spending_limit_proto_rawDesc
+)
+
+func file_rpc_set_user_spending_limit_proto_rawDescGZIP() []byte {
+	file_rpc_set_user_spending_limit_proto_rawDescOnce.Do(func() {
+		file_rpc_set_user_spending_limit_proto_rawDescData = protoimpl.X.CompressGZIP(file_rpc_set_user_spending_limit_proto_rawDescData)
+	})
+	return file_rpc_set_user_spending_limit_proto_rawDescData
+}
+
+var file_rpc_set_user_spending_limit_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_set_user_spending_limit_proto_goTypes = []interface{}{
+	(*SetUserSpendingLimitRequest)(nil),  // 0: pb.SetUserSpendingLimitRequest
+	(*SetUserSpendingLimitResponse)(nil), // 1: pb.SetUserSpendingLimitResponse
+	(*UserSpendingLimit)(nil),            // 2: pb.UserSpendingLimit
+}
+var file_rpc_set_user_spending_limit_proto_depIdxs = []int32{
+	2, // 0: pb.SetUserSpendingLimitResponse.limit:type_name -> pb.UserSpendingLimit
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_rpc_set_user_spending_limit_proto_init() }
+func file_rpc_set_user_spending_limit_proto_init() {
+	if File_rpc_set_user_spending_limit_proto != nil {
+		return
+	}
+	file_spending_limit_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_rpc_set_user_spending_limit_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetUserSpendingLimitRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_set_user_spending_limit_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetUserSpendingLimitResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_rpc_set_user_spending_limit_proto_msgTypes[0].OneofWrappers = []interface{}{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_rpc_set_user_spending_limit_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_set_user_spending_limit_proto_goTypes,
+		DependencyIndexes: file_rpc_set_user_spending_limit_proto_depIdxs,
+		MessageInfos:      file_rpc_set_user_spending_limit_proto_msgTypes,
+	}.Build()
+	File_rpc_set_user_spending_limit_proto = out.File
+	file_rpc_set_user_spending_limit_proto_rawDesc = nil
+	file_rpc_set_user_spending_limit_proto_goTypes = nil
+	file_rpc_set_user_spending_limit_proto_depIdxs = nil
+}
@@ -19,10 +19,36 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	SimpleBank_CreateUser_FullMethodName  = "/pb.SimpleBank/CreateUser"
-	SimpleBank_LoginUser_FullMethodName   = "/pb.SimpleBank/LoginUser"
-	SimpleBank_UpdateUser_FullMethodName  = "/pb.SimpleBank/UpdateUser"
-	SimpleBank_VerifyEmail_FullMethodName = "/pb.SimpleBank/VerifyEmail"
+	SimpleBank_CreateUser_FullMethodName                    = "/pb.SimpleBank/CreateUser"
+	SimpleBank_LoginUser_FullMethodName                     = "/pb.SimpleBank/LoginUser"
+	SimpleBank_UpdateUser_FullMethodName                    = "/pb.SimpleBank/UpdateUser"
+	SimpleBank_VerifyEmail_FullMethodName                   = "/pb.SimpleBank/VerifyEmail"
+	SimpleBank_ResendVerifyEmail_FullMethodName             = "/pb.SimpleBank/ResendVerifyEmail"
+	SimpleBank_WatchAccountEvents_FullMethodName            = "/pb.SimpleBank/WatchAccountEvents"
+	SimpleBank_CreateWebhook_FullMethodName                 = "/pb.SimpleBank/CreateWebhook"
+	SimpleBank_ListWebhooks_FullMethodName                  = "/pb.SimpleBank/ListWebhooks"
+	SimpleBank_DeleteWebhook_FullMethodName                 = "/pb.SimpleBank/DeleteWebhook"
+	SimpleBank_ListWebhookDeliveries_FullMethodName         = "/pb.SimpleBank/ListWebhookDeliveries"
+	SimpleBank_GetNotificationPreferences_FullMethodName    = "/pb.SimpleBank/GetNotificationPreferences"
+	SimpleBank_UpdateNotificationPreferences_FullMethodName = "/pb.SimpleBank/UpdateNotificationPreferences"
+	SimpleBank_CreateClientToken_FullMethodName             = "/pb.SimpleBank/CreateClientToken"
+	SimpleBank_CreateApiClient_FullMethodName               = "/pb.SimpleBank/CreateApiClient"
+	SimpleBank_RotateApiClientSecret_FullMethodName         = "/pb.SimpleBank/RotateApiClientSecret"
+	SimpleBank_RevokeApiClient_FullMethodName               = "/pb.SimpleBank/RevokeApiClient"
+	SimpleBank_CreateApiKey_FullMethodName                  = "/pb.SimpleBank/CreateApiKey"
+	SimpleBank_ListApiKeys_FullMethodName                   = "/pb.SimpleBank/ListApiKeys"
+	SimpleBank_RevokeApiKey_FullMethodName                  = "/pb.SimpleBank/RevokeApiKey"
+	SimpleBank_ListAccounts_FullMethodName                  = "/pb.SimpleBank/ListAccounts"
+	SimpleBank_ListTransfers_FullMethodName                 = "/pb.SimpleBank/ListTransfers"
+	SimpleBank_SearchTransfers_FullMethodName               = "/pb.SimpleBank/SearchTransfers"
+	SimpleBank_CreatePayee_FullMethodName                   = "/pb.SimpleBank/CreatePayee"
+	SimpleBank_ListPayees_FullMethodName                    = "/pb.SimpleBank/ListPayees"
+	SimpleBank_DeletePayee_FullMethodName                   = "/pb.SimpleBank/DeletePayee"
+	SimpleBank_CreateInterestProduct_FullMethodName         = "/pb.SimpleBank/CreateInterestProduct"
+	SimpleBank_SetAccountInterestProduct_FullMethodName     = "/pb.SimpleBank/SetAccountInterestProduct"
+	SimpleBank_ListInterestAccruals_FullMethodName          = "/pb.SimpleBank/ListInterestAccruals"
+	SimpleBank_SetAccountSpendingLimit_FullMethodName       = "/pb.SimpleBank/SetAccountSpendingLimit"
+	SimpleBank_SetUserSpendingLimit_FullMethodName          = "/pb.SimpleBank/SetUserSpendingLimit"
 )
 
 // SimpleBankClient is the client API for SimpleBank service.
@@ -33,6 +59,32 @@ type SimpleBankClient interface {
 	LoginUser(ctx context.Context, in *LoginUserRequest, opts ...grpc.CallOption) (*LoginUserResponse, error)
 	UpdateUser(ctx context.Context, in *UpdateUserRequest, opts ...grpc.CallOption) (*UpdateUserResponse, error)
 	VerifyEmail(ctx context.Context, in *VerifyEmailRequest, opts ...grpc.CallOption) (*VerifyEmailResponse, error)
+	ResendVerifyEmail(ctx context.Context, in *ResendVerifyEmailRequest, opts ...grpc.CallOption) (*ResendVerifyEmailResponse, error)
+	WatchAccountEvents(ctx context.Context, in *WatchAccountEventsRequest, opts ...grpc.CallOption) (SimpleBank_WatchAccountEventsClient, error)
+	CreateWebhook(ctx context.Context, in *CreateWebhookRequest, opts ...grpc.CallOption) (*CreateWebhookResponse, error)
+	ListWebhooks(ctx context.Context, in *ListWebhooksRequest, opts ...grpc.CallOption) (*ListWebhooksResponse, error)
+	DeleteWebhook(ctx context.Context, in *DeleteWebhookRequest, opts ...grpc.CallOption) (*DeleteWebhookResponse, error)
+	ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListWebhookDeliveriesResponse, error)
+	GetNotificationPreferences(ctx context.Context, in *GetNotificationPreferencesRequest, opts ...grpc.CallOption) (*GetNotificationPreferencesResponse, error)
+	UpdateNotificationPreferences(ctx context.Context, in *UpdateNotificationPreferencesRequest, opts ...grpc.CallOption) (*UpdateNotificationPreferencesResponse, error)
+	CreateClientToken(ctx context.Context, in *CreateClientTokenRequest, opts ...grpc.CallOption) (*CreateClientTokenResponse, error)
+	CreateApiClient(ctx context.Context, in *CreateApiClientRequest, opts ...grpc.CallOption) (*CreateApiClientResponse, error)
+	RotateApiClientSecret(ctx context.Context, in *RotateApiClientSecretRequest, opts ...grpc.CallOption) (*RotateApiClientSecretResponse, error)
+	RevokeApiClient(ctx context.Context, in *RevokeApiClientRequest, opts ...grpc.CallOption) (*RevokeApiClientResponse, error)
+	CreateApiKey(ctx context.Context, in *CreateApiKeyRequest, opts ...grpc.CallOption) (*CreateApiKeyResponse, error)
+	ListApiKeys(ctx context.Context, in *ListApiKeysRequest, opts ...grpc.CallOption) (*ListApiKeysResponse, error)
+	RevokeApiKey(ctx context.Context, in *RevokeApiKeyRequest, opts ...grpc.CallOption) (*RevokeApiKeyResponse, error)
+	ListAccounts(ctx context.Context, in *ListAccountsRequest, opts ...grpc.CallOption) (*ListAccountsResponse, error)
+	ListTransfers(ctx context.Context, in *ListTransfersRequest, opts ...grpc.CallOption) (*ListTransfersResponse, error)
+	SearchTransfers(ctx context.Context, in *SearchTransfersRequest, opts ...grpc.CallOption) (*SearchTransfersResponse, error)
+	CreatePayee(ctx context.Context, in *CreatePayeeRequest, opts ...grpc.CallOption) (*CreatePayeeResponse, error)
+	ListPayees(ctx context.Context, in *ListPayeesRequest, opts ...grpc.CallOption) (*ListPayeesResponse, error)
+	DeletePayee(ctx context.Context, in *DeletePayeeRequest, opts ...grpc.CallOption) (*DeletePayeeResponse, error)
+	CreateInterestProduct(ctx context.Context, in *CreateInterestProductRequest, opts ...grpc.CallOption) (*CreateInterestProductResponse, error)
+	SetAccountInterestProduct(ctx context.Context, in *SetAccountInterestProductRequest, opts ...grpc.CallOption) (*SetAccountInterestProductResponse, error)
+	ListInterestAccruals(ctx context.Context, in *ListInterestAccrualsRequest, opts ...grpc.CallOption) (*ListInterestAccrualsResponse, error)
+	SetAccountSpendingLimit(ctx context.Context, in *SetAccountSpendingLimitRequest, opts ...grpc.CallOption) (*SetAccountSpendingLimitResponse, error)
+	SetUserSpendingLimit(ctx context.Context, in *SetUserSpendingLimitRequest, opts ...grpc.CallOption) (*SetUserSpendingLimitResponse, error)
 }
 
 type simpleBankClient struct {
@@ -79,6 +131,263 @@ func (c *simpleBankClient) VerifyEmail(ctx context.Context, in *VerifyEmailReque
 	return out, nil
 }
 
+func (c *simpleBankClient) ResendVerifyEmail(ctx context.Context, in *ResendVerifyEmailRequest, opts ...grpc.CallOption) (*ResendVerifyEmailResponse, error) {
+	out := new(ResendVerifyEmailResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_ResendVerifyEmail_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) WatchAccountEvents(ctx context.Context, in *WatchAccountEventsRequest, opts ...grpc.CallOption) (SimpleBank_WatchAccountEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &SimpleBank_ServiceDesc.Streams[0], SimpleBank_WatchAccountEvents_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &simpleBankWatchAccountEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type SimpleBank_WatchAccountEventsClient interface {
+	Recv() (*AccountEvent, error)
+	grpc.ClientStream
+}
+
+type simpleBankWatchAccountEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *simpleBankWatchAccountEventsClient) Recv() (*AccountEvent, error) {
+	m := new(AccountEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *simpleBankClient) CreateWebhook(ctx context.Context, in *CreateWebhookRequest, opts ...grpc.CallOption) (*CreateWebhookResponse, error) {
+	out := new(CreateWebhookResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_CreateWebhook_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) ListWebhooks(ctx context.Context, in *ListWebhooksRequest, opts ...grpc.CallOption) (*ListWebhooksResponse, error) {
+	out := new(ListWebhooksResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_ListWebhooks_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) DeleteWebhook(ctx context.Context, in *DeleteWebhookRequest, opts ...grpc.CallOption) (*DeleteWebhookResponse, error) {
+	out := new(DeleteWebhookResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_DeleteWebhook_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListWebhookDeliveriesResponse, error) {
+	out := new(ListWebhookDeliveriesResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_ListWebhookDeliveries_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) GetNotificationPreferences(ctx context.Context, in *GetNotificationPreferencesRequest, opts ...grpc.CallOption) (*GetNotificationPreferencesResponse, error) {
+	out := new(GetNotificationPreferencesResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_GetNotificationPreferences_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) UpdateNotificationPreferences(ctx context.Context, in *UpdateNotificationPreferencesRequest, opts ...grpc.CallOption) (*UpdateNotificationPreferencesResponse, error) {
+	out := new(UpdateNotificationPreferencesResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_UpdateNotificationPreferences_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) CreateClientToken(ctx context.Context, in *CreateClientTokenRequest, opts ...grpc.CallOption) (*CreateClientTokenResponse, error) {
+	out := new(CreateClientTokenResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_CreateClientToken_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) CreateApiClient(ctx context.Context, in *CreateApiClientRequest, opts ...grpc.CallOption) (*CreateApiClientResponse, error) {
+	out := new(CreateApiClientResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_CreateApiClient_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) RotateApiClientSecret(ctx context.Context, in *RotateApiClientSecretRequest, opts ...grpc.CallOption) (*RotateApiClientSecretResponse, error) {
+	out := new(RotateApiClientSecretResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_RotateApiClientSecret_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) RevokeApiClient(ctx context.Context, in *RevokeApiClientRequest, opts ...grpc.CallOption) (*RevokeApiClientResponse, error) {
+	out := new(RevokeApiClientResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_RevokeApiClient_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) CreateApiKey(ctx context.Context, in *CreateApiKeyRequest, opts ...grpc.CallOption) (*CreateApiKeyResponse, error) {
+	out := new(CreateApiKeyResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_CreateApiKey_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) ListApiKeys(ctx context.Context, in *ListApiKeysRequest, opts ...grpc.CallOption) (*ListApiKeysResponse, error) {
+	out := new(ListApiKeysResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_ListApiKeys_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) RevokeApiKey(ctx context.Context, in *RevokeApiKeyRequest, opts ...grpc.CallOption) (*RevokeApiKeyResponse, error) {
+	out := new(RevokeApiKeyResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_RevokeApiKey_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) ListAccounts(ctx context.Context, in *ListAccountsRequest, opts ...grpc.CallOption) (*ListAccountsResponse, error) {
+	out := new(ListAccountsResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_ListAccounts_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) ListTransfers(ctx context.Context, in *ListTransfersRequest, opts ...grpc.CallOption) (*ListTransfersResponse, error) {
+	out := new(ListTransfersResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_ListTransfers_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) SearchTransfers(ctx context.Context, in *SearchTransfersRequest, opts ...grpc.CallOption) (*SearchTransfersResponse, error) {
+	out := new(SearchTransfersResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_SearchTransfers_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) CreatePayee(ctx context.Context, in *CreatePayeeRequest, opts ...grpc.CallOption) (*CreatePayeeResponse, error) {
+	out := new(CreatePayeeResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_CreatePayee_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) ListPayees(ctx context.Context, in *ListPayeesRequest, opts ...grpc.CallOption) (*ListPayeesResponse, error) {
+	out := new(ListPayeesResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_ListPayees_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) DeletePayee(ctx context.Context, in *DeletePayeeRequest, opts ...grpc.CallOption) (*DeletePayeeResponse, error) {
+	out := new(DeletePayeeResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_DeletePayee_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) CreateInterestProduct(ctx context.Context, in *CreateInterestProductRequest, opts ...grpc.CallOption) (*CreateInterestProductResponse, error) {
+	out := new(CreateInterestProductResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_CreateInterestProduct_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) SetAccountInterestProduct(ctx context.Context, in *SetAccountInterestProductRequest, opts ...grpc.CallOption) (*SetAccountInterestProductResponse, error) {
+	out := new(SetAccountInterestProductResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_SetAccountInterestProduct_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) ListInterestAccruals(ctx context.Context, in *ListInterestAccrualsRequest, opts ...grpc.CallOption) (*ListInterestAccrualsResponse, error) {
+	out := new(ListInterestAccrualsResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_ListInterestAccruals_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) SetAccountSpendingLimit(ctx context.Context, in *SetAccountSpendingLimitRequest, opts ...grpc.CallOption) (*SetAccountSpendingLimitResponse, error) {
+	out := new(SetAccountSpendingLimitResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_SetAccountSpendingLimit_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) SetUserSpendingLimit(ctx context.Context, in *SetUserSpendingLimitRequest, opts ...grpc.CallOption) (*SetUserSpendingLimitResponse, error) {
+	out := new(SetUserSpendingLimitResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_SetUserSpendingLimit_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SimpleBankServer is the server API for SimpleBank service.
 // All implementations must embed UnimplementedSimpleBankServer
 // for forward compatibility
@@ -87,6 +396,32 @@ type SimpleBankServer interface {
 	LoginUser(context.Context, *LoginUserRequest) (*LoginUserResponse, error)
 	UpdateUser(context.Context, *UpdateUserRequest) (*UpdateUserResponse, error)
 	VerifyEmail(context.Context, *VerifyEmailRequest) (*VerifyEmailResponse, error)
+	ResendVerifyEmail(context.Context, *ResendVerifyEmailRequest) (*ResendVerifyEmailResponse, error)
+	WatchAccountEvents(*WatchAccountEventsRequest, SimpleBank_WatchAccountEventsServer) error
+	CreateWebhook(context.Context, *CreateWebhookRequest) (*CreateWebhookResponse, error)
+	ListWebhooks(context.Context, *ListWebhooksRequest) (*ListWebhooksResponse, error)
+	DeleteWebhook(context.Context, *DeleteWebhookRequest) (*DeleteWebhookResponse, error)
+	ListWebhookDeliveries(context.Context, *ListWebhookDeliveriesRequest) (*ListWebhookDeliveriesResponse, error)
+	GetNotificationPreferences(context.Context, *GetNotificationPreferencesRequest) (*GetNotificationPreferencesResponse, error)
+	UpdateNotificationPreferences(context.Context, *UpdateNotificationPreferencesRequest) (*UpdateNotificationPreferencesResponse, error)
+	CreateClientToken(context.Context, *CreateClientTokenRequest) (*CreateClientTokenResponse, error)
+	CreateApiClient(context.Context, *CreateApiClientRequest) (*CreateApiClientResponse, error)
+	RotateApiClientSecret(context.Context, *RotateApiClientSecretRequest) (*RotateApiClientSecretResponse, error)
+	RevokeApiClient(context.Context, *RevokeApiClientRequest) (*RevokeApiClientResponse, error)
+	CreateApiKey(context.Context, *CreateApiKeyRequest) (*CreateApiKeyResponse, error)
+	ListApiKeys(context.Context, *ListApiKeysRequest) (*ListApiKeysResponse, error)
+	RevokeApiKey(context.Context, *RevokeApiKeyRequest) (*RevokeApiKeyResponse, error)
+	ListAccounts(context.Context, *ListAccountsRequest) (*ListAccountsResponse, error)
+	ListTransfers(context.Context, *ListTransfersRequest) (*ListTransfersResponse, error)
+	SearchTransfers(context.Context, *SearchTransfersRequest) (*SearchTransfersResponse, error)
+	CreatePayee(context.Context, *CreatePayeeRequest) (*CreatePayeeResponse, error)
+	ListPayees(context.Context, *ListPayeesRequest) (*ListPayeesResponse, error)
+	DeletePayee(context.Context, *DeletePayeeRequest) (*DeletePayeeResponse, error)
+	CreateInterestProduct(context.Context, *CreateInterestProductRequest) (*CreateInterestProductResponse, error)
+	SetAccountInterestProduct(context.Context, *SetAccountInterestProductRequest) (*SetAccountInterestProductResponse, error)
+	ListInterestAccruals(context.Context, *ListInterestAccrualsRequest) (*ListInterestAccrualsResponse, error)
+	SetAccountSpendingLimit(context.Context, *SetAccountSpendingLimitRequest) (*SetAccountSpendingLimitResponse, error)
+	SetUserSpendingLimit(context.Context, *SetUserSpendingLimitRequest) (*SetUserSpendingLimitResponse, error)
 	mustEmbedUnimplementedSimpleBankServer()
 }
 
@@ -106,6 +441,84 @@ func (UnimplementedSimpleBankServer) UpdateUser(context.Context, *UpdateUserRequ
 func (UnimplementedSimpleBankServer) VerifyEmail(context.Context, *VerifyEmailRequest) (*VerifyEmailResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method VerifyEmail not implemented")
 }
+func (UnimplementedSimpleBankServer) ResendVerifyEmail(context.Context, *ResendVerifyEmailRequest) (*ResendVerifyEmailResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResendVerifyEmail not implemented")
+}
+func (UnimplementedSimpleBankServer) WatchAccountEvents(*WatchAccountEventsRequest, SimpleBank_WatchAccountEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchAccountEvents not implemented")
+}
+func (UnimplementedSimpleBankServer) CreateWebhook(context.Context, *CreateWebhookRequest) (*CreateWebhookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWebhook not implemented")
+}
+func (UnimplementedSimpleBankServer) ListWebhooks(context.Context, *ListWebhooksRequest) (*ListWebhooksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhooks not implemented")
+}
+func (UnimplementedSimpleBankServer) DeleteWebhook(context.Context, *DeleteWebhookRequest) (*DeleteWebhookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWebhook not implemented")
+}
+func (UnimplementedSimpleBankServer) ListWebhookDeliveries(context.Context, *ListWebhookDeliveriesRequest) (*ListWebhookDeliveriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhookDeliveries not implemented")
+}
+func (UnimplementedSimpleBankServer) GetNotificationPreferences(context.Context, *GetNotificationPreferencesRequest) (*GetNotificationPreferencesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNotificationPreferences not implemented")
+}
+func (UnimplementedSimpleBankServer) UpdateNotificationPreferences(context.Context, *UpdateNotificationPreferencesRequest) (*UpdateNotificationPreferencesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateNotificationPreferences not implemented")
+}
+func (UnimplementedSimpleBankServer) CreateClientToken(context.Context, *CreateClientTokenRequest) (*CreateClientTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateClientToken not implemented")
+}
+func (UnimplementedSimpleBankServer) CreateApiClient(context.Context, *CreateApiClientRequest) (*CreateApiClientResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateApiClient not implemented")
+}
+func (UnimplementedSimpleBankServer) RotateApiClientSecret(context.Context, *RotateApiClientSecretRequest) (*RotateApiClientSecretResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateApiClientSecret not implemented")
+}
+func (UnimplementedSimpleBankServer) RevokeApiClient(context.Context, *RevokeApiClientRequest) (*RevokeApiClientResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeApiClient not implemented")
+}
+func (UnimplementedSimpleBankServer) CreateApiKey(context.Context, *CreateApiKeyRequest) (*CreateApiKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateApiKey not implemented")
+}
+func (UnimplementedSimpleBankServer) ListApiKeys(context.Context, *ListApiKeysRequest) (*ListApiKeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListApiKeys not implemented")
+}
+func (UnimplementedSimpleBankServer) RevokeApiKey(context.Context, *RevokeApiKeyRequest) (*RevokeApiKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeApiKey not implemented")
+}
+func (UnimplementedSimpleBankServer) ListAccounts(context.Context, *ListAccountsRequest) (*ListAccountsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAccounts not implemented")
+}
+func (UnimplementedSimpleBankServer) ListTransfers(context.Context, *ListTransfersRequest) (*ListTransfersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTransfers not implemented")
+}
+func (UnimplementedSimpleBankServer) SearchTransfers(context.Context, *SearchTransfersRequest) (*SearchTransfersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchTransfers not implemented")
+}
+func (UnimplementedSimpleBankServer) CreatePayee(context.Context, *CreatePayeeRequest) (*CreatePayeeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePayee not implemented")
+}
+func (UnimplementedSimpleBankServer) ListPayees(context.Context, *ListPayeesRequest) (*ListPayeesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPayees not implemented")
+}
+func (UnimplementedSimpleBankServer) DeletePayee(context.Context, *DeletePayeeRequest) (*DeletePayeeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletePayee not implemented")
+}
+func (UnimplementedSimpleBankServer) CreateInterestProduct(context.Context, *CreateInterestProductRequest) (*CreateInterestProductResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateInterestProduct not implemented")
+}
+func (UnimplementedSimpleBankServer) SetAccountInterestProduct(context.Context, *SetAccountInterestProductRequest) (*SetAccountInterestProductResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetAccountInterestProduct not implemented")
+}
+func (UnimplementedSimpleBankServer) ListInterestAccruals(context.Context, *ListInterestAccrualsRequest) (*ListInterestAccrualsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListInterestAccruals not implemented")
+}
+func (UnimplementedSimpleBankServer) SetAccountSpendingLimit(context.Context, *SetAccountSpendingLimitRequest) (*SetAccountSpendingLimitResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetAccountSpendingLimit not implemented")
+}
+func (UnimplementedSimpleBankServer) SetUserSpendingLimit(context.Context, *SetUserSpendingLimitRequest) (*SetUserSpendingLimitResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetUserSpendingLimit not implemented")
+}
 func (UnimplementedSimpleBankServer) mustEmbedUnimplementedSimpleBankServer() {}
 
 // UnsafeSimpleBankServer may be embedded to opt out of forward compatibility for this service.
@@ -191,30 +604,607 @@ func _SimpleBank_VerifyEmail_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
-// SimpleBank_ServiceDesc is the grpc.ServiceDesc for SimpleBank service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var SimpleBank_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "pb.SimpleBank",
-	HandlerType: (*SimpleBankServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "CreateUser",
-			Handler:    _SimpleBank_CreateUser_Handler,
-		},
-		{
-			MethodName: "LoginUser",
-			Handler:    _SimpleBank_LoginUser_Handler,
-		},
-		{
-			MethodName: "UpdateUser",
-			Handler:    _SimpleBank_UpdateUser_Handler,
-		},
-		{
-			MethodName: "VerifyEmail",
-			Handler:    _SimpleBank_VerifyEmail_Handler,
+func _SimpleBank_ResendVerifyEmail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResendVerifyEmailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).ResendVerifyEmail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_ResendVerifyEmail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).ResendVerifyEmail(ctx, req.(*ResendVerifyEmailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_WatchAccountEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchAccountEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SimpleBankServer).WatchAccountEvents(m, &simpleBankWatchAccountEventsServer{stream})
+}
+
+type SimpleBank_WatchAccountEventsServer interface {
+	Send(*AccountEvent) error
+	grpc.ServerStream
+}
+
+type simpleBankWatchAccountEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *simpleBankWatchAccountEventsServer) Send(m *AccountEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _SimpleBank_CreateWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).CreateWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_CreateWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).CreateWebhook(ctx, req.(*CreateWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_ListWebhooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhooksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).ListWebhooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_ListWebhooks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).ListWebhooks(ctx, req.(*ListWebhooksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_DeleteWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).DeleteWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_DeleteWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).DeleteWebhook(ctx, req.(*DeleteWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_ListWebhookDeliveries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhookDeliveriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).ListWebhookDeliveries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_ListWebhookDeliveries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).ListWebhookDeliveries(ctx, req.(*ListWebhookDeliveriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_GetNotificationPreferences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNotificationPreferencesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).GetNotificationPreferences(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_GetNotificationPreferences_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).GetNotificationPreferences(ctx, req.(*GetNotificationPreferencesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_UpdateNotificationPreferences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateNotificationPreferencesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).UpdateNotificationPreferences(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_UpdateNotificationPreferences_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).UpdateNotificationPreferences(ctx, req.(*UpdateNotificationPreferencesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_CreateClientToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateClientTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).CreateClientToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_CreateClientToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).CreateClientToken(ctx, req.(*CreateClientTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_CreateApiClient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateApiClientRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).CreateApiClient(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_CreateApiClient_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).CreateApiClient(ctx, req.(*CreateApiClientRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_RotateApiClientSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateApiClientSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).RotateApiClientSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_RotateApiClientSecret_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).RotateApiClientSecret(ctx, req.(*RotateApiClientSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_RevokeApiClient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeApiClientRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).RevokeApiClient(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_RevokeApiClient_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).RevokeApiClient(ctx, req.(*RevokeApiClientRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_CreateApiKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateApiKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).CreateApiKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_CreateApiKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).CreateApiKey(ctx, req.(*CreateApiKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_ListApiKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListApiKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).ListApiKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_ListApiKeys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).ListApiKeys(ctx, req.(*ListApiKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_RevokeApiKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeApiKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).RevokeApiKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_RevokeApiKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).RevokeApiKey(ctx, req.(*RevokeApiKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_ListAccounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAccountsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).ListAccounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_ListAccounts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).ListAccounts(ctx, req.(*ListAccountsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_ListTransfers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTransfersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).ListTransfers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_ListTransfers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).ListTransfers(ctx, req.(*ListTransfersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_SearchTransfers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchTransfersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).SearchTransfers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_SearchTransfers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).SearchTransfers(ctx, req.(*SearchTransfersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_CreatePayee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePayeeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).CreatePayee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_CreatePayee_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).CreatePayee(ctx, req.(*CreatePayeeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_ListPayees_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPayeesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).ListPayees(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_ListPayees_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).ListPayees(ctx, req.(*ListPayeesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_DeletePayee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletePayeeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).DeletePayee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_DeletePayee_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).DeletePayee(ctx, req.(*DeletePayeeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_CreateInterestProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateInterestProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).CreateInterestProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_CreateInterestProduct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).CreateInterestProduct(ctx, req.(*CreateInterestProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_SetAccountInterestProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetAccountInterestProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).SetAccountInterestProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_SetAccountInterestProduct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).SetAccountInterestProduct(ctx, req.(*SetAccountInterestProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_ListInterestAccruals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListInterestAccrualsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).ListInterestAccruals(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_ListInterestAccruals_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).ListInterestAccruals(ctx, req.(*ListInterestAccrualsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_SetAccountSpendingLimit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetAccountSpendingLimitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).SetAccountSpendingLimit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_SetAccountSpendingLimit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).SetAccountSpendingLimit(ctx, req.(*SetAccountSpendingLimitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_SetUserSpendingLimit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetUserSpendingLimitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).SetUserSpendingLimit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_SetUserSpendingLimit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).SetUserSpendingLimit(ctx, req.(*SetUserSpendingLimitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SimpleBank_ServiceDesc is the grpc.ServiceDesc for SimpleBank service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SimpleBank_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "pb.SimpleBank",
+	HandlerType: (*SimpleBankServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateUser",
+			Handler:    _SimpleBank_CreateUser_Handler,
+		},
+		{
+			MethodName: "LoginUser",
+			Handler:    _SimpleBank_LoginUser_Handler,
+		},
+		{
+			MethodName: "UpdateUser",
+			Handler:    _SimpleBank_UpdateUser_Handler,
+		},
+		{
+			MethodName: "VerifyEmail",
+			Handler:    _SimpleBank_VerifyEmail_Handler,
+		},
+		{
+			MethodName: "ResendVerifyEmail",
+			Handler:    _SimpleBank_ResendVerifyEmail_Handler,
+		},
+		{
+			MethodName: "CreateWebhook",
+			Handler:    _SimpleBank_CreateWebhook_Handler,
+		},
+		{
+			MethodName: "ListWebhooks",
+			Handler:    _SimpleBank_ListWebhooks_Handler,
+		},
+		{
+			MethodName: "DeleteWebhook",
+			Handler:    _SimpleBank_DeleteWebhook_Handler,
+		},
+		{
+			MethodName: "ListWebhookDeliveries",
+			Handler:    _SimpleBank_ListWebhookDeliveries_Handler,
+		},
+		{
+			MethodName: "GetNotificationPreferences",
+			Handler:    _SimpleBank_GetNotificationPreferences_Handler,
+		},
+		{
+			MethodName: "UpdateNotificationPreferences",
+			Handler:    _SimpleBank_UpdateNotificationPreferences_Handler,
+		},
+		{
+			MethodName: "CreateClientToken",
+			Handler:    _SimpleBank_CreateClientToken_Handler,
+		},
+		{
+			MethodName: "CreateApiClient",
+			Handler:    _SimpleBank_CreateApiClient_Handler,
+		},
+		{
+			MethodName: "RotateApiClientSecret",
+			Handler:    _SimpleBank_RotateApiClientSecret_Handler,
+		},
+		{
+			MethodName: "RevokeApiClient",
+			Handler:    _SimpleBank_RevokeApiClient_Handler,
+		},
+		{
+			MethodName: "CreateApiKey",
+			Handler:    _SimpleBank_CreateApiKey_Handler,
+		},
+		{
+			MethodName: "ListApiKeys",
+			Handler:    _SimpleBank_ListApiKeys_Handler,
+		},
+		{
+			MethodName: "RevokeApiKey",
+			Handler:    _SimpleBank_RevokeApiKey_Handler,
+		},
+		{
+			MethodName: "ListAccounts",
+			Handler:    _SimpleBank_ListAccounts_Handler,
+		},
+		{
+			MethodName: "ListTransfers",
+			Handler:    _SimpleBank_ListTransfers_Handler,
+		},
+		{
+			MethodName: "SearchTransfers",
+			Handler:    _SimpleBank_SearchTransfers_Handler,
+		},
+		{
+			MethodName: "CreatePayee",
+			Handler:    _SimpleBank_CreatePayee_Handler,
+		},
+		{
+			MethodName: "ListPayees",
+			Handler:    _SimpleBank_ListPayees_Handler,
+		},
+		{
+			MethodName: "DeletePayee",
+			Handler:    _SimpleBank_DeletePayee_Handler,
+		},
+		{
+			MethodName: "CreateInterestProduct",
+			Handler:    _SimpleBank_CreateInterestProduct_Handler,
+		},
+		{
+			MethodName: "SetAccountInterestProduct",
+			Handler:    _SimpleBank_SetAccountInterestProduct_Handler,
+		},
+		{
+			MethodName: "ListInterestAccruals",
+			Handler:    _SimpleBank_ListInterestAccruals_Handler,
+		},
+		{
+			MethodName: "SetAccountSpendingLimit",
+			Handler:    _SimpleBank_SetAccountSpendingLimit_Handler,
+		},
+		{
+			MethodName: "SetUserSpendingLimit",
+			Handler:    _SimpleBank_SetUserSpendingLimit_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchAccountEvents",
+			Handler:       _SimpleBank_WatchAccountEvents_Handler,
+			ServerStreams: true,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "service_simple_bank.proto",
 }
@@ -0,0 +1,66 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+
+	db "github.com/mativm02/bank_system/db/sqlc"
+)
+
+// Operation is a sensitive operation that users can only perform when the policy allows it.
+type Operation string
+
+const (
+	CreateTransfer Operation = "create_transfer"
+	ChangePassword Operation = "change_password"
+)
+
+const (
+	// Domain is the domain of the errdetails.ErrorInfo returned for a policy error.
+	Domain = "bank_system"
+	// ReasonEmailNotVerified means that the user must verify their email first.
+	ReasonEmailNotVerified = "EMAIL_NOT_VERIFIED"
+)
+
+// Error explains why a user is not allowed to perform an operation,
+// so clients can prompt the user to fix it.
+type Error struct {
+	Username  string
+	Operation Operation
+	Reason    string
+}
+
+func (err *Error) Error() string {
+	return fmt.Sprintf("%s requires a verified email", err.Operation)
+}
+
+// Checker enforces the policies of the sensitive operations.
+type Checker struct {
+	store db.Store
+}
+
+func NewChecker(store db.Store) *Checker {
+	return &Checker{
+		store: store,
+	}
+}
+
+// Check returns an *Error if the user is not allowed to perform the operation.
+// Users must verify their email before moving money or changing their password.
+// CreateAccountTx checks that they verified it before opening more than one account.
+func (checker *Checker) Check(ctx context.Context, username string, operation Operation) error {
+	user, err := checker.store.GetUser(ctx, username)
+	if err != nil {
+		return err
+	}
+
+	if user.IsEmailVerified {
+		return nil
+	}
+
+	return &Error{
+		Username:  username,
+		Operation: operation,
+		Reason:    ReasonEmailNotVerified,
+	}
+}
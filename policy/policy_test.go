@@ -0,0 +1,61 @@
+package policy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/mativm02/bank_system/db/mock"
+	db "github.com/mativm02/bank_system/db/sqlc"
+	"github.com/mativm02/bank_system/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheck(t *testing.T) {
+	username := util.RandomOwner()
+
+	testCases := []struct {
+		name       string
+		operation  Operation
+		buildStubs func(store *mockdb.MockStore)
+		checkError func(t *testing.T, err error)
+	}{
+		{
+			name:      "Verified",
+			operation: CreateTransfer,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(username)).Times(1).Return(db.User{Username: username, IsEmailVerified: true}, nil)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name:      "NotVerified",
+			operation: ChangePassword,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(username)).Times(1).Return(db.User{Username: username}, nil)
+			},
+			checkError: func(t *testing.T, err error) {
+				var policyErr *Error
+				require.ErrorAs(t, err, &policyErr)
+				require.Equal(t, ReasonEmailNotVerified, policyErr.Reason)
+				require.Equal(t, ChangePassword, policyErr.Operation)
+				require.Equal(t, username, policyErr.Username)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			err := NewChecker(store).Check(context.Background(), username, tc.operation)
+			tc.checkError(t, err)
+		})
+	}
+}
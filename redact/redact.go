@@ -0,0 +1,160 @@
+package redact
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+	"strings"
+
+	"github.com/mativm02/bank_system/util"
+)
+
+// Redaction levels
+const (
+	// LevelStrict removes the secrets and masks the emails. It is the default level.
+	LevelStrict = "strict"
+	// LevelSecrets only removes the secrets, to make debugging easier in development.
+	LevelSecrets = "secrets"
+)
+
+// Redacted replaces the values of the secret fields.
+const Redacted = "[REDACTED]"
+
+// secretFieldParts are the parts of the field names whose values are secrets,
+// e.g. password, access_token, secret_code or client_secret.
+var secretFieldParts = []string{"password", "token", "secret", "authorization"}
+
+// Redactor removes the secrets and masks the emails of the data written to the logs.
+// The rules are based on the field names, so they apply to the JSON bodies and task payloads
+// as well as the query parameters.
+type Redactor struct {
+	maskEmails bool
+	fields     map[string]bool
+}
+
+// NewRedactor creates a redactor for the redaction level of the environment.
+// The fields of LOG_REDACT_FIELDS are removed in addition to the secrets.
+func NewRedactor(config util.Config) *Redactor {
+	redactor := &Redactor{
+		maskEmails: config.LogRedactionLevel != LevelSecrets,
+		fields:     make(map[string]bool),
+	}
+
+	for _, field := range config.LogRedactFields {
+		if field = strings.ToLower(strings.TrimSpace(field)); field != "" {
+			redactor.fields[field] = true
+		}
+	}
+	return redactor
+}
+
+// JSON returns the JSON document with the values of the sensitive fields redacted.
+// Data that is not JSON is redacted entirely, since its fields cannot be told apart.
+func (redactor *Redactor) JSON(data []byte) []byte {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return data
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return []byte(Redacted)
+	}
+
+	redacted, err := json.Marshal(redactor.value("", value))
+	if err != nil {
+		return []byte(Redacted)
+	}
+	return redacted
+}
+
+// URL returns the request URI with the values of the sensitive query parameters redacted.
+func (redactor *Redactor) URL(uri string) string {
+	path, rawQuery, ok := strings.Cut(uri, "?")
+	if !ok {
+		return uri
+	}
+
+	params := strings.Split(rawQuery, "&")
+	for i, param := range params {
+		rawKey, rawValue, _ := strings.Cut(param, "=")
+		key, err := url.QueryUnescape(rawKey)
+		if err != nil {
+			params[i] = Redacted
+			continue
+		}
+
+		if redactor.isSecret(key) {
+			params[i] = rawKey + "=" + Redacted
+		} else if redactor.isEmail(key) {
+			value, err := url.QueryUnescape(rawValue)
+			if err != nil {
+				value = ""
+			}
+			params[i] = rawKey + "=" + url.QueryEscape(MaskEmail(value))
+		}
+	}
+	return path + "?" + strings.Join(params, "&")
+}
+
+// Email returns the email masked, unless the redaction level keeps the emails.
+func (redactor *Redactor) Email(email string) string {
+	if !redactor.maskEmails {
+		return email
+	}
+	return MaskEmail(email)
+}
+
+func (redactor *Redactor) value(field string, value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			v[key] = redactor.value(key, child)
+		}
+		return v
+	case []interface{}:
+		for i, child := range v {
+			v[i] = redactor.value(field, child)
+		}
+		return v
+	case nil:
+		return nil
+	}
+
+	if redactor.isSecret(field) {
+		return Redacted
+	}
+	if s, ok := value.(string); ok && redactor.isEmail(field) {
+		return MaskEmail(s)
+	}
+	return value
+}
+
+func (redactor *Redactor) isSecret(field string) bool {
+	field = strings.ToLower(field)
+	if field == "key" || redactor.fields[field] {
+		return true
+	}
+
+	for _, part := range secretFieldParts {
+		if strings.Contains(field, part) {
+			return true
+		}
+	}
+	return false
+}
+
+func (redactor *Redactor) isEmail(field string) bool {
+	return redactor.maskEmails && strings.Contains(strings.ToLower(field), "email")
+}
+
+// MaskEmail hides most of the local part of an email, e.g. "jane.doe@example.com" becomes "j*******@example.com".
+func MaskEmail(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at <= 0 {
+		return email
+	}
+	return email[:1] + strings.Repeat("*", at-1) + email[at:]
+}
@@ -0,0 +1,61 @@
+package redact
+
+import (
+	"testing"
+
+	"github.com/mativm02/bank_system/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSON(t *testing.T) {
+	redactor := NewRedactor(util.Config{LogRedactionLevel: LevelStrict})
+
+	data := []byte(`{
+		"user": {"username": "alice", "email": "alice@example.com"},
+		"password": "Secret123",
+		"access_token": "v2.local.abc",
+		"refresh_token": "v2.local.def",
+		"secret_code": "xyz",
+		"key": "sbk_123_456",
+		"api_key": {"id": 1, "prefix": "sbk_123"},
+		"scopes": ["accounts:read"],
+		"amount": 10
+	}`)
+
+	require.JSONEq(t, `{
+		"user": {"username": "alice", "email": "a****@example.com"},
+		"password": "[REDACTED]",
+		"access_token": "[REDACTED]",
+		"refresh_token": "[REDACTED]",
+		"secret_code": "[REDACTED]",
+		"key": "[REDACTED]",
+		"api_key": {"id": 1, "prefix": "sbk_123"},
+		"scopes": ["accounts:read"],
+		"amount": 10
+	}`, string(redactor.JSON(data)))
+
+	require.Equal(t, Redacted, string(redactor.JSON([]byte("password=Secret123"))))
+	require.Empty(t, redactor.JSON(nil))
+}
+
+func TestLevelSecrets(t *testing.T) {
+	redactor := NewRedactor(util.Config{LogRedactionLevel: LevelSecrets, LogRedactFields: []string{"Username"}})
+
+	data := []byte(`{"username": "alice", "email": "alice@example.com", "password": "Secret123"}`)
+	require.JSONEq(t, `{"username": "[REDACTED]", "email": "alice@example.com", "password": "[REDACTED]"}`, string(redactor.JSON(data)))
+	require.Equal(t, "alice@example.com", redactor.Email("alice@example.com"))
+}
+
+func TestURL(t *testing.T) {
+	redactor := NewRedactor(util.Config{})
+
+	require.Equal(t, "/v1/verify_email?id=1&secret_code=[REDACTED]", redactor.URL("/v1/verify_email?id=1&secret_code=xyz"))
+	require.Equal(t, "/v1/users?email=a%2A%2A%2A%2A%40example.com", redactor.URL("/v1/users?email=alice%40example.com"))
+	require.Equal(t, "/v1/webhooks", redactor.URL("/v1/webhooks"))
+}
+
+func TestMaskEmail(t *testing.T) {
+	require.Equal(t, "j*******@example.com", MaskEmail("jane.doe@example.com"))
+	require.Equal(t, "j@example.com", MaskEmail("j@example.com"))
+	require.Equal(t, "not an email", MaskEmail("not an email"))
+}
@@ -0,0 +1,58 @@
+package requestid
+
+import (
+	"context"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// Header carries the request ID in the HTTP requests and responses and in the gRPC metadata.
+const Header = "x-request-id"
+
+// maxLength limits the size of the request IDs accepted from the clients.
+const maxLength = 128
+
+type contextKey struct{}
+
+// New generates a new request ID.
+func New() string {
+	return uuid.NewString()
+}
+
+// Valid reports whether a request ID received from a client can be propagated as is.
+// Other IDs are replaced, so they cannot be used to inject content into the logs.
+func Valid(id string) bool {
+	if len(id) == 0 || len(id) > maxLength {
+		return false
+	}
+
+	for _, r := range id {
+		if r > unicode.MaxASCII || !unicode.IsPrint(r) || r == ' ' {
+			return false
+		}
+	}
+	return true
+}
+
+// NewContext returns a context carrying the request ID, with a zerolog logger
+// that adds the request ID to every log written through log.Ctx.
+func NewContext(ctx context.Context, id string) context.Context {
+	parent := log.Ctx(ctx)
+	if parent.GetLevel() == zerolog.Disabled {
+		// No logger in the context yet: start from the global one.
+		parent = &log.Logger
+	}
+
+	logger := parent.With().Str("request_id", id).Logger()
+	ctx = logger.WithContext(ctx)
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the request ID carried by the context, or an empty string.
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
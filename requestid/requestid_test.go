@@ -0,0 +1,38 @@
+package requestid
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValid(t *testing.T) {
+	require.True(t, Valid(New()))
+	require.True(t, Valid("req-123_abc"))
+
+	require.False(t, Valid(""))
+	require.False(t, Valid(strings.Repeat("a", maxLength+1)))
+	require.False(t, Valid("req 123"))
+	require.False(t, Valid("req\n123"))
+	require.False(t, Valid("réq"))
+}
+
+func TestContext(t *testing.T) {
+	require.Empty(t, FromContext(context.Background()))
+
+	var buf bytes.Buffer
+	logger := zerolog.New(&buf)
+	ctx := logger.WithContext(context.Background())
+
+	id := New()
+	ctx = NewContext(ctx, id)
+	require.Equal(t, id, FromContext(ctx))
+
+	log.Ctx(ctx).Info().Msg("test")
+	require.Contains(t, buf.String(), `"request_id":"`+id+`"`)
+}
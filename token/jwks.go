@@ -0,0 +1,56 @@
+package token
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+)
+
+// JSONWebKey is the public part of a Key in the JWK format (RFC 7517).
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg,omitempty"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+}
+
+// JSONWebKeySet is the document that publishes the public keys of a KeyRing.
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// JWKS returns the public keys of the ring. The algorithm is omitted when it is empty,
+// which is the case of PASETO keys that have no registered JWA name.
+func (ring *KeyRing) JWKS(algorithm string) JSONWebKeySet {
+	set := JSONWebKeySet{Keys: []JSONWebKey{}}
+
+	for _, key := range ring.Keys() {
+		jwk := JSONWebKey{
+			KeyID:     key.ID,
+			Use:       "sig",
+			Algorithm: algorithm,
+		}
+
+		switch publicKey := key.PublicKey.(type) {
+		case ed25519.PublicKey:
+			jwk.KeyType = "OKP"
+			jwk.Curve = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(publicKey)
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes())
+		default:
+			continue
+		}
+
+		set.Keys = append(set.Keys, jwk)
+	}
+
+	return set
+}
@@ -24,13 +24,25 @@ func NewJWTMaker(secretKey string) (Maker, error) {
 	return &JWTMaker{secretKey}, nil
 }
 
-// CreateToken creates a new token for the given username and duration
-func (maker *JWTMaker) CreateToken(username string, duration time.Duration) (string, *Payload, error) {
-	payload, err := NewPayload(username, duration)
+// CreateToken creates a new token for the given username, role and duration
+func (maker *JWTMaker) CreateToken(username string, role string, duration time.Duration) (string, *Payload, error) {
+	payload, err := NewPayload(username, role, duration)
 	if err != nil {
 		return "", nil, err
 	}
+	return maker.createToken(payload)
+}
+
+// CreateClientToken creates a new token for an API client acting for the given username
+func (maker *JWTMaker) CreateClientToken(clientID string, username string, scopes []string, duration time.Duration) (string, *Payload, error) {
+	payload, err := NewClientPayload(clientID, username, scopes, duration)
+	if err != nil {
+		return "", nil, err
+	}
+	return maker.createToken(payload)
+}
 
+func (maker *JWTMaker) createToken(payload *Payload) (string, *Payload, error) {
 	jwtToken := jwt.NewWithClaims(jwt.SigningMethodHS256, payload)
 	token, err := jwtToken.SignedString([]byte(maker.secretKey))
 
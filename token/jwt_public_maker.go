@@ -0,0 +1,147 @@
+package token
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+// Algorithms of the JWTPublicMaker
+const (
+	JWTAlgorithmRS256 = "RS256"
+	JWTAlgorithmEdDSA = "EdDSA"
+)
+
+// JWTPublicMaker is a token maker that signs JSON Web Tokens with RS256 or EdDSA.
+// The ID of the signing key is stored in the kid header of the token.
+type JWTPublicMaker struct {
+	method  jwt.SigningMethod
+	keyRing *KeyRing
+}
+
+// NewJWTPublicMaker creates a new JWTPublicMaker for the given algorithm from a key ring
+// of RSA keys for RS256 or Ed25519 keys for EdDSA
+func NewJWTPublicMaker(algorithm string, keyRing *KeyRing) (PublicKeyMaker, error) {
+	var method jwt.SigningMethod
+	var err error
+	switch algorithm {
+	case JWTAlgorithmRS256:
+		method = jwt.SigningMethodRS256
+		err = keyRing.checkKeys("JWT "+algorithm, isRSAKey)
+	case JWTAlgorithmEdDSA:
+		method = signingMethodEdDSA
+		err = keyRing.checkKeys("JWT "+algorithm, isEd25519Key)
+	default:
+		return nil, fmt.Errorf("unsupported JWT algorithm %q", algorithm)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &JWTPublicMaker{method, keyRing}, nil
+}
+
+// CreateToken creates a new token for the given username, role and duration
+func (maker *JWTPublicMaker) CreateToken(username string, role string, duration time.Duration) (string, *Payload, error) {
+	payload, err := NewPayload(username, role, duration)
+	if err != nil {
+		return "", nil, err
+	}
+	return maker.createToken(payload)
+}
+
+// CreateClientToken creates a new token for an API client acting for the given username
+func (maker *JWTPublicMaker) CreateClientToken(clientID string, username string, scopes []string, duration time.Duration) (string, *Payload, error) {
+	payload, err := NewClientPayload(clientID, username, scopes, duration)
+	if err != nil {
+		return "", nil, err
+	}
+	return maker.createToken(payload)
+}
+
+func (maker *JWTPublicMaker) createToken(payload *Payload) (string, *Payload, error) {
+	key := maker.keyRing.Current()
+	jwtToken := jwt.NewWithClaims(maker.method, payload)
+	jwtToken.Header["kid"] = key.ID
+
+	token, err := jwtToken.SignedString(key.PrivateKey)
+	return token, payload, err
+}
+
+// VerifyToken verifies the given token with the key named in its header and returns the payload
+func (maker *JWTPublicMaker) VerifyToken(token string) (*Payload, error) {
+	jwtToken, err := jwt.ParseWithClaims(token, &Payload{}, func(token *jwt.Token) (interface{}, error) {
+		if token.Method.Alg() != maker.method.Alg() {
+			return nil, ErrTokenInvalid
+		}
+
+		keyID, _ := token.Header["kid"].(string)
+		key, ok := maker.keyRing.Key(keyID)
+		if !ok {
+			return nil, ErrTokenInvalid
+		}
+		return key.PublicKey, nil
+	})
+	if err != nil {
+		verr, ok := err.(*jwt.ValidationError)
+		if ok && errors.Is(verr.Inner, ErrTokenExpired) {
+			return nil, ErrTokenExpired
+		}
+		return nil, ErrTokenInvalid
+	}
+
+	if claims, ok := jwtToken.Claims.(*Payload); ok && jwtToken.Valid {
+		return claims, nil
+	}
+
+	return nil, ErrTokenInvalid
+}
+
+// JWKS returns the public keys that verify the tokens
+func (maker *JWTPublicMaker) JWKS() JSONWebKeySet {
+	return maker.keyRing.JWKS(maker.method.Alg())
+}
+
+// signingMethodEdDSA implements the EdDSA algorithm (RFC 8037) with Ed25519 keys,
+// which is not provided by jwt-go.
+var signingMethodEdDSA = &signingMethodEd25519{}
+
+func init() {
+	jwt.RegisterSigningMethod(JWTAlgorithmEdDSA, func() jwt.SigningMethod {
+		return signingMethodEdDSA
+	})
+}
+
+type signingMethodEd25519 struct{}
+
+func (method *signingMethodEd25519) Alg() string {
+	return JWTAlgorithmEdDSA
+}
+
+func (method *signingMethodEd25519) Sign(signingString string, key interface{}) (string, error) {
+	privateKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return "", jwt.ErrInvalidKeyType
+	}
+	return jwt.EncodeSegment(ed25519.Sign(privateKey, []byte(signingString))), nil
+}
+
+func (method *signingMethodEd25519) Verify(signingString, signature string, key interface{}) error {
+	publicKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return jwt.ErrInvalidKeyType
+	}
+
+	sig, err := jwt.DecodeSegment(signature)
+	if err != nil {
+		return err
+	}
+
+	if !ed25519.Verify(publicKey, []byte(signingString), sig) {
+		return jwt.ErrSignatureInvalid
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package token
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/mativm02/bank_system/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJWTPublicMaker(t *testing.T) {
+	testCases := []struct {
+		algorithm string
+		key       Key
+	}{
+		{JWTAlgorithmRS256, randomRSAKey(t)},
+		{JWTAlgorithmEdDSA, randomEd25519Key(t)},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.algorithm, func(t *testing.T) {
+			maker, err := NewJWTPublicMaker(tc.algorithm, newTestKeyRing(t, tc.key))
+			require.NoError(t, err)
+
+			username := util.RandomOwner()
+			issuedAt := time.Now()
+
+			token, _, err := maker.CreateToken(username, util.DepositorRole, time.Minute)
+			require.NoError(t, err)
+
+			parsed, _, err := new(jwt.Parser).ParseUnverified(token, &Payload{})
+			require.NoError(t, err)
+			require.Equal(t, tc.algorithm, parsed.Header["alg"])
+			require.Equal(t, tc.key.ID, parsed.Header["kid"])
+
+			payload, err := maker.VerifyToken(token)
+			require.NoError(t, err)
+			require.Equal(t, username, payload.Username)
+			require.WithinDuration(t, issuedAt, payload.IssuedAt, time.Second)
+
+			jwks := maker.JWKS()
+			require.Len(t, jwks.Keys, 1)
+			require.Equal(t, tc.algorithm, jwks.Keys[0].Algorithm)
+
+			expired, _, err := maker.CreateToken(username, util.DepositorRole, -time.Minute)
+			require.NoError(t, err)
+			_, err = maker.VerifyToken(expired)
+			require.Equal(t, ErrTokenExpired, err)
+		})
+	}
+}
+
+func TestJWTPublicMakerRotation(t *testing.T) {
+	oldKey := randomEd25519Key(t)
+	newKey := randomEd25519Key(t)
+
+	oldMaker, err := NewJWTPublicMaker(JWTAlgorithmEdDSA, newTestKeyRing(t, oldKey))
+	require.NoError(t, err)
+	oldToken, _, err := oldMaker.CreateToken(util.RandomOwner(), util.DepositorRole, time.Minute)
+	require.NoError(t, err)
+
+	rotatedMaker, err := NewJWTPublicMaker(JWTAlgorithmEdDSA, newTestKeyRing(t, newKey, oldKey))
+	require.NoError(t, err)
+	_, err = rotatedMaker.VerifyToken(oldToken)
+	require.NoError(t, err)
+
+	newMaker, err := NewJWTPublicMaker(JWTAlgorithmEdDSA, newTestKeyRing(t, newKey))
+	require.NoError(t, err)
+	_, err = newMaker.VerifyToken(oldToken)
+	require.Equal(t, ErrTokenInvalid, err)
+}
+
+func TestJWTPublicMakerRejectsOtherAlgorithms(t *testing.T) {
+	key := randomEd25519Key(t)
+	maker, err := NewJWTPublicMaker(JWTAlgorithmEdDSA, newTestKeyRing(t, key))
+	require.NoError(t, err)
+
+	payload, err := NewPayload(util.RandomOwner(), util.DepositorRole, time.Minute)
+	require.NoError(t, err)
+
+	jwtToken := jwt.NewWithClaims(jwt.SigningMethodNone, payload)
+	jwtToken.Header["kid"] = key.ID
+	token, err := jwtToken.SignedString(jwt.UnsafeAllowNoneSignatureType)
+	require.NoError(t, err)
+
+	payload, err = maker.VerifyToken(token)
+	require.Equal(t, ErrTokenInvalid, err)
+	require.Nil(t, payload)
+
+	_, err = NewJWTPublicMaker(JWTAlgorithmRS256, newTestKeyRing(t, key))
+	require.Error(t, err)
+
+	_, err = NewJWTPublicMaker("HS256", newTestKeyRing(t, key))
+	require.Error(t, err)
+}
@@ -0,0 +1,177 @@
+package token
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const minRSAKeyBits = 2048
+
+// Key is a key of a KeyRing, identified in the tokens by its ID.
+type Key struct {
+	ID        string
+	PublicKey crypto.PublicKey // ed25519.PublicKey or *rsa.PublicKey
+	// PrivateKey is nil when the key is only kept to verify the tokens it signed before a rotation.
+	PrivateKey crypto.Signer
+}
+
+// KeyRing holds the current signing key and the previous keys that are still accepted to verify tokens.
+// To rotate the keys, add a new key as the current one and keep the previous one until its tokens expire.
+type KeyRing struct {
+	current Key
+	keys    map[string]Key
+	ids     []string
+}
+
+// NewKeyRing creates a key ring that signs with the current key and verifies with all the keys.
+func NewKeyRing(current Key, previous ...Key) (*KeyRing, error) {
+	if current.PrivateKey == nil {
+		return nil, fmt.Errorf("current key %q has no private key", current.ID)
+	}
+
+	ring := &KeyRing{
+		current: current,
+		keys:    make(map[string]Key),
+	}
+
+	for _, key := range append([]Key{current}, previous...) {
+		if key.ID == "" {
+			return nil, fmt.Errorf("key ID must not be empty")
+		}
+		if _, ok := ring.keys[key.ID]; ok {
+			return nil, fmt.Errorf("duplicate key ID %q", key.ID)
+		}
+		ring.keys[key.ID] = key
+		ring.ids = append(ring.ids, key.ID)
+	}
+
+	return ring, nil
+}
+
+// LoadKeyRing loads the PEM encoded keys stored in dir, each named after its key ID, e.g. 2024-01.pem.
+// The key currentID must be a private key; the other files can be public or private keys.
+func LoadKeyRing(dir string, currentID string) (*KeyRing, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(files)))
+
+	var current *Key
+	var previous []Key
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read key file: %w", err)
+		}
+
+		key, err := ParseKey(strings.TrimSuffix(filepath.Base(file), ".pem"), data)
+		if err != nil {
+			return nil, err
+		}
+
+		if key.ID == currentID {
+			current = &key
+		} else {
+			previous = append(previous, key)
+		}
+	}
+
+	if current == nil {
+		return nil, fmt.Errorf("current key %q not found in %s", currentID, dir)
+	}
+	return NewKeyRing(*current, previous...)
+}
+
+// ParseKey parses a PEM encoded Ed25519 or RSA key, either private (PKCS #8 or PKCS #1) or public (PKIX).
+func ParseKey(id string, data []byte) (Key, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return Key{}, fmt.Errorf("key %q is not PEM encoded", id)
+	}
+
+	var parsed interface{}
+	var err error
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return Key{}, fmt.Errorf("key %q has unsupported PEM type %q", id, block.Type)
+	}
+	if err != nil {
+		return Key{}, fmt.Errorf("cannot parse key %q: %w", id, err)
+	}
+
+	key := Key{ID: id}
+	switch k := parsed.(type) {
+	case ed25519.PrivateKey:
+		key.PrivateKey = k
+		key.PublicKey = k.Public()
+	case ed25519.PublicKey:
+		key.PublicKey = k
+	case *rsa.PrivateKey:
+		key.PrivateKey = k
+		key.PublicKey = k.Public()
+	case *rsa.PublicKey:
+		key.PublicKey = k
+	default:
+		return Key{}, fmt.Errorf("key %q must be an Ed25519 or RSA key", id)
+	}
+
+	if rsaKey, ok := key.PublicKey.(*rsa.PublicKey); ok && rsaKey.N.BitLen() < minRSAKeyBits {
+		return Key{}, fmt.Errorf("key %q must have at least %d bits", id, minRSAKeyBits)
+	}
+	return key, nil
+}
+
+// Current returns the key used to sign new tokens.
+func (ring *KeyRing) Current() Key {
+	return ring.current
+}
+
+// Key returns the key with the given ID.
+func (ring *KeyRing) Key(id string) (Key, bool) {
+	key, ok := ring.keys[id]
+	return key, ok
+}
+
+// Keys returns all the keys, starting with the current one.
+func (ring *KeyRing) Keys() []Key {
+	keys := make([]Key, len(ring.ids))
+	for i, id := range ring.ids {
+		keys[i] = ring.keys[id]
+	}
+	return keys
+}
+
+// checkKeys returns an error if a key of the ring cannot be used by a maker.
+func (ring *KeyRing) checkKeys(maker string, isValid func(publicKey crypto.PublicKey) bool) error {
+	for _, key := range ring.Keys() {
+		if !isValid(key.PublicKey) {
+			return fmt.Errorf("key %q cannot be used by %s", key.ID, maker)
+		}
+	}
+	return nil
+}
+
+func isEd25519Key(publicKey crypto.PublicKey) bool {
+	_, ok := publicKey.(ed25519.PublicKey)
+	return ok
+}
+
+func isRSAKey(publicKey crypto.PublicKey) bool {
+	_, ok := publicKey.(*rsa.PublicKey)
+	return ok
+}
@@ -0,0 +1,125 @@
+package token
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mativm02/bank_system/util"
+	"github.com/stretchr/testify/require"
+)
+
+func randomEd25519Key(t *testing.T) Key {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	return Key{
+		ID:         util.RandomString(8),
+		PublicKey:  publicKey,
+		PrivateKey: privateKey,
+	}
+}
+
+func randomRSAKey(t *testing.T) Key {
+	privateKey, err := rsa.GenerateKey(rand.Reader, minRSAKeyBits)
+	require.NoError(t, err)
+
+	return Key{
+		ID:         util.RandomString(8),
+		PublicKey:  privateKey.Public(),
+		PrivateKey: privateKey,
+	}
+}
+
+func newTestKeyRing(t *testing.T, current Key, previous ...Key) *KeyRing {
+	keyRing, err := NewKeyRing(current, previous...)
+	require.NoError(t, err)
+	return keyRing
+}
+
+func writePEM(t *testing.T, dir, id, blockType string, der []byte) {
+	data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+	err := os.WriteFile(filepath.Join(dir, id+".pem"), data, 0600)
+	require.NoError(t, err)
+}
+
+func TestLoadKeyRing(t *testing.T) {
+	dir := t.TempDir()
+	current := randomEd25519Key(t)
+	previous := randomEd25519Key(t)
+
+	der, err := x509.MarshalPKCS8PrivateKey(current.PrivateKey)
+	require.NoError(t, err)
+	writePEM(t, dir, current.ID, "PRIVATE KEY", der)
+
+	der, err = x509.MarshalPKIXPublicKey(previous.PublicKey)
+	require.NoError(t, err)
+	writePEM(t, dir, previous.ID, "PUBLIC KEY", der)
+
+	keyRing, err := LoadKeyRing(dir, current.ID)
+	require.NoError(t, err)
+	require.Equal(t, current.ID, keyRing.Current().ID)
+	require.Equal(t, current.PublicKey, keyRing.Current().PublicKey)
+	require.Len(t, keyRing.Keys(), 2)
+
+	key, ok := keyRing.Key(previous.ID)
+	require.True(t, ok)
+	require.Equal(t, previous.PublicKey, key.PublicKey)
+	require.Nil(t, key.PrivateKey)
+
+	// A public key cannot sign new tokens.
+	_, err = LoadKeyRing(dir, previous.ID)
+	require.Error(t, err)
+
+	_, err = LoadKeyRing(dir, util.RandomString(8))
+	require.Error(t, err)
+}
+
+func TestNewKeyRingInvalid(t *testing.T) {
+	key := randomEd25519Key(t)
+
+	_, err := NewKeyRing(key, key)
+	require.Error(t, err)
+
+	_, err = NewKeyRing(Key{ID: key.ID, PublicKey: key.PublicKey})
+	require.Error(t, err)
+
+	key.ID = ""
+	_, err = NewKeyRing(key)
+	require.Error(t, err)
+}
+
+func TestParseKeyInvalid(t *testing.T) {
+	_, err := ParseKey("key", []byte("not a pem"))
+	require.Error(t, err)
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+	data := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(privateKey)})
+	_, err = ParseKey("key", data)
+	require.Error(t, err)
+}
+
+func TestJWKS(t *testing.T) {
+	edKey := randomEd25519Key(t)
+	rsaKey := randomRSAKey(t)
+
+	set := newTestKeyRing(t, edKey, rsaKey).JWKS("")
+	require.Len(t, set.Keys, 2)
+
+	require.Equal(t, edKey.ID, set.Keys[0].KeyID)
+	require.Equal(t, "OKP", set.Keys[0].KeyType)
+	require.Equal(t, "Ed25519", set.Keys[0].Curve)
+	require.Len(t, set.Keys[0].X, 43)
+	require.Empty(t, set.Keys[0].Algorithm)
+
+	require.Equal(t, rsaKey.ID, set.Keys[1].KeyID)
+	require.Equal(t, "RSA", set.Keys[1].KeyType)
+	require.Equal(t, "AQAB", set.Keys[1].E)
+	require.NotEmpty(t, set.Keys[1].N)
+}
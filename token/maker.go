@@ -1,11 +1,62 @@
 package token
 
-import "time"
+import (
+	"fmt"
+	"time"
+
+	"github.com/mativm02/bank_system/util"
+)
+
+// Supported token makers
+const (
+	MakerPaseto       = "paseto"
+	MakerJWT          = "jwt"
+	MakerPasetoPublic = "paseto_v4_public"
+	MakerJWTRS256     = "jwt_rs256"
+	MakerJWTEdDSA     = "jwt_eddsa"
+)
 
 // Maker is an interface for generating and validating tokens
 type Maker interface {
-	// CreateToken creates a new token for the given username and duration
-	CreateToken(username string, duration time.Duration) (string, *Payload, error)
+	// CreateToken creates a new token for the given username, role and duration
+	CreateToken(username string, role string, duration time.Duration) (string, *Payload, error)
+	// CreateClientToken creates a new token for an API client acting for the given username,
+	// limited to the given scopes
+	CreateClientToken(clientID string, username string, scopes []string, duration time.Duration) (string, *Payload, error)
 	// VerifyToken verifies the given token and returns the payload
 	VerifyToken(token string) (*Payload, error)
 }
+
+// PublicKeyMaker is a Maker that signs tokens with a private key,
+// so other services can verify them with the published public keys.
+type PublicKeyMaker interface {
+	Maker
+	// JWKS returns the public keys that verify the tokens
+	JWKS() JSONWebKeySet
+}
+
+// NewMaker creates the token maker selected by the configuration.
+// The symmetric makers use TOKEN_SYMMETRIC_KEY, the public-key makers load their keys from TOKEN_KEY_DIR.
+func NewMaker(config util.Config) (Maker, error) {
+	switch config.TokenMaker {
+	case MakerPaseto, "":
+		return NewPasetoMaker(config.TokenSymmetricKey)
+	case MakerJWT:
+		return NewJWTMaker(config.TokenSymmetricKey)
+	}
+
+	keyRing, err := LoadKeyRing(config.TokenKeyDir, config.TokenKeyID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load token keys: %w", err)
+	}
+
+	switch config.TokenMaker {
+	case MakerPasetoPublic:
+		return NewPasetoPublicMaker(keyRing)
+	case MakerJWTRS256:
+		return NewJWTPublicMaker(JWTAlgorithmRS256, keyRing)
+	case MakerJWTEdDSA:
+		return NewJWTPublicMaker(JWTAlgorithmEdDSA, keyRing)
+	}
+	return nil, fmt.Errorf("unsupported token maker %q", config.TokenMaker)
+}
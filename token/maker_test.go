@@ -0,0 +1,39 @@
+package token
+
+import (
+	"crypto/x509"
+	"testing"
+
+	"github.com/mativm02/bank_system/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMaker(t *testing.T) {
+	maker, err := NewMaker(util.Config{TokenSymmetricKey: util.RandomString(32)})
+	require.NoError(t, err)
+	require.IsType(t, &PasetoMaker{}, maker)
+
+	dir := t.TempDir()
+	key := randomEd25519Key(t)
+	der, err := x509.MarshalPKCS8PrivateKey(key.PrivateKey)
+	require.NoError(t, err)
+	writePEM(t, dir, key.ID, "PRIVATE KEY", der)
+
+	config := util.Config{TokenMaker: MakerPasetoPublic, TokenKeyDir: dir, TokenKeyID: key.ID}
+	maker, err = NewMaker(config)
+	require.NoError(t, err)
+	require.IsType(t, &PasetoPublicMaker{}, maker)
+
+	config.TokenMaker = MakerJWTEdDSA
+	maker, err = NewMaker(config)
+	require.NoError(t, err)
+	require.IsType(t, &JWTPublicMaker{}, maker)
+
+	config.TokenMaker = MakerJWTRS256
+	_, err = NewMaker(config)
+	require.Error(t, err)
+
+	config.TokenMaker = "unknown"
+	_, err = NewMaker(config)
+	require.Error(t, err)
+}
@@ -28,13 +28,25 @@ func NewPasetoMaker(symmetricKey string) (Maker, error) {
 	}, nil
 }
 
-// CreateToken creates a new token for the given username and duration
-func (maker *PasetoMaker) CreateToken(username string, duration time.Duration) (string, *Payload, error) {
-	payload, err := NewPayload(username, duration)
+// CreateToken creates a new token for the given username, role and duration
+func (maker *PasetoMaker) CreateToken(username string, role string, duration time.Duration) (string, *Payload, error) {
+	payload, err := NewPayload(username, role, duration)
 	if err != nil {
 		return "", nil, err
 	}
+	return maker.createToken(payload)
+}
+
+// CreateClientToken creates a new token for an API client acting for the given username
+func (maker *PasetoMaker) CreateClientToken(clientID string, username string, scopes []string, duration time.Duration) (string, *Payload, error) {
+	payload, err := NewClientPayload(clientID, username, scopes, duration)
+	if err != nil {
+		return "", nil, err
+	}
+	return maker.createToken(payload)
+}
 
+func (maker *PasetoMaker) createToken(payload *Payload) (string, *Payload, error) {
 	token, err := maker.paseto.Encrypt(maker.symmetricKey, payload, nil)
 
 	return token, payload, err
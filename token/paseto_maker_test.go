@@ -18,7 +18,7 @@ func TestPasetoMaker(t *testing.T) {
 	issuedAt := time.Now()
 	expiredAt := issuedAt.Add(duration)
 
-	token, _, err := maker.CreateToken(username, duration)
+	token, _, err := maker.CreateToken(username, util.DepositorRole, duration)
 	require.NoError(t, err)
 	require.NotEmpty(t, token)
 
@@ -27,6 +27,7 @@ func TestPasetoMaker(t *testing.T) {
 	require.NotEmpty(t, payload)
 
 	require.Equal(t, username, payload.Username)
+	require.Equal(t, util.DepositorRole, payload.Role)
 	require.NotZero(t, payload.ID)
 	require.WithinDuration(t, issuedAt, payload.IssuedAt, time.Second)
 	require.WithinDuration(t, expiredAt, payload.ExpiredAt, time.Second)
@@ -40,7 +41,7 @@ func TestExpiredPasetoToken(t *testing.T) {
 	username := util.RandomOwner()
 	duration := time.Second * -10
 
-	token, _, err := maker.CreateToken(username, duration)
+	token, _, err := maker.CreateToken(username, util.DepositorRole, duration)
 	require.NoError(t, err)
 	require.NotEmpty(t, token)
 
@@ -49,3 +50,25 @@ func TestExpiredPasetoToken(t *testing.T) {
 	require.Equal(t, ErrTokenExpired, err)
 	require.Nil(t, payload)
 }
+
+func TestPasetoClientToken(t *testing.T) {
+	maker, err := NewPasetoMaker(util.RandomString(32))
+	require.NoError(t, err)
+
+	clientID := util.RandomString(10)
+	username := util.RandomOwner()
+	scopes := []string{ScopeAccountsRead, ScopeTransfersRead}
+
+	token, _, err := maker.CreateClientToken(clientID, username, scopes, time.Minute)
+	require.NoError(t, err)
+
+	payload, err := maker.VerifyToken(token)
+	require.NoError(t, err)
+	require.Equal(t, clientID, payload.ClientID)
+	require.Equal(t, username, payload.Username)
+	require.Empty(t, payload.Role)
+	require.Equal(t, scopes, payload.Scopes)
+
+	require.True(t, payload.HasScope(ScopeAccountsRead))
+	require.False(t, payload.HasScope(ScopeTransfersWrite))
+}
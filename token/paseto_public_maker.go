@@ -0,0 +1,138 @@
+package token
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+const pasetoV4PublicHeader = "v4.public."
+
+type pasetoFooter struct {
+	KeyID string `json:"kid"`
+}
+
+// PasetoPublicMaker is a token maker that signs PASETO v4.public tokens with Ed25519.
+// The ID of the signing key is stored in the footer of the token.
+type PasetoPublicMaker struct {
+	keyRing *KeyRing
+}
+
+// NewPasetoPublicMaker creates a new PasetoPublicMaker from a key ring of Ed25519 keys
+func NewPasetoPublicMaker(keyRing *KeyRing) (PublicKeyMaker, error) {
+	if err := keyRing.checkKeys("PASETO v4.public", isEd25519Key); err != nil {
+		return nil, err
+	}
+	return &PasetoPublicMaker{keyRing}, nil
+}
+
+// CreateToken creates a new token for the given username, role and duration
+func (maker *PasetoPublicMaker) CreateToken(username string, role string, duration time.Duration) (string, *Payload, error) {
+	payload, err := NewPayload(username, role, duration)
+	if err != nil {
+		return "", nil, err
+	}
+	return maker.createToken(payload)
+}
+
+// CreateClientToken creates a new token for an API client acting for the given username
+func (maker *PasetoPublicMaker) CreateClientToken(clientID string, username string, scopes []string, duration time.Duration) (string, *Payload, error) {
+	payload, err := NewClientPayload(clientID, username, scopes, duration)
+	if err != nil {
+		return "", nil, err
+	}
+	return maker.createToken(payload)
+}
+
+func (maker *PasetoPublicMaker) createToken(payload *Payload) (string, *Payload, error) {
+	message, err := json.Marshal(payload)
+	if err != nil {
+		return "", nil, err
+	}
+
+	key := maker.keyRing.Current()
+	footer, err := json.Marshal(pasetoFooter{KeyID: key.ID})
+	if err != nil {
+		return "", nil, err
+	}
+
+	signature := ed25519.Sign(key.PrivateKey.(ed25519.PrivateKey), preAuthEncode([]byte(pasetoV4PublicHeader), message, footer, nil))
+
+	token := pasetoV4PublicHeader +
+		base64.RawURLEncoding.EncodeToString(append(message, signature...)) + "." +
+		base64.RawURLEncoding.EncodeToString(footer)
+	return token, payload, nil
+}
+
+// VerifyToken verifies the given token with the key named in its footer and returns the payload
+func (maker *PasetoPublicMaker) VerifyToken(token string) (*Payload, error) {
+	if !strings.HasPrefix(token, pasetoV4PublicHeader) {
+		return nil, ErrTokenInvalid
+	}
+
+	parts := strings.Split(strings.TrimPrefix(token, pasetoV4PublicHeader), ".")
+	if len(parts) != 2 {
+		return nil, ErrTokenInvalid
+	}
+
+	body, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil || len(body) < ed25519.SignatureSize {
+		return nil, ErrTokenInvalid
+	}
+	footer, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, ErrTokenInvalid
+	}
+
+	var f pasetoFooter
+	if err := json.Unmarshal(footer, &f); err != nil {
+		return nil, ErrTokenInvalid
+	}
+	key, ok := maker.keyRing.Key(f.KeyID)
+	if !ok {
+		return nil, ErrTokenInvalid
+	}
+
+	message := body[:len(body)-ed25519.SignatureSize]
+	signature := body[len(body)-ed25519.SignatureSize:]
+	if !ed25519.Verify(key.PublicKey.(ed25519.PublicKey), preAuthEncode([]byte(pasetoV4PublicHeader), message, footer, nil), signature) {
+		return nil, ErrTokenInvalid
+	}
+
+	payload := &Payload{}
+	if err := json.Unmarshal(message, payload); err != nil {
+		return nil, ErrTokenInvalid
+	}
+
+	err = payload.Valid()
+	if err != nil {
+		return nil, err
+	}
+
+	return payload, nil
+}
+
+// JWKS returns the public keys that verify the tokens
+func (maker *PasetoPublicMaker) JWKS() JSONWebKeySet {
+	return maker.keyRing.JWKS("")
+}
+
+// preAuthEncode is the PASETO pre-authentication encoding of the pieces covered by the signature.
+func preAuthEncode(pieces ...[]byte) []byte {
+	output := le64(uint64(len(pieces)))
+	for _, piece := range pieces {
+		output = append(output, le64(uint64(len(piece)))...)
+		output = append(output, piece...)
+	}
+	return output
+}
+
+func le64(n uint64) []byte {
+	b := make([]byte, 8)
+	// The most significant bit must be cleared for interoperability with languages without unsigned integers.
+	binary.LittleEndian.PutUint64(b, n&(1<<63-1))
+	return b
+}
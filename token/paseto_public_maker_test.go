@@ -0,0 +1,113 @@
+package token
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mativm02/bank_system/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPasetoPublicMaker(t *testing.T) {
+	maker, err := NewPasetoPublicMaker(newTestKeyRing(t, randomEd25519Key(t)))
+	require.NoError(t, err)
+
+	username := util.RandomOwner()
+	duration := time.Second * 10
+
+	issuedAt := time.Now()
+	expiredAt := issuedAt.Add(duration)
+
+	token, _, err := maker.CreateToken(username, util.DepositorRole, duration)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(token, pasetoV4PublicHeader))
+
+	payload, err := maker.VerifyToken(token)
+	require.NoError(t, err)
+	require.NotEmpty(t, payload)
+
+	require.Equal(t, username, payload.Username)
+	require.Equal(t, util.DepositorRole, payload.Role)
+	require.NotZero(t, payload.ID)
+	require.WithinDuration(t, issuedAt, payload.IssuedAt, time.Second)
+	require.WithinDuration(t, expiredAt, payload.ExpiredAt, time.Second)
+}
+
+func TestExpiredPasetoPublicToken(t *testing.T) {
+	maker, err := NewPasetoPublicMaker(newTestKeyRing(t, randomEd25519Key(t)))
+	require.NoError(t, err)
+
+	token, _, err := maker.CreateToken(util.RandomOwner(), util.DepositorRole, -time.Minute)
+	require.NoError(t, err)
+
+	payload, err := maker.VerifyToken(token)
+	require.Equal(t, ErrTokenExpired, err)
+	require.Nil(t, payload)
+}
+
+func TestPasetoPublicMakerRotation(t *testing.T) {
+	oldKey := randomEd25519Key(t)
+	newKey := randomEd25519Key(t)
+
+	oldMaker, err := NewPasetoPublicMaker(newTestKeyRing(t, oldKey))
+	require.NoError(t, err)
+	oldToken, _, err := oldMaker.CreateToken(util.RandomOwner(), util.DepositorRole, time.Minute)
+	require.NoError(t, err)
+
+	// The tokens signed before the rotation are accepted while the old key stays in the ring.
+	rotatedMaker, err := NewPasetoPublicMaker(newTestKeyRing(t, newKey, Key{ID: oldKey.ID, PublicKey: oldKey.PublicKey}))
+	require.NoError(t, err)
+	_, err = rotatedMaker.VerifyToken(oldToken)
+	require.NoError(t, err)
+
+	newToken, _, err := rotatedMaker.CreateToken(util.RandomOwner(), util.DepositorRole, time.Minute)
+	require.NoError(t, err)
+	_, err = oldMaker.VerifyToken(newToken)
+	require.Equal(t, ErrTokenInvalid, err)
+
+	// Once the old key is dropped, its tokens are rejected.
+	newMaker, err := NewPasetoPublicMaker(newTestKeyRing(t, newKey))
+	require.NoError(t, err)
+	_, err = newMaker.VerifyToken(oldToken)
+	require.Equal(t, ErrTokenInvalid, err)
+
+	require.Len(t, rotatedMaker.JWKS().Keys, 2)
+}
+
+func TestInvalidPasetoPublicToken(t *testing.T) {
+	key := randomEd25519Key(t)
+	maker, err := NewPasetoPublicMaker(newTestKeyRing(t, key))
+	require.NoError(t, err)
+
+	token, _, err := maker.CreateToken(util.RandomOwner(), util.DepositorRole, time.Minute)
+	require.NoError(t, err)
+
+	// The footer is signed, so the key ID cannot be swapped.
+	forgedFooter := base64.RawURLEncoding.EncodeToString([]byte(`{"kid":"` + key.ID + `","x":1}`))
+	forged := token[:strings.LastIndex(token, ".")+1] + forgedFooter
+
+	for _, invalid := range []string{"", "v2.local.abc", token + "a", strings.TrimSuffix(token, "."+strings.Split(token, ".")[3]), forged} {
+		payload, err := maker.VerifyToken(invalid)
+		require.Equal(t, ErrTokenInvalid, err)
+		require.Nil(t, payload)
+	}
+
+	_, err = NewPasetoPublicMaker(newTestKeyRing(t, randomRSAKey(t)))
+	require.Error(t, err)
+}
+
+func TestPreAuthEncode(t *testing.T) {
+	// Official PASETO test vector 4-S-1.
+	secretKey, err := hex.DecodeString("b4cbfb43df4ce210727d953e4a713307fa19bb7d9f85041438d9e11b942a37741eb9dbbbbc047c03fd70604e0071f0987e16b28b757225c11f00415d0e20b1a2")
+	require.NoError(t, err)
+
+	message := []byte(`{"data":"this is a signed message","exp":"2022-01-01T00:00:00+00:00"}`)
+	signature := ed25519.Sign(secretKey, preAuthEncode([]byte(pasetoV4PublicHeader), message, nil, nil))
+
+	token := pasetoV4PublicHeader + base64.RawURLEncoding.EncodeToString(append(message, signature...))
+	require.Equal(t, "v4.public.eyJkYXRhIjoidGhpcyBpcyBhIHNpZ25lZCBtZXNzYWdlIiwiZXhwIjoiMjAyMi0wMS0wMVQwMDowMDowMCswMDowMCJ9bg_XBBzds8lTZShVlwwKSgeKpLT3yukTw6JUz3W4h_ExsQV-P0V54zemZDcAxFaSeef1QlXEFtkqxT1ciiQEDA", token)
+}
@@ -13,33 +13,72 @@ var (
 )
 
 type Payload struct {
-	ID        uuid.UUID `json:"id"` // we're using an ID to invalidate tokens in case they're leaked
-	Username  string    `json:"username"`
+	ID       uuid.UUID `json:"id"` // we're using an ID to invalidate tokens in case they're leaked
+	Username string    `json:"username"`
+	Role     string    `json:"role,omitempty"`
+	// ClientID is set when the token was issued to an API client acting for the user.
+	ClientID string `json:"client_id,omitempty"`
+	// APIKeyID is set when the request was authenticated with an API key of the user instead of a token.
+	APIKeyID  int64     `json:"api_key_id,omitempty"`
+	Scopes    []string  `json:"scopes,omitempty"`
 	IssuedAt  time.Time `json:"issued_at"`
 	ExpiredAt time.Time `json:"expired_at"`
 }
 
-// NewPayload creates a new payload for the given username and duration
-func NewPayload(username string, duration time.Duration) (*Payload, error) {
+// NewPayload creates a new payload for the given username, role and duration
+func NewPayload(username string, role string, duration time.Duration) (*Payload, error) {
 	tokenID, err := uuid.NewRandom()
 	if err != nil {
 		return nil, err
 	}
 
 	now := time.Now()
-
 	return &Payload{
 		ID:        tokenID,
 		Username:  username,
+		Role:      role,
 		IssuedAt:  now,
 		ExpiredAt: now.Add(duration),
 	}, nil
 }
 
+// NewClientPayload creates a new payload for an API client acting for the given username,
+// limited to the given scopes
+func NewClientPayload(clientID string, username string, scopes []string, duration time.Duration) (*Payload, error) {
+	payload, err := NewPayload(username, "", duration)
+	if err != nil {
+		return nil, err
+	}
+
+	payload.ClientID = clientID
+	payload.Scopes = scopes
+	return payload, nil
+}
+
 func (p *Payload) Valid() error {
 	if p.ExpiredAt.Before(time.Now()) {
 		return ErrTokenExpired
 	}
-
 	return nil
 }
+
+// IsScoped reports whether the payload is limited by its scopes,
+// which is the case of the API clients and the API keys acting for a user.
+func (p *Payload) IsScoped() bool {
+	return p.ClientID != "" || p.APIKeyID != 0
+}
+
+// HasScope reports whether the token grants the scope.
+// The tokens of the users are not limited, only the ones of the API clients and API keys are.
+func (p *Payload) HasScope(scope string) bool {
+	if !p.IsScoped() {
+		return true
+	}
+
+	for _, s := range p.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,39 @@
+package token
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mativm02/bank_system/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPayloadHasScope(t *testing.T) {
+	// The tokens of the users are not limited by scopes.
+	payload, err := NewPayload(util.RandomOwner(), util.DepositorRole, time.Minute)
+	require.NoError(t, err)
+	require.True(t, payload.HasScope(ScopeTransfersWrite))
+
+	payload, err = NewClientPayload(util.RandomString(10), util.RandomOwner(), []string{ScopeAccountsRead}, time.Minute)
+	require.NoError(t, err)
+	require.True(t, payload.HasScope(ScopeAccountsRead))
+	require.False(t, payload.HasScope(ScopeAccountsWrite))
+
+	payload.Scopes = nil
+	require.False(t, payload.HasScope(ScopeAccountsRead))
+
+	payload = &Payload{Username: util.RandomOwner(), APIKeyID: 1, Scopes: []string{ScopeTransfersWrite}}
+	require.True(t, payload.IsScoped())
+	require.True(t, payload.HasScope(ScopeTransfersWrite))
+	require.False(t, payload.HasScope(ScopeTransfersRead))
+}
+
+func TestParseScope(t *testing.T) {
+	scopes := ParseScope(" accounts:read  transfers:write ")
+	require.Equal(t, []string{ScopeAccountsRead, ScopeTransfersWrite}, scopes)
+	require.Equal(t, "accounts:read transfers:write", FormatScope(scopes))
+	require.Empty(t, ParseScope(""))
+
+	require.True(t, IsSupportedScope(ScopeWebhooksWrite))
+	require.False(t, IsSupportedScope("admin"))
+}
@@ -0,0 +1,37 @@
+package token
+
+import "strings"
+
+// Scopes that limit what an API client can do for its user
+const (
+	ScopeAccountsRead   = "accounts:read"
+	ScopeAccountsWrite  = "accounts:write"
+	ScopeTransfersRead  = "transfers:read"
+	ScopeTransfersWrite = "transfers:write"
+	ScopeUsersRead      = "users:read"
+	ScopeUsersWrite     = "users:write"
+	ScopeWebhooksRead   = "webhooks:read"
+	ScopeWebhooksWrite  = "webhooks:write"
+)
+
+// IsSupportedScope returns true if the scope is supported
+func IsSupportedScope(scope string) bool {
+	switch scope {
+	case ScopeAccountsRead, ScopeAccountsWrite,
+		ScopeTransfersRead, ScopeTransfersWrite,
+		ScopeUsersRead, ScopeUsersWrite,
+		ScopeWebhooksRead, ScopeWebhooksWrite:
+		return true
+	}
+	return false
+}
+
+// ParseScope splits an OAuth2 scope parameter, a list of space-separated scopes
+func ParseScope(scope string) []string {
+	return strings.Fields(scope)
+}
+
+// FormatScope joins scopes into an OAuth2 scope parameter
+func FormatScope(scopes []string) string {
+	return strings.Join(scopes, " ")
+}
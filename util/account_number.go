@@ -0,0 +1,67 @@
+package util
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+const (
+	// AccountNumberPrefix starts the account numbers, like the country code of an IBAN.
+	AccountNumberPrefix = "SB"
+	// AccountNumberLength is the length of an account number: the prefix, 2 check digits and 16 digits.
+	AccountNumberLength = len(AccountNumberPrefix) + 2 + accountNumberDigits
+
+	accountNumberDigits = 16
+)
+
+// NewAccountNumber generates a random account number in the IBAN format, e.g. SB971234567890123456.
+// The check digits are computed with ISO 7064 mod 97-10, so that IsValidAccountNumber
+// catches any single wrong digit and almost any transposition of digits.
+func NewAccountNumber() (string, error) {
+	max := new(big.Int).Exp(big.NewInt(10), big.NewInt(accountNumberDigits), nil)
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", fmt.Errorf("cannot generate account number: %w", err)
+	}
+
+	digits := fmt.Sprintf("%0*d", accountNumberDigits, n)
+	checkDigits := 98 - mod97(digits+AccountNumberPrefix+"00")
+	return fmt.Sprintf("%s%02d%s", AccountNumberPrefix, checkDigits, digits), nil
+}
+
+// NormalizeAccountNumber removes the spaces and uppercases an account number typed by a user.
+func NormalizeAccountNumber(number string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(number), ""))
+}
+
+// IsValidAccountNumber reports whether the normalized account number has the expected format and check digits.
+func IsValidAccountNumber(number string) bool {
+	if len(number) != AccountNumberLength || !strings.HasPrefix(number, AccountNumberPrefix) {
+		return false
+	}
+	for _, c := range number[len(AccountNumberPrefix):] {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	// The prefix and the check digits are moved to the end, as for an IBAN.
+	return mod97(number[4:]+number[:4]) == 1
+}
+
+// mod97 returns the remainder of the division by 97 of the number written with the characters,
+// where the letters A to Z stand for 10 to 35.
+func mod97(s string) int {
+	remainder := 0
+	for _, c := range s {
+		switch {
+		case c >= '0' && c <= '9':
+			remainder = (remainder*10 + int(c-'0')) % 97
+		case c >= 'A' && c <= 'Z':
+			remainder = (remainder*100 + int(c-'A') + 10) % 97
+		}
+	}
+	return remainder
+}
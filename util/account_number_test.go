@@ -0,0 +1,57 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAccountNumber(t *testing.T) {
+	number1, err := NewAccountNumber()
+	require.NoError(t, err)
+	require.Len(t, number1, AccountNumberLength)
+	require.True(t, IsValidAccountNumber(number1))
+
+	number2, err := NewAccountNumber()
+	require.NoError(t, err)
+	require.NotEqual(t, number1, number2)
+}
+
+func TestIsValidAccountNumber(t *testing.T) {
+	number, err := NewAccountNumber()
+	require.NoError(t, err)
+
+	// Every change of a single digit is caught.
+	for i := 2; i < len(number); i++ {
+		for d := byte('0'); d <= '9'; d++ {
+			if number[i] == d {
+				continue
+			}
+			typo := number[:i] + string(d) + number[i+1:]
+			require.False(t, IsValidAccountNumber(typo), typo)
+		}
+	}
+
+	// Every transposition of adjacent different digits is caught.
+	for i := 2; i < len(number)-1; i++ {
+		if number[i] == number[i+1] {
+			continue
+		}
+		typo := number[:i] + string(number[i+1]) + string(number[i]) + number[i+2:]
+		require.False(t, IsValidAccountNumber(typo), typo)
+	}
+
+	require.True(t, IsValidAccountNumber("SB971234567890123456"))
+	require.False(t, IsValidAccountNumber(""))
+	require.False(t, IsValidAccountNumber("XX"+number[2:]))
+	require.False(t, IsValidAccountNumber(number[:len(number)-1]))
+	require.False(t, IsValidAccountNumber(number[:10]+"A"+number[11:]))
+}
+
+func TestNormalizeAccountNumber(t *testing.T) {
+	number, err := NewAccountNumber()
+	require.NoError(t, err)
+
+	typed := "sb" + number[2:4] + " " + number[4:8] + " " + number[8:12] + " " + number[12:16] + " " + number[16:]
+	require.Equal(t, number, NormalizeAccountNumber(typed))
+}
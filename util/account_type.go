@@ -0,0 +1,45 @@
+package util
+
+// Types of the accounts
+const (
+	CheckingAccount = "checking"
+	SavingsAccount  = "savings"
+	BusinessAccount = "business"
+)
+
+// IsSupportedAccountType returns true if the account type is supported
+func IsSupportedAccountType(accountType string) bool {
+	switch accountType {
+	case CheckingAccount, SavingsAccount, BusinessAccount:
+		return true
+	}
+	return false
+}
+
+// Default limits of the account types, used when the config does not set them.
+const (
+	defaultMaxCheckingAccounts = 1
+	defaultMaxSavingsAccounts  = 3
+	defaultMaxBusinessAccounts = 2
+)
+
+// MaxAccounts returns how many accounts of the given type a user can open in each currency.
+// The limits that are not set in the config use the defaults, so no account type is closed by mistake.
+func (config Config) MaxAccounts(accountType string) int64 {
+	switch accountType {
+	case CheckingAccount:
+		return maxAccountsOrDefault(config.MaxCheckingAccounts, defaultMaxCheckingAccounts)
+	case SavingsAccount:
+		return maxAccountsOrDefault(config.MaxSavingsAccounts, defaultMaxSavingsAccounts)
+	case BusinessAccount:
+		return maxAccountsOrDefault(config.MaxBusinessAccounts, defaultMaxBusinessAccounts)
+	}
+	return 0
+}
+
+func maxAccountsOrDefault(maxAccounts, defaultMaxAccounts int64) int64 {
+	if maxAccounts > 0 {
+		return maxAccounts
+	}
+	return defaultMaxAccounts
+}
@@ -0,0 +1,16 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaxAccounts(t *testing.T) {
+	config := Config{MaxSavingsAccounts: 5}
+
+	require.Equal(t, int64(5), config.MaxAccounts(SavingsAccount))
+	require.Equal(t, int64(defaultMaxCheckingAccounts), config.MaxAccounts(CheckingAccount))
+	require.Equal(t, int64(defaultMaxBusinessAccounts), config.MaxAccounts(BusinessAccount))
+	require.Zero(t, config.MaxAccounts("loan"))
+}
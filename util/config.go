@@ -9,19 +9,60 @@ import (
 // Config contains the configuration for the application.
 // The values are read by viper from the config file or env vars.
 type Config struct {
-	DBDriver             string        `mapstructure:"DB_DRIVER"`
-	DBSource             string        `mapstructure:"DB_SOURCE"`
-	MigrationURL         string        `mapstructure:"MIGRATION_URL"`
-	HTTPServerAddress    string        `mapstructure:"HTTP_SERVER_ADDRESS"`
-	GRPCServerAddress    string        `mapstructure:"GRPC_SERVER_ADDRESS"`
-	TokenSymmetricKey    string        `mapstructure:"TOKEN_SYMMETRIC_KEY"`
-	AccessTokenDuration  time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
-	RefreshTokenDuration time.Duration `mapstructure:"REFRESH_TOKEN_DURATION"`
-	Environment          string        `mapstructure:"ENVIRONMENT"`
-	RedisAddress         string        `mapstructure:"REDIS_ADDRESS"`
-	EmailSenderName      string        `mapstructure:"EMAIL_SENDER_NAME"`
-	EmailSenderAddress   string        `mapstructure:"EMAIL_SENDER_ADDRESS"`
-	EmailSenderPassword  string        `mapstructure:"EMAIL_SENDER_PASSWORD"`
+	DBDriver                  string        `mapstructure:"DB_DRIVER"`
+	DBSource                  string        `mapstructure:"DB_SOURCE"`
+	MigrationURL              string        `mapstructure:"MIGRATION_URL"`
+	HTTPServerAddress         string        `mapstructure:"HTTP_SERVER_ADDRESS"`
+	PublicBaseURL             string        `mapstructure:"PUBLIC_BASE_URL"`
+	GRPCServerAddress         string        `mapstructure:"GRPC_SERVER_ADDRESS"`
+	TokenSymmetricKey         string        `mapstructure:"TOKEN_SYMMETRIC_KEY"`
+	TokenMaker                string        `mapstructure:"TOKEN_MAKER"`
+	TokenKeyDir               string        `mapstructure:"TOKEN_KEY_DIR"`
+	TokenKeyID                string        `mapstructure:"TOKEN_KEY_ID"`
+	PageTokenKey              string        `mapstructure:"PAGE_TOKEN_KEY"`
+	TransferSearchMaxSpan     time.Duration `mapstructure:"TRANSFER_SEARCH_MAX_SPAN"`
+	PayeeCoolingOffPeriod     time.Duration `mapstructure:"PAYEE_COOLING_OFF_PERIOD"`
+	PayeeCoolingOffMaxAmount  int64         `mapstructure:"PAYEE_COOLING_OFF_MAX_AMOUNT"`
+	MaxCheckingAccounts       int64         `mapstructure:"MAX_CHECKING_ACCOUNTS"`
+	MaxSavingsAccounts        int64         `mapstructure:"MAX_SAVINGS_ACCOUNTS"`
+	MaxBusinessAccounts       int64         `mapstructure:"MAX_BUSINESS_ACCOUNTS"`
+	AccountDailySpendLimit    int64         `mapstructure:"ACCOUNT_DAILY_SPEND_LIMIT"`
+	AccountMonthlySpendLimit  int64         `mapstructure:"ACCOUNT_MONTHLY_SPEND_LIMIT"`
+	UserDailySpendLimit       int64         `mapstructure:"USER_DAILY_SPEND_LIMIT"`
+	UserMonthlySpendLimit     int64         `mapstructure:"USER_MONTHLY_SPEND_LIMIT"`
+	InterestAccrualSchedule   string        `mapstructure:"INTEREST_ACCRUAL_SCHEDULE"`
+	InterestPostingSchedule   string        `mapstructure:"INTEREST_POSTING_SCHEDULE"`
+	AccessTokenDuration       time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
+	RefreshTokenDuration      time.Duration `mapstructure:"REFRESH_TOKEN_DURATION"`
+	VerifyEmailDuration       time.Duration `mapstructure:"VERIFY_EMAIL_DURATION"`
+	VerifyEmailResendInterval time.Duration `mapstructure:"VERIFY_EMAIL_RESEND_INTERVAL"`
+	PasswordMinLength         int           `mapstructure:"PASSWORD_MIN_LENGTH"`
+	PasswordRequireUpper      bool          `mapstructure:"PASSWORD_REQUIRE_UPPER"`
+	PasswordRequireLower      bool          `mapstructure:"PASSWORD_REQUIRE_LOWER"`
+	PasswordRequireDigit      bool          `mapstructure:"PASSWORD_REQUIRE_DIGIT"`
+	PasswordRequireSymbol     bool          `mapstructure:"PASSWORD_REQUIRE_SYMBOL"`
+	PasswordHistorySize       int32         `mapstructure:"PASSWORD_HISTORY_SIZE"`
+	PasswordHashAlgorithm     string        `mapstructure:"PASSWORD_HASH_ALGORITHM"`
+	PasswordBcryptCost        int           `mapstructure:"PASSWORD_BCRYPT_COST"`
+	PasswordArgon2Memory      uint32        `mapstructure:"PASSWORD_ARGON2_MEMORY"`
+	PasswordArgon2Iterations  uint32        `mapstructure:"PASSWORD_ARGON2_ITERATIONS"`
+	PasswordArgon2Parallelism uint8         `mapstructure:"PASSWORD_ARGON2_PARALLELISM"`
+	Environment               string        `mapstructure:"ENVIRONMENT"`
+	LogRedactionLevel         string        `mapstructure:"LOG_REDACTION_LEVEL"`
+	LogRedactFields           []string      `mapstructure:"LOG_REDACT_FIELDS"`
+	RedisAddress              string        `mapstructure:"REDIS_ADDRESS"`
+	OutboxRelayInterval       time.Duration `mapstructure:"OUTBOX_RELAY_INTERVAL"`
+	EmailSenderName           string        `mapstructure:"EMAIL_SENDER_NAME"`
+	EmailSenderAddress        string        `mapstructure:"EMAIL_SENDER_ADDRESS"`
+	EmailSenderPassword       string        `mapstructure:"EMAIL_SENDER_PASSWORD"`
+	EmailSenderType           string        `mapstructure:"EMAIL_SENDER_TYPE"`
+	EmailDropDir              string        `mapstructure:"EMAIL_DROP_DIR"`
+	SMTPHost                  string        `mapstructure:"SMTP_HOST"`
+	SMTPPort                  int           `mapstructure:"SMTP_PORT"`
+	SMTPTLSMode               string        `mapstructure:"SMTP_TLS_MODE"`
+	SMTPAuthMode              string        `mapstructure:"SMTP_AUTH_MODE"`
+	SMTPUsername              string        `mapstructure:"SMTP_USERNAME"`
+	SMTPPassword              string        `mapstructure:"SMTP_PASSWORD"`
 }
 
 // LoadConfig loads the configuration from the config file or env vars.
@@ -0,0 +1,32 @@
+package util
+
+import "math/big"
+
+const (
+	// MicrosPerUnit is the number of millionths of a minor unit in a minor unit.
+	// The interest accrues in millionths so the small balances do not round down to nothing every day.
+	MicrosPerUnit = 1000000
+	// DaysPerYear is the day count of the annual rates, whatever the length of the year.
+	DaysPerYear = 365
+
+	basisPointsPerUnit = 10000
+)
+
+// DailyInterest returns the interest earned in one day by the balance at the annual rate in basis points,
+// in millionths of a minor unit. It is rounded down, so the result only depends on its arguments.
+func DailyInterest(balance int64, annualRateBps int64) int64 {
+	if balance <= 0 || annualRateBps <= 0 {
+		return 0
+	}
+
+	interest := new(big.Int).Mul(big.NewInt(balance), big.NewInt(annualRateBps))
+	interest.Mul(interest, big.NewInt(MicrosPerUnit))
+	interest.Quo(interest, big.NewInt(basisPointsPerUnit*DaysPerYear))
+	return interest.Int64()
+}
+
+// SplitInterest splits interest in millionths of a minor unit into the whole minor units to pay
+// and the remainder to carry to the next payment.
+func SplitInterest(micros int64) (amount int64, carryMicros int64) {
+	return micros / MicrosPerUnit, micros % MicrosPerUnit
+}
@@ -0,0 +1,64 @@
+package util
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDailyInterest(t *testing.T) {
+	testCases := []struct {
+		name          string
+		balance       int64
+		annualRateBps int64
+		micros        int64
+	}{
+		{
+			name:          "RoundedDown",
+			balance:       100000,
+			annualRateBps: 250,
+			// 100000 * 2.5% / 365 = 6.849315... minor units
+			micros: 6849315,
+		},
+		{
+			name:          "SmallBalance",
+			balance:       1,
+			annualRateBps: 100,
+			micros:        27,
+		},
+		{
+			name:          "NegativeBalance",
+			balance:       -100000,
+			annualRateBps: 250,
+			micros:        0,
+		},
+		{
+			name:          "ZeroRate",
+			balance:       100000,
+			annualRateBps: 0,
+			micros:        0,
+		},
+		{
+			name:          "NoOverflow",
+			balance:       math.MaxInt64 / 1000,
+			annualRateBps: 365,
+			// 3.65% a year is 0.01% a day, or 100 millionths of a minor unit per minor unit.
+			micros: math.MaxInt64 / 1000 * 100,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.micros, DailyInterest(tc.balance, tc.annualRateBps))
+		})
+	}
+}
+
+func TestSplitInterest(t *testing.T) {
+	amount, carry := SplitInterest(30*6849315 + 1)
+	require.Equal(t, int64(205), amount)
+	require.Equal(t, int64(479451), carry)
+	require.Equal(t, int64(30*6849315+1), amount*MicrosPerUnit+carry)
+}
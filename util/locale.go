@@ -0,0 +1,17 @@
+package util
+
+const (
+	// EN is the locale code for English
+	EN = "en"
+	// ES is the locale code for Spanish
+	ES = "es"
+)
+
+// IsSupportedLocale checks if the locale is supported
+func IsSupportedLocale(locale string) bool {
+	switch locale {
+	case EN, ES:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,19 @@
+package util
+
+const (
+	// NotifyAlways sends a notification for every transfer
+	NotifyAlways = "always"
+	// NotifyAboveThreshold sends a notification for transfers above the user's threshold
+	NotifyAboveThreshold = "above_threshold"
+	// NotifyNever never sends a notification
+	NotifyNever = "never"
+)
+
+// IsSupportedNotificationMode checks if the notification mode is supported
+func IsSupportedNotificationMode(mode string) bool {
+	switch mode {
+	case NotifyAlways, NotifyAboveThreshold, NotifyNever:
+		return true
+	}
+	return false
+}
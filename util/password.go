@@ -1,20 +1,210 @@
 package util
 
 import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
 	"fmt"
+	"strings"
 
+	"golang.org/x/crypto/argon2"
 	"golang.org/x/crypto/bcrypt"
 )
 
-// HashPassword returns the bcrypt hash of the password
-func HashPassword(password string) (string, error) {
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
-	if err != nil {
-		return "", fmt.Errorf("error hashing password: %v", err)
+// Supported password hashing algorithms
+const (
+	HashBcrypt   = "bcrypt"
+	HashArgon2id = "argon2id"
+)
+
+var (
+	// ErrMismatchedHashAndPassword is returned when the password does not match the hash, whatever the algorithm.
+	// It is the bcrypt error so existing comparisons keep working.
+	ErrMismatchedHashAndPassword = bcrypt.ErrMismatchedHashAndPassword
+	ErrUnsupportedHash           = errors.New("unsupported password hash")
+)
+
+// PasswordHashParams contains the algorithm and the cost of the new password hashes.
+type PasswordHashParams struct {
+	Algorithm         string
+	BcryptCost        int
+	Argon2Memory      uint32 // in KiB
+	Argon2Iterations  uint32
+	Argon2Parallelism uint8
+	Argon2SaltLength  uint32
+	Argon2KeyLength   uint32
+}
+
+// DefaultPasswordHashParams follows the OWASP recommendation for Argon2id.
+var DefaultPasswordHashParams = PasswordHashParams{
+	Algorithm:         HashArgon2id,
+	BcryptCost:        bcrypt.DefaultCost,
+	Argon2Memory:      19 * 1024,
+	Argon2Iterations:  2,
+	Argon2Parallelism: 1,
+	Argon2SaltLength:  16,
+	Argon2KeyLength:   32,
+}
+
+var defaultPasswordHasher = &PasswordHasher{params: DefaultPasswordHashParams}
+
+// PasswordHasher hashes passwords in the PHC string format, e.g.
+// $argon2id$v=19$m=19456,t=2,p=1$<salt>$<hash>. bcrypt hashes keep their own $2a$ format.
+type PasswordHasher struct {
+	params PasswordHashParams
+}
+
+// NewPasswordHasher creates a password hasher from the configuration.
+// The parameters that are not configured take their default value.
+func NewPasswordHasher(config Config) (*PasswordHasher, error) {
+	params := DefaultPasswordHashParams
+	if config.PasswordHashAlgorithm != "" {
+		params.Algorithm = config.PasswordHashAlgorithm
+	}
+	if config.PasswordBcryptCost != 0 {
+		params.BcryptCost = config.PasswordBcryptCost
+	}
+	if config.PasswordArgon2Memory != 0 {
+		params.Argon2Memory = config.PasswordArgon2Memory
+	}
+	if config.PasswordArgon2Iterations != 0 {
+		params.Argon2Iterations = config.PasswordArgon2Iterations
+	}
+	if config.PasswordArgon2Parallelism != 0 {
+		params.Argon2Parallelism = config.PasswordArgon2Parallelism
+	}
+
+	switch params.Algorithm {
+	case HashBcrypt:
+		if params.BcryptCost < bcrypt.MinCost || params.BcryptCost > bcrypt.MaxCost {
+			return nil, fmt.Errorf("invalid bcrypt cost: must be between %d and %d", bcrypt.MinCost, bcrypt.MaxCost)
+		}
+	case HashArgon2id:
+	default:
+		return nil, fmt.Errorf("unsupported password hash algorithm %q", params.Algorithm)
+	}
+
+	return &PasswordHasher{params: params}, nil
+}
+
+// Hash returns the hash of the password with the configured algorithm.
+func (hasher *PasswordHasher) Hash(password string) (string, error) {
+	params := hasher.params
+
+	if params.Algorithm == HashBcrypt {
+		hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), params.BcryptCost)
+		if err != nil {
+			return "", fmt.Errorf("error hashing password: %v", err)
+		}
+		return string(hashedPassword), nil
+	}
+
+	salt := make([]byte, params.Argon2SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("error generating salt: %v", err)
 	}
-	return string(hashedPassword), nil
+
+	key := argon2.IDKey([]byte(password), salt, params.Argon2Iterations, params.Argon2Memory, params.Argon2Parallelism, params.Argon2KeyLength)
+	return fmt.Sprintf(
+		"$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version,
+		params.Argon2Memory,
+		params.Argon2Iterations,
+		params.Argon2Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// NeedsRehash reports whether the hash was created with another algorithm or other parameters
+// than the configured ones, so it should be replaced the next time the password is known.
+func (hasher *PasswordHasher) NeedsRehash(hash string) bool {
+	switch hashAlgorithm(hash) {
+	case HashBcrypt:
+		if hasher.params.Algorithm != HashBcrypt {
+			return true
+		}
+		cost, err := bcrypt.Cost([]byte(hash))
+		return err != nil || cost != hasher.params.BcryptCost
+	case HashArgon2id:
+		if hasher.params.Algorithm != HashArgon2id {
+			return true
+		}
+		params, _, key, err := decodeArgon2idHash(hash)
+		return err != nil ||
+			params.Argon2Memory != hasher.params.Argon2Memory ||
+			params.Argon2Iterations != hasher.params.Argon2Iterations ||
+			params.Argon2Parallelism != hasher.params.Argon2Parallelism ||
+			uint32(len(key)) != hasher.params.Argon2KeyLength
+	default:
+		return true
+	}
+}
+
+// HashPassword returns the hash of the password with the default parameters.
+func HashPassword(password string) (string, error) {
+	return defaultPasswordHasher.Hash(password)
 }
 
+// CheckPassword checks the password against a hash of any supported algorithm.
 func CheckPassword(password, hash string) error {
-	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+	switch hashAlgorithm(hash) {
+	case HashBcrypt:
+		return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+	case HashArgon2id:
+		params, salt, key, err := decodeArgon2idHash(hash)
+		if err != nil {
+			return err
+		}
+
+		otherKey := argon2.IDKey([]byte(password), salt, params.Argon2Iterations, params.Argon2Memory, params.Argon2Parallelism, uint32(len(key)))
+		if subtle.ConstantTimeCompare(key, otherKey) != 1 {
+			return ErrMismatchedHashAndPassword
+		}
+		return nil
+	default:
+		return ErrUnsupportedHash
+	}
+}
+
+func hashAlgorithm(hash string) string {
+	switch {
+	case strings.HasPrefix(hash, "$2a$"), strings.HasPrefix(hash, "$2b$"), strings.HasPrefix(hash, "$2y$"):
+		return HashBcrypt
+	case strings.HasPrefix(hash, "$argon2id$"):
+		return HashArgon2id
+	default:
+		return ""
+	}
+}
+
+func decodeArgon2idHash(hash string) (params PasswordHashParams, salt, key []byte, err error) {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 {
+		return params, nil, nil, ErrUnsupportedHash
+	}
+
+	var version int
+	if _, err = fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return params, nil, nil, ErrUnsupportedHash
+	}
+
+	_, err = fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Argon2Memory, &params.Argon2Iterations, &params.Argon2Parallelism)
+	if err != nil {
+		return params, nil, nil, ErrUnsupportedHash
+	}
+
+	salt, err = base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return params, nil, nil, ErrUnsupportedHash
+	}
+
+	key, err = base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return params, nil, nil, ErrUnsupportedHash
+	}
+
+	params.Algorithm = HashArgon2id
+	return params, salt, key, nil
 }
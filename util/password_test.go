@@ -28,3 +28,65 @@ func TestPass(t *testing.T) {
 	require.NotEqual(t, hashedPassword1, hashedPassword2)
 
 }
+
+func TestPasswordHasher(t *testing.T) {
+	password := RandomString(8)
+
+	for _, algorithm := range []string{HashBcrypt, HashArgon2id} {
+		hasher, err := NewPasswordHasher(Config{PasswordHashAlgorithm: algorithm})
+		require.NoError(t, err)
+
+		hashedPassword, err := hasher.Hash(password)
+		require.NoError(t, err)
+		require.Equal(t, algorithm, hashAlgorithm(hashedPassword))
+		require.False(t, hasher.NeedsRehash(hashedPassword))
+
+		require.NoError(t, CheckPassword(password, hashedPassword))
+		require.ErrorIs(t, CheckPassword(RandomString(9), hashedPassword), ErrMismatchedHashAndPassword)
+	}
+}
+
+func TestArgon2idHashFormat(t *testing.T) {
+	hashedPassword, err := HashPassword(RandomString(8))
+	require.NoError(t, err)
+	require.Regexp(t, `^\$argon2id\$v=19\$m=19456,t=2,p=1\$[A-Za-z0-9+/]{22}\$[A-Za-z0-9+/]{43}$`, hashedPassword)
+}
+
+func TestNeedsRehash(t *testing.T) {
+	password := RandomString(8)
+
+	bcryptHasher, err := NewPasswordHasher(Config{PasswordHashAlgorithm: HashBcrypt, PasswordBcryptCost: bcrypt.MinCost})
+	require.NoError(t, err)
+	bcryptHash, err := bcryptHasher.Hash(password)
+	require.NoError(t, err)
+
+	argon2idHasher, err := NewPasswordHasher(Config{PasswordHashAlgorithm: HashArgon2id})
+	require.NoError(t, err)
+	argon2idHash, err := argon2idHasher.Hash(password)
+	require.NoError(t, err)
+
+	strongerHasher, err := NewPasswordHasher(Config{PasswordHashAlgorithm: HashArgon2id, PasswordArgon2Iterations: 3})
+	require.NoError(t, err)
+
+	require.True(t, argon2idHasher.NeedsRehash(bcryptHash))
+	require.True(t, bcryptHasher.NeedsRehash(argon2idHash))
+	require.True(t, strongerHasher.NeedsRehash(argon2idHash))
+	require.True(t, argon2idHasher.NeedsRehash("plaintext"))
+
+	// Hashes with other parameters are still verified with their own parameters.
+	require.NoError(t, CheckPassword(password, bcryptHash))
+	require.NoError(t, CheckPassword(password, argon2idHash))
+}
+
+func TestNewPasswordHasherInvalid(t *testing.T) {
+	_, err := NewPasswordHasher(Config{PasswordHashAlgorithm: "md5"})
+	require.Error(t, err)
+
+	_, err = NewPasswordHasher(Config{PasswordHashAlgorithm: HashBcrypt, PasswordBcryptCost: 100})
+	require.Error(t, err)
+}
+
+func TestCheckPasswordUnsupportedHash(t *testing.T) {
+	require.ErrorIs(t, CheckPassword("secret", "secret"), ErrUnsupportedHash)
+	require.ErrorIs(t, CheckPassword("secret", "$argon2id$v=19$m=1,t=1,p=1$!!$!!"), ErrUnsupportedHash)
+}
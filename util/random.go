@@ -45,7 +45,19 @@ func RandomCurrency() string {
 	return currencies[rand.Intn(len(currencies))]
 }
 
+// RandomAccountType generates a random account type
+func RandomAccountType() string {
+	accountTypes := []string{CheckingAccount, SavingsAccount, BusinessAccount}
+	return accountTypes[rand.Intn(len(accountTypes))]
+}
+
 // RandomEmail generates a random email
 func RandomEmail() string {
 	return RandomString(6) + "@gmail.com"
 }
+
+// RandomAccountNumber generates a random valid account number
+func RandomAccountNumber() string {
+	number, _ := NewAccountNumber()
+	return number
+}
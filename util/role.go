@@ -0,0 +1,16 @@
+package util
+
+// Roles of the users
+const (
+	DepositorRole = "depositor"
+	AdminRole     = "admin"
+)
+
+// IsSupportedRole returns true if the role is supported
+func IsSupportedRole(role string) bool {
+	switch role {
+	case DepositorRole, AdminRole:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,30 @@
+package util
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+)
+
+// GenerateSecret returns a random secret of size bytes, hex encoded after the prefix.
+func GenerateSecret(prefix string, size int) (string, error) {
+	b := make([]byte, size)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate secret: %w", err)
+	}
+	return prefix + hex.EncodeToString(b), nil
+}
+
+// HashSecret returns the SHA-256 hash of a generated secret.
+// Unlike passwords, random secrets have enough entropy not to need a slow hash.
+func HashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// CheckSecret reports whether the secret matches the hash, in constant time.
+func CheckSecret(secret, hash string) bool {
+	return subtle.ConstantTimeCompare([]byte(HashSecret(secret)), []byte(hash)) == 1
+}
@@ -0,0 +1,23 @@
+package util
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecret(t *testing.T) {
+	secret1, err := GenerateSecret("sbcs_", 32)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(secret1, "sbcs_"))
+	require.Len(t, secret1, len("sbcs_")+64)
+
+	secret2, err := GenerateSecret("sbcs_", 32)
+	require.NoError(t, err)
+	require.NotEqual(t, secret1, secret2)
+
+	hash := HashSecret(secret1)
+	require.True(t, CheckSecret(secret1, hash))
+	require.False(t, CheckSecret(secret2, hash))
+}
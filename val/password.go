@@ -0,0 +1,122 @@
+package val
+
+import (
+	"bufio"
+	_ "embed"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/mativm02/bank_system/util"
+)
+
+const (
+	defaultPasswordMinLength = 6
+	passwordMaxLength        = 100
+)
+
+// ErrPasswordReused is returned when a new password matches one of the recent passwords of the user.
+var ErrPasswordReused = errors.New("must not be one of your recent passwords")
+
+//go:embed common_passwords.txt
+var commonPasswordsFile string
+
+var commonPasswords = loadCommonPasswords(commonPasswordsFile)
+
+func loadCommonPasswords(file string) map[string]bool {
+	passwords := make(map[string]bool)
+
+	scanner := bufio.NewScanner(strings.NewReader(file))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		passwords[strings.ToLower(line)] = true
+	}
+	return passwords
+}
+
+// PasswordPolicy contains the rules that a new password must follow.
+// Passwords set before a rule was enabled are still accepted at login.
+type PasswordPolicy struct {
+	MinLength     int
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+	// HistorySize is how many recent passwords, including the current one, cannot be reused.
+	HistorySize int32
+}
+
+// NewPasswordPolicy creates the password policy from the configuration.
+func NewPasswordPolicy(config util.Config) PasswordPolicy {
+	policy := PasswordPolicy{
+		MinLength:     config.PasswordMinLength,
+		RequireUpper:  config.PasswordRequireUpper,
+		RequireLower:  config.PasswordRequireLower,
+		RequireDigit:  config.PasswordRequireDigit,
+		RequireSymbol: config.PasswordRequireSymbol,
+		HistorySize:   config.PasswordHistorySize,
+	}
+	if policy.MinLength <= 0 {
+		policy.MinLength = defaultPasswordMinLength
+	}
+	return policy
+}
+
+// Validate returns one error for every rule that the password of the user breaks.
+func (policy PasswordPolicy) Validate(password, username string) (violations []error) {
+	if err := ValidateString(password, policy.MinLength, passwordMaxLength); err != nil {
+		violations = append(violations, err)
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			hasSymbol = true
+		}
+	}
+
+	if policy.RequireUpper && !hasUpper {
+		violations = append(violations, fmt.Errorf("must contain an uppercase letter"))
+	}
+	if policy.RequireLower && !hasLower {
+		violations = append(violations, fmt.Errorf("must contain a lowercase letter"))
+	}
+	if policy.RequireDigit && !hasDigit {
+		violations = append(violations, fmt.Errorf("must contain a digit"))
+	}
+	if policy.RequireSymbol && !hasSymbol {
+		violations = append(violations, fmt.Errorf("must contain a symbol"))
+	}
+
+	lower := strings.ToLower(password)
+	if commonPasswords[lower] {
+		violations = append(violations, fmt.Errorf("must not be a commonly used password"))
+	}
+	if username != "" && strings.Contains(lower, strings.ToLower(username)) {
+		violations = append(violations, fmt.Errorf("must not contain the username"))
+	}
+
+	return
+}
+
+// CheckHistory returns ErrPasswordReused if the password matches one of the hashes
+// of the recent passwords of the user.
+func (policy PasswordPolicy) CheckHistory(password string, hashes []string) error {
+	for _, hash := range hashes {
+		if err := util.CheckPassword(password, hash); err == nil {
+			return ErrPasswordReused
+		}
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package val
+
+import (
+	"testing"
+
+	"github.com/mativm02/bank_system/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPasswordPolicyValidate(t *testing.T) {
+	policy := PasswordPolicy{
+		MinLength:     8,
+		RequireUpper:  true,
+		RequireLower:  true,
+		RequireDigit:  true,
+		RequireSymbol: true,
+	}
+
+	testCases := []struct {
+		name       string
+		password   string
+		violations []string
+	}{
+		{
+			name:     "OK",
+			password: "Correct-Horse-9",
+		},
+		{
+			name:     "TooShort",
+			password: "Ab1!",
+			violations: []string{
+				"must contain from 8 to 100 characters",
+			},
+		},
+		{
+			name:     "MissingClasses",
+			password: "abcdefghij",
+			violations: []string{
+				"must contain an uppercase letter",
+				"must contain a digit",
+				"must contain a symbol",
+			},
+		},
+		{
+			name:     "CommonPassword",
+			password: "Password123",
+			violations: []string{
+				"must contain a symbol",
+				"must not be a commonly used password",
+			},
+		},
+		{
+			name:     "ContainsUsername",
+			password: "My-JohnDoe-9",
+			violations: []string{
+				"must not contain the username",
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			var messages []string
+			for _, err := range policy.Validate(tc.password, "johndoe") {
+				messages = append(messages, err.Error())
+			}
+			require.Equal(t, tc.violations, messages)
+		})
+	}
+}
+
+func TestNewPasswordPolicy(t *testing.T) {
+	policy := NewPasswordPolicy(util.Config{PasswordHistorySize: 5})
+	require.Equal(t, defaultPasswordMinLength, policy.MinLength)
+	require.Equal(t, int32(5), policy.HistorySize)
+
+	policy = NewPasswordPolicy(util.Config{PasswordMinLength: 12, PasswordRequireDigit: true})
+	require.Equal(t, 12, policy.MinLength)
+	require.True(t, policy.RequireDigit)
+}
+
+func TestPasswordPolicyCheckHistory(t *testing.T) {
+	policy := PasswordPolicy{HistorySize: 2}
+
+	password := util.RandomString(8)
+	hashedPassword, err := util.HashPassword(password)
+	require.NoError(t, err)
+
+	otherPassword, err := util.HashPassword(util.RandomString(8))
+	require.NoError(t, err)
+
+	require.ErrorIs(t, policy.CheckHistory(password, []string{otherPassword, hashedPassword}), ErrPasswordReused)
+	require.NoError(t, policy.CheckHistory(password, []string{otherPassword}))
+	require.NoError(t, policy.CheckHistory(password, nil))
+}
@@ -2,8 +2,14 @@ package val
 
 import (
 	"fmt"
+	"net"
 	"net/mail"
+	"net/url"
 	"regexp"
+
+	"github.com/mativm02/bank_system/token"
+	"github.com/mativm02/bank_system/util"
+	"github.com/mativm02/bank_system/webhook"
 )
 
 var (
@@ -41,6 +47,8 @@ func ValidateEmail(value string) error {
 	return nil
 }
 
+// ValidatePassword only checks the length of an existing password.
+// New passwords must follow the PasswordPolicy.
 func ValidatePassword(value string) error {
 	return ValidateString(value, 6, 100)
 }
@@ -56,6 +64,10 @@ func ValidateFullName(value string) error {
 	return nil
 }
 
+func ValidateNickname(value string) error {
+	return ValidateString(value, 1, 50)
+}
+
 func ValidateEmailID(value int64) error {
 	if value <= 0 {
 		return fmt.Errorf("must be a positive number")
@@ -63,6 +75,112 @@ func ValidateEmailID(value int64) error {
 	return nil
 }
 
+func ValidateAccountID(value int64) error {
+	if value <= 0 {
+		return fmt.Errorf("must be a positive number")
+	}
+	return nil
+}
+
+func ValidateCurrency(value string) error {
+	if !util.IsSupportedCurrency(value) {
+		return fmt.Errorf("must be a supported currency")
+	}
+	return nil
+}
+
+func ValidateAccountType(value string) error {
+	if !util.IsSupportedAccountType(value) {
+		return fmt.Errorf("must be checking, savings or business")
+	}
+	return nil
+}
+
+func ValidateAmount(value int64) error {
+	if value <= 0 {
+		return fmt.Errorf("must be a positive number")
+	}
+	return nil
+}
+
+// ValidateAnnualRateBps checks an annual interest rate in basis points, up to 100%.
+func ValidateAnnualRateBps(value int64) error {
+	if value < 0 || value > 10000 {
+		return fmt.Errorf("must be from 0 to 10000 basis points")
+	}
+	return nil
+}
+
+// ValidateSpendingLimit checks a spending limit, where 0 means no limit.
+func ValidateSpendingLimit(value int64) error {
+	if value < 0 {
+		return fmt.Errorf("must not be negative")
+	}
+	return nil
+}
+
+// ValidateAccountNumber checks the format and the check digits of a normalized account number,
+// which catches the mistyped digits and almost all the swapped ones.
+func ValidateAccountNumber(value string) error {
+	if !util.IsValidAccountNumber(value) {
+		return fmt.Errorf("must be a valid account number")
+	}
+	return nil
+}
+
+func ValidatePageID(value int32) error {
+	if value < 1 {
+		return fmt.Errorf("must be greater than or equal to 1")
+	}
+	return nil
+}
+
+func ValidatePageSize(value int32) error {
+	if value < 5 || value > 10 {
+		return fmt.Errorf("must be from 5 to 10")
+	}
+	return nil
+}
+
+// ValidateWebhookURL checks that the URL is https and does not name the local machine or an address
+// that is not public. The address of a hostname is checked again when the webhooks are delivered.
+func ValidateWebhookURL(value string) error {
+	if err := ValidateString(value, 10, 2048); err != nil {
+		return err
+	}
+
+	u, err := url.ParseRequestURI(value)
+	if err != nil || u.Hostname() == "" || u.Scheme != "https" {
+		return fmt.Errorf("must be an absolute https URL")
+	}
+
+	if webhook.IsLocalHostname(u.Hostname()) {
+		return fmt.Errorf("must not point to the local machine")
+	}
+	if ip := net.ParseIP(u.Hostname()); ip != nil && !webhook.IsPublicIP(ip) {
+		return fmt.Errorf("must not point to a private address")
+	}
+	return nil
+}
+
+func ValidateLocale(value string) error {
+	if !util.IsSupportedLocale(value) {
+		return fmt.Errorf("must be a supported locale")
+	}
+	return nil
+}
+
 func ValidateSecretCode(value string) error {
 	return ValidateString(value, 32, 128)
 }
+
+func ValidateClientID(value string) error {
+	return ValidateString(value, 4, 64)
+}
+
+func ValidateScope(value string) error {
+	if !token.IsSupportedScope(value) {
+		return fmt.Errorf("unsupported scope %q", value)
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package val
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateWebhookURL(t *testing.T) {
+	require.NoError(t, ValidateWebhookURL("https://example.com/hooks"))
+	require.NoError(t, ValidateWebhookURL("https://93.184.216.34/hooks"))
+
+	for _, value := range []string{
+		"http://example.com/hooks",
+		"ftp://example.com/hooks",
+		"https:///hooks",
+		"https://localhost/hooks",
+		"https://api.localhost:8443/hooks",
+		"https://127.0.0.1/hooks",
+		"https://10.0.0.5/hooks",
+		"https://169.254.169.254/latest/meta-data",
+		"https://[::1]/hooks",
+		"https://0.0.0.0/hooks",
+	} {
+		require.Error(t, ValidateWebhookURL(value), value)
+	}
+}
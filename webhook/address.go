@@ -0,0 +1,71 @@
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"syscall"
+)
+
+// ErrForbiddenAddress is returned when a delivery would connect to an address that is not public,
+// which would let the subscribers reach the internal services of the bank.
+var ErrForbiddenAddress = errors.New("webhook address is not public")
+
+// nonPublicNetworks are the reserved ranges not covered by the net.IP methods.
+var nonPublicNetworks = parseCIDRs(
+	"0.0.0.0/8",     // this network
+	"100.64.0.0/10", // carrier-grade NAT
+	"198.18.0.0/15", // benchmarking
+	"240.0.0.0/4",   // reserved, including broadcast
+	"64:ff9b::/96",  // NAT64, which can map to any IPv4 address
+)
+
+func parseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, len(cidrs))
+	for i, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks[i] = network
+	}
+	return networks
+}
+
+// IsPublicIP reports whether the webhooks can be delivered to the IP address. Loopback, private,
+// link-local (including the cloud metadata endpoint 169.254.169.254), multicast and unspecified
+// addresses are not public.
+func IsPublicIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() {
+		return false
+	}
+	for _, network := range nonPublicNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsLocalHostname reports whether the hostname always names the local machine.
+func IsLocalHostname(hostname string) bool {
+	hostname = strings.ToLower(strings.TrimSuffix(hostname, "."))
+	return hostname == "localhost" || strings.HasSuffix(hostname, ".localhost")
+}
+
+// dialControl refuses the connections to addresses that are not public. It runs after the hostname
+// is resolved, for every connection and redirect, so a hostname cannot be rebound to an internal
+// address after the URL was validated.
+func dialControl(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, address)
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !IsPublicIP(ip) {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, host)
+	}
+	return nil
+}
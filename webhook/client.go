@@ -0,0 +1,79 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Delivery is a signed request sent to a subscriber endpoint.
+type Delivery struct {
+	ID        int64
+	URL       string
+	Secret    string
+	EventType string
+	Body      []byte
+}
+
+// Client posts deliveries to the subscriber endpoints.
+type Client struct {
+	httpClient *http.Client
+}
+
+// NewClient creates a client that only connects to public addresses, unless allowPrivateNetworks
+// is set, e.g. to deliver to a receiver on the local machine in the tests.
+// The client does not use the proxy of the environment and does not follow redirects.
+func NewClient(timeout time.Duration, allowPrivateNetworks bool) *Client {
+	dialer := &net.Dialer{Timeout: timeout}
+	if !allowPrivateNetworks {
+		dialer.Control = dialControl
+	}
+
+	return &Client{
+		httpClient: &http.Client{
+			Timeout: timeout,
+			Transport: &http.Transport{
+				DialContext:         dialer.DialContext,
+				TLSHandshakeTimeout: timeout,
+				MaxIdleConns:        100,
+				IdleConnTimeout:     90 * time.Second,
+			},
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// Deliver posts the delivery and returns the response status code.
+// Any status outside of the 2xx range is reported as an error. The error never includes
+// the response body, as it is shown to the subscriber.
+func (client *Client) Deliver(ctx context.Context, delivery Delivery) (int, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	timestamp := time.Now().Unix()
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(SignatureHeader, Sign(delivery.Secret, timestamp, delivery.Body))
+	request.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	request.Header.Set(EventTypeHeader, delivery.EventType)
+	request.Header.Set(DeliveryIDHeader, strconv.FormatInt(delivery.ID, 10))
+
+	response, err := client.httpClient.Do(request)
+	if err != nil {
+		return 0, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return response.StatusCode, fmt.Errorf("unexpected status %d", response.StatusCode)
+	}
+
+	return response.StatusCode, nil
+}
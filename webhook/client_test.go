@@ -0,0 +1,125 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/mativm02/bank_system/util"
+	"github.com/stretchr/testify/require"
+)
+
+func randomDelivery(t *testing.T, url string) Delivery {
+	secret, err := GenerateSecret()
+	require.NoError(t, err)
+
+	return Delivery{
+		ID:        util.RandomInt(1, 1000),
+		URL:       url,
+		Secret:    secret,
+		EventType: "transfer.completed",
+		Body:      []byte(`{"id":1,"type":"transfer.completed"}`),
+	}
+}
+
+func TestDeliver(t *testing.T) {
+	var delivery Delivery
+
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.Equal(t, delivery.Body, body)
+		require.Equal(t, delivery.EventType, r.Header.Get(EventTypeHeader))
+
+		err = Verify(delivery.Secret, r.Header.Get(SignatureHeader), r.Header.Get(TimestampHeader), body, time.Minute)
+		require.NoError(t, err)
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	delivery = randomDelivery(t, receiver.URL)
+
+	statusCode, err := NewClient(time.Second, true).Deliver(context.Background(), delivery)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNoContent, statusCode)
+}
+
+func TestDeliverUnexpectedStatus(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "boom", http.StatusInternalServerError)
+	}))
+	defer receiver.Close()
+
+	statusCode, err := NewClient(time.Second, true).Deliver(context.Background(), randomDelivery(t, receiver.URL))
+	require.Error(t, err)
+	require.NotContains(t, err.Error(), "boom")
+	require.Equal(t, http.StatusInternalServerError, statusCode)
+}
+
+func TestDeliverPrivateAddress(t *testing.T) {
+	var called bool
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer receiver.Close()
+
+	statusCode, err := NewClient(time.Second, false).Deliver(context.Background(), randomDelivery(t, receiver.URL))
+	require.ErrorIs(t, err, ErrForbiddenAddress)
+	require.Zero(t, statusCode)
+	require.False(t, called)
+}
+
+func TestDeliverNoRedirect(t *testing.T) {
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer target.Close()
+
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, target.URL, http.StatusTemporaryRedirect)
+	}))
+	defer receiver.Close()
+
+	statusCode, err := NewClient(time.Second, true).Deliver(context.Background(), randomDelivery(t, receiver.URL))
+	require.Error(t, err)
+	require.Equal(t, http.StatusTemporaryRedirect, statusCode)
+}
+
+func TestIsPublicIP(t *testing.T) {
+	for _, address := range []string{"8.8.8.8", "2001:4860:4860::8888"} {
+		require.True(t, IsPublicIP(net.ParseIP(address)), address)
+	}
+
+	for _, address := range []string{
+		"127.0.0.1", "10.1.2.3", "172.16.0.1", "192.168.1.1", "169.254.169.254", "0.0.0.0",
+		"100.64.0.1", "::1", "::", "fe80::1", "fd00::1", "::ffff:127.0.0.1", "::ffff:169.254.169.254",
+	} {
+		require.False(t, IsPublicIP(net.ParseIP(address)), address)
+	}
+}
+
+func TestVerifyInvalidSignature(t *testing.T) {
+	secret, err := GenerateSecret()
+	require.NoError(t, err)
+
+	body := []byte(`{"amount":10}`)
+	timestamp := time.Now().Unix()
+	signature := Sign(secret, timestamp, body)
+
+	err = Verify(secret, signature, "not-a-number", body, time.Minute)
+	require.Error(t, err)
+
+	tampered := []byte(`{"amount":1000}`)
+	err = Verify(secret, signature, strconv.FormatInt(timestamp, 10), tampered, time.Minute)
+	require.ErrorIs(t, err, ErrInvalidSignature)
+
+	old := time.Now().Add(-time.Hour).Unix()
+	err = Verify(secret, Sign(secret, old, body), strconv.FormatInt(old, 10), body, time.Minute)
+	require.ErrorIs(t, err, ErrExpiredTimestamp)
+}
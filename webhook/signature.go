@@ -0,0 +1,67 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+const (
+	SignatureHeader  = "X-Bank-Signature"
+	TimestampHeader  = "X-Bank-Timestamp"
+	EventTypeHeader  = "X-Bank-Event"
+	DeliveryIDHeader = "X-Bank-Delivery"
+
+	signatureVersion = "v1"
+	secretPrefix     = "whsec_"
+	secretSize       = 32
+)
+
+var (
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	ErrExpiredTimestamp = errors.New("webhook timestamp outside of tolerance")
+)
+
+// GenerateSecret returns a new random signing secret for a subscription.
+func GenerateSecret() (string, error) {
+	b := make([]byte, secretSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate secret: %w", err)
+	}
+	return secretPrefix + hex.EncodeToString(b), nil
+}
+
+// Sign returns the HMAC-SHA256 signature of the body.
+// The timestamp is part of the signed content, so a captured request cannot be replayed later.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signatureVersion + "=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the signature and timestamp headers of a received delivery.
+// Receivers can use it to authenticate the requests sent by the bank.
+func Verify(secret, signature, timestamp string, body []byte, tolerance time.Duration) error {
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid webhook timestamp: %w", err)
+	}
+
+	age := time.Since(time.Unix(ts, 0))
+	if age > tolerance || age < -tolerance {
+		return ErrExpiredTimestamp
+	}
+
+	expected := Sign(secret, ts, body)
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
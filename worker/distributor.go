@@ -2,23 +2,45 @@ package worker
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/hibiken/asynq"
+	"github.com/mativm02/bank_system/redact"
+	"github.com/rs/zerolog/log"
 )
 
 // Using interfaces to mock the dependencies of the worker package
 // also, it's easier to move to different message brokers.
 type TaskDistributor interface {
+	DistributeTask(ctx context.Context, taskType string, payload []byte, opts ...asynq.Option) error
 	DistributeTaskSendVerifyEmail(ctx context.Context, payload *PayloadSendVerifyEmail, opts ...asynq.Option) error
+	DistributeTaskSendEmailChangeNotice(ctx context.Context, payload *PayloadSendEmailChangeNotice, opts ...asynq.Option) error
+	DistributeTaskDispatchWebhooks(ctx context.Context, payload *PayloadDispatchWebhooks, opts ...asynq.Option) error
+	DistributeTaskDeliverWebhook(ctx context.Context, payload *PayloadDeliverWebhook, opts ...asynq.Option) error
+	DistributeTaskNotifyTransfer(ctx context.Context, payload *PayloadNotifyTransfer, opts ...asynq.Option) error
 }
 
 type RedisTaskDistributor struct {
-	client *asynq.Client
+	client   *asynq.Client
+	redactor *redact.Redactor
 }
 
-func NewRedisTaskDistributor(redisOpt asynq.RedisClientOpt) TaskDistributor {
+func NewRedisTaskDistributor(redisOpt asynq.RedisClientOpt, redactor *redact.Redactor) TaskDistributor {
 	client := asynq.NewClient(redisOpt)
 	return &RedisTaskDistributor{
-		client: client,
+		client:   client,
+		redactor: redactor,
 	}
 }
+
+// DistributeTask enqueues a task whose payload is already encoded.
+func (distributor *RedisTaskDistributor) DistributeTask(ctx context.Context, taskType string, payload []byte, opts ...asynq.Option) error {
+	task := asynq.NewTask(taskType, payload, opts...)
+	info, err := distributor.client.EnqueueContext(ctx, task)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+
+	log.Ctx(ctx).Info().Str("type", task.Type()).Bytes("payload", distributor.redactor.JSON(task.Payload())).Str("queue", info.Queue).Int("max_retry", info.MaxRetry).Msg("enqueued task")
+	return nil
+}
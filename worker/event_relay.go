@@ -0,0 +1,136 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	db "github.com/mativm02/bank_system/db/sqlc"
+	"github.com/mativm02/bank_system/event"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	eventBatchSize = 100
+	// eventLease is how long a relay has to publish a batch before the other relays claim it.
+	eventLease = time.Minute
+	// eventMaxAttempts is how many times an event can fail to be published before it is given up.
+	eventMaxAttempts = 20
+)
+
+// EventRelay periodically publishes the domain events recorded by the store transactions
+// and queues the webhook dispatch of every event and the notification of every transfer.
+// Delivery is at-least-once, so consumers should use the event ID to drop duplicates.
+type EventRelay struct {
+	store       db.Store
+	publisher   event.Publisher
+	distributor TaskDistributor
+	interval    time.Duration
+}
+
+func NewEventRelay(store db.Store, publisher event.Publisher, distributor TaskDistributor, interval time.Duration) *EventRelay {
+	return &EventRelay{
+		store:       store,
+		publisher:   publisher,
+		distributor: distributor,
+		interval:    interval,
+	}
+}
+
+// Start relays the unpublished events every interval until the context is canceled.
+func (relay *EventRelay) Start(ctx context.Context) {
+	ticker := time.NewTicker(relay.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := relay.RelayPending(ctx); err != nil {
+			log.Error().Err(err).Msg("failed to relay domain events")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RelayPending publishes one batch of unpublished events and returns how many were published.
+func (relay *EventRelay) RelayPending(ctx context.Context) (int, error) {
+	result, err := relay.store.RelayDomainEvents(ctx, db.RelayDomainEventsParams{
+		Limit:       eventBatchSize,
+		Lease:       eventLease,
+		MaxAttempts: eventMaxAttempts,
+		Publish: func(domainEvent db.DomainEvent) error {
+			events := []event.Event{domainEvent.ToEvent()}
+			if err := relay.publisher.Publish(ctx, events...); err != nil {
+				return err
+			}
+			if err := relay.dispatchWebhooks(ctx, events); err != nil {
+				return err
+			}
+			return relay.notifyTransfers(ctx, events)
+		},
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, e := range result.Failed {
+		msg := "failed to publish domain event"
+		if e.FailedAt.Valid {
+			msg = "gave up on domain event"
+		}
+		log.Error().Int64("id", e.ID).Str("type", e.EventType).Int32("attempts", e.Attempts).Str("error", e.LastError.String).Msg(msg)
+	}
+
+	return len(result.Published), nil
+}
+
+func (relay *EventRelay) dispatchWebhooks(ctx context.Context, events []event.Event) error {
+	for _, e := range events {
+		opts := []asynq.Option{
+			asynq.TaskID(fmt.Sprintf("dispatch-webhooks:%d", e.ID)),
+			asynq.MaxRetry(10),
+			asynq.Queue(QueueDefault),
+		}
+
+		err := relay.distributor.DistributeTaskDispatchWebhooks(ctx, &PayloadDispatchWebhooks{EventID: e.ID}, opts...)
+		if err != nil && !errors.Is(err, asynq.ErrTaskIDConflict) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (relay *EventRelay) notifyTransfers(ctx context.Context, events []event.Event) error {
+	for _, e := range events {
+		if e.Type != event.TypeTransferCompleted {
+			continue
+		}
+
+		payload, err := e.Decode()
+		if err != nil {
+			return err
+		}
+		transfer := payload.(*event.TransferCompleted)
+
+		// Each user is notified by their own task, so a failed email is retried alone.
+		for _, direction := range []string{TransferSent, TransferReceived} {
+			opts := []asynq.Option{
+				asynq.TaskID(fmt.Sprintf("notify-transfer:%d:%s", transfer.TransferID, direction)),
+				asynq.MaxRetry(10),
+				asynq.Queue(QueueDefault),
+			}
+
+			payload := &PayloadNotifyTransfer{TransferID: transfer.TransferID, Direction: direction}
+			err = relay.distributor.DistributeTaskNotifyTransfer(ctx, payload, opts...)
+			if err != nil && !errors.Is(err, asynq.ErrTaskIDConflict) {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package worker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hibiken/asynq"
+	db "github.com/mativm02/bank_system/db/sqlc"
+	"github.com/mativm02/bank_system/mail"
+	"github.com/mativm02/bank_system/redact"
+	"github.com/mativm02/bank_system/util"
+	"github.com/mativm02/bank_system/webhook"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestProcessor creates a processor without a Redis server, which keeps the emails in
+// a mail.MemorySender and whose webhook client can deliver to the httptest receivers
+// on the local machine.
+func newTestProcessor(t *testing.T, store db.Store, distributor TaskDistributor) *RedisTaskProcessor {
+	templates, err := mail.NewTemplates(util.EN)
+	require.NoError(t, err)
+
+	return &RedisTaskProcessor{
+		store:         store,
+		mailer:        mail.NewMemorySender(),
+		templates:     templates,
+		distributor:   distributor,
+		webhookClient: webhook.NewClient(time.Second, true),
+		redactor:      redact.NewRedactor(util.Config{}),
+	}
+}
+
+// fakeDistributor records the tasks instead of enqueuing them.
+type fakeDistributor struct {
+	TaskDistributor
+	deliverWebhooks []*PayloadDeliverWebhook
+}
+
+func (distributor *fakeDistributor) DistributeTaskDeliverWebhook(ctx context.Context, payload *PayloadDeliverWebhook, opts ...asynq.Option) error {
+	distributor.deliverWebhooks = append(distributor.deliverWebhooks, payload)
+	return nil
+}
@@ -0,0 +1,114 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	db "github.com/mativm02/bank_system/db/sqlc"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	outboxBatchSize = 100
+	// outboxLease is how long a relay has to publish a batch before the other relays claim it.
+	outboxLease = time.Minute
+	// outboxMaxAttempts is how many times a message can fail to be published before it is given up.
+	outboxMaxAttempts = 20
+)
+
+// NewOutboxMessage encodes a task so it can be stored in the outbox within a database transaction.
+// The task is enqueued by the OutboxRelay once the transaction is committed, with the request ID of ctx.
+func NewOutboxMessage(ctx context.Context, taskType string, payload interface{}, queue string, maxRetry int32, processIn time.Duration) (db.CreateOutboxMessageParams, error) {
+	withRequestID(ctx, payload)
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return db.CreateOutboxMessageParams{}, fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	return db.CreateOutboxMessageParams{
+		TaskType:  taskType,
+		Payload:   jsonPayload,
+		Queue:     queue,
+		MaxRetry:  maxRetry,
+		ProcessAt: time.Now().Add(processIn),
+	}, nil
+}
+
+// OutboxRelay periodically publishes the pending outbox messages through the TaskDistributor.
+// Delivery is at-least-once: a message may be enqueued again if the relay fails before recording it.
+type OutboxRelay struct {
+	store       db.Store
+	distributor TaskDistributor
+	interval    time.Duration
+}
+
+func NewOutboxRelay(store db.Store, distributor TaskDistributor, interval time.Duration) *OutboxRelay {
+	return &OutboxRelay{
+		store:       store,
+		distributor: distributor,
+		interval:    interval,
+	}
+}
+
+// Start relays the pending messages every interval until the context is canceled.
+func (relay *OutboxRelay) Start(ctx context.Context) {
+	ticker := time.NewTicker(relay.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := relay.RelayPending(ctx); err != nil {
+			log.Error().Err(err).Msg("failed to relay outbox messages")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RelayPending publishes one batch of pending messages and returns how many were published.
+func (relay *OutboxRelay) RelayPending(ctx context.Context) (int, error) {
+	result, err := relay.store.RelayOutbox(ctx, db.RelayOutboxParams{
+		Limit:       outboxBatchSize,
+		Lease:       outboxLease,
+		MaxAttempts: outboxMaxAttempts,
+		Publish: func(message db.OutboxMessage) error {
+			return relay.publish(ctx, message)
+		},
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, message := range result.Failed {
+		msg := "failed to publish outbox message"
+		if message.FailedAt.Valid {
+			msg = "gave up on outbox message"
+		}
+		log.Error().Int64("id", message.ID).Str("type", message.TaskType).Int32("attempts", message.Attempts).Str("error", message.LastError.String).Msg(msg)
+	}
+
+	return len(result.Published), nil
+}
+
+func (relay *OutboxRelay) publish(ctx context.Context, message db.OutboxMessage) error {
+	opts := []asynq.Option{
+		// The task ID lets Redis drop the duplicates produced by a relay that published but could not commit.
+		asynq.TaskID(fmt.Sprintf("outbox:%d", message.ID)),
+		asynq.Queue(message.Queue),
+		asynq.MaxRetry(int(message.MaxRetry)),
+		asynq.ProcessAt(message.ProcessAt),
+	}
+
+	err := relay.distributor.DistributeTask(ctx, message.TaskType, message.Payload, opts...)
+	if errors.Is(err, asynq.ErrTaskIDConflict) {
+		return nil
+	}
+	return err
+}
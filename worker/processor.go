@@ -2,10 +2,16 @@ package worker
 
 import (
 	"context"
+	"net/url"
+	"strings"
+	"time"
 
 	"github.com/hibiken/asynq"
 	db "github.com/mativm02/bank_system/db/sqlc"
 	"github.com/mativm02/bank_system/mail"
+	"github.com/mativm02/bank_system/redact"
+	"github.com/mativm02/bank_system/util"
+	"github.com/mativm02/bank_system/webhook"
 	"github.com/redis/go-redis/v9"
 	"github.com/rs/zerolog/log"
 )
@@ -18,38 +24,77 @@ const (
 type TaskProcessor interface {
 	Start() error
 	ProcessTaskSendVerifyEmail(ctx context.Context, task *asynq.Task) error
+	ProcessTaskSendEmailChangeNotice(ctx context.Context, task *asynq.Task) error
+	ProcessTaskDispatchWebhooks(ctx context.Context, task *asynq.Task) error
+	ProcessTaskDeliverWebhook(ctx context.Context, task *asynq.Task) error
+	ProcessTaskNotifyTransfer(ctx context.Context, task *asynq.Task) error
+	ProcessTaskAccrueInterest(ctx context.Context, task *asynq.Task) error
+	ProcessTaskPostInterest(ctx context.Context, task *asynq.Task) error
 }
 
 type RedisTaskProcessor struct {
-	server *asynq.Server
-	store  db.Store
-	mailer mail.EmailSender
+	server        *asynq.Server
+	store         db.Store
+	mailer        mail.EmailSender
+	templates     *mail.Templates
+	distributor   TaskDistributor
+	webhookClient *webhook.Client
+	redactor      *redact.Redactor
+	config        util.Config
 }
 
-func NewRedisTaskProcessor(redisOpt asynq.RedisClientOpt, store db.Store, mailer mail.EmailSender) TaskProcessor {
+func NewRedisTaskProcessor(config util.Config, redisOpt asynq.RedisClientOpt, store db.Store, mailer mail.EmailSender, templates *mail.Templates, distributor TaskDistributor) TaskProcessor {
 	logger := NewLogger()
 	redis.SetLogger(logger)
+	redactor := redact.NewRedactor(config)
 	server := asynq.NewServer(redisOpt, asynq.Config{
 		Queues: map[string]int{
 			QueueCritical: 10,
 			QueueDefault:  5,
 		},
 		ErrorHandler: asynq.ErrorHandlerFunc(func(ctx context.Context, task *asynq.Task, err error) {
-			log.Error().Err(err).Str("type", task.Type()).Bytes("payload", task.Payload()).Msg("process task failed")
+			log.Ctx(taskContext(ctx, task)).Error().Err(err).Str("type", task.Type()).Bytes("payload", redactor.JSON(task.Payload())).Msg("process task failed")
 		}),
+		RetryDelayFunc: func(n int, err error, task *asynq.Task) time.Duration {
+			if task.Type() == TaskDeliverWebhook {
+				return webhookRetryDelay(n)
+			}
+			return asynq.DefaultRetryDelayFunc(n, err, task)
+		},
 		Logger: logger,
 	})
 	return &RedisTaskProcessor{
-		server: server,
-		store:  store,
-		mailer: mailer,
+		server:        server,
+		store:         store,
+		mailer:        mailer,
+		templates:     templates,
+		distributor:   distributor,
+		webhookClient: webhook.NewClient(webhookClientTimeout, false),
+		redactor:      redactor,
+		config:        config,
 	}
 
 }
 
 func (processor *RedisTaskProcessor) Start() error {
 	mux := asynq.NewServeMux()
+	mux.Use(requestIDMiddleware)
 	mux.HandleFunc(TaskSendVerifyEmail, processor.ProcessTaskSendVerifyEmail)
+	mux.HandleFunc(TaskSendEmailChangeNotice, processor.ProcessTaskSendEmailChangeNotice)
+	mux.HandleFunc(TaskDispatchWebhooks, processor.ProcessTaskDispatchWebhooks)
+	mux.HandleFunc(TaskDeliverWebhook, processor.ProcessTaskDeliverWebhook)
+	mux.HandleFunc(TaskNotifyTransfer, processor.ProcessTaskNotifyTransfer)
+	mux.HandleFunc(TaskAccrueInterest, processor.ProcessTaskAccrueInterest)
+	mux.HandleFunc(TaskPostInterest, processor.ProcessTaskPostInterest)
 
 	return processor.server.Start(mux)
 }
+
+// publicURL builds a link to the public API for the emails sent to users.
+func (processor *RedisTaskProcessor) publicURL(path string, query url.Values) string {
+	link := strings.TrimSuffix(processor.config.PublicBaseURL, "/") + path
+	if len(query) > 0 {
+		link += "?" + query.Encode()
+	}
+	return link
+}
@@ -0,0 +1,51 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hibiken/asynq"
+	"github.com/mativm02/bank_system/requestid"
+)
+
+// TaskMetadata is embedded in the task payloads to correlate the logs of the worker
+// with the request that produced the task.
+type TaskMetadata struct {
+	RequestID string `json:"request_id,omitempty"`
+}
+
+func (metadata *TaskMetadata) setRequestID(id string) {
+	metadata.RequestID = id
+}
+
+type taskMetadataSetter interface {
+	setRequestID(id string)
+}
+
+// withRequestID copies the request ID of the context into the payload.
+func withRequestID(ctx context.Context, payload interface{}) {
+	setter, ok := payload.(taskMetadataSetter)
+	if !ok {
+		return
+	}
+
+	if id := requestid.FromContext(ctx); id != "" {
+		setter.setRequestID(id)
+	}
+}
+
+// taskContext returns a context carrying the request ID of the task payload, if any.
+func taskContext(ctx context.Context, task *asynq.Task) context.Context {
+	var metadata TaskMetadata
+	if err := json.Unmarshal(task.Payload(), &metadata); err != nil || !requestid.Valid(metadata.RequestID) {
+		return ctx
+	}
+	return requestid.NewContext(ctx, metadata.RequestID)
+}
+
+// requestIDMiddleware restores the request ID of the task in the context of its handler.
+func requestIDMiddleware(handler asynq.Handler) asynq.Handler {
+	return asynq.HandlerFunc(func(ctx context.Context, task *asynq.Task) error {
+		return handler.ProcessTask(taskContext(ctx, task), task)
+	})
+}
@@ -0,0 +1,38 @@
+package worker
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/mativm02/bank_system/util"
+)
+
+// NewScheduler creates a scheduler that enqueues the periodic tasks following the cron specs of the config.
+// Several schedulers may run at the same time: a task is only enqueued once per period.
+func NewScheduler(config util.Config, redisOpt asynq.RedisClientOpt) (*asynq.Scheduler, error) {
+	scheduler := asynq.NewScheduler(redisOpt, &asynq.SchedulerOpts{
+		Location: time.UTC,
+		Logger:   NewLogger(),
+	})
+
+	periodicTasks := []struct {
+		cronspec string
+		taskType string
+		// unique is shorter than the period, so a task is enqueued once per period by all the schedulers.
+		unique time.Duration
+	}{
+		{config.InterestAccrualSchedule, TaskAccrueInterest, time.Hour},
+		{config.InterestPostingSchedule, TaskPostInterest, time.Hour},
+	}
+
+	for _, periodicTask := range periodicTasks {
+		task := asynq.NewTask(periodicTask.taskType, []byte("{}"))
+		_, err := scheduler.Register(periodicTask.cronspec, task, asynq.Queue(QueueDefault), asynq.Unique(periodicTask.unique))
+		if err != nil {
+			return nil, fmt.Errorf("cannot schedule %s: %w", periodicTask.taskType, err)
+		}
+	}
+
+	return scheduler, nil
+}
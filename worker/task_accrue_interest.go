@@ -0,0 +1,69 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	db "github.com/mativm02/bank_system/db/sqlc"
+	"github.com/mativm02/bank_system/util"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskAccrueInterest = "task:accrue_interest"
+
+// dateLayout is the layout of the dates in the task payloads.
+const dateLayout = "2006-01-02"
+
+type PayloadAccrueInterest struct {
+	TaskMetadata
+
+	// Date is the day that accrues interest. The scheduled tasks leave it empty to accrue the previous day.
+	Date string `json:"date,omitempty"`
+}
+
+// ProcessTaskAccrueInterest records the interest of the day for every account with an interest product.
+// The accruals are unique per account and day, so a retried task only accrues the accounts it missed.
+func (processor *RedisTaskProcessor) ProcessTaskAccrueInterest(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadAccrueInterest
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	date := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -1)
+	if payload.Date != "" {
+		var err error
+		date, err = time.Parse(dateLayout, payload.Date)
+		if err != nil {
+			return fmt.Errorf("invalid date: %w", asynq.SkipRetry)
+		}
+	}
+
+	// The accruals use the balances at the end of the day, so a late or retried task accrues
+	// the same amounts as a task that ran on time.
+	accounts, err := processor.store.ListInterestBearingAccounts(ctx, date.AddDate(0, 0, 1))
+	if err != nil {
+		return fmt.Errorf("failed to list interest bearing accounts: %w", err)
+	}
+
+	var accrued int64
+	for _, account := range accounts {
+		rows, err := processor.store.CreateInterestAccrual(ctx, db.CreateInterestAccrualParams{
+			AccountID:     account.AccountID,
+			ProductID:     account.ProductID,
+			AccrualDate:   date,
+			Balance:       account.Balance,
+			AnnualRateBps: account.AnnualRateBps,
+			AmountMicros:  util.DailyInterest(account.Balance, account.AnnualRateBps),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create interest accrual: %w", err)
+		}
+		accrued += rows
+	}
+
+	log.Ctx(ctx).Info().Str("type", task.Type()).Str("date", date.Format(dateLayout)).Int64("accounts", accrued).Msg("processed task")
+	return nil
+}
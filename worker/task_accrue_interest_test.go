@@ -0,0 +1,52 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hibiken/asynq"
+	mockdb "github.com/mativm02/bank_system/db/mock"
+	db "github.com/mativm02/bank_system/db/sqlc"
+	"github.com/mativm02/bank_system/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccrueInterestPastDay(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	date := time.Date(2026, time.March, 14, 0, 0, 0, 0, time.UTC)
+	account := db.ListInterestBearingAccountsRow{
+		AccountID:     util.RandomInt(1, 1000),
+		Balance:       5000,
+		ProductID:     util.RandomInt(1, 1000),
+		AnnualRateBps: 365,
+	}
+
+	// The balances are the ones at the end of the accrued day, not the current ones.
+	store.EXPECT().
+		ListInterestBearingAccounts(gomock.Any(), gomock.Eq(date.AddDate(0, 0, 1))).
+		Times(1).
+		Return([]db.ListInterestBearingAccountsRow{account}, nil)
+	store.EXPECT().
+		CreateInterestAccrual(gomock.Any(), gomock.Eq(db.CreateInterestAccrualParams{
+			AccountID:     account.AccountID,
+			ProductID:     account.ProductID,
+			AccrualDate:   date,
+			Balance:       account.Balance,
+			AnnualRateBps: account.AnnualRateBps,
+			AmountMicros:  util.DailyInterest(account.Balance, account.AnnualRateBps),
+		})).
+		Times(1).
+		Return(int64(1), nil)
+
+	payload, err := json.Marshal(PayloadAccrueInterest{Date: date.Format(dateLayout)})
+	require.NoError(t, err)
+
+	processor := newTestProcessor(t, store, nil)
+	err = processor.ProcessTaskAccrueInterest(context.Background(), asynq.NewTask(TaskAccrueInterest, payload))
+	require.NoError(t, err)
+}
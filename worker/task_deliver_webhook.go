@@ -0,0 +1,140 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/hibiken/asynq"
+	db "github.com/mativm02/bank_system/db/sqlc"
+	"github.com/mativm02/bank_system/webhook"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskDeliverWebhook = "task:deliver_webhook"
+
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliverySucceeded = "succeeded"
+	WebhookDeliveryDead      = "dead"
+
+	webhookMaxRetry      = 10
+	webhookBaseDelay     = 10 * time.Second
+	webhookMaxDelay      = 6 * time.Hour
+	webhookClientTimeout = 10 * time.Second
+)
+
+type PayloadDeliverWebhook struct {
+	TaskMetadata
+
+	DeliveryID int64 `json:"delivery_id"`
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskDeliverWebhook(ctx context.Context, payload *PayloadDeliverWebhook, opts ...asynq.Option) error {
+	withRequestID(ctx, payload)
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	return distributor.DistributeTask(ctx, TaskDeliverWebhook, jsonPayload, opts...)
+}
+
+// ProcessTaskDeliverWebhook posts a signed delivery to the subscriber and records the attempt.
+// Failed attempts are retried with exponential backoff until the delivery is marked as dead.
+func (processor *RedisTaskProcessor) ProcessTaskDeliverWebhook(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadDeliverWebhook
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	retried, _ := asynq.GetRetryCount(ctx)
+	maxRetry, _ := asynq.GetMaxRetry(ctx)
+	return processor.deliverWebhook(ctx, payload.DeliveryID, retried >= maxRetry)
+}
+
+// deliverWebhook makes an attempt to deliver the webhook. The delivery is dead when the last attempt fails.
+func (processor *RedisTaskProcessor) deliverWebhook(ctx context.Context, deliveryID int64, lastAttempt bool) error {
+	delivery, err := processor.store.GetWebhookDelivery(ctx, deliveryID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("webhook delivery not found: %w", asynq.SkipRetry)
+		}
+		return fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+
+	if delivery.Status != WebhookDeliveryPending {
+		return nil
+	}
+
+	subscription, err := processor.store.GetWebhookSubscription(ctx, delivery.SubscriptionID)
+	if err != nil {
+		return fmt.Errorf("failed to get webhook subscription: %w", err)
+	}
+
+	if !subscription.IsActive {
+		_, err = processor.store.UpdateWebhookDeliveryAttempt(ctx, db.UpdateWebhookDeliveryAttemptParams{
+			ID:        delivery.ID,
+			Status:    WebhookDeliveryDead,
+			LastError: sql.NullString{String: "subscription is not active", Valid: true},
+		})
+		return err
+	}
+
+	statusCode, deliverErr := processor.webhookClient.Deliver(ctx, webhook.Delivery{
+		ID:        delivery.ID,
+		URL:       subscription.Url,
+		Secret:    subscription.Secret,
+		EventType: delivery.EventType,
+		Body:      delivery.Payload,
+	})
+
+	arg := db.UpdateWebhookDeliveryAttemptParams{
+		ID:             delivery.ID,
+		Status:         WebhookDeliverySucceeded,
+		ResponseStatus: sql.NullInt32{Int32: int32(statusCode), Valid: statusCode != 0},
+		DeliveredAt:    sql.NullTime{Time: time.Now(), Valid: true},
+	}
+
+	if deliverErr != nil {
+		arg.Status = WebhookDeliveryPending
+		arg.LastError = sql.NullString{String: deliverErr.Error(), Valid: true}
+		arg.DeliveredAt = sql.NullTime{}
+		if lastAttempt {
+			arg.Status = WebhookDeliveryDead
+		}
+	}
+
+	delivery, err = processor.store.UpdateWebhookDeliveryAttempt(ctx, arg)
+	if err != nil {
+		return fmt.Errorf("failed to update webhook delivery: %w", err)
+	}
+
+	if deliverErr != nil {
+		if delivery.Status == WebhookDeliveryDead {
+			return fmt.Errorf("webhook delivery is dead: %v: %w", deliverErr, asynq.SkipRetry)
+		}
+		return fmt.Errorf("failed to deliver webhook: %w", deliverErr)
+	}
+
+	log.Ctx(ctx).Info().Str("type", TaskDeliverWebhook).Int64("delivery_id", delivery.ID).Int("status_code", statusCode).Msg("processed task")
+	return nil
+}
+
+// webhookRetryDelay doubles the delay after every failed attempt, with some jitter
+// so the deliveries of an endpoint that was down are not all retried at once.
+func webhookRetryDelay(n int) time.Duration {
+	delay := webhookBaseDelay
+	for i := 0; i < n && delay < webhookMaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > webhookMaxDelay {
+		delay = webhookMaxDelay
+	}
+
+	jitter := time.Duration(rand.Int63n(int64(delay) / 10))
+	return delay + jitter
+}
@@ -0,0 +1,157 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hibiken/asynq"
+	mockdb "github.com/mativm02/bank_system/db/mock"
+	db "github.com/mativm02/bank_system/db/sqlc"
+	"github.com/mativm02/bank_system/util"
+	"github.com/mativm02/bank_system/webhook"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeliverWebhook(t *testing.T) {
+	testCases := []struct {
+		name         string
+		statusCode   int
+		lastAttempt  bool
+		isActive     bool
+		deliveryDone bool
+		check        func(t *testing.T, arg db.UpdateWebhookDeliveryAttemptParams, requests int, err error)
+	}{
+		{
+			name:       "OK",
+			statusCode: http.StatusNoContent,
+			isActive:   true,
+			check: func(t *testing.T, arg db.UpdateWebhookDeliveryAttemptParams, requests int, err error) {
+				require.NoError(t, err)
+				require.Equal(t, 1, requests)
+				require.Equal(t, WebhookDeliverySucceeded, arg.Status)
+				require.Equal(t, int32(http.StatusNoContent), arg.ResponseStatus.Int32)
+				require.True(t, arg.DeliveredAt.Valid)
+				require.False(t, arg.LastError.Valid)
+			},
+		},
+		{
+			name:       "Retry",
+			statusCode: http.StatusServiceUnavailable,
+			isActive:   true,
+			check: func(t *testing.T, arg db.UpdateWebhookDeliveryAttemptParams, requests int, err error) {
+				require.Error(t, err)
+				require.False(t, errors.Is(err, asynq.SkipRetry))
+				require.Equal(t, 1, requests)
+				require.Equal(t, WebhookDeliveryPending, arg.Status)
+				require.Equal(t, int32(http.StatusServiceUnavailable), arg.ResponseStatus.Int32)
+				require.Equal(t, "unexpected status 503", arg.LastError.String)
+				require.False(t, arg.DeliveredAt.Valid)
+			},
+		},
+		{
+			name:        "DeadLetter",
+			statusCode:  http.StatusInternalServerError,
+			lastAttempt: true,
+			isActive:    true,
+			check: func(t *testing.T, arg db.UpdateWebhookDeliveryAttemptParams, requests int, err error) {
+				require.ErrorIs(t, err, asynq.SkipRetry)
+				require.Equal(t, 1, requests)
+				require.Equal(t, WebhookDeliveryDead, arg.Status)
+				require.Equal(t, "unexpected status 500", arg.LastError.String)
+			},
+		},
+		{
+			name:       "InactiveSubscription",
+			statusCode: http.StatusNoContent,
+			check: func(t *testing.T, arg db.UpdateWebhookDeliveryAttemptParams, requests int, err error) {
+				require.NoError(t, err)
+				require.Zero(t, requests)
+				require.Equal(t, WebhookDeliveryDead, arg.Status)
+			},
+		},
+		{
+			name:         "AlreadyDelivered",
+			statusCode:   http.StatusNoContent,
+			isActive:     true,
+			deliveryDone: true,
+			check: func(t *testing.T, arg db.UpdateWebhookDeliveryAttemptParams, requests int, err error) {
+				require.NoError(t, err)
+				require.Zero(t, requests)
+				require.Empty(t, arg)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			secret, err := webhook.GenerateSecret()
+			require.NoError(t, err)
+
+			requests := 0
+			receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				require.Equal(t, "transfer.completed", r.Header.Get(webhook.EventTypeHeader))
+				w.WriteHeader(tc.statusCode)
+			}))
+			defer receiver.Close()
+
+			subscription := db.WebhookSubscription{
+				ID:       util.RandomInt(1, 1000),
+				Owner:    util.RandomOwner(),
+				Url:      receiver.URL,
+				Secret:   secret,
+				IsActive: tc.isActive,
+			}
+			delivery := db.WebhookDelivery{
+				ID:             util.RandomInt(1, 1000),
+				SubscriptionID: subscription.ID,
+				EventType:      "transfer.completed",
+				Payload:        []byte(`{"id":1}`),
+				Status:         WebhookDeliveryPending,
+			}
+			if tc.deliveryDone {
+				delivery.Status = WebhookDeliverySucceeded
+			}
+
+			ctrl := gomock.NewController(t)
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetWebhookDelivery(gomock.Any(), gomock.Eq(delivery.ID)).Times(1).Return(delivery, nil)
+			store.EXPECT().GetWebhookSubscription(gomock.Any(), gomock.Eq(subscription.ID)).AnyTimes().Return(subscription, nil)
+
+			var arg db.UpdateWebhookDeliveryAttemptParams
+			store.EXPECT().UpdateWebhookDeliveryAttempt(gomock.Any(), gomock.Any()).AnyTimes().
+				DoAndReturn(func(_ context.Context, params db.UpdateWebhookDeliveryAttemptParams) (db.WebhookDelivery, error) {
+					arg = params
+					updated := delivery
+					updated.Status = params.Status
+					updated.Attempts++
+					return updated, nil
+				})
+
+			processor := newTestProcessor(t, store, &fakeDistributor{})
+			err = processor.deliverWebhook(context.Background(), delivery.ID, tc.lastAttempt)
+			tc.check(t, arg, requests, err)
+		})
+	}
+}
+
+func TestWebhookRetryDelay(t *testing.T) {
+	previous := time.Duration(0)
+	for n := 0; n < 8; n++ {
+		delay := webhookRetryDelay(n)
+		require.Greater(t, delay, previous)
+		require.LessOrEqual(t, delay, webhookBaseDelay<<n+webhookBaseDelay<<n/10)
+		previous = webhookBaseDelay << n
+	}
+
+	delay := webhookRetryDelay(webhookMaxRetry * 10)
+	require.GreaterOrEqual(t, delay, webhookMaxDelay)
+	require.LessOrEqual(t, delay, webhookMaxDelay+webhookMaxDelay/10)
+}
@@ -0,0 +1,150 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	db "github.com/mativm02/bank_system/db/sqlc"
+	"github.com/mativm02/bank_system/event"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskDispatchWebhooks = "task:dispatch_webhooks"
+
+type PayloadDispatchWebhooks struct {
+	TaskMetadata
+
+	EventID int64 `json:"event_id"`
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskDispatchWebhooks(ctx context.Context, payload *PayloadDispatchWebhooks, opts ...asynq.Option) error {
+	withRequestID(ctx, payload)
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	return distributor.DistributeTask(ctx, TaskDispatchWebhooks, jsonPayload, opts...)
+}
+
+// ProcessTaskDispatchWebhooks creates a delivery for every subscription interested in the event
+// and queues them. Every subscriber only receives its own part of the event. Deliveries are unique per subscription and event, so a retried dispatch is safe.
+func (processor *RedisTaskProcessor) ProcessTaskDispatchWebhooks(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadDispatchWebhooks
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	domainEvent, err := processor.store.GetDomainEvent(ctx, payload.EventID)
+	if err != nil {
+		return fmt.Errorf("failed to get domain event: %w", err)
+	}
+
+	subscriptions, err := processor.store.ListWebhookSubscriptionsForEvent(ctx, db.ListWebhookSubscriptionsForEventParams{
+		Usernames: domainEvent.Usernames,
+		EventType: domainEvent.EventType,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list webhook subscriptions: %w", err)
+	}
+
+	accountOwners := make(map[int64]string, len(domainEvent.AccountIds))
+	for _, accountID := range domainEvent.AccountIds {
+		account, err := processor.store.GetAccount(ctx, accountID)
+		if err != nil {
+			return fmt.Errorf("failed to get account: %w", err)
+		}
+		accountOwners[accountID] = account.Owner
+	}
+
+	for _, subscription := range subscriptions {
+		e, err := webhookEvent(domainEvent.ToEvent(), subscription.Owner, accountOwners)
+		if err != nil {
+			return err
+		}
+		body, err := json.Marshal(e)
+		if err != nil {
+			return fmt.Errorf("failed to marshal event: %w", err)
+		}
+
+		delivery, err := processor.store.CreateWebhookDelivery(ctx, db.CreateWebhookDeliveryParams{
+			SubscriptionID: subscription.ID,
+			EventID:        domainEvent.ID,
+			EventType:      domainEvent.EventType,
+			Payload:        body,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create webhook delivery: %w", err)
+		}
+
+		if delivery.Status != WebhookDeliveryPending || delivery.Attempts > 0 {
+			continue
+		}
+
+		opts := []asynq.Option{
+			asynq.TaskID(fmt.Sprintf("webhook-delivery:%d", delivery.ID)),
+			asynq.MaxRetry(webhookMaxRetry),
+			asynq.Queue(QueueDefault),
+		}
+		err = processor.distributor.DistributeTaskDeliverWebhook(ctx, &PayloadDeliverWebhook{DeliveryID: delivery.ID}, opts...)
+		if err != nil && !errors.Is(err, asynq.ErrTaskIDConflict) {
+			return fmt.Errorf("failed to distribute webhook delivery: %w", err)
+		}
+	}
+
+	log.Ctx(ctx).Info().Str("type", task.Type()).Int64("event_id", domainEvent.ID).Int("subscriptions", len(subscriptions)).Msg("processed task")
+	return nil
+}
+
+// webhookTransferCompleted is the data of a transfer.completed delivery.
+// The account of the other party is left out.
+type webhookTransferCompleted struct {
+	TransferID    int64  `json:"transfer_id"`
+	FromAccountID int64  `json:"from_account_id,omitempty"`
+	ToAccountID   int64  `json:"to_account_id,omitempty"`
+	Amount        int64  `json:"amount"`
+	Currency      string `json:"currency"`
+}
+
+// webhookEvent returns the event as seen by the owner of a subscription: it only lists the owner
+// and its own accounts, so the deliveries do not disclose the usernames and accounts of other users.
+func webhookEvent(e event.Event, owner string, accountOwners map[int64]string) (event.Event, error) {
+	e.Usernames = []string{owner}
+
+	accountIDs := []int64{}
+	for _, accountID := range e.AccountIDs {
+		if accountOwners[accountID] == owner {
+			accountIDs = append(accountIDs, accountID)
+		}
+	}
+	e.AccountIDs = accountIDs
+
+	if e.Type == event.TypeTransferCompleted {
+		payload, err := e.Decode()
+		if err != nil {
+			return e, fmt.Errorf("failed to decode event: %w", asynq.SkipRetry)
+		}
+		transfer := payload.(*event.TransferCompleted)
+
+		data := webhookTransferCompleted{
+			TransferID: transfer.TransferID,
+			Amount:     transfer.Amount,
+			Currency:   transfer.Currency,
+		}
+		if accountOwners[transfer.FromAccountID] == owner {
+			data.FromAccountID = transfer.FromAccountID
+		}
+		if accountOwners[transfer.ToAccountID] == owner {
+			data.ToAccountID = transfer.ToAccountID
+		}
+
+		e.Data, err = json.Marshal(data)
+		if err != nil {
+			return e, fmt.Errorf("failed to marshal event data: %w", err)
+		}
+	}
+	return e, nil
+}
@@ -0,0 +1,78 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hibiken/asynq"
+	mockdb "github.com/mativm02/bank_system/db/mock"
+	db "github.com/mativm02/bank_system/db/sqlc"
+	"github.com/mativm02/bank_system/event"
+	"github.com/mativm02/bank_system/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDispatchWebhooksSendsOwnData(t *testing.T) {
+	sender := db.Account{ID: util.RandomInt(1, 1000), Owner: util.RandomOwner()}
+	receiver := db.Account{ID: sender.ID + 1, Owner: util.RandomOwner()}
+
+	data, err := json.Marshal(event.TransferCompleted{
+		TransferID:    util.RandomInt(1, 1000),
+		FromAccountID: sender.ID,
+		ToAccountID:   receiver.ID,
+		Amount:        10,
+		Currency:      util.USD,
+	})
+	require.NoError(t, err)
+
+	domainEvent := db.DomainEvent{
+		ID:         util.RandomInt(1, 1000),
+		EventType:  string(event.TypeTransferCompleted),
+		Usernames:  []string{sender.Owner, receiver.Owner},
+		AccountIds: []int64{sender.ID, receiver.ID},
+		Payload:    data,
+		CreatedAt:  time.Now(),
+	}
+	subscription := db.WebhookSubscription{
+		ID:       util.RandomInt(1, 1000),
+		Owner:    receiver.Owner,
+		IsActive: true,
+	}
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetDomainEvent(gomock.Any(), gomock.Eq(domainEvent.ID)).Times(1).Return(domainEvent, nil)
+	store.EXPECT().ListWebhookSubscriptionsForEvent(gomock.Any(), gomock.Any()).Times(1).Return([]db.WebhookSubscription{subscription}, nil)
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(sender.ID)).Times(1).Return(sender, nil)
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(receiver.ID)).Times(1).Return(receiver, nil)
+
+	var body []byte
+	store.EXPECT().CreateWebhookDelivery(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, arg db.CreateWebhookDeliveryParams) (db.WebhookDelivery, error) {
+			body = arg.Payload
+			return db.WebhookDelivery{ID: 1, Status: WebhookDeliveryPending}, nil
+		})
+
+	distributor := &fakeDistributor{}
+	processor := newTestProcessor(t, store, distributor)
+
+	payload, err := json.Marshal(PayloadDispatchWebhooks{EventID: domainEvent.ID})
+	require.NoError(t, err)
+	err = processor.ProcessTaskDispatchWebhooks(context.Background(), asynq.NewTask(TaskDispatchWebhooks, payload))
+	require.NoError(t, err)
+	require.Len(t, distributor.deliverWebhooks, 1)
+
+	var delivered event.Event
+	require.NoError(t, json.Unmarshal(body, &delivered))
+	require.Equal(t, []string{receiver.Owner}, delivered.Usernames)
+	require.Equal(t, []int64{receiver.ID}, delivered.AccountIDs)
+	require.NotContains(t, string(body), sender.Owner)
+
+	var transfer map[string]interface{}
+	require.NoError(t, json.Unmarshal(delivered.Data, &transfer))
+	require.NotContains(t, transfer, "from_account_id")
+	require.Equal(t, float64(receiver.ID), transfer["to_account_id"])
+}
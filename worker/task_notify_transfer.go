@@ -0,0 +1,136 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	db "github.com/mativm02/bank_system/db/sqlc"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskNotifyTransfer = "task:notify_transfer"
+
+// The directions of a transfer, which name the user a notification is sent to.
+const (
+	TransferSent     = "sent"
+	TransferReceived = "received"
+)
+
+// PayloadNotifyTransfer notifies one user of a transfer, so a retried task does not notify
+// the other user again.
+type PayloadNotifyTransfer struct {
+	TaskMetadata
+
+	TransferID int64  `json:"transfer_id"`
+	Direction  string `json:"direction"`
+}
+
+type transferNotificationData struct {
+	FullName              string
+	Amount                int64
+	Currency              string
+	AccountID             int64
+	CounterpartyAccountID int64
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskNotifyTransfer(ctx context.Context, payload *PayloadNotifyTransfer, opts ...asynq.Option) error {
+	withRequestID(ctx, payload)
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	return distributor.DistributeTask(ctx, TaskNotifyTransfer, jsonPayload, opts...)
+}
+
+// ProcessTaskNotifyTransfer emails the sender or the receiver of a transfer, following their
+// notification preferences. Users whose email is not verified are skipped, and a user who
+// receives a transfer from their own account is only notified that it was sent.
+func (processor *RedisTaskProcessor) ProcessTaskNotifyTransfer(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadNotifyTransfer
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+	if payload.Direction != TransferSent && payload.Direction != TransferReceived {
+		return fmt.Errorf("invalid direction %q: %w", payload.Direction, asynq.SkipRetry)
+	}
+
+	transfer, err := processor.store.GetTransfer(ctx, payload.TransferID)
+	if err != nil {
+		return fmt.Errorf("failed to get transfer: %w", err)
+	}
+
+	fromAccount, err := processor.store.GetAccount(ctx, transfer.FromAccountID)
+	if err != nil {
+		return fmt.Errorf("failed to get from account: %w", err)
+	}
+
+	toAccount, err := processor.store.GetAccount(ctx, transfer.ToAccountID)
+	if err != nil {
+		return fmt.Errorf("failed to get to account: %w", err)
+	}
+
+	incoming := payload.Direction == TransferReceived
+	username := fromAccount.Owner
+	if incoming {
+		username = toAccount.Owner
+	}
+
+	if !incoming || toAccount.Owner != fromAccount.Owner {
+		err = processor.notifyTransfer(ctx, username, transfer, fromAccount, toAccount, incoming)
+		if err != nil {
+			return err
+		}
+	}
+
+	log.Ctx(ctx).Info().Str("type", task.Type()).Bytes("payload", processor.redactor.JSON(task.Payload())).Msg("processed task")
+	return nil
+}
+
+func (processor *RedisTaskProcessor) notifyTransfer(ctx context.Context, username string, transfer db.Transfer, fromAccount, toAccount db.Account, incoming bool) error {
+	user, err := processor.store.GetUser(ctx, username)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if !user.IsEmailVerified {
+		log.Ctx(ctx).Info().Str("username", username).Int64("transfer_id", transfer.ID).Msg("skip transfer notification: email is not verified")
+		return nil
+	}
+
+	preference, err := db.GetNotificationPreferenceOrDefault(ctx, processor.store, username)
+	if err != nil {
+		return fmt.Errorf("failed to get notification preference: %w", err)
+	}
+
+	if !preference.ShouldNotifyTransfer(transfer.Amount) {
+		return nil
+	}
+
+	name := "transfer_sent"
+	data := transferNotificationData{
+		FullName:              user.FullName,
+		Amount:                transfer.Amount,
+		Currency:              fromAccount.Currency,
+		AccountID:             fromAccount.ID,
+		CounterpartyAccountID: toAccount.ID,
+	}
+	if incoming {
+		name = "transfer_received"
+		data.AccountID, data.CounterpartyAccountID = toAccount.ID, fromAccount.ID
+	}
+
+	message, err := processor.templates.Render(name, user.Locale, data)
+	if err != nil {
+		return fmt.Errorf("failed to render email: %w", err)
+	}
+	to := []string{user.Email}
+
+	err = processor.mailer.SendMessage(message, to, nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hibiken/asynq"
+	mockdb "github.com/mativm02/bank_system/db/mock"
+	db "github.com/mativm02/bank_system/db/sqlc"
+	"github.com/mativm02/bank_system/mail"
+	"github.com/mativm02/bank_system/util"
+	"github.com/stretchr/testify/require"
+)
+
+func randomNotifiedUser() db.User {
+	return db.User{
+		Username:        util.RandomOwner(),
+		FullName:        util.RandomOwner(),
+		Email:           util.RandomEmail(),
+		Locale:          util.EN,
+		IsEmailVerified: true,
+	}
+}
+
+func TestNotifyTransfer(t *testing.T) {
+	sender := randomNotifiedUser()
+	receiver := randomNotifiedUser()
+	unverified := randomNotifiedUser()
+	unverified.IsEmailVerified = false
+
+	fromAccount := db.Account{ID: util.RandomInt(1, 1000), Owner: sender.Username, Currency: util.USD}
+	toAccount := db.Account{ID: fromAccount.ID + 1, Owner: receiver.Username, Currency: util.USD}
+	ownAccount := db.Account{ID: fromAccount.ID + 2, Owner: sender.Username, Currency: util.USD}
+	unverifiedAccount := db.Account{ID: fromAccount.ID + 3, Owner: unverified.Username, Currency: util.USD}
+
+	transfer := func(to db.Account) db.Transfer {
+		return db.Transfer{
+			ID:            util.RandomInt(1, 1000),
+			FromAccountID: fromAccount.ID,
+			ToAccountID:   to.ID,
+			Amount:        100,
+		}
+	}
+
+	testCases := []struct {
+		name       string
+		transfer   db.Transfer
+		direction  string
+		buildStubs func(store *mockdb.MockStore)
+		checkSent  func(t *testing.T, emails []mail.SentEmail, err error)
+	}{
+		{
+			name:      "Sent",
+			transfer:  transfer(toAccount),
+			direction: TransferSent,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(sender.Username)).Times(1).Return(sender, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(receiver.Username)).Times(0)
+				store.EXPECT().GetNotificationPreference(gomock.Any(), gomock.Eq(sender.Username)).Times(1).Return(db.NotificationPreference{}, sql.ErrNoRows)
+			},
+			checkSent: func(t *testing.T, emails []mail.SentEmail, err error) {
+				require.NoError(t, err)
+				require.Len(t, emails, 1)
+				require.Equal(t, []string{sender.Email}, emails[0].To)
+			},
+		},
+		{
+			name:      "Received",
+			transfer:  transfer(toAccount),
+			direction: TransferReceived,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(receiver.Username)).Times(1).Return(receiver, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(sender.Username)).Times(0)
+				store.EXPECT().GetNotificationPreference(gomock.Any(), gomock.Eq(receiver.Username)).Times(1).Return(db.NotificationPreference{}, sql.ErrNoRows)
+			},
+			checkSent: func(t *testing.T, emails []mail.SentEmail, err error) {
+				require.NoError(t, err)
+				require.Len(t, emails, 1)
+				require.Equal(t, []string{receiver.Email}, emails[0].To)
+			},
+		},
+		{
+			name:      "OwnAccountReceived",
+			transfer:  transfer(ownAccount),
+			direction: TransferReceived,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkSent: func(t *testing.T, emails []mail.SentEmail, err error) {
+				require.NoError(t, err)
+				require.Empty(t, emails)
+			},
+		},
+		{
+			name:      "EmailNotVerified",
+			transfer:  transfer(unverifiedAccount),
+			direction: TransferReceived,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(unverified.Username)).Times(1).Return(unverified, nil)
+				store.EXPECT().GetNotificationPreference(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkSent: func(t *testing.T, emails []mail.SentEmail, err error) {
+				require.NoError(t, err)
+				require.Empty(t, emails)
+			},
+		},
+		{
+			name:      "BelowThreshold",
+			transfer:  transfer(toAccount),
+			direction: TransferSent,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(sender.Username)).Times(1).Return(sender, nil)
+				store.EXPECT().GetNotificationPreference(gomock.Any(), gomock.Eq(sender.Username)).Times(1).Return(db.NotificationPreference{
+					Username:          sender.Username,
+					TransferMode:      util.NotifyAboveThreshold,
+					TransferThreshold: 100,
+				}, nil)
+			},
+			checkSent: func(t *testing.T, emails []mail.SentEmail, err error) {
+				require.NoError(t, err)
+				require.Empty(t, emails)
+			},
+		},
+		{
+			name:      "InvalidDirection",
+			transfer:  transfer(toAccount),
+			direction: "both",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkSent: func(t *testing.T, emails []mail.SentEmail, err error) {
+				require.True(t, errors.Is(err, asynq.SkipRetry))
+				require.Empty(t, emails)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			accounts := []db.Account{fromAccount, toAccount, ownAccount, unverifiedAccount}
+			for _, account := range accounts {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).AnyTimes().Return(account, nil)
+			}
+			store.EXPECT().GetTransfer(gomock.Any(), gomock.Eq(tc.transfer.ID)).AnyTimes().Return(tc.transfer, nil)
+
+			payload, err := json.Marshal(PayloadNotifyTransfer{TransferID: tc.transfer.ID, Direction: tc.direction})
+			require.NoError(t, err)
+
+			processor := newTestProcessor(t, store, nil)
+			err = processor.ProcessTaskNotifyTransfer(context.Background(), asynq.NewTask(TaskNotifyTransfer, payload))
+			tc.checkSent(t, processor.mailer.(*mail.MemorySender).Emails(), err)
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	db "github.com/mativm02/bank_system/db/sqlc"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskPostInterest = "task:post_interest"
+
+type PayloadPostInterest struct {
+	TaskMetadata
+
+	// Before excludes the interest accrued on that date and later. The scheduled tasks leave it empty
+	// to post the interest accrued until the end of the previous month.
+	Before string `json:"before,omitempty"`
+}
+
+// ProcessTaskPostInterest pays the accrued interest of every account. Each account is paid in its own
+// transaction and the paid accruals are marked, so a retried task only pays the accounts it missed.
+func (processor *RedisTaskProcessor) ProcessTaskPostInterest(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadPostInterest
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	now := time.Now().UTC()
+	before := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if payload.Before != "" {
+		var err error
+		before, err = time.Parse(dateLayout, payload.Before)
+		if err != nil {
+			return fmt.Errorf("invalid date: %w", asynq.SkipRetry)
+		}
+	}
+
+	accountIDs, err := processor.store.ListAccountsWithUnpostedInterest(ctx, before)
+	if err != nil {
+		return fmt.Errorf("failed to list accounts with unposted interest: %w", err)
+	}
+
+	for _, accountID := range accountIDs {
+		_, err := processor.store.PostInterestTx(ctx, db.PostInterestTxParams{
+			AccountID: accountID,
+			Before:    before,
+		})
+		if errors.Is(err, db.ErrNoAccruedInterest) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to post interest of account %d: %w", accountID, err)
+		}
+	}
+
+	log.Ctx(ctx).Info().Str("type", task.Type()).Str("before", before.Format(dateLayout)).Int("accounts", len(accountIDs)).Msg("processed task")
+	return nil
+}
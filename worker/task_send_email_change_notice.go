@@ -0,0 +1,67 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	"github.com/mativm02/bank_system/redact"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskSendEmailChangeNotice = "task:send_email_change_notice"
+
+type PayloadSendEmailChangeNotice struct {
+	TaskMetadata
+
+	Username string `json:"username"`
+	OldEmail string `json:"old_email"`
+	NewEmail string `json:"new_email"`
+}
+
+type emailChangeNoticeData struct {
+	FullName string
+	NewEmail string
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskSendEmailChangeNotice(ctx context.Context, payload *PayloadSendEmailChangeNotice, opts ...asynq.Option) error {
+	withRequestID(ctx, payload)
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	return distributor.DistributeTask(ctx, TaskSendEmailChangeNotice, jsonPayload, opts...)
+}
+
+// ProcessTaskSendEmailChangeNotice warns the old email that a change to a new email was requested,
+// so the owner can react if their session was hijacked.
+func (processor *RedisTaskProcessor) ProcessTaskSendEmailChangeNotice(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadSendEmailChangeNotice
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	user, err := processor.store.GetUser(ctx, payload.Username)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	message, err := processor.templates.Render("email_change_notice", user.Locale, emailChangeNoticeData{
+		FullName: user.FullName,
+		NewEmail: redact.MaskEmail(payload.NewEmail),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to render email: %w", err)
+	}
+	to := []string{payload.OldEmail}
+
+	err = processor.mailer.SendMessage(message, to, nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	log.Ctx(ctx).Info().Str("type", task.Type()).Str("username", user.Username).Msg("processed task")
+
+	return nil
+}
@@ -3,6 +3,9 @@ package worker
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"strconv"
+	"time"
 
 	"encoding/json"
 
@@ -15,22 +18,26 @@ import (
 const TaskSendVerifyEmail = "task:send_verify_email"
 
 type PayloadSendVerifyEmail struct {
+	TaskMetadata
+
 	Username string `json:"username"`
+	// Email is set when the user asked to change their email to this address.
+	Email string `json:"email,omitempty"`
+}
+
+type verifyEmailData struct {
+	FullName  string
+	VerifyURL string
 }
 
 func (distributor *RedisTaskDistributor) DistributeTaskSendVerifyEmail(ctx context.Context, payload *PayloadSendVerifyEmail, opts ...asynq.Option) error {
+	withRequestID(ctx, payload)
 	jsonPayload, err := json.Marshal(payload)
 	if err != nil {
 		return fmt.Errorf("failed to marshal payload: %w", err)
 	}
-	task := asynq.NewTask(TaskSendVerifyEmail, jsonPayload, opts...)
-	info, err := distributor.client.EnqueueContext(ctx, task)
-	if err != nil {
-		return fmt.Errorf("failed to enqueue task: %w", err)
-	}
 
-	log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).Str("queue", info.Queue).Int("max_retry", info.MaxRetry).Msg("enqueued task")
-	return nil
+	return distributor.DistributeTask(ctx, TaskSendVerifyEmail, jsonPayload, opts...)
 }
 
 func (processor *RedisTaskProcessor) ProcessTaskSendVerifyEmail(ctx context.Context, task *asynq.Task) error {
@@ -44,27 +51,45 @@ func (processor *RedisTaskProcessor) ProcessTaskSendVerifyEmail(ctx context.Cont
 		return fmt.Errorf("failed to get user: %w", err)
 	}
 
+	templateName := "verify_email"
+	email := user.Email
+	if payload.Email != "" {
+		if payload.Email != user.PendingEmail.String {
+			log.Ctx(ctx).Info().Str("type", task.Type()).Str("username", user.Username).Msg("skip verify email: the email change was superseded")
+			return nil
+		}
+		templateName = "verify_email_change"
+		email = payload.Email
+	}
+
 	verifyEmail, err := processor.store.CreateVerifyEmail(ctx, db.CreateVerifyEmailParams{
 		Username:   user.Username,
-		Email:      user.Email,
+		Email:      email,
 		SecretCode: util.RandomString(32),
+		ExpiredAt:  time.Now().Add(processor.config.VerifyEmailDuration),
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create verify email: %w", err)
 	}
-	subject := "Welcome to Bank System"
-	verifyUrl := fmt.Sprintf("http://localhost:8080/v1/verify_email?id=%d&secret_code=%s", verifyEmail.ID, verifyEmail.SecretCode)
-	content := fmt.Sprintf(`Hello %s, <br/>
-	Thank you for registering to Bank System. <br/>
-	Please verify your email by clicking <a href="%s">here</a>. <br/>
-	`, user.FullName, verifyUrl)
-	to := []string{user.Email}
-
-	err = processor.mailer.SendEmail(subject, content, to, nil, nil, nil)
+
+	verifyURL := processor.publicURL("/v1/verify_email", url.Values{
+		"id":          []string{strconv.FormatInt(verifyEmail.ID, 10)},
+		"secret_code": []string{verifyEmail.SecretCode},
+	})
+	message, err := processor.templates.Render(templateName, user.Locale, verifyEmailData{
+		FullName:  user.FullName,
+		VerifyURL: verifyURL,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to render email: %w", err)
+	}
+	to := []string{email}
+
+	err = processor.mailer.SendMessage(message, to, nil, nil, nil)
 	if err != nil {
 		return fmt.Errorf("failed to send email: %w", err)
 	}
-	log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).Str("email", user.Email).Msg("processing task")
+	log.Ctx(ctx).Info().Str("type", task.Type()).Bytes("payload", processor.redactor.JSON(task.Payload())).Str("email", processor.redactor.Email(email)).Msg("processing task")
 
-	return nil 
+	return nil
 }